@@ -3,7 +3,12 @@ package config
 import (
 	"payslip-generation-system/pkg/dbconfig"
 	"payslip-generation-system/pkg/env"
+	"payslip-generation-system/pkg/fieldcrypt"
+	"payslip-generation-system/pkg/jwtkey"
 	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/notify"
+	"payslip-generation-system/pkg/oidc"
+	"payslip-generation-system/pkg/storage"
 	"time"
 )
 
@@ -24,7 +29,16 @@ type Config struct {
 		}
 	} `mapstructure:"server"`
 
-	Cors CORSConfig `mapstructure:"cors"`
+	Cors    CORSConfig    `mapstructure:"cors"`
+	Auth    AuthConfig    `mapstructure:"auth"`
+	Payroll PayrollConfig `mapstructure:"payroll"`
+
+	Attendance AttendanceConfig `mapstructure:"attendance"`
+	Overtime   OvertimeConfig   `mapstructure:"overtime"`
+
+	Notifier   notify.Config     `mapstructure:"notifier"`
+	Storage    storage.Config    `mapstructure:"storage"`
+	FieldCrypt fieldcrypt.Config `mapstructure:"fieldCrypt"`
 }
 
 type AppEnvMode struct {
@@ -44,3 +58,113 @@ type CORSConfig struct {
 	AllowCredentials bool     `mapstructure:"allowCredentials"`
 	ExposeHeaders    []string `mapstructure:"exposeHeaders"`
 }
+
+type AuthConfig struct {
+	AccessTokenTTL  time.Duration `mapstructure:"accessTokenTTL"`  // e.g., 15m
+	RefreshTokenTTL time.Duration `mapstructure:"refreshTokenTTL"` // e.g., 720h
+	JWT             jwtkey.Config `mapstructure:"jwt"`
+
+	PasswordPolicy PasswordPolicy      `mapstructure:"passwordPolicy"`
+	PasswordReset  PasswordResetConfig `mapstructure:"passwordReset"`
+	Lockout        LockoutConfig       `mapstructure:"lockout"`
+	TwoFactor      TwoFactorConfig     `mapstructure:"twoFactor"`
+	OIDC           oidc.Config         `mapstructure:"oidc"`
+}
+
+type PasswordPolicy struct {
+	MinLength     int  `mapstructure:"minLength"` // default 8
+	RequireUpper  bool `mapstructure:"requireUpper"`
+	RequireLower  bool `mapstructure:"requireLower"`
+	RequireDigit  bool `mapstructure:"requireDigit"`
+	RequireSymbol bool `mapstructure:"requireSymbol"`
+}
+
+type PasswordResetConfig struct {
+	TokenTTL time.Duration `mapstructure:"tokenTTL"` // default 30m
+	// URL halaman reset di frontend; "%s" diganti token, e.g. http://localhost:3000/reset-password?token=%s
+	URLTemplate string `mapstructure:"urlTemplate"`
+}
+
+// LockoutConfig: setelah MaxAccountFailures / MaxIPFailures gagal berturut-turut, key dikunci
+// selama BaseLockout dan durasinya berlipat dua tiap kegagalan berikutnya (maks MaxLockout).
+type LockoutConfig struct {
+	Store              string        `mapstructure:"store"`              // memory | db (default memory)
+	MaxAccountFailures int           `mapstructure:"maxAccountFailures"` // default 5
+	MaxIPFailures      int           `mapstructure:"maxIPFailures"`      // default 20
+	BaseLockout        time.Duration `mapstructure:"baseLockout"`        // default 1m
+	MaxLockout         time.Duration `mapstructure:"maxLockout"`         // default 1h
+	Window             time.Duration `mapstructure:"window"`             // default 15m, counter di-reset setelah tidak ada aktivitas
+}
+
+type TwoFactorConfig struct {
+	// RequiredForAdmin: endpoint admin menolak access token yang login-nya tanpa OTP
+	RequiredForAdmin bool          `mapstructure:"requiredForAdmin"`
+	Issuer           string        `mapstructure:"issuer"`       // nama di aplikasi authenticator, default "Payslip"
+	ChallengeTTL     time.Duration `mapstructure:"challengeTTL"` // default 5m
+}
+
+type PayrollConfig struct {
+	// BasePayMode: days (hari hadir * 8 jam, default) | hours (jam kerja aktual dari check-in/check-out)
+	BasePayMode  string             `mapstructure:"basePayMode"`
+	Disbursement DisbursementConfig `mapstructure:"disbursement"`
+	Approval     ApprovalConfig     `mapstructure:"approval"`
+
+	PeriodTemplate  PeriodTemplateConfig  `mapstructure:"periodTemplate"`
+	PeriodScheduler PeriodSchedulerConfig `mapstructure:"periodScheduler"`
+}
+
+// AttendanceConfig: jadwal kerja (WIB) untuk menandai terlambat / pulang cepat pada check-in/check-out.
+type AttendanceConfig struct {
+	WorkStart string        `mapstructure:"workStart"` // HH:MM, default 09:00
+	WorkEnd   string        `mapstructure:"workEnd"`   // HH:MM, default 17:00
+	LateGrace time.Duration `mapstructure:"lateGrace"` // check-in sampai WorkStart+LateGrace tidak dianggap terlambat
+	// ProratePartialDays (mode days): hari hadir dipotong menit terlambat & pulang cepat; false = tetap dibayar penuh
+	ProratePartialDays bool `mapstructure:"proratePartialDays"`
+
+	Geofence GeofenceConfig `mapstructure:"geofence"`
+	// Holidays: hari libur nasional / perusahaan (ditandai di kalender attendance)
+	Holidays []HolidayConfig `mapstructure:"holidays"`
+}
+
+// OvertimeConfig: overtime harus direncanakan & disetujui sebelum dijalankan.
+type OvertimeConfig struct {
+	// PlanMode: off (tidak dicocokkan, default) | flag (tanpa / melebihi rencana ditandai) | reject (ditolak)
+	PlanMode string `mapstructure:"planMode"`
+}
+
+type HolidayConfig struct {
+	Date string `mapstructure:"date"` // YYYY-MM-DD
+	Name string `mapstructure:"name"`
+}
+
+// GeofenceConfig: cek lokasi / IP submission terhadap office_locations yang aktif.
+type GeofenceConfig struct {
+	// Mode: off (hanya dicatat) | flag (ditandai untuk laporan admin) | reject (ditolak), default off
+	Mode string `mapstructure:"mode"`
+}
+
+// PeriodTemplateConfig: aturan default pembentukan attendance period bulanan.
+type PeriodTemplateConfig struct {
+	Mode       string `mapstructure:"mode"`       // calendar_month | cutoff, default calendar_month
+	CutoffDay  int    `mapstructure:"cutoffDay"`  // mode cutoff: e.g. 25 = tgl 26 bulan lalu s/d 25 bulan ini
+	NameFormat string `mapstructure:"nameFormat"` // layout time Go, default "Payroll Jan 2006"
+}
+
+// PeriodSchedulerConfig: job in-process yang membuat period berikutnya sebelum period itu dimulai.
+type PeriodSchedulerConfig struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"` // default 24h
+	LeadDays int           `mapstructure:"leadDays"` // dibuat N hari sebelum mulai, default 7
+}
+
+// ApprovalConfig: aturan four-eyes sebelum run payroll final.
+type ApprovalConfig struct {
+	RequiredApprovers int `mapstructure:"requiredApprovers"` // jumlah admin selain pembuat run; default 1
+}
+
+// DisbursementConfig: identitas perusahaan di file transfer gaji ke bank.
+type DisbursementConfig struct {
+	CompanyCode   string `mapstructure:"companyCode"`   // kode perusahaan dari bank
+	SourceAccount string `mapstructure:"sourceAccount"` // rekening sumber (debet)
+	DefaultFormat string `mapstructure:"defaultFormat"` // csv | bca_fixed, default csv
+}
@@ -0,0 +1,20 @@
+package infra
+
+import (
+	"payslip-generation-system/config"
+	"payslip-generation-system/pkg/fieldcrypt"
+	"payslip-generation-system/pkg/log"
+)
+
+// ProvideFieldCipher memuat key enkripsi kolom sensitif (fieldCrypt). Data rekening & secret TOTP tidak boleh tersimpan plaintext.
+func ProvideFieldCipher(cfg *config.Config, logger *log.LogCustom) *fieldcrypt.Cipher {
+	c, err := fieldcrypt.New(cfg.FieldCrypt)
+	if err != nil {
+		logger.Error(log.LogData{
+			Err:         err,
+			Description: "failed to load field encryption keys",
+		})
+		panic("cannot start app without field encryption keys")
+	}
+	return c
+}
@@ -0,0 +1,24 @@
+package infra
+
+import (
+	"payslip-generation-system/config"
+	"payslip-generation-system/pkg/jwtkey"
+	"payslip-generation-system/pkg/log"
+)
+
+// ProvideKeyManager memuat key JWT dari config (auth.jwt). App tidak boleh jalan tanpa signing key.
+func ProvideKeyManager(cfg *config.Config, logger *log.LogCustom) *jwtkey.Manager {
+	km, err := jwtkey.New(cfg.Auth.JWT)
+	if err != nil {
+		logger.Error(log.LogData{
+			Err:         err,
+			Description: "failed to load JWT signing keys",
+		})
+		panic("cannot start app without JWT signing keys")
+	}
+	logger.Info(log.LogData{
+		Description: "JWT signing keys loaded",
+		Response:    km.ActiveKid(),
+	})
+	return km
+}
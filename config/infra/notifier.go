@@ -0,0 +1,20 @@
+package infra
+
+import (
+	"payslip-generation-system/config"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/notify"
+)
+
+// ProvideNotifier memilih implementasi notifier sesuai config (notifier.driver).
+func ProvideNotifier(cfg *config.Config, logger *log.LogCustom) notify.Notifier {
+	n, err := notify.New(cfg.Notifier, logger)
+	if err != nil {
+		logger.Error(log.LogData{
+			Err:         err,
+			Description: "failed to init notifier",
+		})
+		panic("invalid notifier config")
+	}
+	return n
+}
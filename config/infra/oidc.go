@@ -0,0 +1,20 @@
+package infra
+
+import (
+	"payslip-generation-system/config"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/oidc"
+)
+
+// ProvideOIDC membaca provider SSO dari config (auth.oidc). Tanpa provider, endpoint OIDC mengembalikan 404.
+func ProvideOIDC(cfg *config.Config, logger *log.LogCustom) *oidc.Registry {
+	reg, err := oidc.New(cfg.Auth.OIDC, nil)
+	if err != nil {
+		logger.Error(log.LogData{
+			Err:         err,
+			Description: "invalid OIDC provider config",
+		})
+		panic("cannot start app with invalid OIDC config")
+	}
+	return reg
+}
@@ -38,7 +38,26 @@ func ProvideInfra(cfg *config.Config, logger *log.LogCustom) *Infra {
 			&model.Reimbursement{},
 			&model.PayrollRun{},
 			&model.PayrollItem{},
-			&model.User{}); err != nil {
+			&model.User{},
+			&model.AuthSession{},
+			&model.RefreshToken{},
+			&model.PasswordResetToken{},
+			&model.LoginAttempt{},
+			&model.RecoveryCode{},
+			&model.OIDCLoginState{},
+			&model.EmployeeBankAccount{},
+			&model.EmployeeBankAccountHistory{},
+			&model.DisbursementBatch{},
+			&model.PayrollRunEvent{},
+			&model.PayrollRunApproval{},
+			&model.OfficeLocation{},
+			&model.AttendanceCorrection{},
+			&model.AttendanceAudit{},
+			&model.PayrollAdjustment{},
+			&model.Device{},
+			&model.DeviceUser{},
+			&model.DevicePunch{},
+			&model.OvertimePlan{}); err != nil {
 			logger.Error(log.LogData{
 				Err:         err,
 				Description: "database migration failed",
@@ -47,6 +66,18 @@ func ProvideInfra(cfg *config.Config, logger *log.LogCustom) *Infra {
 			})
 			panic("auto migration failed")
 		}
+		// disbursement batch susulan: unique lama (satu batch per run) diganti idx_disbursement_run_batch
+		if m := infra.DB.Migrator(); m.HasIndex(&model.DisbursementBatch{}, "idx_disbursement_batches_payroll_run_id") {
+			if err := m.DropIndex(&model.DisbursementBatch{}, "idx_disbursement_batches_payroll_run_id"); err != nil {
+				logger.Error(log.LogData{
+					Err:         err,
+					Description: "database migration failed",
+					StartTime:   nil,
+					Response:    nil,
+				})
+				panic("auto migration failed")
+			}
+		}
 		logger.Info(log.LogData{
 			Description: "database migration completed successfully",
 			StartTime:   nil,
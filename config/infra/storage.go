@@ -0,0 +1,20 @@
+package infra
+
+import (
+	"payslip-generation-system/config"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/storage"
+)
+
+// ProvideStorage memilih driver penyimpanan file upload sesuai config (storage.driver).
+func ProvideStorage(cfg *config.Config, logger *log.LogCustom) storage.Storage {
+	s, err := storage.New(cfg.Storage)
+	if err != nil {
+		logger.Error(log.LogData{
+			Err:         err,
+			Description: "failed to init storage",
+		})
+		panic("invalid storage config")
+	}
+	return s
+}
@@ -1,6 +1,8 @@
 package router
 
 import (
+	"context"
+
 	"payslip-generation-system/config"
 	"payslip-generation-system/internal/handler"
 	"payslip-generation-system/internal/usecase"
@@ -27,3 +29,8 @@ func ProvideRoute(
 		usecase: usecase,
 	}
 }
+
+// StartBackgroundJobs menjalankan job in-process (saat ini: scheduler attendance period jika diaktifkan).
+func (r *Route) StartBackgroundJobs(ctx context.Context) {
+	r.usecase.StartPeriodScheduler(ctx)
+}
@@ -9,11 +9,19 @@ import (
 	"github.com/gin-gonic/gin"
 
 	authmidware "payslip-generation-system/internal/middleware"
+	"payslip-generation-system/pkg/storage"
 )
 
 func (r *Route) SetupRoute(router *gin.Engine) {
 	// Health check
 	router.GET("/health-check", healthCheck)
+	router.GET("/.well-known/jwks.json", WrapWithErrorHandler(r.handler.JWKSHandler))
+
+	// file upload driver local disajikan langsung oleh gin
+	if d := r.Cfg.Storage.Driver; d == "" || d == storage.DriverLocal {
+		local := storage.NewLocal(r.Cfg.Storage.Local)
+		router.Static(local.PublicURL(), local.Dir())
+	}
 
 	// CORS
 	configCors := cors.DefaultConfig()
@@ -30,24 +38,113 @@ func (r *Route) SetupRoute(router *gin.Engine) {
 	auth := v1.Group("/auth")
 	auth.POST("/register", r.processTimeout(WrapWithErrorHandler(r.handler.RegisterUserHandler), 5*time.Second))
 	auth.POST("/login", r.processTimeout(WrapWithErrorHandler(r.handler.LoginUserHandler), 5*time.Second))
+	auth.POST("/refresh", r.processTimeout(WrapWithErrorHandler(r.handler.RefreshTokenHandler), 5*time.Second))
+	auth.POST("/password/forgot", r.processTimeout(WrapWithErrorHandler(r.handler.ForgotPasswordHandler), 10*time.Second))
+	auth.POST("/password/reset", r.processTimeout(WrapWithErrorHandler(r.handler.ResetPasswordHandler), 10*time.Second))
+	auth.GET("/oidc/:provider/start", r.processTimeout(WrapWithErrorHandler(r.handler.StartOIDCLoginHandler), 10*time.Second))
+	auth.GET("/oidc/:provider/callback", r.processTimeout(WrapWithErrorHandler(r.handler.OIDCCallbackHandler), 15*time.Second))
+	auth.POST("/2fa/verify", r.processTimeout(WrapWithErrorHandler(r.handler.VerifyTwoFactorHandler), 5*time.Second))
+
+	// Mesin absensi (API key mesin, bukan JWT)
+	v1.POST("/devices/punches", r.processTimeout(WrapWithErrorHandler(r.handler.IngestDevicePunchesHandler), 30*time.Second))
 
 	// Protected (JWT) — apply middleware.Auth
 	protected := v1.Group("")
-	authmidware.New(protected, r.Cfg, r.Log) // ini memasang AuthJwt untuk semua route di bawahnya
+	authmidware.New(protected, r.Cfg, r.Log, r.usecase) // ini memasang AuthJwt untuk semua route di bawahnya
+	protected.POST("/auth/logout", r.processTimeout(WrapWithErrorHandler(r.handler.LogoutHandler), 5*time.Second))
+	protected.POST("/auth/password/change", r.processTimeout(WrapWithErrorHandler(r.handler.ChangePasswordHandler), 10*time.Second))
+	protected.POST("/auth/2fa/enroll", r.processTimeout(WrapWithErrorHandler(r.handler.EnrollTwoFactorHandler), 5*time.Second))
+	protected.POST("/auth/2fa/activate", r.processTimeout(WrapWithErrorHandler(r.handler.ActivateTwoFactorHandler), 5*time.Second))
+	protected.POST("/auth/2fa/recovery-codes", r.processTimeout(WrapWithErrorHandler(r.handler.RegenerateRecoveryCodesHandler), 5*time.Second))
+	protected.POST("/auth/2fa/disable", r.processTimeout(WrapWithErrorHandler(r.handler.DisableTwoFactorHandler), 5*time.Second))
 
 	// ADMIN only group
 	admin := protected.Group("")
-	admin.Use(RequireAdmin()) // helper kecil di bawah
+	admin.Use(RequireAdmin(r.Cfg.Auth.TwoFactor.RequiredForAdmin)) // helper kecil di bawah
 	// contoh endpoint admin (buat period payroll)
 	admin.POST("/payroll/periods", r.processTimeout(WrapWithErrorHandler(r.handler.CreateAttendancePeriodHandler), 10*time.Second))
+	admin.GET("/payroll/periods", r.processTimeout(WrapWithErrorHandler(r.handler.ListAttendancePeriodsHandler), 10*time.Second))
+	admin.POST("/payroll/periods/generate", r.processTimeout(WrapWithErrorHandler(r.handler.GenerateAttendancePeriodsHandler), 30*time.Second))
+	admin.GET("/payroll/periods/:period_id", r.processTimeout(WrapWithErrorHandler(r.handler.GetAttendancePeriodHandler), 10*time.Second))
+	admin.PATCH("/payroll/periods/:period_id", r.processTimeout(WrapWithErrorHandler(r.handler.UpdateAttendancePeriodHandler), 10*time.Second))
+	admin.DELETE("/payroll/periods/:period_id", r.processTimeout(WrapWithErrorHandler(r.handler.DeleteAttendancePeriodHandler), 10*time.Second))
+	admin.POST("/payroll/periods/:period_id/close", r.processTimeout(WrapWithErrorHandler(r.handler.CloseAttendancePeriodHandler), 10*time.Second))
 	admin.POST("/payroll/periods/:period_id/run", r.processTimeout(WrapWithErrorHandler(r.handler.RunPayrollHandler), 30*time.Second))
+	admin.GET("/payroll/periods/:period_id/run", r.processTimeout(WrapWithErrorHandler(r.handler.GetPayrollRunHandler), 10*time.Second))
+	admin.POST("/payroll/periods/:period_id/run/status", r.processTimeout(WrapWithErrorHandler(r.handler.TransitionPayrollRunHandler), 10*time.Second))
+	admin.POST("/payroll/periods/:period_id/run/approval-request", r.processTimeout(WrapWithErrorHandler(r.handler.RequestRunApprovalHandler), 10*time.Second))
+	admin.POST("/payroll/periods/:period_id/run/approve", r.processTimeout(WrapWithErrorHandler(r.handler.ApproveRunHandler), 10*time.Second))
+	admin.POST("/payroll/periods/:period_id/run/reject", r.processTimeout(WrapWithErrorHandler(r.handler.RejectRunHandler), 10*time.Second))
+	admin.POST("/payroll/periods/:period_id/disbursement", r.processTimeout(WrapWithErrorHandler(r.handler.GenerateDisbursementHandler), 30*time.Second))
+	admin.GET("/payroll/periods/:period_id/disbursement", r.processTimeout(WrapWithErrorHandler(r.handler.GetDisbursementHandler), 10*time.Second))
+	admin.GET("/payroll/periods/:period_id/disbursement/file", r.processTimeout(WrapWithErrorHandler(r.handler.DownloadDisbursementHandler), 30*time.Second))
+	admin.POST("/payroll/periods/:period_id/disbursement/results", r.processTimeout(WrapWithErrorHandler(r.handler.ImportPaymentResultsHandler), 60*time.Second))
+	admin.POST("/payroll/periods/:period_id/import", r.processTimeout(WrapWithErrorHandler(r.handler.ImportPeriodEntriesHandler), 60*time.Second))
+	admin.GET("/payroll/periods/:period_id/attendance/flagged", r.processTimeout(WrapWithErrorHandler(r.handler.ListFlaggedAttendancesHandler), 10*time.Second))
+	admin.GET("/payroll/periods/:period_id/overtime/flagged", r.processTimeout(WrapWithErrorHandler(r.handler.ListFlaggedOvertimesHandler), 10*time.Second))
+	admin.GET("/payroll/periods/:period_id/adjustments", r.processTimeout(WrapWithErrorHandler(r.handler.ListPeriodAdjustmentsHandler), 10*time.Second))
+	admin.POST("/payroll/adjustments/:adjustment_id/approve", r.processTimeout(WrapWithErrorHandler(r.handler.ApprovePriorPeriodAdjustmentHandler), 10*time.Second))
+	admin.POST("/payroll/adjustments/:adjustment_id/reject", r.processTimeout(WrapWithErrorHandler(r.handler.RejectPriorPeriodAdjustmentHandler), 10*time.Second))
+	admin.GET("/payroll/periods/:period_id/payments", r.processTimeout(WrapWithErrorHandler(r.handler.ListPaymentsHandler), 10*time.Second))
+	admin.POST("/admin/users/:user_id/sessions/revoke", r.processTimeout(WrapWithErrorHandler(r.handler.RevokeUserSessionsHandler), 10*time.Second))
+	admin.GET("/admin/employees", r.processTimeout(WrapWithErrorHandler(r.handler.ListEmployeesHandler), 10*time.Second))
+	admin.GET("/admin/employees/:user_id", r.processTimeout(WrapWithErrorHandler(r.handler.GetEmployeeHandler), 10*time.Second))
+	admin.PATCH("/admin/employees/:user_id", r.processTimeout(WrapWithErrorHandler(r.handler.UpdateEmployeeHandler), 10*time.Second))
+	admin.GET("/admin/employees/:user_id/bank-account", r.processTimeout(WrapWithErrorHandler(r.handler.GetEmployeeBankAccountHandler), 10*time.Second))
+	admin.PUT("/admin/employees/:user_id/bank-account", r.processTimeout(WrapWithErrorHandler(r.handler.SetEmployeeBankAccountHandler), 10*time.Second))
+	admin.POST("/admin/employees/:user_id/bank-account/verify", r.processTimeout(WrapWithErrorHandler(r.handler.VerifyEmployeeBankAccountHandler), 10*time.Second))
+	admin.GET("/admin/employees/:user_id/bank-account/history", r.processTimeout(WrapWithErrorHandler(r.handler.ListBankAccountHistoryHandler), 10*time.Second))
+	admin.GET("/admin/attendance/corrections", r.processTimeout(WrapWithErrorHandler(r.handler.ListAttendanceCorrectionsHandler), 10*time.Second))
+	admin.POST("/admin/attendance/corrections/:correction_id/approve", r.processTimeout(WrapWithErrorHandler(r.handler.ApproveAttendanceCorrectionHandler), 10*time.Second))
+	admin.POST("/admin/attendance/corrections/:correction_id/reject", r.processTimeout(WrapWithErrorHandler(r.handler.RejectAttendanceCorrectionHandler), 10*time.Second))
+	admin.GET("/admin/overtime/plans", r.processTimeout(WrapWithErrorHandler(r.handler.ListOvertimePlansHandler), 10*time.Second))
+	admin.POST("/admin/overtime/plans/:plan_id/approve", r.processTimeout(WrapWithErrorHandler(r.handler.ApproveOvertimePlanHandler), 10*time.Second))
+	admin.POST("/admin/overtime/plans/:plan_id/reject", r.processTimeout(WrapWithErrorHandler(r.handler.RejectOvertimePlanHandler), 10*time.Second))
+	admin.POST("/admin/attendance/submit", r.processTimeout(WrapWithErrorHandler(r.handler.SubmitAttendanceOnBehalfHandler), 10*time.Second))
+	admin.POST("/admin/overtime/submit", r.processTimeout(WrapWithErrorHandler(r.handler.SubmitOvertimeOnBehalfHandler), 10*time.Second))
+	admin.POST("/admin/reimbursements", r.processTimeout(WrapWithErrorHandler(r.handler.CreateReimbursementOnBehalfHandler), 10*time.Second))
+	admin.GET("/admin/office-locations", r.processTimeout(WrapWithErrorHandler(r.handler.ListOfficeLocationsHandler), 10*time.Second))
+	admin.POST("/admin/office-locations", r.processTimeout(WrapWithErrorHandler(r.handler.CreateOfficeLocationHandler), 10*time.Second))
+	admin.PATCH("/admin/office-locations/:office_location_id", r.processTimeout(WrapWithErrorHandler(r.handler.UpdateOfficeLocationHandler), 10*time.Second))
+	admin.DELETE("/admin/office-locations/:office_location_id", r.processTimeout(WrapWithErrorHandler(r.handler.DeleteOfficeLocationHandler), 10*time.Second))
+	admin.GET("/admin/devices", r.processTimeout(WrapWithErrorHandler(r.handler.ListDevicesHandler), 10*time.Second))
+	admin.POST("/admin/devices", r.processTimeout(WrapWithErrorHandler(r.handler.CreateDeviceHandler), 10*time.Second))
+	admin.PATCH("/admin/devices/:device_id", r.processTimeout(WrapWithErrorHandler(r.handler.UpdateDeviceHandler), 10*time.Second))
+	admin.POST("/admin/devices/:device_id/rotate-key", r.processTimeout(WrapWithErrorHandler(r.handler.RotateDeviceKeyHandler), 10*time.Second))
+	admin.GET("/admin/devices/:device_id/users", r.processTimeout(WrapWithErrorHandler(r.handler.ListDeviceUsersHandler), 10*time.Second))
+	admin.PUT("/admin/devices/:device_id/users", r.processTimeout(WrapWithErrorHandler(r.handler.MapDeviceUserHandler), 10*time.Second))
+	admin.DELETE("/admin/devices/:device_id/users/:device_user_id", r.processTimeout(WrapWithErrorHandler(r.handler.UnmapDeviceUserHandler), 10*time.Second))
+	admin.GET("/admin/lockouts", r.processTimeout(WrapWithErrorHandler(r.handler.ListLockoutsHandler), 10*time.Second))
+	admin.POST("/admin/lockouts/clear", r.processTimeout(WrapWithErrorHandler(r.handler.ClearLockoutHandler), 10*time.Second))
 	// USER or ADMIN
 	user := protected.Group("")
 	user.Use(RequireUserOrAdmin())
 	// contoh endpoint submit attendance
 	user.POST("/attendance/submit", r.processTimeout(WrapWithErrorHandler(r.handler.SubmitAttendanceHandler), 10*time.Second))
+	user.GET("/attendance", r.processTimeout(WrapWithErrorHandler(r.handler.ListMyAttendancesHandler), 10*time.Second))
+	user.GET("/attendance/calendar", r.processTimeout(WrapWithErrorHandler(r.handler.GetMyAttendanceCalendarHandler), 10*time.Second))
+	user.POST("/attendance/check-in", r.processTimeout(WrapWithErrorHandler(r.handler.CheckInHandler), 10*time.Second))
+	user.POST("/attendance/check-out", r.processTimeout(WrapWithErrorHandler(r.handler.CheckOutHandler), 10*time.Second))
+	user.POST("/attendance/corrections", r.processTimeout(WrapWithErrorHandler(r.handler.CreateAttendanceCorrectionHandler), 10*time.Second))
+	user.GET("/attendance/corrections", r.processTimeout(WrapWithErrorHandler(r.handler.ListMyAttendanceCorrectionsHandler), 10*time.Second))
+	user.DELETE("/attendance/:attendance_id", r.processTimeout(WrapWithErrorHandler(r.handler.DeleteMyAttendanceHandler), 10*time.Second))
 	user.POST("/overtime/submit", r.processTimeout(WrapWithErrorHandler(r.handler.SubmitOvertimeHandler), 10*time.Second))
+	user.GET("/overtime", r.processTimeout(WrapWithErrorHandler(r.handler.ListMyOvertimesHandler), 10*time.Second))
+	user.POST("/overtime/plans", r.processTimeout(WrapWithErrorHandler(r.handler.CreateOvertimePlanHandler), 10*time.Second))
+	user.GET("/overtime/plans", r.processTimeout(WrapWithErrorHandler(r.handler.ListMyOvertimePlansHandler), 10*time.Second))
+	user.PATCH("/overtime/:overtime_id", r.processTimeout(WrapWithErrorHandler(r.handler.UpdateMyOvertimeHandler), 10*time.Second))
+	user.DELETE("/overtime/:overtime_id", r.processTimeout(WrapWithErrorHandler(r.handler.DeleteMyOvertimeHandler), 10*time.Second))
 	user.POST("/reimbursements", r.processTimeout(WrapWithErrorHandler(r.handler.CreateReimbursementHandler), 10*time.Second))
+	user.GET("/reimbursements", r.processTimeout(WrapWithErrorHandler(r.handler.ListMyReimbursementsHandler), 10*time.Second))
+	user.PATCH("/reimbursements/:reimbursement_id", r.processTimeout(WrapWithErrorHandler(r.handler.UpdateMyReimbursementHandler), 10*time.Second))
+	user.DELETE("/reimbursements/:reimbursement_id", r.processTimeout(WrapWithErrorHandler(r.handler.DeleteMyReimbursementHandler), 10*time.Second))
+	user.POST("/adjustments", r.processTimeout(WrapWithErrorHandler(r.handler.CreatePriorPeriodAdjustmentHandler), 10*time.Second))
+	user.GET("/adjustments", r.processTimeout(WrapWithErrorHandler(r.handler.ListMyAdjustmentsHandler), 10*time.Second))
+	user.GET("/me", r.processTimeout(WrapWithErrorHandler(r.handler.GetMyProfileHandler), 5*time.Second))
+	user.PATCH("/me", r.processTimeout(WrapWithErrorHandler(r.handler.UpdateMyProfileHandler), 5*time.Second))
+	user.PUT("/me/profile-image", r.processTimeout(WrapWithErrorHandler(r.handler.UploadProfileImageHandler), 15*time.Second))
+	user.GET("/me/bank-account", r.processTimeout(WrapWithErrorHandler(r.handler.GetMyBankAccountHandler), 5*time.Second))
+	user.PUT("/me/bank-account", r.processTimeout(WrapWithErrorHandler(r.handler.SetMyBankAccountHandler), 10*time.Second))
 	user.GET("/payslips/periods/:period_id",
 		r.processTimeout(WrapWithErrorHandler(r.handler.GeneratePayslipHandler), 10*time.Second))
 
@@ -100,7 +197,8 @@ func (r *Route) processTimeout(handler gin.HandlerFunc, duration time.Duration)
 	}
 }
 
-func RequireAdmin() gin.HandlerFunc {
+// RequireAdmin; requireOTP (auth.twoFactor.requiredForAdmin) menolak token yang login-nya tanpa 2FA.
+func RequireAdmin(requireOTP bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if role := c.GetString("role"); role != "admin" {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
@@ -109,9 +207,28 @@ func RequireAdmin() gin.HandlerFunc {
 			})
 			return
 		}
+		if requireOTP && !hasAMR(c, "otp") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"responseCode":    "4030102",
+				"responseMessage": "two-factor authentication required",
+			})
+			return
+		}
 		c.Next()
 	}
 }
+
+func hasAMR(c *gin.Context, method string) bool {
+	amr, _ := c.Get("amr")
+	list, _ := amr.([]string)
+	for _, m := range list {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
 func RequireUserOrAdmin() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if role := c.GetString("role"); role != "user" && role != "admin" {
@@ -15,19 +15,35 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/v1/attendance/submit": {
-            "post": {
-                "description": "Users can submit one attendance per day. Weekend submissions are rejected. If already submitted for the same day, response will indicate \"already_exists\".",
-                "consumes": [
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Public keys (RS256/EdDSA) used to sign access tokens, including rotated keys that are still accepted. HS256 secrets are never published.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/jwtkey.JWKSet"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/adjustments": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Attendance"
+                    "Adjustments"
                 ],
-                "summary": "Submit attendance (weekday only)",
+                "summary": "List my prior period adjustments",
                 "parameters": [
                     {
                         "type": "string",
@@ -37,24 +53,27 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Submit Attendance Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/attendance.SubmitAttendanceRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/attendance.SubmitAttendanceResponse"
+                            "$ref": "#/definitions/utils.Response-array_adjustment_AdjustmentResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request / weekend not allowed",
+                        "description": "Invalid query",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -65,18 +84,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response-any"
-                        }
-                    },
-                    "408": {
-                        "description": "Request Process Timeout",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response-any"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -84,11 +91,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/auth/login": {
+            },
             "post": {
-                "description": "Login user with email and password",
+                "description": "Overtime / reimbursement untuk tanggal di period yang sudah di-run. Dicatat sebagai adjustment pending; setelah di-approve admin dibayar di period open berikutnya (terpisah di payslip).",
                 "consumes": [
                     "application/json"
                 ],
@@ -96,41 +101,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Adjustments"
                 ],
-                "summary": "Login User",
+                "summary": "Submit a prior period adjustment",
                 "parameters": [
                     {
-                        "description": "Login User Request",
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Adjustment Request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginUserRequest"
+                            "$ref": "#/definitions/adjustment.CreateAdjustmentRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/auth.LoginUserResponse"
+                            "$ref": "#/definitions/utils.Response-adjustment_AdjustmentResponse"
                         }
                     },
                     "400": {
-                        "description": "Error response",
+                        "description": "Invalid request / date not locked / no open period",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
                     "401": {
-                        "description": "Error response",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Overtime already submitted for that date",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
                     "500": {
-                        "description": "Error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -138,51 +156,76 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/auth/register": {
-            "post": {
-                "description": "Register new user with required fields",
-                "consumes": [
-                    "application/json"
-                ],
+        "/v1/admin/attendance/corrections": {
+            "get": {
+                "description": "Admin: semua pengajuan koreksi, terbaru lebih dulu.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Attendance"
                 ],
-                "summary": "Register User",
+                "summary": "List attendance correction requests",
                 "parameters": [
                     {
-                        "description": "Register User Request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/auth.RegisterUserRequest"
-                        }
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending | approved | rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by employee",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/auth.RegisterUserResponse"
+                            "$ref": "#/definitions/utils.Response-array_attendance_CorrectionResponse"
                         }
                     },
                     "400": {
-                        "description": "Error response",
+                        "description": "Invalid query",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
-                    "409": {
-                        "description": "Error response",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
                     "500": {
-                        "description": "Error response",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -190,9 +233,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/overtime/submit": {
+        "/v1/admin/attendance/corrections/{correction_id}/approve": {
             "post": {
-                "description": "Submit overtime hours (\u003c= 3h). Only allowed after 17:00 WIB if submitting for today. Weekend allowed.",
+                "description": "Menerapkan koreksi (attendance dibuat / dihapus) dan mencatat audit. Pengaju tidak bisa menyetujui koreksinya sendiri.",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,9 +243,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Overtime"
+                    "Attendance"
                 ],
-                "summary": "Submit overtime",
+                "summary": "Approve an attendance correction",
                 "parameters": [
                     {
                         "type": "string",
@@ -212,12 +255,18 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Submit Overtime Request",
+                        "type": "integer",
+                        "description": "Correction ID",
+                        "name": "correction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/overtime.SubmitOvertimeRequest"
+                            "$ref": "#/definitions/attendance.ReviewCorrectionRequest"
                         }
                     }
                 ],
@@ -225,11 +274,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/overtime.SubmitOvertimeResponse"
+                            "$ref": "#/definitions/utils.Response-attendance_CorrectionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request / hours \u003e 3 / before 17:00",
+                        "description": "Invalid request / period locked",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -241,13 +290,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Admin only / own request",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
-                    "408": {
-                        "description": "Request Process Timeout",
+                    "404": {
+                        "description": "Correction not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed / attendance changed meanwhile",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -261,9 +316,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/payroll/periods": {
+        "/v1/admin/attendance/corrections/{correction_id}/reject": {
             "post": {
-                "description": "Admin membuat periode payroll (tidak boleh overlap, end_date \u003e= start_date). Tanggal format YYYY-MM-DD.",
                 "consumes": [
                     "application/json"
                 ],
@@ -271,9 +325,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payroll"
+                    "Attendance"
                 ],
-                "summary": "Create payroll attendance period",
+                "summary": "Reject an attendance correction",
                 "parameters": [
                     {
                         "type": "string",
@@ -283,24 +337,31 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Create Payroll Period Request",
+                        "type": "integer",
+                        "description": "Correction ID",
+                        "name": "correction_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection note (required)",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/attendance_period.CreatePeriodRequest"
+                            "$ref": "#/definitions/attendance.ReviewCorrectionRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/attendance_period.PeriodResponse"
+                            "$ref": "#/definitions/utils.Response-attendance_CorrectionResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body / invalid dates / overlapping period",
+                        "description": "Invalid request / missing note",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -312,13 +373,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Admin only",
+                        "description": "Admin only / own request",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
-                    "408": {
-                        "description": "Request Process Timeout",
+                    "404": {
+                        "description": "Correction not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -332,9 +399,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/payroll/periods/{period_id}/run": {
+        "/v1/admin/attendance/submit": {
             "post": {
-                "description": "Processes payslips for the specified attendance period. After run, submissions in that period won't affect payslip. Can only run once per period.",
+                "description": "Admin menginput attendance untuk karyawan (tanpa device / sedang cuti). Validasi sama dengan submit biasa (weekday, period belum terkunci) tanpa geofence. Admin \u0026 alasan dicatat di attendance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -342,9 +409,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Payroll"
+                    "Attendance"
                 ],
-                "summary": "Run payroll for a period (admin only)",
+                "summary": "Submit attendance on behalf of an employee",
                 "parameters": [
                     {
                         "type": "string",
@@ -354,22 +421,24 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Attendance Period ID",
-                        "name": "period_id",
-                        "in": "path",
-                        "required": true
+                        "description": "On-behalf Attendance Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attendance.OnBehalfAttendanceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/payroll.RunPayrollResponse"
+                            "$ref": "#/definitions/attendance.SubmitAttendanceResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid period / already run / no working days",
+                        "description": "Invalid request / weekend not allowed / period locked / employee terminated",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -386,8 +455,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
-                    "408": {
-                        "description": "Request Process Timeout",
+                    "404": {
+                        "description": "Employee not found",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -401,19 +470,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/payslips/periods/{period_id}": {
+        "/v1/admin/devices": {
             "get": {
-                "description": "Generates a payslip with attendance, overtime, reimbursements and totals. If payroll already ran for the period, snapshot values are used.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Semua mesin absensi biometrik yang terdaftar (aktif \u0026 nonaktif).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Payslip"
+                    "Device"
                 ],
-                "summary": "Generate payslip for a period (employee)",
+                "summary": "List attendance devices",
                 "parameters": [
                     {
                         "type": "string",
@@ -421,26 +487,13 @@ const docTemplate = `{
                         "name": "Authorization",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Attendance Period ID",
-                        "name": "period_id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/payslip.PayslipResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid period / no working days",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response-any"
+                            "$ref": "#/definitions/utils.Response-array_device_DeviceResponse"
                         }
                     },
                     "401": {
@@ -450,13 +503,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/utils.Response-any"
-                        }
-                    },
-                    "408": {
-                        "description": "Request Process Timeout",
+                        "description": "Admin only",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -468,11 +515,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/reimbursements": {
+            },
             "post": {
-                "description": "Create a reimbursement with amount and optional description.",
+                "description": "Mendaftarkan mesin absensi; vendor harus punya adapter (zkteco, generic). api_key hanya ditampilkan sekali dan dikirim mesin lewat header X-Device-Key.",
                 "consumes": [
                     "application/json"
                 ],
@@ -480,9 +525,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reimbursement"
+                    "Device"
                 ],
-                "summary": "Create reimbursement",
+                "summary": "Register attendance device",
                 "parameters": [
                     {
                         "type": "string",
@@ -492,12 +537,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Create Reimbursement Request",
+                        "description": "Device",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/reimbursement.CreateReimbursementRequest"
+                            "$ref": "#/definitions/device.CreateDeviceRequest"
                         }
                     }
                 ],
@@ -505,11 +550,11 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/reimbursement.ReimbursementResponse"
+                            "$ref": "#/definitions/utils.Response-device_DeviceKeyResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request / amount \u003c= 0",
+                        "description": "Invalid request / unknown vendor",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -521,13 +566,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Admin only",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
                     },
-                    "408": {
-                        "description": "Request Process Timeout",
+                    "409": {
+                        "description": "Serial number already registered",
                         "schema": {
                             "$ref": "#/definitions/utils.Response-any"
                         }
@@ -540,409 +585,9180 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "attendance.SubmitAttendanceRequest": {
-            "type": "object",
-            "properties": {
-                "date": {
-                    "description": "Optional, default = today (WIB). Format YYYY-MM-DD",
-                    "type": "string"
-                }
-            }
         },
-        "attendance.SubmitAttendanceResponse": {
-            "type": "object",
-            "properties": {
-                "date": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "\"created\" atau \"already_exists\"",
-                    "type": "string"
-                },
+        "/v1/admin/devices/{device_id}": {
+            "patch": {
+                "description": "Field yang tidak dikirim tidak diubah. Set active=false untuk menolak kiriman dari mesin tsb.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Update attendance device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/device.UpdateDeviceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-device_DeviceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Device not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/devices/{device_id}/rotate-key": {
+            "post": {
+                "description": "Membuat API key baru; key lama langsung tidak berlaku.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Rotate device API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-device_DeviceKeyResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid device_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Device not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/devices/{device_id}/users": {
+            "get": {
+                "description": "Pemetakan PIN karyawan di mesin ke user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "List device user mappings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_device_DeviceUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid device_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Device not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Memetakan PIN karyawan di mesin ke user; PIN yang sudah dipetakan akan ditimpa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Map device user to employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mapping",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/device.MapDeviceUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-device_DeviceUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Device / employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/devices/{device_id}/users/{device_user_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Remove device user mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Device ID",
+                        "name": "device_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "PIN karyawan di mesin",
+                        "name": "device_user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid device_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Mapping not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/employees": {
+            "get": {
+                "description": "Employee directory with search by name/email, filters, sorting and pagination. Pagination info is returned in metadata.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employee"
+                ],
+                "summary": "List employees (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search first/last name or email",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "admin | user",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Department (case-insensitive exact match)",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "active | probation | on_leave | terminated",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name | email | department | salary | created_at (default id)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "asc (default) | desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_employee_EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/employees/{user_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employee"
+                ],
+                "summary": "Get employee detail (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-employee_EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates name, role, salary, department and employment status. Omitted fields are unchanged. Changing the role or terminating the employee revokes all of their sessions; terminated employees cannot log in. Admins cannot change their own role or status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employee"
+                ],
+                "summary": "Update employee (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Employee Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/employee.UpdateEmployeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-employee_EmployeeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only / own role or status",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/employees/{user_id}/bank-account": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Account"
+                ],
+                "summary": "Get employee bank account (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-bankaccount_BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "No bank account",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Same as PUT /v1/me/bank-account on behalf of an employee. The account becomes unverified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Account"
+                ],
+                "summary": "Set employee bank account (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Bank Account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bankaccount.SetBankAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-bankaccount_BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/employees/{user_id}/bank-account/history": {
+            "get": {
+                "description": "Every change and verification of the employee's account, newest first. Account numbers are masked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Account"
+                ],
+                "summary": "Bank account change history (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_bankaccount_BankAccountHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/employees/{user_id}/bank-account/verify": {
+            "post": {
+                "description": "Marks the current account as verified (e.g. after a bank inquiry). Admins cannot verify their own account.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Account"
+                ],
+                "summary": "Verify employee bank account (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-bankaccount_BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only / own account",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "No bank account",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already verified",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/lockouts": {
+            "get": {
+                "description": "Accounts (by email) and IPs that are locked out after repeated failed logins. Use all=true to include keys that have failures but are not locked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "List login lockouts (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include unlocked keys with failures",
+                        "name": "all",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_auth_LockoutResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/lockouts/clear": {
+            "post": {
+                "description": "Resets the failed-attempt counter and lock of an account (email) or IP.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Clear a login lockout (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Clear Lockout Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ClearLockoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/office-locations": {
+            "get": {
+                "description": "Semua office location (aktif \u0026 nonaktif) yang dipakai geofence attendance.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Office Location"
+                ],
+                "summary": "List office locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_officelocation_OfficeLocationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Kantor dengan titik koordinat + radius (meter) dan/atau allowed_ip_ranges (CIDR / IP dipisah koma). Submission lolos jika dalam radius ATAU IP cocok.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Office Location"
+                ],
+                "summary": "Create office location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Office Location",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/officelocation.CreateOfficeLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-officelocation_OfficeLocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / invalid ip range",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/office-locations/{office_location_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Office Location"
+                ],
+                "summary": "Delete office location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Office Location ID",
+                        "name": "office_location_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid office_location_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Office location not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Field yang tidak dikirim tidak diubah. Set active=false untuk mengecualikan kantor dari geofence.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Office Location"
+                ],
+                "summary": "Update office location",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Office Location ID",
+                        "name": "office_location_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/officelocation.UpdateOfficeLocationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-officelocation_OfficeLocationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / invalid ip range",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Office location not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/overtime/plans": {
+            "get": {
+                "description": "Admin: semua rencana overtime, tanggal terbaru lebih dulu.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "List overtime plans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending | approved | rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by employee",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_overtime_OvertimePlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/overtime/plans/{plan_id}/approve": {
+            "post": {
+                "description": "Overtime yang sudah terlanjur disubmit pada tanggal tsb dicocokkan ulang. Pengaju tidak bisa menyetujui rencananya sendiri.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "Approve an overtime plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Overtime Plan ID",
+                        "name": "plan_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/overtime.ReviewOvertimePlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-overtime_OvertimePlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only / own plan",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/overtime/plans/{plan_id}/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "Reject an overtime plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Overtime Plan ID",
+                        "name": "plan_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection note (required)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/overtime.ReviewOvertimePlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-overtime_OvertimePlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / missing note",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only / own plan",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/overtime/submit": {
+            "post": {
+                "description": "Admin menginput overtime untuk karyawan. Validasi sama dengan submit biasa (\u003c= 3h, setelah 17:00 WIB jika hari ini, period belum terkunci). Admin \u0026 alasan dicatat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "Submit overtime on behalf of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "On-behalf Overtime Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/overtime.OnBehalfOvertimeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/overtime.SubmitOvertimeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / hours \u003e 3 / before 17:00 / period locked / employee terminated",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/reimbursements": {
+            "post": {
+                "description": "Admin menginput reimbursement untuk karyawan. Validasi sama dengan create biasa (amount \u003e 0, period belum terkunci). Admin \u0026 alasan dicatat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursement"
+                ],
+                "summary": "Create reimbursement on behalf of an employee",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "On-behalf Reimbursement Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reimbursement.OnBehalfReimbursementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/reimbursement.ReimbursementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / period locked / employee terminated",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Employee not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/users/{user_id}/sessions/revoke": {
+            "post": {
+                "description": "Revokes every refresh token and access token of the user, e.g. when an employee is terminated.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Revoke all sessions of a user (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/attendance": {
+            "get": {
+                "description": "Riwayat milik sendiri per period_id atau start_date \u0026 end_date (maks 366 hari); tanpa keduanya dipakai period yang memuat hari ini. status = status period tanggal tsb: open | running | closed | no_period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "List my attendances",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (with end_date)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (with start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_attendance_AttendanceHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query / no period covers today",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/attendance/calendar": {
+            "get": {
+                "description": "Satu baris per hari dalam period (default period yang memuat hari ini): attended | missing | weekend | holiday | upcoming. Hari libur diambil dari konfigurasi attendance.holidays.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "My attendance calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_AttendanceCalendarResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query / no period covers today",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/attendance/check-in": {
+            "post": {
+                "description": "Mencatat jam masuk hari ini (WIB, weekday saja). Terlambat jika lewat attendance.workStart + lateGrace.\nBody opsional berisi latitude/longitude; lokasi \u0026 IP dicek terhadap office location (attendance.geofence.mode).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Clock in for today",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Lokasi device",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/attendance.CheckInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_AttendanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Weekend / period locked / outside office locations",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already checked in",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/attendance/check-out": {
+            "post": {
+                "description": "Mencatat jam pulang hari ini dan menghitung jam kerja. Pulang cepat jika sebelum attendance.workEnd.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Clock out for today",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_AttendanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Not checked in / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already checked out",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/attendance/corrections": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "List my attendance correction requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending | approved | rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_attendance_CorrectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ajukan penambahan (lupa submit) atau penghapusan (salah tanggal) attendance pada satu tanggal beserta alasan. Diterapkan setelah disetujui admin. Tanggal di period yang sudah terkunci ditolak.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Request an attendance correction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Correction Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attendance.CreateCorrectionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_CorrectionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / weekend / future date / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Attendance already exists / correction already pending",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/attendance/submit": {
+            "post": {
+                "description": "Users can submit one attendance per day. Weekend submissions are rejected. If already submitted for the same day, response will indicate \"already_exists\".\nLatitude/longitude (opsional) dan IP client dicatat; di luar office location ditandai atau ditolak sesuai attendance.geofence.mode.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Submit attendance (weekday only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Submit Attendance Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attendance.SubmitAttendanceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/attendance.SubmitAttendanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / weekend not allowed / outside office locations",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "408": {
+                        "description": "Request Process Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/attendance/{attendance_id}": {
+            "delete": {
+                "description": "Hapus attendance milik sendiri. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll). Penghapusan tercatat di attendance_audits (source = self).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "Delete my attendance",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance ID",
+                        "name": "attendance_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid attendance_id / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Submitted by an admin / recorded by a device / added by a correction",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Attendance not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/2fa/activate": {
+            "post": {
+                "description": "Confirms enrollment with a code from the authenticator app and returns one-time recovery codes (shown only once). Log in again to get a token that carries the otp method.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Activate two-factor authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Authenticator code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-auth_RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Not enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized / wrong code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/2fa/disable": {
+            "post": {
+                "description": "Requires the password and a current authenticator or recovery code. Not allowed for admins when 2FA is mandatory.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Disable Two-Factor Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.TwoFactorDisableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong password / 2FA not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized / wrong code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "2FA mandatory for admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/2fa/enroll": {
+            "post": {
+                "description": "Generates a new TOTP secret and otpauth URI (render it as a QR code). 2FA is not active until /v1/auth/2fa/activate succeeds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Start two-factor enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-auth_TwoFactorEnrollResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/2fa/recovery-codes": {
+            "post": {
+                "description": "Replaces all recovery codes; the old ones stop working. Requires a current authenticator or recovery code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Authenticator or recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-auth_RecoveryCodesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "2FA not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized / wrong code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/2fa/verify": {
+            "post": {
+                "description": "Exchanges the challengeToken from /v1/auth/login plus a 6-digit authenticator code (or a recovery code) for access + refresh tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Complete login with a two-factor code",
+                "parameters": [
+                    {
+                        "description": "Two-Factor Verify Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.TwoFactorVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid / expired challenge or wrong code",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many wrong codes",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/login": {
+            "post": {
+                "description": "Login user with email and password. When two-factor authentication is enabled no token is issued; the response carries twoFactorRequired=true and a short-lived challengeToken for POST /v1/auth/2fa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Login User",
+                "parameters": [
+                    {
+                        "description": "Login User Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error response",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts (locked out)",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Error response",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/logout": {
+            "post": {
+                "description": "Revoke the current session. Set all_devices=true to revoke every session and access token of the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Logout Request",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "Redirect target registered at the identity provider. Verifies the ID token, links it to an existing user (by GoogleID or verified email) and returns our own tokens, or a 2FA challenge when enabled. Accounts are never created here.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "SSO login callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State from the start endpoint",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.LoginUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid / expired state or provider error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "ID token rejected",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "No linked account / unverified email",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown provider",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/oidc/{provider}/start": {
+            "get": {
+                "description": "Returns the identity provider authorization URL (authorization code flow with PKCE). Send the browser there; the provider redirects back to the callback endpoint.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Start SSO login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name from auth.oidc.providers, e.g. google",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Device identifier for the session",
+                        "name": "device_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Respond with 302 to the provider instead of JSON",
+                        "name": "redirect",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-auth_OIDCStartResponse"
+                        }
+                    },
+                    "302": {
+                        "description": "Redirect to the provider"
+                    },
+                    "404": {
+                        "description": "Unknown provider",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/password/change": {
+            "post": {
+                "description": "Changes the password of the logged-in user. All sessions (including the current one) are revoked; login again afterwards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Change Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong current password or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/password/forgot": {
+            "post": {
+                "description": "Sends a single-use, expiring reset link to the email if it is registered. Always responds with success so registered emails cannot be discovered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Request password reset",
+                "parameters": [
+                    {
+                        "description": "Forgot Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/password/reset": {
+            "post": {
+                "description": "Sets a new password using a reset token. The token can only be used once; all existing sessions are revoked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset Password Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid / expired token or password policy violation",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access + refresh token pair. Refresh tokens are single-use (rotation); reusing an old one revokes the whole session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/auth.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid / expired / reused refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/auth/register": {
+            "post": {
+                "description": "Register new user with required fields",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Register User",
+                "parameters": [
+                    {
+                        "description": "Register User Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/auth.RegisterUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/auth.RegisterUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Error response",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Error response",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Error response",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/devices/punches": {
+            "post": {
+                "description": "Dipanggil mesin absensi (bukan user). Diautentikasi dengan header X-Device-Key. Body sesuai vendor mesin: zkteco = ATTLOG (PIN\u003cTAB\u003eYYYY-MM-DD HH:MM:SS\u003cTAB\u003estatus per baris); generic = JSON {\"punches\":[{\"user_id\",\"time\",\"direction\"}]}. Punch pertama per hari membuat attendance; kiriman ulang tidak membuat data ganda.",
+                "consumes": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Device"
+                ],
+                "summary": "Push punches from an attendance device",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device API key",
+                        "name": "X-Device-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Vendor payload (max 2MB)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-device_IngestPunchesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid payload",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unknown / inactive device key",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me": {
+            "get": {
+                "description": "Returns the profile of the logged-in employee. Salary and role are read-only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Get my profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-profile_ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially updates first_name, last_name, age, bio, location and interests of the logged-in employee. Omitted fields are left unchanged; unknown fields (e.g. salary, role, email) are rejected. is_profile_complete is recomputed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Update my profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Profile Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/profile.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-profile_ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body / validation error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/bank-account": {
+            "get": {
+                "description": "The account number is always masked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Account"
+                ],
+                "summary": "Get my salary bank account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-bankaccount_BankAccountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "No bank account yet",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Creates or replaces the account. A changed account must be verified again by an admin before payroll treats it as payable; the employee is notified by email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bank Account"
+                ],
+                "summary": "Set my salary bank account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Bank Account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/bankaccount.SetBankAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-bankaccount_BankAccountResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/me/profile-image": {
+            "put": {
+                "description": "Replaces the profile image of the logged-in employee. Accepts JPEG, PNG or WebP up to 2MB (multipart field \"image\"); the type is detected from the file content.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Profile"
+                ],
+                "summary": "Upload my profile image",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Profile image",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-profile_ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing / too large / unsupported image",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/overtime": {
+            "get": {
+                "description": "Riwayat milik sendiri per period_id atau start_date \u0026 end_date (maks 366 hari); tanpa keduanya dipakai period yang memuat hari ini. status = status period tanggal tsb: open | running | closed | no_period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "List my overtime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (with end_date)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (with start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_overtime_OvertimeHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query / no period covers today",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/overtime/plans": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "List my overtime plans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pending | approved | rejected",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_overtime_OvertimePlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Ajukan rencana overtime (tanggal, jam yang direncanakan, justifikasi) sebelum dijalankan: tanggal hari ini paling lambat 17:00 WIB atau tanggal mendatang. Disetujui admin; overtime yang disubmit kemudian dicocokkan dengan rencana ini.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "Request an overtime plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Overtime Plan Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/overtime.CreateOvertimePlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-overtime_OvertimePlanResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / past date / after 17:00 / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Plan already pending or approved for that date",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/overtime/submit": {
+            "post": {
+                "description": "Submit overtime hours (\u003c= 3h). Only allowed after 17:00 WIB if submitting for today. Weekend allowed.\nDicocokkan dengan rencana overtime yang disetujui: tanpa / melebihi rencana ditandai (plan_flag) atau ditolak sesuai overtime.planMode.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "Submit overtime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Submit Overtime Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/overtime.SubmitOvertimeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/overtime.SubmitOvertimeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / hours \u003e 3 / before 17:00 / no or exceeded plan (overtime.planMode = reject)",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "408": {
+                        "description": "Request Process Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/overtime/{overtime_id}": {
+            "delete": {
+                "description": "Hapus overtime milik sendiri. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "Delete my overtime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Overtime ID",
+                        "name": "overtime_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid overtime_id / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Submitted by an admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Overtime not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Ubah jam overtime milik sendiri (\u003c= 3h). Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "Update my overtime",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Overtime ID",
+                        "name": "overtime_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New hours",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/overtime.UpdateOvertimeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-overtime_OvertimeHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Submitted by an admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Overtime not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/adjustments/{adjustment_id}/approve": {
+            "post": {
+                "description": "Adjustment baru ikut dihitung di payroll setelah di-approve. Hanya masuk period yang belum di-run atau run-nya masih draft (jika sudah diajukan / terkunci, dipindah ke period berikutnya); run draft di period itu ditandai stale. Karyawan tidak bisa menyetujui adjustment-nya sendiri.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Adjustments"
+                ],
+                "summary": "Approve a prior period adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Adjustment ID",
+                        "name": "adjustment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/adjustment.ReviewAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-adjustment_AdjustmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / no open period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only / own adjustment",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Adjustment not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/adjustments/{adjustment_id}/reject": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Adjustments"
+                ],
+                "summary": "Reject a prior period adjustment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Adjustment ID",
+                        "name": "adjustment_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection note (required)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/adjustment.ReviewAdjustmentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-adjustment_AdjustmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / missing note",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only / own adjustment",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Adjustment not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods": {
+            "get": {
+                "description": "Daftar period terbaru lebih dulu. Status: open (belum di-run), running (run draft / menunggu approval), closed (run approved ke atas atau ditutup manual).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "List payroll attendance periods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "open | running | closed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_attendance_period_PeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Admin membuat periode payroll (tidak boleh overlap, end_date \u003e= start_date). Tanggal format YYYY-MM-DD.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Create payroll attendance period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Payroll Period Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attendance_period.CreatePeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/attendance_period.PeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body / invalid dates / overlapping period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "408": {
+                        "description": "Request Process Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/generate": {
+            "post": {
+                "description": "Membuat ` + "`" + `count` + "`" + ` period bulanan berurutan dari template (payroll.periodTemplate, bisa ditimpa lewat mode/cutoff_day/name_format). Tanpa ` + "`" + `from` + "`" + ` (YYYY-MM) dimulai setelah period terakhir. Period yang overlap dilewati dan dilaporkan di ` + "`" + `skipped` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Generate next attendance periods from template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Generate Periods Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attendance_period.GeneratePeriodsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_period_GeneratePeriodsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / invalid template",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Get payroll attendance period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_period_PeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Hanya untuk period yang belum di-run dan belum memiliki attendance/overtime/reimbursement.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Delete payroll attendance period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Payroll already run or period has submissions",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Ubah nama/tanggal period selama belum di-run dan belum ditutup. Rentang baru tidak boleh overlap period lain.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Update payroll attendance period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/attendance_period.UpdatePeriodRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_period_PeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / invalid dates / overlapping period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Payroll already run or period closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/adjustments": {
+            "get": {
+                "description": "Admin: semua prior period adjustment untuk period ini (pending, approved \u0026 rejected); hanya yang approved dibayar.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Adjustments"
+                ],
+                "summary": "List adjustments carried into a period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_adjustment_AdjustmentResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/attendance/flagged": {
+            "get": {
+                "description": "Laporan attendance pada rentang period yang lokasi/IP-nya di luar semua office location aktif (attendance.geofence.mode = flag).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attendance"
+                ],
+                "summary": "List attendances flagged outside office locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_attendance_FlaggedAttendanceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period_id / query",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/close": {
+            "post": {
+                "description": "Menutup period secara manual. Attendance/overtime/reimbursement pada rentang period tidak bisa disubmit lagi.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Close payroll attendance period",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-attendance_period_PeriodResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period_id",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Period already closed",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/disbursement": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Get latest disbursement batch (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_DisbursementBatchResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not generated yet",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Builds the bulk-transfer file for the period's approved payroll run (formats: csv, bca_fixed) and records the batch with its totals and SHA-256 checksum. Only items still pending are included. Items without a verified bank account are rejected unless skip_unpayable is true; once verified they are paid through a supplementary batch of the same run.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Generate bank disbursement file (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/payroll.GenerateDisbursementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_DisbursementBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format / run not approved / unverified bank accounts / nothing to pay",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "All items already in a disbursement file",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/disbursement/file": {
+            "get": {
+                "description": "Returns the latest generated file as an attachment; the X-Checksum-SHA256 header carries its checksum.",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Download disbursement file (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Not generated yet",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/disbursement/results": {
+            "post": {
+                "description": "Reconciles payment status from the bank result file (CSV with header; required columns: no, status; optional: account_number, amount, bank_reference, note). Rows are matched by their sequence number in the disbursement file. Status accepts paid/success, failed/rejected and returned. Invalid rows are reported per line without aborting the others.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Import bank transfer results (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Bank result file (CSV, max 5MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_ImportPaymentResultsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing / invalid result file",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run / disbursement not generated",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/import": {
+            "post": {
+                "description": "CSV dengan header; kolom wajib: user_id, date; opsional: type (attendance | overtime | reimbursement, default attendance), hours, amount, description. Tanggal boleh berisi jam (export mesin fingerprint). Setiap baris divalidasi seperti submit biasa (weekend, overtime \u003c= 3 jam, period terkunci) dan dilaporkan per baris. Attendance / overtime yang sudah ada dilewati. Jika ada baris error tidak ada yang disimpan; dry_run=true hanya memvalidasi.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Import attendance, overtime and reimbursements from CSV (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate only",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Import file (CSV, max 5MB)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_ImportEntriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing / invalid file / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/overtime/flagged": {
+            "get": {
+                "description": "Laporan overtime pada rentang period tanpa rencana yang disetujui (no_plan) atau melebihi jam rencana (exceeds_plan); terisi saat overtime.planMode = flag.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Overtime"
+                ],
+                "summary": "List overtime outside the approved plan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_overtime_FlaggedOvertimeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period_id / query",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/payments": {
+            "get": {
+                "description": "Returns the payment status (pending, sent, paid, failed, returned) of every payroll item in the period's run, with count and amount per status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "List payment status of a payroll run (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_PaymentListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/run": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Get payroll run status and history (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_PayrollRunResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Calculates payroll for the specified attendance period as a draft run. A draft can be re-run to recalculate; once submitted for approval it can no longer be re-run. Submissions in the period are locked when the run is approved.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Run payroll for a period (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/payroll.RunPayrollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period / run no longer draft / no working days",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "408": {
+                        "description": "Request Process Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/run/approval-request": {
+            "post": {
+                "description": "Moves a draft run to pending_approval and opens a new approval round. A stale run (inputs changed after it was calculated) must be recalculated first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Submit payroll run for approval (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/payroll.RunApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_PayrollRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Run is not a draft / run is stale",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Status changed concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/run/approve": {
+            "post": {
+                "description": "Records the approval of the calling admin. The run creator cannot approve. The run becomes approved once payroll.approval.requiredApprovers admins have approved in the current round.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Approve payroll run (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/payroll.RunApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_PayrollRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Run is not pending approval",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only / run creator",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already decided in this round",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/run/reject": {
+            "post": {
+                "description": "Sends a pending run back to draft with a mandatory comment; approvals of the current round no longer count.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Reject payroll run (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payroll.RunApprovalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_PayrollRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing comment / run is not pending approval",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Already decided in this round",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payroll/periods/{period_id}/run/status": {
+            "post": {
+                "description": "Moves an approved run to paid (requires a generated disbursement file) and a paid run to closed. Submitting, approving and rejecting go through the approval endpoints. Every change is recorded in the run history with the acting admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payroll"
+                ],
+                "summary": "Change payroll run status (admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/payroll.TransitionRunRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-payroll_PayrollRunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid transition",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "409": {
+                        "description": "Status changed concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/payslips/periods/{period_id}": {
+            "get": {
+                "description": "Returns the payslip (attendance, overtime, reimbursements, adjustments and totals) from the approved payroll run snapshot.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payslip"
+                ],
+                "summary": "Generate payslip for a period (employee)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/payslip.PayslipResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Payroll not run or not approved yet",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "408": {
+                        "description": "Request Process Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reimbursements": {
+            "get": {
+                "description": "Riwayat milik sendiri per period_id atau start_date \u0026 end_date (maks 366 hari); tanpa keduanya dipakai period yang memuat hari ini. status = status period tanggal tsb: open | running | closed | no_period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursement"
+                ],
+                "summary": "List my reimbursements",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attendance Period ID",
+                        "name": "period_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (with end_date)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "YYYY-MM-DD (with start_date)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 100)",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-array_reimbursement_ReimbursementHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query / no period covers today",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Period not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a reimbursement with amount and optional description.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursement"
+                ],
+                "summary": "Create reimbursement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Create Reimbursement Request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reimbursement.CreateReimbursementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/reimbursement.ReimbursementResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / amount \u003c= 0",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "408": {
+                        "description": "Request Process Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/reimbursements/{reimbursement_id}": {
+            "delete": {
+                "description": "Hapus reimbursement milik sendiri. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursement"
+                ],
+                "summary": "Delete my reimbursement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reimbursement ID",
+                        "name": "reimbursement_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reimbursement_id / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Submitted by an admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Reimbursement not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Ubah amount / description reimbursement milik sendiri; field yang tidak dikirim tidak diubah. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reimbursement"
+                ],
+                "summary": "Update my reimbursement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer JWT Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Reimbursement ID",
+                        "name": "reimbursement_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/reimbursement.UpdateReimbursementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-reimbursement_ReimbursementHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request / period locked",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "403": {
+                        "description": "Submitted by an admin",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "404": {
+                        "description": "Reimbursement not found",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/utils.Response-any"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "adjustment.AdjustmentResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "overtime | reimbursement",
+                    "type": "string"
+                },
+                "original_date": {
+                    "type": "string"
+                },
+                "original_period_id": {
+                    "type": "integer"
+                },
+                "period_id": {
+                    "description": "period tempat adjustment dibayar",
+                    "type": "integer"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending | approved | rejected; hanya approved yang dibayar",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "adjustment.CreateAdjustmentRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "kind"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "kind reimbursement",
+                    "type": "number"
+                },
+                "date": {
+                    "description": "tanggal asli",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "hours": {
+                    "description": "kind overtime",
+                    "type": "number",
+                    "maximum": 3
+                },
+                "kind": {
+                    "type": "string",
+                    "enum": [
+                        "overtime",
+                        "reimbursement"
+                    ]
+                }
+            }
+        },
+        "adjustment.ReviewAdjustmentRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "attendance.AttendanceCalendarResponse": {
+            "type": "object",
+            "properties": {
+                "attended": {
+                    "type": "integer"
+                },
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attendance.CalendarDay"
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "holiday": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "type": "integer"
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "period_name": {
+                    "type": "string"
+                },
+                "period_status": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "upcoming": {
+                    "type": "integer"
+                },
+                "weekend": {
+                    "type": "integer"
+                }
+            }
+        },
+        "attendance.AttendanceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "check_in_at": {
+                    "type": "string"
+                },
+                "check_out_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "early_leave_minutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_early_leave": {
+                    "type": "boolean"
+                },
+                "is_late": {
+                    "type": "boolean"
+                },
+                "late_minutes": {
+                    "type": "integer"
+                },
+                "location_flagged": {
+                    "type": "boolean"
+                },
+                "on_behalf_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "worked_hours": {
+                    "type": "number"
+                }
+            }
+        },
+        "attendance.AttendanceResponse": {
+            "type": "object",
+            "properties": {
+                "check_in_at": {
+                    "type": "string"
+                },
+                "check_out_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "early_leave_minutes": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_early_leave": {
+                    "type": "boolean"
+                },
+                "is_late": {
+                    "type": "boolean"
+                },
+                "late_minutes": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "worked_hours": {
+                    "type": "number"
+                }
+            }
+        },
+        "attendance.CalendarDay": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "type": "integer"
+                },
+                "check_in_at": {
+                    "type": "string"
+                },
+                "check_out_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "holiday": {
+                    "type": "string"
+                },
+                "is_late": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "attended | missing | weekend | holiday | upcoming",
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "string"
+                }
+            }
+        },
+        "attendance.CheckInRequest": {
+            "type": "object",
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "attendance.CorrectionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "attendance_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending | approved | rejected",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "attendance.CreateCorrectionRequest": {
+            "type": "object",
+            "required": [
+                "action",
+                "date",
+                "reason"
+            ],
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "add",
+                        "remove"
+                    ]
+                },
+                "date": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 5
+                }
+            }
+        },
+        "attendance.FlaggedAttendanceResponse": {
+            "type": "object",
+            "properties": {
+                "check_in_at": {
+                    "type": "string"
+                },
+                "client_ip": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "distance_meters": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "nearest_office_location_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "missing_location,outside_radius,ip_not_allowed",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "attendance.OnBehalfAttendanceRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "user_id"
+            ],
+            "properties": {
+                "date": {
+                    "description": "default hari ini (WIB)",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "attendance.ReviewCorrectionRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "attendance.SubmitAttendanceRequest": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Optional, default = today (WIB). Format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                }
+            }
+        },
+        "attendance.SubmitAttendanceResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "on_behalf_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "\"created\" atau \"already_exists\"",
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "description": "admin, jika diinput atas nama karyawan",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "attendance_period.CreatePeriodRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "description": "optional",
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "attendance_period.GeneratePeriodsRequest": {
+            "type": "object",
+            "required": [
+                "count"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "maximum": 24,
+                    "minimum": 1
+                },
+                "cutoff_day": {
+                    "type": "integer",
+                    "maximum": 28,
+                    "minimum": 1
+                },
+                "from": {
+                    "type": "string"
+                },
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "calendar_month",
+                        "cutoff"
+                    ]
+                },
+                "name_format": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "attendance_period.GeneratePeriodsResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attendance_period.PeriodResponse"
+                    }
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attendance_period.SkippedPeriod"
+                    }
+                }
+            }
+        },
+        "attendance_period.PeriodResponse": {
+            "type": "object",
+            "properties": {
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "run_status": {
+                    "description": "status payroll run jika sudah di-run",
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "YYYY-MM-DD",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status: open | running | closed",
+                    "type": "string"
+                }
+            }
+        },
+        "attendance_period.SkippedPeriod": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "attendance_period.UpdatePeriodRequest": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.ClearLockoutRequest": {
+            "type": "object",
+            "required": [
+                "identifier",
+                "scope"
+            ],
+            "properties": {
+                "identifier": {
+                    "description": "email (account), IP (ip) atau user id (2fa)",
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string",
+                    "enum": [
+                        "account",
+                        "ip",
+                        "2fa"
+                    ]
+                }
+            }
+        },
+        "auth.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LockoutResponse": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer"
+                },
+                "identifier": {
+                    "type": "string"
+                },
+                "lastFailedAt": {
+                    "type": "string"
+                },
+                "locked": {
+                    "type": "boolean"
+                },
+                "lockedUntil": {
+                    "type": "string"
+                },
+                "scope": {
+                    "description": "account | ip",
+                    "type": "string"
+                }
+            }
+        },
+        "auth.LoginUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "device_id": {
+                    "description": "optional, untuk membedakan session per device",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                }
+            }
+        },
+        "auth.LoginUserResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "challengeToken": {
+                    "type": "string"
+                },
+                "expiresIn": {
+                    "description": "detik sampai access token expired",
+                    "type": "integer"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "twoFactorRequired": {
+                    "description": "2FA aktif: token belum diterbitkan, lanjutkan ke POST /v1/auth/2fa/verify dengan challengeToken",
+                    "type": "boolean"
+                },
+                "twoFactorSetupRequired": {
+                    "description": "2FA wajib untuk role ini tapi belum di-enroll; endpoint admin akan menolak sampai diaktifkan",
+                    "type": "boolean"
+                },
+                "user": {
+                    "$ref": "#/definitions/auth.UserResponse"
+                }
+            }
+        },
+        "auth.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "all_devices": {
+                    "description": "true =\u003e logout dari semua device (semua session \u0026 access token user dicabut)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "auth.OIDCStartResponse": {
+            "type": "object",
+            "properties": {
+                "authorizationUrl": {
+                    "description": "arahkan browser ke URL ini",
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "description": "hanya ditampilkan sekali",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "auth.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.RegisterUserRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "password",
+                "role",
+                "salary"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "google_id": {
+                    "type": "string"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "coding",
+                        "reading",
+                        "travel"
+                    ]
+                },
+                "is_profile_complete": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 6
+                },
+                "profile_image_url": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user"
+                    ]
+                },
+                "salary": {
+                    "type": "number"
+                }
+            }
+        },
+        "auth.RegisterUserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/auth.UserResponse"
+                }
+            }
+        },
+        "auth.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "accessToken": {
+                    "type": "string"
+                },
+                "expiresIn": {
+                    "type": "integer"
+                },
+                "refreshToken": {
+                    "type": "string"
+                },
+                "tokenType": {
+                    "description": "\"Bearer\"",
+                    "type": "string"
+                }
+            }
+        },
+        "auth.TwoFactorCodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.TwoFactorDisableRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.TwoFactorEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "otpauthUri": {
+                    "description": "tampilkan sebagai QR code",
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "auth.TwoFactorVerifyRequest": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "kode 6 digit dari authenticator atau recovery code",
+                    "type": "string"
+                }
+            }
+        },
+        "auth.UserResponse": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "salary": {
+                    "type": "number"
+                }
+            }
+        },
+        "bankaccount.BankAccountHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "account_number": {
+                    "type": "string"
+                },
+                "action": {
+                    "description": "set | verified",
+                    "type": "string"
+                },
+                "bank_code": {
+                    "type": "string"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "integer"
+                },
+                "holder_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "bankaccount.BankAccountResponse": {
+            "type": "object",
+            "properties": {
+                "account_number": {
+                    "type": "string"
+                },
+                "bank_code": {
+                    "type": "string"
+                },
+                "holder_name": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "verified": {
+                    "type": "boolean"
+                },
+                "verified_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "bankaccount.SetBankAccountRequest": {
+            "type": "object",
+            "required": [
+                "account_number",
+                "bank_code",
+                "holder_name"
+            ],
+            "properties": {
+                "account_number": {
+                    "description": "digit saja; spasi/strip diabaikan",
+                    "type": "string",
+                    "example": "1234567890"
+                },
+                "bank_code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "BCA"
+                },
+                "holder_name": {
+                    "type": "string",
+                    "maxLength": 100
+                }
+            }
+        },
+        "device.CreateDeviceRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "serial_number",
+                "vendor"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "serial_number": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "vendor": {
+                    "type": "string",
+                    "maxLength": 30
+                }
+            }
+        },
+        "device.DeviceKeyResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "api_key": {
+                    "type": "string"
+                },
+                "api_key_prefix": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "vendor": {
+                    "type": "string"
+                }
+            }
+        },
+        "device.DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "api_key_prefix": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "serial_number": {
+                    "type": "string"
+                },
+                "vendor": {
+                    "type": "string"
+                }
+            }
+        },
+        "device.DeviceUserResponse": {
+            "type": "object",
+            "properties": {
+                "device_user_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "device.IngestPunchesResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "device_id": {
+                    "type": "integer"
+                },
+                "duplicate": {
+                    "description": "punch yang sama sudah pernah diterima",
+                    "type": "integer"
+                },
+                "existing": {
+                    "type": "integer"
+                },
+                "punches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/device.PunchLine"
+                    }
+                },
+                "received": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "unmapped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "device.MapDeviceUserRequest": {
+            "type": "object",
+            "required": [
+                "device_user_id",
+                "user_id"
+            ],
+            "properties": {
+                "device_user_id": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "device.PunchLine": {
+            "type": "object",
+            "properties": {
+                "attendance_id": {
+                    "type": "integer"
+                },
+                "device_user_id": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "created | existing | duplicate | unmapped | rejected",
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "device.UpdateDeviceRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                }
+            }
+        },
+        "employee.EmployeeResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "employment_status": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_profile_complete": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "profile_image_url": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "salary": {
+                    "type": "number"
+                },
+                "two_factor_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "employee.UpdateEmployeeRequest": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "employment_status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "probation",
+                        "on_leave",
+                        "terminated"
+                    ]
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "user"
+                    ]
+                },
+                "salary": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "jwtkey.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "OKP (Ed25519)",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "jwtkey.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/jwtkey.JWK"
+                    }
+                }
+            }
+        },
+        "officelocation.CreateOfficeLocationRequest": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude",
+                "name"
+            ],
+            "properties": {
+                "active": {
+                    "description": "default true",
+                    "type": "boolean"
+                },
+                "allowed_ip_ranges": {
+                    "description": "CIDR / IP dipisah koma",
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "radius_meters": {
+                    "type": "integer",
+                    "maximum": 100000,
+                    "minimum": 0
+                }
+            }
+        },
+        "officelocation.OfficeLocationResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "allowed_ip_ranges": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "radius_meters": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "officelocation.UpdateOfficeLocationRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "allowed_ip_ranges": {
+                    "type": "string",
+                    "maxLength": 1000
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "radius_meters": {
+                    "type": "integer",
+                    "maximum": 100000,
+                    "minimum": 0
+                }
+            }
+        },
+        "overtime.CreateOvertimePlanRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "hours",
+                "justification"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number",
+                    "maximum": 3
+                },
+                "justification": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 5
+                }
+            }
+        },
+        "overtime.FlaggedOvertimeResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "plan_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "no_plan | exceeds_plan",
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "overtime.OnBehalfOvertimeRequest": {
+            "type": "object",
+            "required": [
+                "hours",
+                "reason",
+                "user_id"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number",
+                    "maximum": 3
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "overtime.OvertimeHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "on_behalf_reason": {
+                    "type": "string"
+                },
+                "plan_flag": {
+                    "type": "string"
+                },
+                "plan_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "type": "integer"
+                }
+            }
+        },
+        "overtime.OvertimePlanResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "justification": {
+                    "type": "string"
+                },
+                "review_note": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending | approved | rejected",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "overtime.ReviewOvertimePlanRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "overtime.SubmitOvertimeRequest": {
+            "type": "object",
+            "required": [
+                "hours"
+            ],
+            "properties": {
+                "date": {
+                    "description": "default = today (WIB), format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "hours": {
+                    "type": "number",
+                    "maximum": 3
+                }
+            }
+        },
+        "overtime.SubmitOvertimeResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "hours": {
+                    "description": "string biar rapi saat format (2 desimal)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "on_behalf_reason": {
+                    "type": "string"
+                },
+                "plan_flag": {
+                    "description": "no_plan | exceeds_plan (mode flag)",
+                    "type": "string"
+                },
+                "plan_id": {
+                    "description": "rencana approved yang cocok",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "created | already_exists",
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "description": "admin, jika diinput atas nama karyawan",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "overtime.UpdateOvertimeRequest": {
+            "type": "object",
+            "required": [
+                "hours"
+            ],
+            "properties": {
+                "hours": {
+                    "type": "number",
+                    "maximum": 3
+                }
+            }
+        },
+        "payroll.DisbursementBatchResponse": {
+            "type": "object",
+            "properties": {
+                "batch_no": {
+                    "description": "1 = batch utama, \u003e1 = susulan untuk item yang sebelumnya dilewati",
+                    "type": "integer"
+                },
+                "checksum": {
+                    "description": "SHA-256 isi file",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "execution_date": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "hash_total": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "item_count": {
+                    "type": "integer"
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "reference": {
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "skipped_count": {
+                    "type": "integer"
+                },
+                "skipped_user_ids": {
+                    "description": "hanya saat generate: karyawan yang tidak ikut karena rekening belum ada/terverifikasi",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "total_amount": {
+                    "type": "string"
+                }
+            }
+        },
+        "payroll.GenerateDisbursementRequest": {
+            "type": "object",
+            "properties": {
+                "execution_date": {
+                    "description": "YYYY-MM-DD, default hari ini",
+                    "type": "string",
+                    "example": "2025-09-01"
+                },
+                "format": {
+                    "description": "csv | bca_fixed; default dari config",
+                    "type": "string",
+                    "example": "csv"
+                },
+                "skip_unpayable": {
+                    "description": "SkipUnpayable: buat file tanpa karyawan yang rekeningnya belum ada/terverifikasi (default ditolak)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "payroll.ImportEntriesResponse": {
+            "type": "object",
+            "properties": {
+                "attendances": {
+                    "description": "jumlah yang (akan) dibuat per jenis",
+                    "type": "integer"
+                },
+                "committed": {
+                    "type": "boolean"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payroll.ImportEntryLine"
+                    }
+                },
+                "overtimes": {
+                    "type": "integer"
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "reimbursements": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payroll.ImportEntryLine": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "ok | skipped | error",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payroll.ImportPaymentResultsResponse": {
+            "type": "object",
+            "properties": {
+                "batch_reference": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payroll.PaymentResultLine"
+                    }
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "processed": {
+                    "type": "integer"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payroll.PaymentItemResponse": {
+            "type": "object",
+            "properties": {
+                "account_last4": {
+                    "type": "string"
+                },
+                "bank_reference": {
+                    "type": "string"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "grand_total": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "returned_at": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending | sent | paid | failed | returned",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payroll.PaymentListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payroll.PaymentItemResponse"
+                    }
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "summary": {
+                    "description": "per status",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/payroll.PaymentStatusSummary"
+                    }
+                }
+            }
+        },
+        "payroll.PaymentResultLine": {
+            "type": "object",
+            "properties": {
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "result": {
+                    "description": "updated | unchanged | error",
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payroll.PaymentStatusSummary": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payroll.PayrollItemSummary": {
+            "type": "object",
+            "properties": {
+                "attendance_days": {
+                    "type": "integer"
+                },
+                "bank_account_status": {
+                    "description": "ok | missing | unverified",
+                    "type": "string"
+                },
+                "base_pay": {
+                    "type": "string"
+                },
+                "grand_total": {
+                    "type": "string"
+                },
+                "overtime_hours": {
+                    "type": "string"
+                },
+                "overtime_pay": {
+                    "type": "string"
+                },
+                "paid_hours": {
+                    "type": "string"
+                },
+                "reimbursement_total": {
+                    "type": "string"
+                },
+                "snapshot_salary": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "working_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payroll.PayrollRunEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created | recalculated | transition",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "from_status": {
+                    "type": "string"
+                },
+                "to_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "payroll.PayrollRunResponse": {
+            "type": "object",
+            "properties": {
+                "approval_round": {
+                    "type": "integer"
+                },
+                "approvals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payroll.RunApprovalResponse"
+                    }
+                },
+                "calculated_by": {
+                    "type": "integer"
+                },
+                "created_by": {
+                    "type": "integer"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payroll.PayrollRunEventResponse"
+                    }
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "required_approvers": {
+                    "description": "Four-eyes: keputusan pada putaran approval terakhir",
+                    "type": "integer"
+                },
+                "run_at": {
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "stale": {
+                    "description": "perlu dihitung ulang sebelum diajukan",
+                    "type": "boolean"
+                },
+                "status": {
+                    "description": "draft | pending_approval | approved | paid | closed",
+                    "type": "string"
+                },
+                "status_changed_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "payroll.RunApprovalRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Checked against timesheets"
+                }
+            }
+        },
+        "payroll.RunApprovalResponse": {
+            "type": "object",
+            "properties": {
+                "approver_id": {
+                    "type": "integer"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "decision": {
+                    "description": "approved | rejected",
+                    "type": "string"
+                }
+            }
+        },
+        "payroll.RunPayrollResponse": {
+            "type": "object",
+            "properties": {
+                "bank_account_issues": {
+                    "description": "jumlah karyawan dengan rekening missing/unverified (belum bisa ditransfer)",
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payroll.PayrollItemSummary"
+                    }
+                },
+                "period_id": {
+                    "type": "integer"
+                },
+                "run_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "draft sampai diajukan \u0026 di-approve",
+                    "type": "string"
+                }
+            }
+        },
+        "payroll.TransitionRunRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "status": {
+                    "description": "status tujuan setelah approved: paid | closed (pengajuan \u0026 approval lewat endpoint approval)",
+                    "type": "string",
+                    "enum": [
+                        "paid",
+                        "closed"
+                    ],
+                    "example": "paid"
+                }
+            }
+        },
+        "payslip.AdjustmentLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "hours": {
+                    "description": "kind overtime",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "overtime | reimbursement",
+                    "type": "string"
+                },
+                "original_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "payslip.PaymentInfo": {
+            "type": "object",
+            "properties": {
+                "bank_reference": {
+                    "type": "string"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "returned_at": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "pending | sent | paid | failed | returned",
+                    "type": "string"
+                }
+            }
+        },
+        "payslip.PayslipResponse": {
+            "type": "object",
+            "properties": {
+                "attendance_days": {
+                    "type": "integer"
+                },
+                "attendance_hours": {
+                    "type": "integer"
+                },
+                "base_pay": {
+                    "type": "string"
+                },
+                "base_pay_mode": {
+                    "description": "days | hours",
+                    "type": "string"
+                },
+                "grand_total": {
+                    "type": "string"
+                },
+                "hourly_rate": {
+                    "type": "string"
+                },
+                "overtime_hours": {
+                    "description": "Overtime breakdown",
+                    "type": "string"
+                },
+                "overtime_multiplier": {
+                    "description": "2.0",
+                    "type": "number"
+                },
+                "overtime_pay": {
+                    "type": "string"
+                },
+                "paid_hours": {
+                    "description": "jam yang dibayar sebagai base pay",
+                    "type": "string"
+                },
+                "payment": {
+                    "description": "Status transfer gaji; hanya ada jika payroll sudah run",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/payslip.PaymentInfo"
+                        }
+                    ]
+                },
+                "period": {
+                    "type": "object",
+                    "properties": {
+                        "end_date": {
+                            "type": "string"
+                        },
+                        "id": {
+                            "type": "integer"
+                        },
+                        "name": {
+                            "type": "string"
+                        },
+                        "start_date": {
+                            "type": "string"
+                        }
+                    }
+                },
+                "prior_period_adjustment_sum": {
+                    "type": "string"
+                },
+                "prior_period_adjustments": {
+                    "description": "Prior period adjustments (overtime dibayar dengan tarif per jam period ini)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payslip.AdjustmentLine"
+                    }
+                },
+                "reimbursement_sum": {
+                    "type": "string"
+                },
+                "reimbursements": {
+                    "description": "Reimbursements",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/payslip.ReimbursementLine"
+                    }
+                },
+                "salary_snapshot": {
+                    "description": "Totals",
+                    "type": "string"
+                },
+                "snapshot_used": {
+                    "description": "true jika payroll sudah run",
+                    "type": "boolean"
+                },
+                "working_days": {
+                    "description": "Breakdown attendance / base pay",
+                    "type": "integer"
+                },
+                "working_hours": {
+                    "type": "integer"
+                }
+            }
+        },
+        "payslip.ReimbursementLine": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "profile.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_profile_complete": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "profile_image_url": {
+                    "type": "string"
+                },
+                "role": {
+                    "description": "read-only, diubah admin",
+                    "type": "string"
+                },
+                "salary": {
+                    "description": "read-only, diubah admin",
+                    "type": "number"
+                },
+                "two_factor_enabled": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "profile.UpdateProfileRequest": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "bio": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "interests": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "coding",
+                        "reading",
+                        "travel"
+                    ]
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                }
+            }
+        },
+        "reimbursement.CreateReimbursementRequest": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "description": "default = today (WIB), format YYYY-MM-DD",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "reimbursement.OnBehalfReimbursementRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "reason",
+                "user_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
                 "user_id": {
                     "type": "integer"
                 }
             }
         },
-        "attendance_period.CreatePeriodRequest": {
+        "reimbursement.ReimbursementHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "on_behalf_reason": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "description": "admin, jika diinput atas nama karyawan",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "reimbursement.ReimbursementResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "on_behalf_reason": {
+                    "type": "string"
+                },
+                "submitted_by": {
+                    "description": "admin, jika diinput atas nama karyawan",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "reimbursement.UpdateReimbursementRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "utils.Metadata": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                },
+                "totalData": {
+                    "type": "integer"
+                },
+                "totalPage": {
+                    "type": "integer"
+                }
+            }
+        },
+        "utils.Response-adjustment_AdjustmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/adjustment.AdjustmentResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-any": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_adjustment_AdjustmentResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/adjustment.AdjustmentResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_attendance_AttendanceHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attendance.AttendanceHistoryResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_attendance_CorrectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attendance.CorrectionResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_attendance_FlaggedAttendanceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attendance.FlaggedAttendanceResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_attendance_period_PeriodResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/attendance_period.PeriodResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_auth_LockoutResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/auth.LockoutResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_bankaccount_BankAccountHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/bankaccount.BankAccountHistoryResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_device_DeviceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/device.DeviceResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_device_DeviceUserResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/device.DeviceUserResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_employee_EmployeeResponse": {
             "type": "object",
-            "required": [
-                "end_date",
-                "start_date"
-            ],
             "properties": {
-                "end_date": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/employee.EmployeeResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "optional",
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_officelocation_OfficeLocationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/officelocation.OfficeLocationResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "start_date": {
+                "responseMessage": {
                     "type": "string"
                 }
             }
         },
-        "attendance_period.PeriodResponse": {
+        "utils.Response-array_overtime_FlaggedOvertimeResponse": {
             "type": "object",
             "properties": {
-                "end_date": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/overtime.FlaggedOvertimeResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-array_overtime_OvertimeHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/overtime.OvertimeHistoryResponse"
+                    }
                 },
-                "name": {
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "start_date": {
-                    "description": "YYYY-MM-DD",
+                "responseMessage": {
                     "type": "string"
                 }
             }
         },
-        "auth.LoginUserRequest": {
+        "utils.Response-array_overtime_OvertimePlanResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/overtime.OvertimePlanResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "auth.LoginUserResponse": {
+        "utils.Response-array_reimbursement_ReimbursementHistoryResponse": {
             "type": "object",
             "properties": {
-                "accessToken": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/reimbursement.ReimbursementHistoryResponse"
+                    }
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/auth.UserResponse"
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "auth.RegisterUserRequest": {
+        "utils.Response-attendance_AttendanceCalendarResponse": {
             "type": "object",
-            "required": [
-                "email",
-                "first_name",
-                "password",
-                "role",
-                "salary"
-            ],
             "properties": {
-                "age": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/attendance.AttendanceCalendarResponse"
                 },
-                "bio": {
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "email": {
+                "responseMessage": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.Response-attendance_AttendanceResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/attendance.AttendanceResponse"
                 },
-                "first_name": {
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "google_id": {
+                "responseMessage": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.Response-attendance_CorrectionResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/attendance.CorrectionResponse"
                 },
-                "interests": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "coding",
-                        "reading",
-                        "travel"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
                     ]
                 },
-                "is_profile_complete": {
-                    "type": "boolean"
+                "responseCode": {
+                    "type": "string"
                 },
-                "last_name": {
+                "responseMessage": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.Response-attendance_period_GeneratePeriodsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/attendance_period.GeneratePeriodsResponse"
                 },
-                "location": {
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 6
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-attendance_period_PeriodResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/attendance_period.PeriodResponse"
                 },
-                "profile_image_url": {
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "admin",
-                        "user"
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-auth_OIDCStartResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/auth.OIDCStartResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-auth_RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/auth.RecoveryCodesResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-auth_TwoFactorEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/auth.TwoFactorEnrollResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.Response-bankaccount_BankAccountResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/bankaccount.BankAccountResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
                     ]
                 },
-                "salary": {
-                    "type": "number"
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "auth.RegisterUserResponse": {
+        "utils.Response-device_DeviceKeyResponse": {
             "type": "object",
             "properties": {
                 "data": {
-                    "$ref": "#/definitions/auth.UserResponse"
+                    "$ref": "#/definitions/device.DeviceKeyResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "auth.UserResponse": {
+        "utils.Response-device_DeviceResponse": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/device.DeviceResponse"
                 },
-                "name": {
-                    "type": "string"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "role": {
+                "responseCode": {
                     "type": "string"
                 },
-                "salary": {
-                    "type": "number"
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "overtime.SubmitOvertimeRequest": {
+        "utils.Response-device_DeviceUserResponse": {
             "type": "object",
-            "required": [
-                "hours"
-            ],
             "properties": {
-                "date": {
-                    "description": "default = today (WIB), format YYYY-MM-DD",
+                "data": {
+                    "$ref": "#/definitions/device.DeviceUserResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "hours": {
-                    "type": "number",
-                    "maximum": 3
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "overtime.SubmitOvertimeResponse": {
+        "utils.Response-device_IngestPunchesResponse": {
             "type": "object",
             "properties": {
-                "date": {
-                    "type": "string"
-                },
-                "hours": {
-                    "description": "string biar rapi saat format (2 desimal)",
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/device.IngestPunchesResponse"
                 },
-                "id": {
-                    "type": "integer"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "status": {
-                    "description": "created | already_exists",
+                "responseCode": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "payroll.PayrollItemSummary": {
+        "utils.Response-employee_EmployeeResponse": {
             "type": "object",
             "properties": {
-                "attendance_days": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/employee.EmployeeResponse"
                 },
-                "base_pay": {
-                    "type": "string"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "grand_total": {
+                "responseCode": {
                     "type": "string"
                 },
-                "overtime_hours": {
+                "responseMessage": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.Response-officelocation_OfficeLocationResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/officelocation.OfficeLocationResponse"
                 },
-                "overtime_pay": {
-                    "type": "string"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "reimbursement_total": {
+                "responseCode": {
                     "type": "string"
                 },
-                "snapshot_salary": {
+                "responseMessage": {
                     "type": "string"
-                },
-                "user_id": {
-                    "type": "integer"
-                },
-                "working_days": {
-                    "type": "integer"
                 }
             }
         },
-        "payroll.RunPayrollResponse": {
+        "utils.Response-overtime_OvertimeHistoryResponse": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/payroll.PayrollItemSummary"
-                    }
+                "data": {
+                    "$ref": "#/definitions/overtime.OvertimeHistoryResponse"
                 },
-                "period_id": {
-                    "type": "integer"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "run_id": {
-                    "type": "integer"
+                "responseCode": {
+                    "type": "string"
+                },
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "payslip.PayslipResponse": {
+        "utils.Response-overtime_OvertimePlanResponse": {
             "type": "object",
             "properties": {
-                "attendance_days": {
-                    "type": "integer"
+                "data": {
+                    "$ref": "#/definitions/overtime.OvertimePlanResponse"
                 },
-                "attendance_hours": {
-                    "type": "integer"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "base_pay": {
+                "responseCode": {
                     "type": "string"
                 },
-                "grand_total": {
+                "responseMessage": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.Response-payroll_DisbursementBatchResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/payroll.DisbursementBatchResponse"
                 },
-                "hourly_rate": {
-                    "type": "string"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "overtime_hours": {
-                    "description": "Overtime breakdown",
+                "responseCode": {
                     "type": "string"
                 },
-                "overtime_multiplier": {
-                    "description": "2.0",
-                    "type": "number"
-                },
-                "overtime_pay": {
+                "responseMessage": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.Response-payroll_ImportEntriesResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/payroll.ImportEntriesResponse"
                 },
-                "period": {
-                    "type": "object",
-                    "properties": {
-                        "end_date": {
-                            "type": "string"
-                        },
-                        "id": {
-                            "type": "integer"
-                        },
-                        "name": {
-                            "type": "string"
-                        },
-                        "start_date": {
-                            "type": "string"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
                         }
-                    }
+                    ]
                 },
-                "reimbursement_sum": {
+                "responseCode": {
                     "type": "string"
                 },
-                "reimbursements": {
-                    "description": "Reimbursements",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/payslip.ReimbursementLine"
-                    }
-                },
-                "salary_snapshot": {
-                    "description": "Totals",
+                "responseMessage": {
                     "type": "string"
+                }
+            }
+        },
+        "utils.Response-payroll_ImportPaymentResultsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/payroll.ImportPaymentResultsResponse"
                 },
-                "snapshot_used": {
-                    "description": "true jika payroll sudah run",
-                    "type": "boolean"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "working_days": {
-                    "description": "Breakdown attendance / base pay",
-                    "type": "integer"
+                "responseCode": {
+                    "type": "string"
                 },
-                "working_hours": {
-                    "type": "integer"
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "payslip.ReimbursementLine": {
+        "utils.Response-payroll_PaymentListResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/payroll.PaymentListResponse"
                 },
-                "date": {
-                    "type": "string"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "description": {
+                "responseCode": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "reimbursement.CreateReimbursementRequest": {
+        "utils.Response-payroll_PayrollRunResponse": {
             "type": "object",
-            "required": [
-                "amount"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number"
+                "data": {
+                    "$ref": "#/definitions/payroll.PayrollRunResponse"
                 },
-                "date": {
-                    "description": "default = today (WIB), format YYYY-MM-DD",
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
+                "responseCode": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string",
-                    "maxLength": 255
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "reimbursement.ReimbursementResponse": {
+        "utils.Response-profile_ProfileResponse": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "string"
+                "data": {
+                    "$ref": "#/definitions/profile.ProfileResponse"
                 },
-                "date": {
-                    "type": "string"
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
                 },
-                "description": {
+                "responseCode": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "type": "integer"
+                "responseMessage": {
+                    "type": "string"
                 }
             }
         },
-        "utils.Response-any": {
+        "utils.Response-reimbursement_ReimbursementHistoryResponse": {
             "type": "object",
             "properties": {
-                "data": {},
+                "data": {
+                    "$ref": "#/definitions/reimbursement.ReimbursementHistoryResponse"
+                },
+                "metadata": {
+                    "description": "hanya untuk response list",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.Metadata"
+                        }
+                    ]
+                },
                 "responseCode": {
                     "type": "string"
                 },
@@ -0,0 +1,160 @@
+// Package bulkimport membaca file CSV attendance / overtime / reimbursement (mis. export mesin fingerprint)
+// untuk diimpor admin ke satu period.
+package bulkimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jenis baris import.
+const (
+	KindAttendance    = "attendance"
+	KindOvertime      = "overtime"
+	KindReimbursement = "reimbursement"
+)
+
+var ErrInvalidFile = errors.New("bulkimport: invalid file")
+
+// MaxRows membatasi jumlah baris per file agar satu transaksi tidak terlalu besar.
+const MaxRows = 10000
+
+// Row satu baris file. Error terisi jika baris tidak bisa dibaca; baris tetap dikembalikan
+// supaya bisa dilaporkan per baris.
+type Row struct {
+	Line        int
+	Kind        string
+	UserID      uint
+	Date        time.Time // tengah malam WIB
+	Hours       float64
+	Amount      float64
+	Description string
+	Error       string
+}
+
+var kindAliases = map[string]string{
+	"": KindAttendance, "attendance": KindAttendance, "hadir": KindAttendance,
+	"overtime": KindOvertime, "lembur": KindOvertime,
+	"reimbursement": KindReimbursement, "reimburse": KindReimbursement,
+}
+
+// format tanggal yang diterima; jam (export mesin fingerprint) diabaikan
+var dateLayouts = []string{"2006-01-02", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05"}
+
+var wib = time.FixedZone("WIB", 7*3600)
+
+// ParseCSV membaca file CSV dengan header. Kolom wajib: user_id, date; opsional: type
+// (default attendance), hours (overtime), amount & description (reimbursement). Urutan kolom bebas.
+func ParseCSV(r io.Reader) ([]Row, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidFile)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, req := range []string{"user_id", "date"} {
+		if _, ok := cols[req]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidFile, req)
+		}
+	}
+	get := func(rec []string, name string) string {
+		i, ok := cols[name]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	var rows []Row
+	line := 1
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidFile, line, err)
+		}
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		if len(rows) >= MaxRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidFile, MaxRows)
+		}
+		rows = append(rows, parseRow(line, func(name string) string { return get(rec, name) }))
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrInvalidFile)
+	}
+	return rows, nil
+}
+
+func parseRow(line int, get func(string) string) Row {
+	row := Row{Line: line, Description: get("description")}
+
+	kind, ok := kindAliases[strings.ToLower(get("type"))]
+	if !ok {
+		row.Kind = get("type")
+		row.Error = fmt.Sprintf("unknown type %q", get("type"))
+		return row
+	}
+	row.Kind = kind
+
+	uid, err := strconv.ParseUint(get("user_id"), 10, 64)
+	if err != nil || uid == 0 {
+		row.Error = "invalid user_id"
+		return row
+	}
+	row.UserID = uint(uid)
+
+	if row.Date, ok = parseDate(get("date")); !ok {
+		row.Error = "invalid date (YYYY-MM-DD)"
+		return row
+	}
+
+	switch kind {
+	case KindOvertime:
+		if row.Hours, ok = parseNumber(get("hours")); !ok {
+			row.Error = "invalid hours"
+		}
+	case KindReimbursement:
+		if row.Amount, ok = parseNumber(get("amount")); !ok {
+			row.Error = "invalid amount"
+		}
+		if len(row.Description) > 255 {
+			row.Error = "description is too long"
+		}
+	}
+	return row
+}
+
+func parseDate(v string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, v, wib); err == nil {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, wib), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseNumber: NaN / Inf diterima strconv.ParseFloat tapi lolos validasi batas, jadi ditolak di sini.
+func parseNumber(v string) (float64, bool) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
@@ -0,0 +1,64 @@
+// Package device menerima log absen (punch) dari mesin absensi biometrik.
+// Format tiap vendor ditangani Adapter sehingga vendor lain cukup ditambahkan ke registry.
+package device
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrUnknownVendor  = errors.New("device: unknown vendor")
+	ErrInvalidPayload = errors.New("device: invalid payload")
+)
+
+// MaxPunches membatasi jumlah punch per kiriman.
+const MaxPunches = 5000
+
+// Arah punch; kosong jika mesin tidak membedakan masuk / pulang.
+const (
+	DirectionIn  = "in"
+	DirectionOut = "out"
+)
+
+// Punch satu log absen dari mesin. DeviceUserID adalah nomor / PIN karyawan di mesin,
+// dipetakan ke user lewat device user mapping.
+type Punch struct {
+	DeviceUserID string
+	Time         time.Time
+	Direction    string
+}
+
+// Adapter membaca payload kiriman mesin satu vendor.
+type Adapter interface {
+	Vendor() string
+	Parse(body []byte) ([]Punch, error)
+}
+
+var adapters = map[string]Adapter{}
+
+// Register menambahkan adapter ke registry (dipanggil dari init tiap vendor).
+func Register(a Adapter) { adapters[a.Vendor()] = a }
+
+func Get(vendor string) (Adapter, error) {
+	a, ok := adapters[vendor]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownVendor, vendor)
+	}
+	return a, nil
+}
+
+// Vendors daftar vendor yang tersedia (urut).
+func Vendors() []string {
+	out := make([]string, 0, len(adapters))
+	for v := range adapters {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// zona waktu mesin (jam lokal tanpa offset)
+var wib = time.FixedZone("WIB", 7*3600)
@@ -0,0 +1,50 @@
+package device
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Generic: format JSON untuk vendor / middleware lain yang bisa dikonfigurasi sendiri:
+// {"punches":[{"user_id":"12","time":"2025-08-05T08:01:00+07:00","direction":"in"}]}
+type generic struct{}
+
+func init() { Register(generic{}) }
+
+func (generic) Vendor() string { return "generic" }
+
+type genericPayload struct {
+	Punches []struct {
+		UserID    string    `json:"user_id"`
+		Time      time.Time `json:"time"`
+		Direction string    `json:"direction"`
+	} `json:"punches"`
+}
+
+func (generic) Parse(body []byte) ([]Punch, error) {
+	var in genericPayload
+	if err := json.Unmarshal(body, &in); err != nil {
+		return nil, fmt.Errorf("%w: invalid json", ErrInvalidPayload)
+	}
+	if len(in.Punches) == 0 {
+		return nil, fmt.Errorf("%w: no punches", ErrInvalidPayload)
+	}
+	if len(in.Punches) > MaxPunches {
+		return nil, fmt.Errorf("%w: more than %d punches", ErrInvalidPayload, MaxPunches)
+	}
+	out := make([]Punch, 0, len(in.Punches))
+	for i, p := range in.Punches {
+		id := strings.TrimSpace(p.UserID)
+		if id == "" || p.Time.IsZero() {
+			return nil, fmt.Errorf("%w: punch %d: user_id and time are required", ErrInvalidPayload, i+1)
+		}
+		dir := strings.ToLower(p.Direction)
+		if dir != DirectionIn && dir != DirectionOut {
+			dir = ""
+		}
+		out = append(out, Punch{DeviceUserID: id, Time: p.Time.In(wib), Direction: dir})
+	}
+	return out, nil
+}
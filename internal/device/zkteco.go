@@ -0,0 +1,61 @@
+package device
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ZKTeco: log ATTLOG dari push protocol (ADMS), satu punch per baris dipisah tab:
+// PIN, waktu (YYYY-MM-DD HH:MM:SS jam lokal), status (0 masuk, 1 pulang, 4/5 lembur masuk/pulang), verify, ...
+type zkteco struct{}
+
+func init() { Register(zkteco{}) }
+
+func (zkteco) Vendor() string { return "zkteco" }
+
+func (zkteco) Parse(body []byte) ([]Punch, error) {
+	var out []Punch
+	sc := bufio.NewScanner(bytes.NewReader(body))
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Split(text, "\t")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%w: line %d: expected PIN and time", ErrInvalidPayload, line)
+		}
+		at, err := time.ParseInLocation("2006-01-02 15:04:05", strings.TrimSpace(fields[1]), wib)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: invalid time", ErrInvalidPayload, line)
+		}
+		p := Punch{DeviceUserID: strings.TrimSpace(fields[0]), Time: at}
+		if p.DeviceUserID == "" {
+			return nil, fmt.Errorf("%w: line %d: empty PIN", ErrInvalidPayload, line)
+		}
+		if len(fields) > 2 {
+			switch strings.TrimSpace(fields[2]) {
+			case "0", "4":
+				p.Direction = DirectionIn
+			case "1", "5":
+				p.Direction = DirectionOut
+			}
+		}
+		if len(out) >= MaxPunches {
+			return nil, fmt.Errorf("%w: more than %d punches", ErrInvalidPayload, MaxPunches)
+		}
+		out = append(out, p)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("%w: no punches", ErrInvalidPayload)
+	}
+	return out, nil
+}
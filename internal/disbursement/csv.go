@@ -0,0 +1,40 @@
+package disbursement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+)
+
+const FormatCSV = "csv"
+
+func init() { Register(CSVFormatter{}) }
+
+// CSVFormatter: format generik satu baris per transfer, dengan header kolom. Total & checksum ada di record batch.
+type CSVFormatter struct{}
+
+func (CSVFormatter) Name() string        { return FormatCSV }
+func (CSVFormatter) Extension() string   { return "csv" }
+func (CSVFormatter) ContentType() string { return "text/csv" }
+
+func (CSVFormatter) Format(b *Batch) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"no", "bank_code", "account_number", "holder_name", "amount", "reference", "execution_date"})
+	for _, e := range b.Entries {
+		_ = w.Write([]string{
+			strconv.Itoa(e.Sequence),
+			e.BankCode,
+			e.AccountNumber,
+			e.HolderName,
+			FormatAmount(e.Amount),
+			e.Reference,
+			b.ExecutionDate.Format("2006-01-02"),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
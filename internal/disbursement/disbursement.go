@@ -0,0 +1,152 @@
+// Package disbursement menyusun file transfer massal (bulk transfer) untuk bank dari hasil payroll run.
+// Format file dipilih lewat Formatter sehingga format bank lain cukup ditambahkan ke registry.
+package disbursement
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+)
+
+var (
+	ErrUnknownFormat = errors.New("disbursement: unknown format")
+	ErrEmptyBatch    = errors.New("disbursement: batch has no entries")
+	ErrInvalidAmount = errors.New("disbursement: amount must be positive")
+)
+
+// Entry satu baris transfer. Amount dalam satuan sen (1/100 rupiah) supaya bebas pembulatan float.
+type Entry struct {
+	Sequence      int
+	UserID        uint
+	BankCode      string
+	AccountNumber string
+	HolderName    string
+	Amount        int64
+	Reference     string
+}
+
+type Batch struct {
+	Reference     string
+	CompanyCode   string
+	SourceAccount string
+	ExecutionDate time.Time
+	Entries       []Entry
+	TotalAmount   int64
+	// HashTotal: jumlah seluruh nomor rekening tujuan (kontrol umum file bank untuk mendeteksi rekening tertukar)
+	HashTotal string
+}
+
+// NewBatch memvalidasi entry, memberi nomor urut dan menghitung total.
+func NewBatch(reference, companyCode, sourceAccount string, executionDate time.Time, entries []Entry) (*Batch, error) {
+	if len(entries) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	b := &Batch{
+		Reference:     reference,
+		CompanyCode:   companyCode,
+		SourceAccount: sourceAccount,
+		ExecutionDate: executionDate,
+		Entries:       make([]Entry, len(entries)),
+	}
+	copy(b.Entries, entries)
+	// urutan deterministik supaya file (dan checksum) sama untuk data yang sama
+	sort.SliceStable(b.Entries, func(i, j int) bool { return b.Entries[i].UserID < b.Entries[j].UserID })
+
+	hash := new(big.Int)
+	for i := range b.Entries {
+		e := &b.Entries[i]
+		if e.Amount <= 0 {
+			return nil, fmt.Errorf("%w (user %d)", ErrInvalidAmount, e.UserID)
+		}
+		n, ok := new(big.Int).SetString(e.AccountNumber, 10)
+		if !ok {
+			return nil, fmt.Errorf("disbursement: invalid account number for user %d", e.UserID)
+		}
+		e.Sequence = i + 1
+		b.TotalAmount += e.Amount
+		hash.Add(hash, n)
+	}
+	b.HashTotal = hash.String()
+	return b, nil
+}
+
+func (b *Batch) Count() int { return len(b.Entries) }
+
+// StartAt menomori ulang entry mulai dari first; batch susulan melanjutkan nomor batch sebelumnya
+// sehingga nomor urut tetap unik dalam satu run.
+func (b *Batch) StartAt(first int) {
+	for i := range b.Entries {
+		b.Entries[i].Sequence = first + i
+	}
+}
+
+// File hasil format beserta checksum SHA-256 isinya.
+type File struct {
+	Name        string
+	Format      string
+	ContentType string
+	Content     []byte
+	Checksum    string
+}
+
+type Formatter interface {
+	Name() string
+	Extension() string
+	ContentType() string
+	Format(b *Batch) ([]byte, error)
+}
+
+var formatters = map[string]Formatter{}
+
+// Register menambahkan formatter ke registry (dipanggil dari init tiap format).
+func Register(f Formatter) { formatters[f.Name()] = f }
+
+func Get(name string) (Formatter, error) {
+	f, ok := formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownFormat, name)
+	}
+	return f, nil
+}
+
+// Names daftar format yang tersedia (urut).
+func Names() []string {
+	out := make([]string, 0, len(formatters))
+	for n := range formatters {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func Generate(f Formatter, b *Batch) (*File, error) {
+	content, err := f.Format(b)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	return &File{
+		Name:        fmt.Sprintf("%s.%s", b.Reference, f.Extension()),
+		Format:      f.Name(),
+		ContentType: f.ContentType(),
+		Content:     content,
+		Checksum:    hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// ToMinorUnits rupiah (float, 2 desimal) -> sen.
+func ToMinorUnits(v float64) int64 { return int64(math.Round(v * 100)) }
+
+// FormatAmount sen -> "1234.56".
+func FormatAmount(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
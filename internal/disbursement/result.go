@@ -0,0 +1,119 @@
+package disbursement
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Status hasil transfer dari bank (sudah dinormalisasi).
+const (
+	ResultPaid     = "paid"
+	ResultFailed   = "failed"
+	ResultReturned = "returned"
+)
+
+var ErrInvalidResultFile = errors.New("disbursement: invalid result file")
+
+// MaxResultRows membatasi ukuran file hasil agar import tidak memproses file sembarangan.
+const MaxResultRows = 10000
+
+// ResultRow satu baris file hasil transfer dari bank. Amount < 0 berarti kolom amount kosong.
+type ResultRow struct {
+	Line          int
+	Sequence      int
+	AccountNumber string
+	Amount        int64
+	Status        string
+	BankReference string
+	Note          string
+}
+
+// alias status yang umum dipakai di file hasil bank
+var resultStatusAliases = map[string]string{
+	"paid": ResultPaid, "success": ResultPaid, "sukses": ResultPaid, "berhasil": ResultPaid,
+	"failed": ResultFailed, "fail": ResultFailed, "gagal": ResultFailed, "rejected": ResultFailed,
+	"returned": ResultReturned, "retur": ResultReturned, "return": ResultReturned,
+}
+
+// ParseResultCSV membaca file hasil bank berformat CSV dengan header. Kolom wajib: no, status;
+// opsional: account_number, amount, bank_reference, note. Urutan kolom bebas.
+func ParseResultCSV(r io.Reader) ([]ResultRow, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidResultFile)
+	}
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	for _, req := range []string{"no", "status"} {
+		if _, ok := cols[req]; !ok {
+			return nil, fmt.Errorf("%w: missing column %s", ErrInvalidResultFile, req)
+		}
+	}
+	get := func(rec []string, name string) string {
+		i, ok := cols[name]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	var rows []ResultRow
+	line := 1
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidResultFile, line, err)
+		}
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+		if len(rows) >= MaxResultRows {
+			return nil, fmt.Errorf("%w: more than %d rows", ErrInvalidResultFile, MaxResultRows)
+		}
+
+		row := ResultRow{
+			Line:          line,
+			AccountNumber: get(rec, "account_number"),
+			BankReference: get(rec, "bank_reference"),
+			Note:          get(rec, "note"),
+			Amount:        -1,
+		}
+		if row.Sequence, err = strconv.Atoi(get(rec, "no")); err != nil || row.Sequence <= 0 {
+			return nil, fmt.Errorf("%w: line %d: invalid no", ErrInvalidResultFile, line)
+		}
+		status, ok := resultStatusAliases[strings.ToLower(get(rec, "status"))]
+		if !ok {
+			return nil, fmt.Errorf("%w: line %d: unknown status %q", ErrInvalidResultFile, line, get(rec, "status"))
+		}
+		row.Status = status
+		if v := get(rec, "amount"); v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil || f < 0 {
+				return nil, fmt.Errorf("%w: line %d: invalid amount", ErrInvalidResultFile, line)
+			}
+			row.Amount = ToMinorUnits(f)
+		}
+		if len(row.BankReference) > 64 || len(row.Note) > 255 {
+			return nil, fmt.Errorf("%w: line %d: bank_reference or note too long", ErrInvalidResultFile, line)
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows", ErrInvalidResultFile)
+	}
+	return rows, nil
+}
@@ -0,0 +1,21 @@
+package adjustment
+
+import "payslip-generation-system/utils"
+
+// CreateAdjustmentRequest: overtime (hours) atau reimbursement (amount) untuk tanggal di period yang sudah terkunci
+type CreateAdjustmentRequest struct {
+	Kind        string  `json:"kind" binding:"required,oneof=overtime reimbursement"`
+	Date        string  `json:"date" binding:"required,datetime=2006-01-02"` // tanggal asli
+	Hours       float64 `json:"hours" binding:"omitempty,gt=0,lte=3"`        // kind overtime
+	Amount      float64 `json:"amount" binding:"omitempty,gt=0"`             // kind reimbursement
+	Description string  `json:"description" binding:"max=255"`
+}
+
+// ReviewAdjustmentRequest: note wajib diisi saat reject
+type ReviewAdjustmentRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+type ListAdjustmentsQuery struct {
+	utils.Pagination
+}
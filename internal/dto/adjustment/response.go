@@ -0,0 +1,19 @@
+package adjustment
+
+import "time"
+
+type AdjustmentResponse struct {
+	ID               uint      `json:"id"`
+	UserID           uint      `json:"user_id"`
+	Kind             string    `json:"kind"` // overtime | reimbursement
+	OriginalDate     string    `json:"original_date"`
+	OriginalPeriodID *uint     `json:"original_period_id,omitempty"`
+	PeriodID         uint      `json:"period_id"` // period tempat adjustment dibayar
+	Hours            float64   `json:"hours,omitempty"`
+	Amount           float64   `json:"amount,omitempty"`
+	Description      string    `json:"description"`
+	Status           string    `json:"status"` // pending | approved | rejected; hanya approved yang dibayar
+	ReviewedBy       *uint     `json:"reviewed_by,omitempty"`
+	ReviewNote       string    `json:"review_note,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
@@ -1,6 +1,72 @@
 package attendance
 
+import "payslip-generation-system/utils"
+
 type SubmitAttendanceRequest struct {
 	// Optional, default = today (WIB). Format YYYY-MM-DD
 	Date string `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	LocationRequest
+}
+
+// CheckInRequest: body opsional check-in (lokasi untuk geofence)
+type CheckInRequest struct {
+	LocationRequest
+}
+
+// LocationRequest: koordinat device saat submit; latitude & longitude harus dikirim berpasangan
+type LocationRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"omitempty,gte=-90,lte=90,required_with=Longitude"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,gte=-180,lte=180,required_with=Latitude"`
+}
+
+// Location: lokasi + IP client yang diteruskan handler ke usecase
+type Location struct {
+	Latitude  *float64
+	Longitude *float64
+	ClientIP  string
+}
+
+// FlaggedAttendanceQuery: laporan attendance yang ditandai di luar geofence per period
+type FlaggedAttendanceQuery struct {
+	utils.Pagination
+}
+
+// CreateCorrectionRequest: koreksi attendance; add = lupa submit, remove = attendance salah tanggal
+type CreateCorrectionRequest struct {
+	Date   string `json:"date" binding:"required,datetime=2006-01-02"`
+	Action string `json:"action" binding:"required,oneof=add remove"`
+	Reason string `json:"reason" binding:"required,min=5,max=500"`
+}
+
+// ListCorrectionsQuery: UserID hanya dipakai di endpoint admin
+type ListCorrectionsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	UserID uint   `form:"user_id"`
+	utils.Pagination
+}
+
+// ReviewCorrectionRequest: note wajib diisi saat reject
+type ReviewCorrectionRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// OnBehalfAttendanceRequest: admin menginput attendance untuk karyawan lain
+type OnBehalfAttendanceRequest struct {
+	UserID uint   `json:"user_id" binding:"required"`
+	Date   string `json:"date" binding:"omitempty,datetime=2006-01-02"` // default hari ini (WIB)
+	Reason string `json:"reason" binding:"required,max=255"`
+}
+
+// HistoryQuery: riwayat milik sendiri per period (period_id) atau rentang tanggal (start_date & end_date).
+// Tanpa keduanya dipakai period yang memuat hari ini.
+type HistoryQuery struct {
+	PeriodID  uint   `form:"period_id"`
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	utils.Pagination
+}
+
+// CalendarQuery: kalender attendance satu period (default period yang memuat hari ini)
+type CalendarQuery struct {
+	PeriodID uint `form:"period_id"`
 }
@@ -1,8 +1,91 @@
 package attendance
 
+import "time"
+
 type SubmitAttendanceResponse struct {
 	ID     uint   `json:"id"`
 	UserID uint   `json:"user_id"`
 	Date   string `json:"date"`
 	Status string `json:"status"` // "created" atau "already_exists"
+
+	SubmittedBy    *uint  `json:"submitted_by,omitempty"` // admin, jika diinput atas nama karyawan
+	OnBehalfReason string `json:"on_behalf_reason,omitempty"`
+}
+
+// AttendanceResponse: attendance satu hari dengan jam masuk/pulang (zona WIB).
+type AttendanceResponse struct {
+	ID                uint       `json:"id"`
+	UserID            uint       `json:"user_id"`
+	Date              string     `json:"date"`
+	CheckInAt         *time.Time `json:"check_in_at,omitempty"`
+	CheckOutAt        *time.Time `json:"check_out_at,omitempty"`
+	WorkedHours       float64    `json:"worked_hours"`
+	IsLate            bool       `json:"is_late"`
+	LateMinutes       int        `json:"late_minutes"`
+	IsEarlyLeave      bool       `json:"is_early_leave"`
+	EarlyLeaveMinutes int        `json:"early_leave_minutes"`
+}
+
+// FlaggedAttendanceResponse: attendance di luar semua kantor (laporan admin per period)
+type FlaggedAttendanceResponse struct {
+	ID               uint       `json:"id"`
+	UserID           uint       `json:"user_id"`
+	Date             string     `json:"date"`
+	CheckInAt        *time.Time `json:"check_in_at,omitempty"`
+	Latitude         *float64   `json:"latitude,omitempty"`
+	Longitude        *float64   `json:"longitude,omitempty"`
+	ClientIP         string     `json:"client_ip"`
+	OfficeLocationID *uint      `json:"nearest_office_location_id,omitempty"`
+	DistanceMeters   *float64   `json:"distance_meters,omitempty"`
+	Reason           string     `json:"reason"` // missing_location,outside_radius,ip_not_allowed
+}
+
+type CorrectionResponse struct {
+	ID           uint       `json:"id"`
+	UserID       uint       `json:"user_id"`
+	Date         string     `json:"date"`
+	Action       string     `json:"action"`
+	Reason       string     `json:"reason"`
+	Status       string     `json:"status"` // pending | approved | rejected
+	ReviewedBy   *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote   string     `json:"review_note,omitempty"`
+	AttendanceID *uint      `json:"attendance_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// AttendanceHistoryResponse: attendance milik sendiri; status = status period tanggal tsb
+// (open | running | closed, no_period jika tanggal belum masuk period).
+type AttendanceHistoryResponse struct {
+	AttendanceResponse
+	LocationFlagged bool   `json:"location_flagged"`
+	SubmittedBy     *uint  `json:"submitted_by,omitempty"`
+	OnBehalfReason  string `json:"on_behalf_reason,omitempty"`
+	Status          string `json:"status"`
+}
+
+type CalendarDay struct {
+	Date         string     `json:"date"`
+	Weekday      string     `json:"weekday"`
+	Status       string     `json:"status"` // attended | missing | weekend | holiday | upcoming
+	Holiday      string     `json:"holiday,omitempty"`
+	AttendanceID *uint      `json:"attendance_id,omitempty"`
+	CheckInAt    *time.Time `json:"check_in_at,omitempty"`
+	CheckOutAt   *time.Time `json:"check_out_at,omitempty"`
+	IsLate       bool       `json:"is_late,omitempty"`
+}
+
+// AttendanceCalendarResponse: satu baris per hari dalam period. Hari kerja yang belum lewat ditandai upcoming.
+type AttendanceCalendarResponse struct {
+	PeriodID     uint          `json:"period_id"`
+	PeriodName   string        `json:"period_name"`
+	StartDate    string        `json:"start_date"`
+	EndDate      string        `json:"end_date"`
+	PeriodStatus string        `json:"period_status"`
+	Attended     int           `json:"attended"`
+	Missing      int           `json:"missing"`
+	Weekend      int           `json:"weekend"`
+	Holiday      int           `json:"holiday"`
+	Upcoming     int           `json:"upcoming"`
+	Days         []CalendarDay `json:"days"`
 }
@@ -1,7 +1,31 @@
 package attendance_period
 
+import "payslip-generation-system/utils"
+
 type CreatePeriodRequest struct {
 	Name      string `json:"name"`                 // optional
 	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
 	EndDate   string `json:"end_date"   binding:"required,datetime=2006-01-02"`
 }
+
+type ListPeriodsQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=open running closed"`
+	utils.Pagination
+}
+
+// UpdatePeriodRequest: field nil tidak diubah. Hanya boleh sebelum payroll di-run.
+type UpdatePeriodRequest struct {
+	Name      *string `json:"name" binding:"omitempty,max=100"`
+	StartDate *string `json:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   *string `json:"end_date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// GeneratePeriodsRequest: mode/cutoff_day/name_format kosong memakai payroll.periodTemplate di config.
+// From (YYYY-MM) kosong = bulan setelah period terakhir.
+type GeneratePeriodsRequest struct {
+	Count      int    `json:"count" binding:"required,min=1,max=24"`
+	From       string `json:"from" binding:"omitempty,datetime=2006-01"`
+	Mode       string `json:"mode" binding:"omitempty,oneof=calendar_month cutoff"`
+	CutoffDay  int    `json:"cutoff_day" binding:"omitempty,min=1,max=28"`
+	NameFormat string `json:"name_format" binding:"omitempty,max=100"`
+}
@@ -1,8 +1,28 @@
 package attendance_period
 
+import "time"
+
 type PeriodResponse struct {
 	ID        uint   `json:"id"`
 	Name      string `json:"name"`
 	StartDate string `json:"start_date"` // YYYY-MM-DD
 	EndDate   string `json:"end_date"`
+	// Status: open | running | closed
+	Status    string     `json:"status"`
+	RunStatus string     `json:"run_status,omitempty"` // status payroll run jika sudah di-run
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	ClosedBy  *uint      `json:"closed_by,omitempty"`
+}
+
+type GeneratePeriodsResponse struct {
+	Created []PeriodResponse `json:"created"`
+	Skipped []SkippedPeriod  `json:"skipped"`
+}
+
+// SkippedPeriod: period dari template yang tidak dibuat karena overlap period yang sudah ada.
+type SkippedPeriod struct {
+	Name      string `json:"name"`
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+	Reason    string `json:"reason"`
 }
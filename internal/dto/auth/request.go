@@ -21,4 +21,47 @@ type RegisterUserRequest struct {
 type LoginUserRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=6"`
+	DeviceID string `json:"device_id" binding:"omitempty,max=255"` // optional, untuk membedakan session per device
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	// true => logout dari semua device (semua session & access token user dicabut)
+	AllDevices bool `json:"all_devices"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required"`
+}
+
+type ClearLockoutRequest struct {
+	Scope      string `json:"scope" binding:"required,oneof=account ip 2fa"`
+	Identifier string `json:"identifier" binding:"required"` // email (account), IP (ip) atau user id (2fa)
+}
+
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"` // kode 6 digit dari authenticator atau recovery code
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorDisableRequest struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required"`
 }
@@ -1,8 +1,25 @@
 package auth
 
+import "time"
+
 type LoginUserResponse struct {
-	Token string       `json:"accessToken"`
-	User  UserResponse `json:"user"`
+	Token        string        `json:"accessToken,omitempty"`
+	RefreshToken string        `json:"refreshToken,omitempty"`
+	ExpiresIn    int64         `json:"expiresIn,omitempty"` // detik sampai access token expired
+	User         *UserResponse `json:"user,omitempty"`
+
+	// 2FA aktif: token belum diterbitkan, lanjutkan ke POST /v1/auth/2fa/verify dengan challengeToken
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken,omitempty"`
+	// 2FA wajib untuk role ini tapi belum di-enroll; endpoint admin akan menolak sampai diaktifkan
+	TwoFactorSetupRequired bool `json:"twoFactorSetupRequired,omitempty"`
+}
+
+type TokenResponse struct {
+	AccessToken  string `json:"accessToken"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"` // "Bearer"
+	ExpiresIn    int64  `json:"expiresIn"`
 }
 
 type UserResponse struct {
@@ -15,3 +32,26 @@ type UserResponse struct {
 type RegisterUserResponse struct {
 	Data UserResponse `json:"data"`
 }
+
+type LockoutResponse struct {
+	Scope        string     `json:"scope"` // account | ip
+	Identifier   string     `json:"identifier"`
+	Failures     int        `json:"failures"`
+	LastFailedAt time.Time  `json:"lastFailedAt"`
+	LockedUntil  *time.Time `json:"lockedUntil,omitempty"`
+	Locked       bool       `json:"locked"`
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauthUri"` // tampilkan sebagai QR code
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"` // hanya ditampilkan sekali
+}
+
+type OIDCStartResponse struct {
+	AuthorizationURL string `json:"authorizationUrl"` // arahkan browser ke URL ini
+	State            string `json:"state"`
+}
@@ -0,0 +1,7 @@
+package bankaccount
+
+type SetBankAccountRequest struct {
+	BankCode      string `json:"bank_code" binding:"required,max=20" example:"BCA"`
+	AccountNumber string `json:"account_number" binding:"required" example:"1234567890"` // digit saja; spasi/strip diabaikan
+	HolderName    string `json:"holder_name" binding:"required,max=100"`
+}
@@ -0,0 +1,23 @@
+package bankaccount
+
+import "time"
+
+// Nomor rekening selalu dikirim masked (mis. ******7890).
+type BankAccountResponse struct {
+	UserID        uint       `json:"user_id"`
+	BankCode      string     `json:"bank_code"`
+	AccountNumber string     `json:"account_number"`
+	HolderName    string     `json:"holder_name"`
+	Verified      bool       `json:"verified"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+type BankAccountHistoryResponse struct {
+	Action        string    `json:"action"` // set | verified
+	BankCode      string    `json:"bank_code"`
+	AccountNumber string    `json:"account_number"`
+	HolderName    string    `json:"holder_name"`
+	ChangedBy     uint      `json:"changed_by"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
@@ -0,0 +1,20 @@
+package device
+
+// CreateDeviceRequest: vendor harus salah satu adapter yang terdaftar (zkteco, generic).
+type CreateDeviceRequest struct {
+	Name         string `json:"name" binding:"required,max=100"`
+	Vendor       string `json:"vendor" binding:"required,max=30"`
+	SerialNumber string `json:"serial_number" binding:"required,max=64"`
+}
+
+// UpdateDeviceRequest: field nil tidak diubah.
+type UpdateDeviceRequest struct {
+	Name   *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Active *bool   `json:"active"`
+}
+
+// MapDeviceUserRequest: PIN karyawan di mesin -> user. PIN yang sudah dipetakan akan ditimpa.
+type MapDeviceUserRequest struct {
+	DeviceUserID string `json:"device_user_id" binding:"required,max=32"`
+	UserID       uint   `json:"user_id" binding:"required"`
+}
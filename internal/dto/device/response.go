@@ -0,0 +1,46 @@
+package device
+
+import "time"
+
+type DeviceResponse struct {
+	ID           uint       `json:"id"`
+	Name         string     `json:"name"`
+	Vendor       string     `json:"vendor"`
+	SerialNumber string     `json:"serial_number"`
+	APIKeyPrefix string     `json:"api_key_prefix"`
+	Active       bool       `json:"active"`
+	LastSeenAt   *time.Time `json:"last_seen_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// DeviceKeyResponse: api_key hanya ditampilkan sekali (saat dibuat / rotate), simpan di konfigurasi mesin.
+type DeviceKeyResponse struct {
+	DeviceResponse
+	APIKey string `json:"api_key"`
+}
+
+type DeviceUserResponse struct {
+	DeviceUserID string `json:"device_user_id"`
+	UserID       uint   `json:"user_id"`
+}
+
+type PunchLine struct {
+	DeviceUserID string    `json:"device_user_id"`
+	Time         time.Time `json:"time"`
+	Direction    string    `json:"direction,omitempty"`
+	UserID       uint      `json:"user_id,omitempty"`
+	AttendanceID uint      `json:"attendance_id,omitempty"`
+	Result       string    `json:"result"` // created | existing | duplicate | unmapped | rejected
+	Message      string    `json:"message,omitempty"`
+}
+
+type IngestPunchesResponse struct {
+	DeviceID  uint        `json:"device_id"`
+	Received  int         `json:"received"`
+	Created   int         `json:"created"`
+	Existing  int         `json:"existing"`
+	Duplicate int         `json:"duplicate"` // punch yang sama sudah pernah diterima
+	Unmapped  int         `json:"unmapped"`
+	Rejected  int         `json:"rejected"`
+	Punches   []PunchLine `json:"punches"`
+}
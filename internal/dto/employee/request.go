@@ -0,0 +1,23 @@
+package employee
+
+import "payslip-generation-system/utils"
+
+type ListEmployeesQuery struct {
+	Search           string `form:"search"` // nama atau email
+	Role             string `form:"role" binding:"omitempty,oneof=admin user"`
+	Department       string `form:"department"`
+	EmploymentStatus string `form:"status" binding:"omitempty,oneof=active probation on_leave terminated"`
+	Sort             string `form:"sort" binding:"omitempty,oneof=name email department salary created_at"`
+	Order            string `form:"order" binding:"omitempty,oneof=asc desc"`
+	utils.Pagination
+}
+
+// UpdateEmployeeRequest: field nil tidak diubah. Perubahan role atau status terminated mencabut semua session.
+type UpdateEmployeeRequest struct {
+	FirstName        *string  `json:"first_name" binding:"omitempty,min=1,max=100"`
+	LastName         *string  `json:"last_name" binding:"omitempty,min=1,max=100"`
+	Role             *string  `json:"role" binding:"omitempty,oneof=admin user"`
+	Salary           *float64 `json:"salary" binding:"omitempty,gte=0"`
+	Department       *string  `json:"department" binding:"omitempty,max=100"`
+	EmploymentStatus *string  `json:"employment_status" binding:"omitempty,oneof=active probation on_leave terminated"`
+}
@@ -0,0 +1,18 @@
+package employee
+
+import "time"
+
+type EmployeeResponse struct {
+	ID                uint      `json:"id"`
+	Email             string    `json:"email"`
+	FirstName         string    `json:"first_name"`
+	LastName          string    `json:"last_name"`
+	Role              string    `json:"role"`
+	Department        string    `json:"department"`
+	EmploymentStatus  string    `json:"employment_status"`
+	Salary            float64   `json:"salary"`
+	ProfileImageURL   string    `json:"profile_image_url"`
+	IsProfileComplete bool      `json:"is_profile_complete"`
+	TwoFactorEnabled  bool      `json:"two_factor_enabled"`
+	CreatedAt         time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package officelocation
+
+// CreateOfficeLocationRequest: minimal salah satu radius_meters > 0 atau allowed_ip_ranges diisi.
+type CreateOfficeLocationRequest struct {
+	Name            string   `json:"name" binding:"required,max=100"`
+	Latitude        *float64 `json:"latitude" binding:"required,gte=-90,lte=90"`
+	Longitude       *float64 `json:"longitude" binding:"required,gte=-180,lte=180"`
+	RadiusMeters    int      `json:"radius_meters" binding:"gte=0,lte=100000"`
+	AllowedIPRanges string   `json:"allowed_ip_ranges" binding:"max=1000"` // CIDR / IP dipisah koma
+	Active          *bool    `json:"active"`                               // default true
+}
+
+// UpdateOfficeLocationRequest: field nil tidak diubah.
+type UpdateOfficeLocationRequest struct {
+	Name            *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Latitude        *float64 `json:"latitude" binding:"omitempty,gte=-90,lte=90"`
+	Longitude       *float64 `json:"longitude" binding:"omitempty,gte=-180,lte=180"`
+	RadiusMeters    *int     `json:"radius_meters" binding:"omitempty,gte=0,lte=100000"`
+	AllowedIPRanges *string  `json:"allowed_ip_ranges" binding:"omitempty,max=1000"`
+	Active          *bool    `json:"active"`
+}
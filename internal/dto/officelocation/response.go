@@ -0,0 +1,15 @@
+package officelocation
+
+import "time"
+
+type OfficeLocationResponse struct {
+	ID              uint      `json:"id"`
+	Name            string    `json:"name"`
+	Latitude        float64   `json:"latitude"`
+	Longitude       float64   `json:"longitude"`
+	RadiusMeters    int       `json:"radius_meters"`
+	AllowedIPRanges string    `json:"allowed_ip_ranges"`
+	Active          bool      `json:"active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
@@ -1,9 +1,55 @@
 package overtime
 
+import "time"
+
 type SubmitOvertimeResponse struct {
 	ID     uint   `json:"id"`
 	UserID uint   `json:"user_id"`
 	Date   string `json:"date"`
 	Hours  string `json:"hours"` // string biar rapi saat format (2 desimal)
 	Status string `json:"status"` // created | already_exists
-}
\ No newline at end of file
+
+	SubmittedBy    *uint  `json:"submitted_by,omitempty"` // admin, jika diinput atas nama karyawan
+	OnBehalfReason string `json:"on_behalf_reason,omitempty"`
+
+	PlanID   *uint  `json:"plan_id,omitempty"`   // rencana approved yang cocok
+	PlanFlag string `json:"plan_flag,omitempty"` // no_plan | exceeds_plan (mode flag)
+}
+
+// OvertimeHistoryResponse: overtime milik sendiri; status = status period tanggal tsb
+// (open | running | closed, no_period jika tanggal belum masuk period).
+type OvertimeHistoryResponse struct {
+	ID             uint      `json:"id"`
+	Date           string    `json:"date"`
+	Hours          float64   `json:"hours"`
+	SubmittedBy    *uint     `json:"submitted_by,omitempty"`
+	OnBehalfReason string    `json:"on_behalf_reason,omitempty"`
+	PlanID         *uint     `json:"plan_id,omitempty"`
+	PlanFlag       string    `json:"plan_flag,omitempty"`
+	Status         string    `json:"status"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type OvertimePlanResponse struct {
+	ID            uint       `json:"id"`
+	UserID        uint       `json:"user_id"`
+	Date          string     `json:"date"`
+	Hours         float64    `json:"hours"`
+	Justification string     `json:"justification"`
+	Status        string     `json:"status"` // pending | approved | rejected
+	ReviewedBy    *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote    string     `json:"review_note,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// FlaggedOvertimeResponse: overtime tanpa / melebihi rencana yang disetujui (laporan admin per period)
+type FlaggedOvertimeResponse struct {
+	ID          uint    `json:"id"`
+	UserID      uint    `json:"user_id"`
+	Date        string  `json:"date"`
+	Hours       float64 `json:"hours"`
+	PlanID      *uint   `json:"plan_id,omitempty"`
+	SubmittedBy *uint   `json:"submitted_by,omitempty"`
+	Reason      string  `json:"reason"` // no_plan | exceeds_plan
+}
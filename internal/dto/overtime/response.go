@@ -1,7 +1,55 @@
 package overtime
 
+import "payslip-generation-system/utils"
+
 type SubmitOvertimeRequest struct {
 	// default = today (WIB), format YYYY-MM-DD
 	Date  string  `json:"date"  binding:"omitempty,datetime=2006-01-02"`
 	Hours float64 `json:"hours" binding:"required,gt=0,lte=3"`
 }
+
+// OnBehalfOvertimeRequest: admin menginput overtime untuk karyawan lain
+type OnBehalfOvertimeRequest struct {
+	UserID uint    `json:"user_id" binding:"required"`
+	Date   string  `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Hours  float64 `json:"hours" binding:"required,gt=0,lte=3"`
+	Reason string  `json:"reason" binding:"required,max=255"`
+}
+
+// HistoryQuery: riwayat milik sendiri per period (period_id) atau rentang tanggal (start_date & end_date).
+// Tanpa keduanya dipakai period yang memuat hari ini.
+type HistoryQuery struct {
+	PeriodID  uint   `form:"period_id"`
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	utils.Pagination
+}
+
+// UpdateOvertimeRequest: ubah jam overtime milik sendiri (period belum di-run)
+type UpdateOvertimeRequest struct {
+	Hours float64 `json:"hours" binding:"required,gt=0,lte=3"`
+}
+
+// CreateOvertimePlanRequest: rencana overtime diajukan sebelum dijalankan
+type CreateOvertimePlanRequest struct {
+	Date          string  `json:"date" binding:"required,datetime=2006-01-02"`
+	Hours         float64 `json:"hours" binding:"required,gt=0,lte=3"`
+	Justification string  `json:"justification" binding:"required,min=5,max=500"`
+}
+
+// ListOvertimePlansQuery: UserID hanya dipakai di endpoint admin
+type ListOvertimePlansQuery struct {
+	Status string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	UserID uint   `form:"user_id"`
+	utils.Pagination
+}
+
+// ReviewOvertimePlanRequest: note wajib diisi saat reject
+type ReviewOvertimePlanRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+// FlaggedOvertimeQuery: laporan overtime yang tidak sesuai rencana per period
+type FlaggedOvertimeQuery struct {
+	utils.Pagination
+}
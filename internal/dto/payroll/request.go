@@ -0,0 +1,19 @@
+package payroll
+
+type GenerateDisbursementRequest struct {
+	Format        string `json:"format" example:"csv"`                // csv | bca_fixed; default dari config
+	ExecutionDate string `json:"execution_date" example:"2025-09-01"` // YYYY-MM-DD, default hari ini
+	// SkipUnpayable: buat file tanpa karyawan yang rekeningnya belum ada/terverifikasi (default ditolak)
+	SkipUnpayable bool `json:"skip_unpayable"`
+}
+
+type TransitionRunRequest struct {
+	// status tujuan setelah approved: paid | closed (pengajuan & approval lewat endpoint approval)
+	Status  string `json:"status" binding:"required,oneof=paid closed" example:"paid"`
+	Comment string `json:"comment" binding:"max=500"`
+}
+
+// RunApprovalRequest dipakai untuk request/approve/reject; comment wajib saat reject.
+type RunApprovalRequest struct {
+	Comment string `json:"comment" binding:"max=500" example:"Checked against timesheets"`
+}
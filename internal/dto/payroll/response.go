@@ -1,10 +1,15 @@
 // internal/dto/payroll/response.go
 package payroll
 
+import "time"
+
 type RunPayrollResponse struct {
 	RunID    uint                 `json:"run_id"`
 	PeriodID uint                 `json:"period_id"`
+	Status   string               `json:"status"` // draft sampai diajukan & di-approve
 	Items    []PayrollItemSummary `json:"items"`
+	// jumlah karyawan dengan rekening missing/unverified (belum bisa ditransfer)
+	BankAccountIssues int `json:"bank_account_issues"`
 }
 
 type PayrollItemSummary struct {
@@ -12,9 +17,135 @@ type PayrollItemSummary struct {
 	SnapshotSalary     string `json:"snapshot_salary"`
 	WorkingDays        int    `json:"working_days"`
 	AttendanceDays     int    `json:"attendance_days"`
+	PaidHours          string `json:"paid_hours"`
 	OvertimeHours      string `json:"overtime_hours"`
 	BasePay            string `json:"base_pay"`
 	OvertimePay        string `json:"overtime_pay"`
 	ReimbursementTotal string `json:"reimbursement_total"`
 	GrandTotal         string `json:"grand_total"`
+	BankAccountStatus  string `json:"bank_account_status"` // ok | missing | unverified
+}
+
+type DisbursementBatchResponse struct {
+	ID            uint   `json:"id"`
+	PeriodID      uint   `json:"period_id"`
+	RunID         uint   `json:"run_id"`
+	BatchNo       int    `json:"batch_no"` // 1 = batch utama, >1 = susulan untuk item yang sebelumnya dilewati
+	Reference     string `json:"reference"`
+	Format        string `json:"format"`
+	FileName      string `json:"file_name"`
+	Checksum      string `json:"checksum"` // SHA-256 isi file
+	ItemCount     int    `json:"item_count"`
+	TotalAmount   string `json:"total_amount"`
+	HashTotal     string `json:"hash_total"`
+	SkippedCount  int    `json:"skipped_count"`
+	ExecutionDate string `json:"execution_date"`
+	CreatedBy     uint   `json:"created_by"`
+	CreatedAt     string `json:"created_at"`
+	// hanya saat generate: karyawan yang tidak ikut karena rekening belum ada/terverifikasi
+	SkippedUserIDs []uint `json:"skipped_user_ids,omitempty"`
+}
+
+type PaymentItemResponse struct {
+	UserID        uint       `json:"user_id"`
+	GrandTotal    string     `json:"grand_total"`
+	Status        string     `json:"status"` // pending | sent | paid | failed | returned
+	Sequence      int        `json:"sequence,omitempty"`
+	AccountLast4  string     `json:"account_last4,omitempty"`
+	BankReference string     `json:"bank_reference,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	FailedAt      *time.Time `json:"failed_at,omitempty"`
+	ReturnedAt    *time.Time `json:"returned_at,omitempty"`
+}
+
+type PaymentStatusSummary struct {
+	Count  int    `json:"count"`
+	Amount string `json:"amount"`
+}
+
+type PaymentListResponse struct {
+	PeriodID uint                            `json:"period_id"`
+	RunID    uint                            `json:"run_id"`
+	Summary  map[string]PaymentStatusSummary `json:"summary"` // per status
+	Items    []PaymentItemResponse           `json:"items"`
+}
+
+type PaymentResultLine struct {
+	Line     int    `json:"line"`
+	Sequence int    `json:"sequence"`
+	UserID   uint   `json:"user_id,omitempty"`
+	Status   string `json:"status"`
+	Result   string `json:"result"` // updated | unchanged | error
+	Message  string `json:"message,omitempty"`
+}
+
+type ImportPaymentResultsResponse struct {
+	PeriodID       uint                `json:"period_id"`
+	RunID          uint                `json:"run_id"`
+	BatchReference string              `json:"batch_reference"`
+	Processed      int                 `json:"processed"`
+	Updated        int                 `json:"updated"`
+	Unchanged      int                 `json:"unchanged"`
+	Errors         int                 `json:"errors"`
+	Lines          []PaymentResultLine `json:"lines"`
+}
+
+// ImportEntryLine hasil validasi satu baris file import attendance / overtime / reimbursement
+type ImportEntryLine struct {
+	Line    int    `json:"line"`
+	Type    string `json:"type"`
+	UserID  uint   `json:"user_id,omitempty"`
+	Date    string `json:"date,omitempty"`
+	Result  string `json:"result"` // ok | skipped | error
+	Message string `json:"message,omitempty"`
+}
+
+// ImportEntriesResponse: Committed hanya true jika bukan dry-run dan tidak ada baris error.
+type ImportEntriesResponse struct {
+	PeriodID       uint              `json:"period_id"`
+	DryRun         bool              `json:"dry_run"`
+	Committed      bool              `json:"committed"`
+	Processed      int               `json:"processed"`
+	Valid          int               `json:"valid"`
+	Skipped        int               `json:"skipped"`
+	Errors         int               `json:"errors"`
+	Attendances    int               `json:"attendances"` // jumlah yang (akan) dibuat per jenis
+	Overtimes      int               `json:"overtimes"`
+	Reimbursements int               `json:"reimbursements"`
+	Lines          []ImportEntryLine `json:"lines"`
+}
+
+type PayrollRunEventResponse struct {
+	Action     string    `json:"action"` // created | recalculated | transition
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	ActorID    uint      `json:"actor_id"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type PayrollRunResponse struct {
+	RunID           uint                      `json:"run_id"`
+	PeriodID        uint                      `json:"period_id"`
+	Status          string                    `json:"status"` // draft | pending_approval | approved | paid | closed
+	RunAt           time.Time                 `json:"run_at"`
+	StatusChangedAt time.Time                 `json:"status_changed_at"`
+	CreatedBy       uint                      `json:"created_by"`
+	CalculatedBy    uint                      `json:"calculated_by"`
+	Stale           bool                      `json:"stale"` // perlu dihitung ulang sebelum diajukan
+	History         []PayrollRunEventResponse `json:"history"`
+
+	// Four-eyes: keputusan pada putaran approval terakhir
+	RequiredApprovers int                   `json:"required_approvers"`
+	ApprovalRound     int                   `json:"approval_round"`
+	Approvals         []RunApprovalResponse `json:"approvals"`
+}
+
+type RunApprovalResponse struct {
+	ApproverID uint      `json:"approver_id"`
+	Decision   string    `json:"decision"` // approved | rejected
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
 }
@@ -1,5 +1,7 @@
 package payslip
 
+import "time"
+
 type ReimbursementLine struct {
 	ID          uint   `json:"id"`
 	Date        string `json:"date"`
@@ -7,6 +9,26 @@ type ReimbursementLine struct {
 	Description string `json:"description"`
 }
 
+// AdjustmentLine: prior period adjustment (item terlambat dari period terkunci)
+type AdjustmentLine struct {
+	ID           uint   `json:"id"`
+	Kind         string `json:"kind"` // overtime | reimbursement
+	OriginalDate string `json:"original_date"`
+	Hours        string `json:"hours,omitempty"` // kind overtime
+	Amount       string `json:"amount"`
+	Description  string `json:"description"`
+}
+
+type PaymentInfo struct {
+	Status        string     `json:"status"` // pending | sent | paid | failed | returned
+	BankReference string     `json:"bank_reference,omitempty"`
+	Note          string     `json:"note,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+	FailedAt      *time.Time `json:"failed_at,omitempty"`
+	ReturnedAt    *time.Time `json:"returned_at,omitempty"`
+}
+
 type PayslipResponse struct {
 	Period struct {
 		ID        uint   `json:"id"`
@@ -22,6 +44,8 @@ type PayslipResponse struct {
 	AttendanceDays  int    `json:"attendance_days"`
 	WorkingHours    int    `json:"working_hours"`
 	AttendanceHours int    `json:"attendance_hours"`
+	BasePayMode     string `json:"base_pay_mode"` // days | hours
+	PaidHours       string `json:"paid_hours"`    // jam yang dibayar sebagai base pay
 	HourlyRate      string `json:"hourly_rate"`
 	BasePay         string `json:"base_pay"`
 
@@ -34,7 +58,14 @@ type PayslipResponse struct {
 	Reimbursements   []ReimbursementLine `json:"reimbursements"`
 	ReimbursementSum string              `json:"reimbursement_sum"`
 
+	// Prior period adjustments (overtime dibayar dengan tarif per jam period ini)
+	Adjustments   []AdjustmentLine `json:"prior_period_adjustments"`
+	AdjustmentSum string           `json:"prior_period_adjustment_sum"`
+
 	// Totals
 	SalarySnapshot string `json:"salary_snapshot"`
 	GrandTotal     string `json:"grand_total"`
+
+	// Status transfer gaji; hanya ada jika payroll sudah run
+	Payment *PaymentInfo `json:"payment,omitempty"`
 }
@@ -0,0 +1,12 @@
+package profile
+
+// UpdateProfileRequest: field yang tidak dikirim (nil) tidak diubah. Salary, role dan email sengaja tidak ada;
+// handler menolak field yang tidak dikenal supaya permintaan seperti itu tidak diam-diam diabaikan.
+type UpdateProfileRequest struct {
+	FirstName *string   `json:"first_name"`
+	LastName  *string   `json:"last_name"`
+	Age       *int      `json:"age"`
+	Bio       *string   `json:"bio"`
+	Location  *string   `json:"location"`
+	Interests *[]string `json:"interests" swaggertype:"array,string" example:"coding,reading,travel"`
+}
@@ -0,0 +1,17 @@
+package profile
+
+type ProfileResponse struct {
+	ID                uint     `json:"id"`
+	Email             string   `json:"email"`
+	FirstName         string   `json:"first_name"`
+	LastName          string   `json:"last_name"`
+	ProfileImageURL   string   `json:"profile_image_url"`
+	Age               int      `json:"age"`
+	Bio               string   `json:"bio"`
+	Location          string   `json:"location"`
+	Interests         []string `json:"interests"`
+	Role              string   `json:"role"`   // read-only, diubah admin
+	Salary            float64  `json:"salary"` // read-only, diubah admin
+	IsProfileComplete bool     `json:"is_profile_complete"`
+	TwoFactorEnabled  bool     `json:"two_factor_enabled"`
+}
@@ -1,8 +1,34 @@
 package reimbursement
 
+import "payslip-generation-system/utils"
+
 type CreateReimbursementRequest struct {
 	// default = today (WIB), format YYYY-MM-DD
 	Date        string  `json:"date"        binding:"omitempty,datetime=2006-01-02"`
 	Amount      float64 `json:"amount"      binding:"required,gt=0"`
 	Description string  `json:"description" binding:"omitempty,max=255"`
 }
+
+// OnBehalfReimbursementRequest: admin menginput reimbursement untuk karyawan lain
+type OnBehalfReimbursementRequest struct {
+	UserID      uint    `json:"user_id" binding:"required"`
+	Date        string  `json:"date" binding:"omitempty,datetime=2006-01-02"`
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+	Description string  `json:"description" binding:"omitempty,max=255"`
+	Reason      string  `json:"reason" binding:"required,max=255"`
+}
+
+// HistoryQuery: riwayat milik sendiri per period (period_id) atau rentang tanggal (start_date & end_date).
+// Tanpa keduanya dipakai period yang memuat hari ini.
+type HistoryQuery struct {
+	PeriodID  uint   `form:"period_id"`
+	StartDate string `form:"start_date" binding:"omitempty,datetime=2006-01-02"`
+	EndDate   string `form:"end_date" binding:"omitempty,datetime=2006-01-02"`
+	utils.Pagination
+}
+
+// UpdateReimbursementRequest: field nil tidak diubah
+type UpdateReimbursementRequest struct {
+	Amount      *float64 `json:"amount" binding:"omitempty,gt=0"`
+	Description *string  `json:"description" binding:"omitempty,max=255"`
+}
@@ -1,9 +1,22 @@
 package reimbursement
 
+import "time"
+
 type ReimbursementResponse struct {
 	ID          uint   `json:"id"`
 	UserID      uint   `json:"user_id"`
 	Date        string `json:"date"`
 	Amount      string `json:"amount"`
 	Description string `json:"description"`
+
+	SubmittedBy    *uint  `json:"submitted_by,omitempty"` // admin, jika diinput atas nama karyawan
+	OnBehalfReason string `json:"on_behalf_reason,omitempty"`
+}
+
+// ReimbursementHistoryResponse: reimbursement milik sendiri; status = status period tanggal tsb
+// (open | running | closed, no_period jika tanggal belum masuk period).
+type ReimbursementHistoryResponse struct {
+	ReimbursementResponse
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
 }
@@ -15,6 +15,9 @@ const (
 	NotFoundError       = "Not Found"
 	ConflictError       = "Conflict"
 	ErrForbidden        = "Forbidden"
+	InvalidCredentials  = "Invalid Credentials"
+	TooManyRequests     = "Too Many Requests"
+	InvalidOTP          = "Invalid OTP"
 )
 
 var (
@@ -27,6 +30,9 @@ var (
 		NotFoundError:       "03",
 		ConflictError:       "04",
 		ErrForbidden:        "05",
+		InvalidCredentials:  "06",
+		TooManyRequests:     "07",
+		InvalidOTP:          "08",
 	}
 
 	ErrorMapMessage = map[string]string{
@@ -38,6 +44,9 @@ var (
 		NotFoundError:       "Data tidak ditemukan",
 		ConflictError:       "Data sudah ada, Reason: %v",
 		ErrForbidden:        "Forbidden %v",
+		InvalidCredentials:  "Email atau password salah",
+		TooManyRequests:     "Terlalu banyak percobaan login gagal, coba lagi setelah %v",
+		InvalidOTP:          "Kode verifikasi salah atau sudah dipakai",
 	}
 
 	ErrorMapHttpCode = map[string]int{
@@ -49,5 +58,8 @@ var (
 		NotFoundError:       http.StatusNotFound,
 		ConflictError:       http.StatusConflict,
 		ErrForbidden:        http.StatusForbidden,
+		InvalidCredentials:  http.StatusUnauthorized,
+		TooManyRequests:     http.StatusTooManyRequests,
+		InvalidOTP:          http.StatusUnauthorized,
 	}
 )
@@ -0,0 +1,136 @@
+// Package geofence memeriksa apakah lokasi (lat/lng) atau IP client sebuah submission berada
+// di salah satu kantor. Submission lolos jika berada dalam radius kantor ATAU IP-nya ada di rentang kantor.
+package geofence
+
+import (
+	"errors"
+	"math"
+	"net"
+	"strings"
+)
+
+const earthRadiusMeters = 6371000
+
+// Mode penanganan submission di luar kantor (attendance.geofence.mode)
+const (
+	ModeOff    = "off"
+	ModeFlag   = "flag"
+	ModeReject = "reject"
+)
+
+// Alasan penandaan (disimpan dipisah koma)
+const (
+	ReasonMissingLocation = "missing_location"
+	ReasonOutsideRadius   = "outside_radius"
+	ReasonIPNotAllowed    = "ip_not_allowed"
+)
+
+var ErrInvalidIPRange = errors.New("geofence: invalid ip range")
+
+type Zone struct {
+	ID           uint
+	Latitude     float64
+	Longitude    float64
+	RadiusMeters float64 // 0 = kantor hanya dicek lewat IP
+	IPRanges     []*net.IPNet
+}
+
+type Result struct {
+	Allowed bool
+	ZoneID  uint // kantor yang cocok (atau terdekat jika tidak ada yang cocok)
+	// DistanceMeters ke kantor terdekat; nil jika lokasi tidak dikirim
+	DistanceMeters *float64
+	Reasons        []string
+}
+
+func (r Result) Reason() string { return strings.Join(r.Reasons, ",") }
+
+// ParseIPRanges menerima daftar CIDR / IP tunggal dipisah koma, e.g. "10.0.0.0/24, 203.0.113.7".
+func ParseIPRanges(s string) ([]*net.IPNet, error) {
+	var out []*net.IPNet
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if !strings.Contains(part, "/") {
+			ip := net.ParseIP(part)
+			if ip == nil {
+				return nil, ErrInvalidIPRange
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			out = append(out, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(part)
+		if err != nil {
+			return nil, ErrInvalidIPRange
+		}
+		out = append(out, n)
+	}
+	return out, nil
+}
+
+// Distance: jarak haversine dalam meter.
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	rad := func(d float64) float64 { return d * math.Pi / 180 }
+	dLat := rad(lat2 - lat1)
+	dLng := rad(lng2 - lng1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(rad(lat1))*math.Cos(rad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(a))
+}
+
+// Evaluate memeriksa lokasi & IP terhadap semua zona. Tanpa zona semua submission diizinkan.
+func Evaluate(zones []Zone, lat, lng *float64, clientIP string) Result {
+	res := Result{}
+	if len(zones) == 0 {
+		res.Allowed = true
+		return res
+	}
+	ip := net.ParseIP(clientIP)
+	hasRadius, hasIPRanges := false, false
+
+	for _, z := range zones {
+		if z.RadiusMeters > 0 {
+			hasRadius = true
+			if lat != nil && lng != nil {
+				d := Distance(*lat, *lng, z.Latitude, z.Longitude)
+				if res.DistanceMeters == nil || d < *res.DistanceMeters {
+					res.DistanceMeters = &d
+					if !res.Allowed {
+						res.ZoneID = z.ID
+					}
+				}
+				if d <= z.RadiusMeters && !res.Allowed {
+					res.Allowed, res.ZoneID = true, z.ID
+				}
+			}
+		}
+		for _, n := range z.IPRanges {
+			hasIPRanges = true
+			if ip != nil && n.Contains(ip) && !res.Allowed {
+				res.Allowed, res.ZoneID = true, z.ID
+			}
+		}
+	}
+	if res.Allowed || (!hasRadius && !hasIPRanges) {
+		res.Allowed = true
+		return res
+	}
+
+	if hasRadius {
+		if lat == nil || lng == nil {
+			res.Reasons = append(res.Reasons, ReasonMissingLocation)
+		} else {
+			res.Reasons = append(res.Reasons, ReasonOutsideRadius)
+		}
+	}
+	if hasIPRanges {
+		res.Reasons = append(res.Reasons, ReasonIPNotAllowed)
+	}
+	return res
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	adjDTO "payslip-generation-system/internal/dto/adjustment"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePriorPeriodAdjustmentHandler godoc
+// @Summary      Submit a prior period adjustment
+// @Description  Overtime / reimbursement untuk tanggal di period yang sudah di-run. Dicatat sebagai adjustment pending; setelah di-approve admin dibayar di period open berikutnya (terpisah di payslip).
+// @Tags         Adjustments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  adjDTO.CreateAdjustmentRequest  true  "Adjustment Request"
+// @Success      201  {object}  utils.Response[adjDTO.AdjustmentResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / date not locked / no open period"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      409  {object}  utils.Response[any] "Overtime already submitted for that date"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/adjustments [post]
+func (h *Handler) CreatePriorPeriodAdjustmentHandler(c *gin.Context) error {
+	var req adjDTO.CreateAdjustmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.CreatePriorPeriodAdjustment(c, c.GetUint("user_id"), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to create prior period adjustment"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[adjDTO.AdjustmentResponse]{Data: *row}
+	resp.SetToSuccessCreated()
+	c.JSON(http.StatusCreated, resp)
+	return nil
+}
+
+// ListMyAdjustmentsHandler godoc
+// @Summary      List my prior period adjustments
+// @Tags         Adjustments
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        page      query  int  false  "Page (default 1)"
+// @Param        pageSize  query  int  false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]adjDTO.AdjustmentResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/adjustments [get]
+func (h *Handler) ListMyAdjustmentsHandler(c *gin.Context) error {
+	var q adjDTO.ListAdjustmentsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListMyAdjustments(c, c.GetUint("user_id"), q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list adjustments"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]adjDTO.AdjustmentResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ListPeriodAdjustmentsHandler godoc
+// @Summary      List adjustments carried into a period
+// @Description  Admin: semua prior period adjustment untuk period ini (pending, approved & rejected); hanya yang approved dibayar.
+// @Tags         Adjustments
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[[]adjDTO.AdjustmentResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid period_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/adjustments [get]
+func (h *Handler) ListPeriodAdjustmentsHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	rows, err := h.usecase.ListPeriodAdjustments(c, uint(pid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list period adjustments"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]adjDTO.AdjustmentResponse]{Data: rows}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ApprovePriorPeriodAdjustmentHandler godoc
+// @Summary      Approve a prior period adjustment
+// @Description  Adjustment baru ikut dihitung di payroll setelah di-approve. Hanya masuk period yang belum di-run atau run-nya masih draft (jika sudah diajukan / terkunci, dipindah ke period berikutnya); run draft di period itu ditandai stale. Karyawan tidak bisa menyetujui adjustment-nya sendiri.
+// @Tags         Adjustments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        adjustment_id  path  int                             true   "Adjustment ID"
+// @Param        request        body  adjDTO.ReviewAdjustmentRequest  false  "Review note"
+// @Success      200  {object}  utils.Response[adjDTO.AdjustmentResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / no open period"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own adjustment"
+// @Failure      404  {object}  utils.Response[any] "Adjustment not found"
+// @Failure      409  {object}  utils.Response[any] "Already reviewed"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/adjustments/{adjustment_id}/approve [post]
+func (h *Handler) ApprovePriorPeriodAdjustmentHandler(c *gin.Context) error {
+	return h.reviewAdjustment(c, h.usecase.ApprovePriorPeriodAdjustment, "Failed to approve adjustment")
+}
+
+// RejectPriorPeriodAdjustmentHandler godoc
+// @Summary      Reject a prior period adjustment
+// @Tags         Adjustments
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        adjustment_id  path  int                             true  "Adjustment ID"
+// @Param        request        body  adjDTO.ReviewAdjustmentRequest  true  "Rejection note (required)"
+// @Success      200  {object}  utils.Response[adjDTO.AdjustmentResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / missing note"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own adjustment"
+// @Failure      404  {object}  utils.Response[any] "Adjustment not found"
+// @Failure      409  {object}  utils.Response[any] "Already reviewed"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/adjustments/{adjustment_id}/reject [post]
+func (h *Handler) RejectPriorPeriodAdjustmentHandler(c *gin.Context) error {
+	return h.reviewAdjustment(c, h.usecase.RejectPriorPeriodAdjustment, "Failed to reject adjustment")
+}
+
+func (h *Handler) reviewAdjustment(c *gin.Context,
+	action func(*gin.Context, uint, uint, adjDTO.ReviewAdjustmentRequest) (*adjDTO.AdjustmentResponse, error),
+	failMsg string,
+) error {
+	id64, err := strconv.ParseUint(c.Param("adjustment_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid adjustment_id")
+	}
+	var req adjDTO.ReviewAdjustmentRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+			utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+			c.Abort()
+			return err
+		}
+	}
+
+	row, err := action(c, c.GetUint("user_id"), uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: failMsg})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[adjDTO.AdjustmentResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAttendanceCorrectionHandler godoc
+// @Summary      Request an attendance correction
+// @Description  Ajukan penambahan (lupa submit) atau penghapusan (salah tanggal) attendance pada satu tanggal beserta alasan. Diterapkan setelah disetujui admin. Tanggal di period yang sudah terkunci ditolak.
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  atDTO.CreateCorrectionRequest  true  "Correction Request"
+// @Success      201  {object}  utils.Response[atDTO.CorrectionResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / weekend / future date / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      409  {object}  utils.Response[any] "Attendance already exists / correction already pending"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/attendance/corrections [post]
+func (h *Handler) CreateAttendanceCorrectionHandler(c *gin.Context) error {
+	var req atDTO.CreateCorrectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.RequestAttendanceCorrection(c, c.GetUint("user_id"), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to request attendance correction"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[atDTO.CorrectionResponse]{Data: *row}
+	resp.SetToSuccessCreated()
+	c.JSON(http.StatusCreated, resp)
+	return nil
+}
+
+// ListMyAttendanceCorrectionsHandler godoc
+// @Summary      List my attendance correction requests
+// @Tags         Attendance
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        status    query  string  false  "pending | approved | rejected"
+// @Param        page      query  int     false  "Page (default 1)"
+// @Param        pageSize  query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]atDTO.CorrectionResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/attendance/corrections [get]
+func (h *Handler) ListMyAttendanceCorrectionsHandler(c *gin.Context) error {
+	return h.listAttendanceCorrections(c, c.GetUint("user_id"))
+}
+
+// ListAttendanceCorrectionsHandler godoc
+// @Summary      List attendance correction requests
+// @Description  Admin: semua pengajuan koreksi, terbaru lebih dulu.
+// @Tags         Attendance
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        status    query  string  false  "pending | approved | rejected"
+// @Param        user_id   query  int     false  "Filter by employee"
+// @Param        page      query  int     false  "Page (default 1)"
+// @Param        pageSize  query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]atDTO.CorrectionResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/attendance/corrections [get]
+func (h *Handler) ListAttendanceCorrectionsHandler(c *gin.Context) error {
+	return h.listAttendanceCorrections(c, 0)
+}
+
+func (h *Handler) listAttendanceCorrections(c *gin.Context, userID uint) error {
+	var q atDTO.ListCorrectionsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListAttendanceCorrections(c, userID, q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list attendance corrections"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]atDTO.CorrectionResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ApproveAttendanceCorrectionHandler godoc
+// @Summary      Approve an attendance correction
+// @Description  Menerapkan koreksi (attendance dibuat / dihapus) dan mencatat audit. Pengaju tidak bisa menyetujui koreksinya sendiri.
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        correction_id  path  int                            true   "Correction ID"
+// @Param        request        body  atDTO.ReviewCorrectionRequest  false  "Review note"
+// @Success      200  {object}  utils.Response[atDTO.CorrectionResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own request"
+// @Failure      404  {object}  utils.Response[any] "Correction not found"
+// @Failure      409  {object}  utils.Response[any] "Already reviewed / attendance changed meanwhile"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/attendance/corrections/{correction_id}/approve [post]
+func (h *Handler) ApproveAttendanceCorrectionHandler(c *gin.Context) error {
+	return h.reviewAttendanceCorrection(c, h.usecase.ApproveAttendanceCorrection, "Failed to approve attendance correction")
+}
+
+// RejectAttendanceCorrectionHandler godoc
+// @Summary      Reject an attendance correction
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        correction_id  path  int                            true  "Correction ID"
+// @Param        request        body  atDTO.ReviewCorrectionRequest  true  "Rejection note (required)"
+// @Success      200  {object}  utils.Response[atDTO.CorrectionResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / missing note"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own request"
+// @Failure      404  {object}  utils.Response[any] "Correction not found"
+// @Failure      409  {object}  utils.Response[any] "Already reviewed"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/attendance/corrections/{correction_id}/reject [post]
+func (h *Handler) RejectAttendanceCorrectionHandler(c *gin.Context) error {
+	return h.reviewAttendanceCorrection(c, h.usecase.RejectAttendanceCorrection, "Failed to reject attendance correction")
+}
+
+func (h *Handler) reviewAttendanceCorrection(c *gin.Context,
+	action func(*gin.Context, uint, uint, atDTO.ReviewCorrectionRequest) (*atDTO.CorrectionResponse, error),
+	failMsg string,
+) error {
+	id64, err := strconv.ParseUint(c.Param("correction_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid correction_id")
+	}
+	var req atDTO.ReviewCorrectionRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+			utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+			c.Abort()
+			return err
+		}
+	}
+
+	row, err := action(c, c.GetUint("user_id"), uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: failMsg})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[atDTO.CorrectionResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -1,11 +1,14 @@
 package handler
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	atDTO "payslip-generation-system/internal/dto/attendance"
 	errorUc "payslip-generation-system/internal/error"
 	"payslip-generation-system/pkg/log"
 	"payslip-generation-system/utils"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,13 +16,14 @@ import (
 // SubmitAttendanceHandler godoc
 // @Summary      Submit attendance (weekday only)
 // @Description  Users can submit one attendance per day. Weekend submissions are rejected. If already submitted for the same day, response will indicate "already_exists".
+// @Description  Latitude/longitude (opsional) dan IP client dicatat; di luar office location ditandai atau ditolak sesuai attendance.geofence.mode.
 // @Tags         Attendance
 // @Accept       json
 // @Produce      json
 // @Param 		 Authorization header string true "Bearer JWT Token"
 // @Param        request  body      atDTO.SubmitAttendanceRequest  true  "Submit Attendance Request"
 // @Success      200      {object}  atDTO.SubmitAttendanceResponse
-// @Failure      400      {object}  utils.Response[any] "Invalid request / weekend not allowed"
+// @Failure      400      {object}  utils.Response[any] "Invalid request / weekend not allowed / outside office locations"
 // @Failure      401      {object}  utils.Response[any] "Unauthorized"
 // @Failure      403      {object}  utils.Response[any] "Forbidden"
 // @Failure      408      {object}  utils.Response[any] "Request Process Timeout"
@@ -44,13 +48,19 @@ func (h *Handler) SubmitAttendanceHandler(c *gin.Context) error {
 	}
 	userID, _ := uidAny.(uint)
 
-	row, existed, err := h.usecase.SubmitAttendance(c, userID, req.Date)
+	row, existed, err := h.usecase.SubmitAttendance(c, userID, req.Date, atDTO.Location{
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		ClientIP:  c.ClientIP(),
+	})
 	if err != nil {
 		h.log.Error(log.LogData{
 			Err:         err,
 			Description: "Failed to submit attendance",
 		})
-		return utils.MakeError(errorUc.InternalServerError, "failed to submit attendance")
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
 	}
 
 	status := "created"
@@ -66,3 +76,270 @@ func (h *Handler) SubmitAttendanceHandler(c *gin.Context) error {
 
 	return nil
 }
+
+// CheckInHandler godoc
+// @Summary      Clock in for today
+// @Description  Mencatat jam masuk hari ini (WIB, weekday saja). Terlambat jika lewat attendance.workStart + lateGrace.
+// @Description  Body opsional berisi latitude/longitude; lokasi & IP dicek terhadap office location (attendance.geofence.mode).
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  atDTO.CheckInRequest  false  "Lokasi device"
+// @Success      200  {object}  utils.Response[atDTO.AttendanceResponse]
+// @Failure      400  {object}  utils.Response[any] "Weekend / period locked / outside office locations"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      409  {object}  utils.Response[any] "Already checked in"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/attendance/check-in [post]
+func (h *Handler) CheckInHandler(c *gin.Context) error {
+	var req atDTO.CheckInRequest
+	// body boleh kosong
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	return h.attendanceClock(c, func(c *gin.Context, userID uint) (*atDTO.AttendanceResponse, error) {
+		return h.usecase.CheckIn(c, userID, atDTO.Location{
+			Latitude:  req.Latitude,
+			Longitude: req.Longitude,
+			ClientIP:  c.ClientIP(),
+		})
+	}, "Failed to check in")
+}
+
+// CheckOutHandler godoc
+// @Summary      Clock out for today
+// @Description  Mencatat jam pulang hari ini dan menghitung jam kerja. Pulang cepat jika sebelum attendance.workEnd.
+// @Tags         Attendance
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Success      200  {object}  utils.Response[atDTO.AttendanceResponse]
+// @Failure      400  {object}  utils.Response[any] "Not checked in / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      409  {object}  utils.Response[any] "Already checked out"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/attendance/check-out [post]
+func (h *Handler) CheckOutHandler(c *gin.Context) error {
+	return h.attendanceClock(c, h.usecase.CheckOut, "Failed to check out")
+}
+
+func (h *Handler) attendanceClock(c *gin.Context,
+	action func(*gin.Context, uint) (*atDTO.AttendanceResponse, error),
+	failMsg string,
+) error {
+	row, err := action(c, c.GetUint("user_id"))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: failMsg})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[atDTO.AttendanceResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ListFlaggedAttendancesHandler godoc
+// @Summary      List attendances flagged outside office locations
+// @Description  Laporan attendance pada rentang period yang lokasi/IP-nya di luar semua office location aktif (attendance.geofence.mode = flag).
+// @Tags         Attendance
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path   int  true   "Attendance Period ID"
+// @Param        page       query  int  false  "Page (default 1)"
+// @Param        pageSize   query  int  false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]atDTO.FlaggedAttendanceResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid period_id / query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/attendance/flagged [get]
+func (h *Handler) ListFlaggedAttendancesHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+	var q atDTO.FlaggedAttendanceQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListFlaggedAttendances(c, uint(pid64), q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list flagged attendances"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]atDTO.FlaggedAttendanceResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// SubmitAttendanceOnBehalfHandler godoc
+// @Summary      Submit attendance on behalf of an employee
+// @Description  Admin menginput attendance untuk karyawan (tanpa device / sedang cuti). Validasi sama dengan submit biasa (weekday, period belum terkunci) tanpa geofence. Admin & alasan dicatat di attendance.
+// @Tags         Attendance
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body      atDTO.OnBehalfAttendanceRequest  true  "On-behalf Attendance Request"
+// @Success      200      {object}  atDTO.SubmitAttendanceResponse
+// @Failure      400      {object}  utils.Response[any] "Invalid request / weekend not allowed / period locked / employee terminated"
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      403      {object}  utils.Response[any] "Admin only"
+// @Failure      404      {object}  utils.Response[any] "Employee not found"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/attendance/submit [post]
+func (h *Handler) SubmitAttendanceOnBehalfHandler(c *gin.Context) error {
+	var req atDTO.OnBehalfAttendanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, existed, err := h.usecase.SubmitAttendanceOnBehalf(c, c.GetUint("user_id"), req.UserID, req.Date, req.Reason)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to submit attendance on behalf"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	status := "created"
+	if existed {
+		status = "already_exists"
+	}
+	c.JSON(http.StatusOK, atDTO.SubmitAttendanceResponse{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		Date:           row.Date.Format("2006-01-02"),
+		Status:         status,
+		SubmittedBy:    row.SubmittedBy,
+		OnBehalfReason: row.OnBehalfReason,
+	})
+	return nil
+}
+
+// ListMyAttendancesHandler godoc
+// @Summary      List my attendances
+// @Description  Riwayat milik sendiri per period_id atau start_date & end_date (maks 366 hari); tanpa keduanya dipakai period yang memuat hari ini. status = status period tanggal tsb: open | running | closed | no_period.
+// @Tags         Attendance
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id   query  int     false  "Attendance Period ID"
+// @Param        start_date  query  string  false  "YYYY-MM-DD (with end_date)"
+// @Param        end_date    query  string  false  "YYYY-MM-DD (with start_date)"
+// @Param        page        query  int     false  "Page (default 1)"
+// @Param        pageSize    query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]atDTO.AttendanceHistoryResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query / no period covers today"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/attendance [get]
+func (h *Handler) ListMyAttendancesHandler(c *gin.Context) error {
+	var q atDTO.HistoryQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListMyAttendances(c, c.GetUint("user_id"), q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list attendances"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]atDTO.AttendanceHistoryResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// GetMyAttendanceCalendarHandler godoc
+// @Summary      My attendance calendar
+// @Description  Satu baris per hari dalam period (default period yang memuat hari ini): attended | missing | weekend | holiday | upcoming. Hari libur diambil dari konfigurasi attendance.holidays.
+// @Tags         Attendance
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  query  int  false  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[atDTO.AttendanceCalendarResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query / no period covers today"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/attendance/calendar [get]
+func (h *Handler) GetMyAttendanceCalendarHandler(c *gin.Context) error {
+	var q atDTO.CalendarQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	cal, err := h.usecase.GetMyAttendanceCalendar(c, c.GetUint("user_id"), q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to build attendance calendar"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[atDTO.AttendanceCalendarResponse]{Data: *cal}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// DeleteMyAttendanceHandler godoc
+// @Summary      Delete my attendance
+// @Description  Hapus attendance milik sendiri. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll). Penghapusan tercatat di attendance_audits (source = self).
+// @Tags         Attendance
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        attendance_id  path  int  true  "Attendance ID"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid attendance_id / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Submitted by an admin / recorded by a device / added by a correction"
+// @Failure      404  {object}  utils.Response[any] "Attendance not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/attendance/{attendance_id} [delete]
+func (h *Handler) DeleteMyAttendanceHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("attendance_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid attendance_id")
+	}
+
+	if err := h.usecase.DeleteMyAttendance(c, c.GetUint("user_id"), uint(id64)); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to delete attendance"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
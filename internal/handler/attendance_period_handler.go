@@ -2,8 +2,11 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
+
 	apDTO "payslip-generation-system/internal/dto/attendance_period"
 	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
 	"payslip-generation-system/pkg/log"
 	"payslip-generation-system/utils"
 
@@ -51,6 +54,224 @@ func (h *Handler) CreateAttendancePeriodHandler(c *gin.Context) error {
 		Name:      row.Name,
 		StartDate: row.StartDate.Format("2006-01-02"),
 		EndDate:   row.EndDate.Format("2006-01-02"),
+		Status:    model.PeriodStatusOpen,
 	})
 	return nil
 }
+
+// ListAttendancePeriodsHandler godoc
+// @Summary      List payroll attendance periods
+// @Description  Daftar period terbaru lebih dulu. Status: open (belum di-run), running (run draft / menunggu approval), closed (run approved ke atas atau ditutup manual).
+// @Tags         Payroll
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        status    query  string  false  "open | running | closed"
+// @Param        page      query  int     false  "Page (default 1)"
+// @Param        pageSize  query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]apDTO.PeriodResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods [get]
+func (h *Handler) ListAttendancePeriodsHandler(c *gin.Context) error {
+	var q apDTO.ListPeriodsQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListAttendancePeriods(c, q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list attendance periods"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]apDTO.PeriodResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// GetAttendancePeriodHandler godoc
+// @Summary      Get payroll attendance period
+// @Tags         Payroll
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[apDTO.PeriodResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid period_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id} [get]
+func (h *Handler) GetAttendancePeriodHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	row, err := h.usecase.GetAttendancePeriod(c, uint(pid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to get attendance period"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[apDTO.PeriodResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// UpdateAttendancePeriodHandler godoc
+// @Summary      Update payroll attendance period
+// @Description  Ubah nama/tanggal period selama belum di-run dan belum ditutup. Rentang baru tidak boleh overlap period lain.
+// @Tags         Payroll
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int                        true  "Attendance Period ID"
+// @Param        request    body  apDTO.UpdatePeriodRequest  true  "Fields to change"
+// @Success      200  {object}  utils.Response[apDTO.PeriodResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / invalid dates / overlapping period"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      409  {object}  utils.Response[any] "Payroll already run or period closed"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id} [patch]
+func (h *Handler) UpdateAttendancePeriodHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+	var req apDTO.UpdatePeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	row, err := h.usecase.UpdateAttendancePeriod(c, uint(pid64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to update attendance period"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[apDTO.PeriodResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// CloseAttendancePeriodHandler godoc
+// @Summary      Close payroll attendance period
+// @Description  Menutup period secara manual. Attendance/overtime/reimbursement pada rentang period tidak bisa disubmit lagi.
+// @Tags         Payroll
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[apDTO.PeriodResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid period_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      409  {object}  utils.Response[any] "Period already closed"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/close [post]
+func (h *Handler) CloseAttendancePeriodHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	row, err := h.usecase.CloseAttendancePeriod(c, c.GetUint("user_id"), uint(pid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to close attendance period"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[apDTO.PeriodResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// DeleteAttendancePeriodHandler godoc
+// @Summary      Delete payroll attendance period
+// @Description  Hanya untuk period yang belum di-run dan belum memiliki attendance/overtime/reimbursement.
+// @Tags         Payroll
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid period_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      409  {object}  utils.Response[any] "Payroll already run or period has submissions"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id} [delete]
+func (h *Handler) DeleteAttendancePeriodHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	if err := h.usecase.DeleteAttendancePeriod(c, uint(pid64)); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to delete attendance period"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// GenerateAttendancePeriodsHandler godoc
+// @Summary      Generate next attendance periods from template
+// @Description  Membuat `count` period bulanan berurutan dari template (payroll.periodTemplate, bisa ditimpa lewat mode/cutoff_day/name_format). Tanpa `from` (YYYY-MM) dimulai setelah period terakhir. Period yang overlap dilewati dan dilaporkan di `skipped`.
+// @Tags         Payroll
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  apDTO.GeneratePeriodsRequest  true  "Generate Periods Request"
+// @Success      201  {object}  utils.Response[apDTO.GeneratePeriodsResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / invalid template"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/generate [post]
+func (h *Handler) GenerateAttendancePeriodsHandler(c *gin.Context) error {
+	var req apDTO.GeneratePeriodsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	out, err := h.usecase.GenerateAttendancePeriods(c, req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to generate attendance periods"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[apDTO.GeneratePeriodsResponse]{Data: *out}
+	resp.SetToSuccessCreated()
+	c.JSON(http.StatusCreated, resp)
+	return nil
+}
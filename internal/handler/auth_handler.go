@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"payslip-generation-system/pkg/log"
 	"payslip-generation-system/utils"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -64,14 +65,15 @@ func (h *Handler) RegisterUserHandler(c *gin.Context) error {
 
 // LoginUserHandler godoc
 // @Summary      Login User
-// @Description  Login user with email and password
+// @Description  Login user with email and password. When two-factor authentication is enabled no token is issued; the response carries twoFactorRequired=true and a short-lived challengeToken for POST /v1/auth/2fa/verify.
 // @Tags         User
 // @Accept       json
 // @Produce      json
 // @Param        request  body      authDTO.LoginUserRequest  true  "Login User Request"
 // @Success      200      {object}  authDTO.LoginUserResponse
 // @Failure      400      {object}  utils.Response[any] "Error response"
-// @Failure	  	 401      {object} 	utils.Response[any] "Error response"
+// @Failure	  	 401      {object} 	utils.Response[any] "Invalid email or password"
+// @Failure      429      {object}  utils.Response[any] "Too many failed attempts (locked out)"
 // @Failure      500      {object}  utils.Response[any] "Error response"
 // @Router       /v1/auth/login [post]
 func (h *Handler) LoginUserHandler(c *gin.Context) error {
@@ -89,32 +91,166 @@ func (h *Handler) LoginUserHandler(c *gin.Context) error {
 			Err:         err,
 			Description: "Failed to login user",
 		})
-		return utils.MakeError(errorUc.InternalServerError, err.Error())
+		// 401 seragam untuk email/password salah, 429 saat lockout
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
 	}
 
 	FullName := user.FirstName + " " + user.LastName
 	role := user.Role
 
-	token, err := h.usecase.GenerateToken(user.ID, FullName, role)
+	// 2FA aktif: token baru terbit setelah POST /v1/auth/2fa/verify
+	if user.TwoFactorEnabled {
+		challenge, err := h.usecase.StartTwoFactorChallenge(c, user, req.DeviceID)
+		if err != nil {
+			h.log.Error(log.LogData{
+				Err:         err,
+				Description: "Failed to start two-factor challenge",
+			})
+			return err
+		}
+		c.JSON(http.StatusOK, authDTO.LoginUserResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+		})
+		return nil
+	}
+
+	tokens, err := h.usecase.StartSession(c, user, req.DeviceID)
 	if err != nil {
 		h.log.Error(log.LogData{
 			Err:         err,
-			Description: "Failed to generate token",
+			Description: "Failed to start session",
 		})
-		return utils.MakeError(errorUc.InternalServerError, err.Error())
+		return err
 	}
 	h.log.Info(log.LogData{
 		Description: "User logged in successfully",
 		Response:    user,
 	})
 	c.JSON(http.StatusOK, authDTO.LoginUserResponse{
-		User: authDTO.UserResponse{
+		User: &authDTO.UserResponse{
 			Name:   FullName,
 			Email:  user.Email,
 			Salary: user.Salary,
 			Role:   role,
 		},
-		Token: token,
+		Token:                  tokens.AccessToken,
+		RefreshToken:           tokens.RefreshToken,
+		ExpiresIn:              tokens.ExpiresIn,
+		TwoFactorSetupRequired: h.usecase.TwoFactorSetupRequired(user),
 	})
 	return nil
 }
+
+// RefreshTokenHandler godoc
+// @Summary      Refresh access token
+// @Description  Exchange a refresh token for a new access + refresh token pair. Refresh tokens are single-use (rotation); reusing an old one revokes the whole session.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request  body      authDTO.RefreshTokenRequest  true  "Refresh Token Request"
+// @Success      200      {object}  authDTO.TokenResponse
+// @Failure      400      {object}  utils.Response[any] "Invalid request body"
+// @Failure      401      {object}  utils.Response[any] "Invalid / expired / reused refresh token"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/refresh [post]
+func (h *Handler) RefreshTokenHandler(c *gin.Context) error {
+	var req authDTO.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	tokens, err := h.usecase.RefreshSession(c, req.RefreshToken)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to refresh token"})
+		return err
+	}
+
+	c.JSON(http.StatusOK, tokens)
+	return nil
+}
+
+// LogoutHandler godoc
+// @Summary      Logout
+// @Description  Revoke the current session. Set all_devices=true to revoke every session and access token of the user.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body      authDTO.LogoutRequest  false  "Logout Request"
+// @Success      200      {object}  utils.Response[any]
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/logout [post]
+func (h *Handler) LogoutHandler(c *gin.Context) error {
+	var req authDTO.LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+			return utils.MakeError(errorUc.BadRequest, "invalid request body")
+		}
+	}
+
+	userID := c.GetUint("user_id")
+	sessionID := c.GetUint("session_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	if err := h.usecase.Logout(c, userID, sessionID, req.AllDevices); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to logout"})
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// RevokeUserSessionsHandler godoc
+// @Summary      Revoke all sessions of a user (admin only)
+// @Description  Revokes every refresh token and access token of the user, e.g. when an employee is terminated.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        user_id  path  int  true  "User ID"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid user_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "User not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/users/{user_id}/sessions/revoke [post]
+func (h *Handler) RevokeUserSessionsHandler(c *gin.Context) error {
+	uid64, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || uid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid user_id")
+	}
+
+	if err := h.usecase.RevokeUserSessions(c, uint(uid64), "revoked_by_admin"); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to revoke user sessions"})
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// JWKSHandler godoc
+// @Summary      JSON Web Key Set
+// @Description  Public keys (RS256/EdDSA) used to sign access tokens, including rotated keys that are still accepted. HS256 secrets are never published.
+// @Tags         User
+// @Produce      json
+// @Success      200  {object}  jwtkey.JWKSet
+// @Router       /.well-known/jwks.json [get]
+func (h *Handler) JWKSHandler(c *gin.Context) error {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.usecase.JWKS())
+	return nil
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	bankDTO "payslip-generation-system/internal/dto/bankaccount"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMyBankAccountHandler godoc
+// @Summary      Get my salary bank account
+// @Description  The account number is always masked.
+// @Tags         Bank Account
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Success      200  {object}  utils.Response[bankDTO.BankAccountResponse]
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      404  {object}  utils.Response[any] "No bank account yet"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/me/bank-account [get]
+func (h *Handler) GetMyBankAccountHandler(c *gin.Context) error {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	return h.respondBankAccount(c, func() (*bankDTO.BankAccountResponse, error) {
+		return h.usecase.GetBankAccount(c, userID)
+	})
+}
+
+// SetMyBankAccountHandler godoc
+// @Summary      Set my salary bank account
+// @Description  Creates or replaces the account. A changed account must be verified again by an admin before payroll treats it as payable; the employee is notified by email.
+// @Tags         Bank Account
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  bankDTO.SetBankAccountRequest  true  "Bank Account"
+// @Success      200  {object}  utils.Response[bankDTO.BankAccountResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request body"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/me/bank-account [put]
+func (h *Handler) SetMyBankAccountHandler(c *gin.Context) error {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	var req bankDTO.SetBankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+	return h.respondBankAccount(c, func() (*bankDTO.BankAccountResponse, error) {
+		return h.usecase.SetBankAccount(c, userID, userID, req)
+	})
+}
+
+// GetEmployeeBankAccountHandler godoc
+// @Summary      Get employee bank account (admin only)
+// @Tags         Bank Account
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        user_id  path  int  true  "User ID"
+// @Success      200  {object}  utils.Response[bankDTO.BankAccountResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid user_id"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "No bank account"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/employees/{user_id}/bank-account [get]
+func (h *Handler) GetEmployeeBankAccountHandler(c *gin.Context) error {
+	uid64, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || uid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid user_id")
+	}
+	return h.respondBankAccount(c, func() (*bankDTO.BankAccountResponse, error) {
+		return h.usecase.GetBankAccount(c, uint(uid64))
+	})
+}
+
+// SetEmployeeBankAccountHandler godoc
+// @Summary      Set employee bank account (admin only)
+// @Description  Same as PUT /v1/me/bank-account on behalf of an employee. The account becomes unverified.
+// @Tags         Bank Account
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        user_id  path  int  true  "User ID"
+// @Param        request  body  bankDTO.SetBankAccountRequest  true  "Bank Account"
+// @Success      200  {object}  utils.Response[bankDTO.BankAccountResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Employee not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/employees/{user_id}/bank-account [put]
+func (h *Handler) SetEmployeeBankAccountHandler(c *gin.Context) error {
+	uid64, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || uid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid user_id")
+	}
+	var req bankDTO.SetBankAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+	return h.respondBankAccount(c, func() (*bankDTO.BankAccountResponse, error) {
+		return h.usecase.SetBankAccount(c, c.GetUint("user_id"), uint(uid64), req)
+	})
+}
+
+// VerifyEmployeeBankAccountHandler godoc
+// @Summary      Verify employee bank account (admin only)
+// @Description  Marks the current account as verified (e.g. after a bank inquiry). Admins cannot verify their own account.
+// @Tags         Bank Account
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        user_id  path  int  true  "User ID"
+// @Success      200  {object}  utils.Response[bankDTO.BankAccountResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid user_id"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own account"
+// @Failure      404  {object}  utils.Response[any] "No bank account"
+// @Failure      409  {object}  utils.Response[any] "Already verified"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/employees/{user_id}/bank-account/verify [post]
+func (h *Handler) VerifyEmployeeBankAccountHandler(c *gin.Context) error {
+	uid64, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || uid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid user_id")
+	}
+	return h.respondBankAccount(c, func() (*bankDTO.BankAccountResponse, error) {
+		return h.usecase.VerifyBankAccount(c, c.GetUint("user_id"), uint(uid64))
+	})
+}
+
+// ListBankAccountHistoryHandler godoc
+// @Summary      Bank account change history (admin only)
+// @Description  Every change and verification of the employee's account, newest first. Account numbers are masked.
+// @Tags         Bank Account
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        user_id  path  int  true  "User ID"
+// @Success      200  {object}  utils.Response[[]bankDTO.BankAccountHistoryResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid user_id"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/employees/{user_id}/bank-account/history [get]
+func (h *Handler) ListBankAccountHistoryHandler(c *gin.Context) error {
+	uid64, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || uid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid user_id")
+	}
+
+	rows, err := h.usecase.ListBankAccountHistory(c, uint(uid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list bank account history"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]bankDTO.BankAccountHistoryResponse]{Data: rows}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+func (h *Handler) respondBankAccount(c *gin.Context, fn func() (*bankDTO.BankAccountResponse, error)) error {
+	acc, err := fn()
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Bank account request failed"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[bankDTO.BankAccountResponse]{Data: *acc}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
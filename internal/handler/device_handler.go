@@ -0,0 +1,312 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	devDTO "payslip-generation-system/internal/dto/device"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxDevicePayloadSize batas ukuran body kiriman punch dari mesin
+const maxDevicePayloadSize = 2 << 20
+
+// DeviceKeyHeader header API key mesin absensi
+const DeviceKeyHeader = "X-Device-Key"
+
+// ListDevicesHandler godoc
+// @Summary      List attendance devices
+// @Description  Semua mesin absensi biometrik yang terdaftar (aktif & nonaktif).
+// @Tags         Device
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Success      200  {object}  utils.Response[[]devDTO.DeviceResponse]
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/devices [get]
+func (h *Handler) ListDevicesHandler(c *gin.Context) error {
+	rows, err := h.usecase.ListDevices(c)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list devices"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]devDTO.DeviceResponse]{Data: rows}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// CreateDeviceHandler godoc
+// @Summary      Register attendance device
+// @Description  Mendaftarkan mesin absensi; vendor harus punya adapter (zkteco, generic). api_key hanya ditampilkan sekali dan dikirim mesin lewat header X-Device-Key.
+// @Tags         Device
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  devDTO.CreateDeviceRequest  true  "Device"
+// @Success      201  {object}  utils.Response[devDTO.DeviceKeyResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / unknown vendor"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      409  {object}  utils.Response[any] "Serial number already registered"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/devices [post]
+func (h *Handler) CreateDeviceHandler(c *gin.Context) error {
+	var req devDTO.CreateDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.CreateDevice(c, c.GetUint("user_id"), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to create device"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[devDTO.DeviceKeyResponse]{Data: *row}
+	resp.SetToSuccessCreated()
+	c.JSON(http.StatusCreated, resp)
+	return nil
+}
+
+// UpdateDeviceHandler godoc
+// @Summary      Update attendance device
+// @Description  Field yang tidak dikirim tidak diubah. Set active=false untuk menolak kiriman dari mesin tsb.
+// @Tags         Device
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        device_id  path  int                         true  "Device ID"
+// @Param        request    body  devDTO.UpdateDeviceRequest  true  "Fields to change"
+// @Success      200  {object}  utils.Response[devDTO.DeviceResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Device not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/devices/{device_id} [patch]
+func (h *Handler) UpdateDeviceHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("device_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid device_id")
+	}
+	var req devDTO.UpdateDeviceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.UpdateDevice(c, uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to update device"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[devDTO.DeviceResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// RotateDeviceKeyHandler godoc
+// @Summary      Rotate device API key
+// @Description  Membuat API key baru; key lama langsung tidak berlaku.
+// @Tags         Device
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        device_id  path  int  true  "Device ID"
+// @Success      200  {object}  utils.Response[devDTO.DeviceKeyResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid device_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Device not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/devices/{device_id}/rotate-key [post]
+func (h *Handler) RotateDeviceKeyHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("device_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid device_id")
+	}
+
+	row, err := h.usecase.RotateDeviceKey(c, uint(id64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to rotate device key"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[devDTO.DeviceKeyResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ListDeviceUsersHandler godoc
+// @Summary      List device user mappings
+// @Description  Pemetakan PIN karyawan di mesin ke user.
+// @Tags         Device
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        device_id  path  int  true  "Device ID"
+// @Success      200  {object}  utils.Response[[]devDTO.DeviceUserResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid device_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Device not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/devices/{device_id}/users [get]
+func (h *Handler) ListDeviceUsersHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("device_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid device_id")
+	}
+
+	rows, err := h.usecase.ListDeviceUsers(c, uint(id64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list device users"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]devDTO.DeviceUserResponse]{Data: rows}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// MapDeviceUserHandler godoc
+// @Summary      Map device user to employee
+// @Description  Memetakan PIN karyawan di mesin ke user; PIN yang sudah dipetakan akan ditimpa.
+// @Tags         Device
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        device_id  path  int                          true  "Device ID"
+// @Param        request    body  devDTO.MapDeviceUserRequest  true  "Mapping"
+// @Success      200  {object}  utils.Response[devDTO.DeviceUserResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Device / employee not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/devices/{device_id}/users [put]
+func (h *Handler) MapDeviceUserHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("device_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid device_id")
+	}
+	var req devDTO.MapDeviceUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.MapDeviceUser(c, uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to map device user"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[devDTO.DeviceUserResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// UnmapDeviceUserHandler godoc
+// @Summary      Remove device user mapping
+// @Tags         Device
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        device_id       path  int     true  "Device ID"
+// @Param        device_user_id  path  string  true  "PIN karyawan di mesin"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid device_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Mapping not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/devices/{device_id}/users/{device_user_id} [delete]
+func (h *Handler) UnmapDeviceUserHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("device_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid device_id")
+	}
+
+	if err := h.usecase.UnmapDeviceUser(c, uint(id64), c.Param("device_user_id")); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to unmap device user"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// IngestDevicePunchesHandler godoc
+// @Summary      Push punches from an attendance device
+// @Description  Dipanggil mesin absensi (bukan user). Diautentikasi dengan header X-Device-Key. Body sesuai vendor mesin: zkteco = ATTLOG (PIN<TAB>YYYY-MM-DD HH:MM:SS<TAB>status per baris); generic = JSON {"punches":[{"user_id","time","direction"}]}. Punch pertama per hari membuat attendance; kiriman ulang tidak membuat data ganda.
+// @Tags         Device
+// @Accept       plain
+// @Accept       json
+// @Produce      json
+// @Param        X-Device-Key  header  string  true  "Device API key"
+// @Param        request       body    string  true  "Vendor payload (max 2MB)"
+// @Success      200  {object}  utils.Response[devDTO.IngestPunchesResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid payload"
+// @Failure      401  {object}  utils.Response[any] "Unknown / inactive device key"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/devices/punches [post]
+func (h *Handler) IngestDevicePunchesHandler(c *gin.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxDevicePayloadSize+1))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to read device payload"})
+		return utils.MakeError(errorUc.BadRequest, "failed to read payload")
+	}
+	if len(body) > maxDevicePayloadSize {
+		err = utils.MakeError(errorUc.BadRequest, "payload is too large")
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	result, err := h.usecase.IngestDevicePunches(c, c.GetHeader(DeviceKeyHeader), body)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to ingest device punches"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[devDTO.IngestPunchesResponse]{Data: *result}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	employeeDTO "payslip-generation-system/internal/dto/employee"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListEmployeesHandler godoc
+// @Summary      List employees (admin only)
+// @Description  Employee directory with search by name/email, filters, sorting and pagination. Pagination info is returned in metadata.
+// @Tags         Employee
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        search      query  string  false  "Search first/last name or email"
+// @Param        role        query  string  false  "admin | user"
+// @Param        department  query  string  false  "Department (case-insensitive exact match)"
+// @Param        status      query  string  false  "active | probation | on_leave | terminated"
+// @Param        sort        query  string  false  "name | email | department | salary | created_at (default id)"
+// @Param        order       query  string  false  "asc (default) | desc"
+// @Param        page        query  int     false  "Page (default 1)"
+// @Param        pageSize    query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]employeeDTO.EmployeeResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/employees [get]
+func (h *Handler) ListEmployeesHandler(c *gin.Context) error {
+	var q employeeDTO.ListEmployeesQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListEmployees(c, q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list employees"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]employeeDTO.EmployeeResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// GetEmployeeHandler godoc
+// @Summary      Get employee detail (admin only)
+// @Tags         Employee
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        user_id  path  int  true  "User ID"
+// @Success      200  {object}  utils.Response[employeeDTO.EmployeeResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid user_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Employee not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/employees/{user_id} [get]
+func (h *Handler) GetEmployeeHandler(c *gin.Context) error {
+	uid64, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || uid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid user_id")
+	}
+
+	emp, err := h.usecase.GetEmployee(c, uint(uid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to get employee"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[employeeDTO.EmployeeResponse]{Data: *emp}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// UpdateEmployeeHandler godoc
+// @Summary      Update employee (admin only)
+// @Description  Updates name, role, salary, department and employment status. Omitted fields are unchanged. Changing the role or terminating the employee revokes all of their sessions; terminated employees cannot log in. Admins cannot change their own role or status.
+// @Tags         Employee
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        user_id  path  int  true  "User ID"
+// @Param        request  body  employeeDTO.UpdateEmployeeRequest  true  "Update Employee Request"
+// @Success      200  {object}  utils.Response[employeeDTO.EmployeeResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request body"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own role or status"
+// @Failure      404  {object}  utils.Response[any] "Employee not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/employees/{user_id} [patch]
+func (h *Handler) UpdateEmployeeHandler(c *gin.Context) error {
+	uid64, err := strconv.ParseUint(c.Param("user_id"), 10, 64)
+	if err != nil || uid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid user_id")
+	}
+
+	var req employeeDTO.UpdateEmployeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	emp, err := h.usecase.UpdateEmployee(c, c.GetUint("user_id"), uint(uid64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to update employee"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[employeeDTO.EmployeeResponse]{Data: *emp}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxEntryImportSize batas ukuran file CSV import attendance / overtime / reimbursement
+const maxEntryImportSize = 5 << 20
+
+// ImportPeriodEntriesHandler godoc
+// @Summary      Import attendance, overtime and reimbursements from CSV (admin only)
+// @Description  CSV dengan header; kolom wajib: user_id, date; opsional: type (attendance | overtime | reimbursement, default attendance), hours, amount, description. Tanggal boleh berisi jam (export mesin fingerprint). Setiap baris divalidasi seperti submit biasa (weekend, overtime <= 3 jam, period terkunci) dan dilaporkan per baris. Attendance / overtime yang sudah ada dilewati. Jika ada baris error tidak ada yang disimpan; dry_run=true hanya memvalidasi.
+// @Tags         Payroll
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path      int   true   "Attendance Period ID"
+// @Param        dry_run    query     bool  false  "Validate only"
+// @Param        file       formData  file  true   "Import file (CSV, max 5MB)"
+// @Success      200  {object}  utils.Response[pDTO.ImportEntriesResponse]
+// @Failure      400  {object}  utils.Response[any] "Missing / invalid file / period locked"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/import [post]
+func (h *Handler) ImportPeriodEntriesHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+	dryRun := false
+	if v := c.Query("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			return utils.MakeError(errorUc.BadRequest, "invalid dry_run")
+		}
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Missing import file"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.InvalidMandatory, "file"))))
+		c.Abort()
+		return err
+	}
+	if fh.Size > maxEntryImportSize {
+		err = utils.MakeError(errorUc.BadRequest, "import file is too large")
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+	f, err := fh.Open()
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to open import file"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to read import file")
+	}
+	defer f.Close()
+
+	result, err := h.usecase.ImportPeriodEntries(c, c.GetUint("user_id"), uint(pid64), f, dryRun)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to import period entries"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.ImportEntriesResponse]{Data: *result}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
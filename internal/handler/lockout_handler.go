@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListLockoutsHandler godoc
+// @Summary      List login lockouts (admin only)
+// @Description  Accounts (by email) and IPs that are locked out after repeated failed logins. Use all=true to include keys that have failures but are not locked.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        all  query  bool  false  "Include unlocked keys with failures"
+// @Success      200  {object}  utils.Response[[]authDTO.LockoutResponse]
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/lockouts [get]
+func (h *Handler) ListLockoutsHandler(c *gin.Context) error {
+	all := c.Query("all") == "true"
+
+	rows, err := h.usecase.ListLockouts(c, all)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list lockouts"})
+		return err
+	}
+
+	resp := utils.Response[[]authDTO.LockoutResponse]{Data: rows}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ClearLockoutHandler godoc
+// @Summary      Clear a login lockout (admin only)
+// @Description  Resets the failed-attempt counter and lock of an account (email) or IP.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  authDTO.ClearLockoutRequest  true  "Clear Lockout Request"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid request body"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/lockouts/clear [post]
+func (h *Handler) ClearLockoutHandler(c *gin.Context) error {
+	var req authDTO.ClearLockoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	if err := h.usecase.ClearLockout(c, req.Scope, req.Identifier); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to clear lockout"})
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
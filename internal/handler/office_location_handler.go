@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	olDTO "payslip-generation-system/internal/dto/officelocation"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListOfficeLocationsHandler godoc
+// @Summary      List office locations
+// @Description  Semua office location (aktif & nonaktif) yang dipakai geofence attendance.
+// @Tags         Office Location
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Success      200  {object}  utils.Response[[]olDTO.OfficeLocationResponse]
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/office-locations [get]
+func (h *Handler) ListOfficeLocationsHandler(c *gin.Context) error {
+	rows, err := h.usecase.ListOfficeLocations(c)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list office locations"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]olDTO.OfficeLocationResponse]{Data: rows}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// CreateOfficeLocationHandler godoc
+// @Summary      Create office location
+// @Description  Kantor dengan titik koordinat + radius (meter) dan/atau allowed_ip_ranges (CIDR / IP dipisah koma). Submission lolos jika dalam radius ATAU IP cocok.
+// @Tags         Office Location
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  olDTO.CreateOfficeLocationRequest  true  "Office Location"
+// @Success      201  {object}  utils.Response[olDTO.OfficeLocationResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / invalid ip range"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/office-locations [post]
+func (h *Handler) CreateOfficeLocationHandler(c *gin.Context) error {
+	var req olDTO.CreateOfficeLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.CreateOfficeLocation(c, req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to create office location"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[olDTO.OfficeLocationResponse]{Data: *row}
+	resp.SetToSuccessCreated()
+	c.JSON(http.StatusCreated, resp)
+	return nil
+}
+
+// UpdateOfficeLocationHandler godoc
+// @Summary      Update office location
+// @Description  Field yang tidak dikirim tidak diubah. Set active=false untuk mengecualikan kantor dari geofence.
+// @Tags         Office Location
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        office_location_id  path  int                                true  "Office Location ID"
+// @Param        request             body  olDTO.UpdateOfficeLocationRequest  true  "Fields to change"
+// @Success      200  {object}  utils.Response[olDTO.OfficeLocationResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / invalid ip range"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Office location not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/office-locations/{office_location_id} [patch]
+func (h *Handler) UpdateOfficeLocationHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("office_location_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid office_location_id")
+	}
+	var req olDTO.UpdateOfficeLocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.UpdateOfficeLocation(c, uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to update office location"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[olDTO.OfficeLocationResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// DeleteOfficeLocationHandler godoc
+// @Summary      Delete office location
+// @Tags         Office Location
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        office_location_id  path  int  true  "Office Location ID"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid office_location_id"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Office location not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/office-locations/{office_location_id} [delete]
+func (h *Handler) DeleteOfficeLocationHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("office_location_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid office_location_id")
+	}
+
+	if err := h.usecase.DeleteOfficeLocation(c, uint(id64)); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to delete office location"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
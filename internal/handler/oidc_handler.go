@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"net/http"
+
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StartOIDCLoginHandler godoc
+// @Summary      Start SSO login
+// @Description  Returns the identity provider authorization URL (authorization code flow with PKCE). Send the browser there; the provider redirects back to the callback endpoint.
+// @Tags         User
+// @Produce      json
+// @Param        provider   path   string  true   "Provider name from auth.oidc.providers, e.g. google"
+// @Param        device_id  query  string  false  "Device identifier for the session"
+// @Param        redirect   query  bool    false  "Respond with 302 to the provider instead of JSON"
+// @Success      200  {object}  utils.Response[authDTO.OIDCStartResponse]
+// @Success      302  "Redirect to the provider"
+// @Failure      404  {object}  utils.Response[any] "Unknown provider"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/oidc/{provider}/start [get]
+func (h *Handler) StartOIDCLoginHandler(c *gin.Context) error {
+	out, err := h.usecase.StartOIDCLogin(c, c.Param("provider"), c.Query("device_id"))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to start sso login"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	if c.Query("redirect") == "true" {
+		c.Redirect(http.StatusFound, out.AuthorizationURL)
+		return nil
+	}
+
+	resp := utils.Response[authDTO.OIDCStartResponse]{Data: *out}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// OIDCCallbackHandler godoc
+// @Summary      SSO login callback
+// @Description  Redirect target registered at the identity provider. Verifies the ID token, links it to an existing user (by GoogleID or verified email) and returns our own tokens, or a 2FA challenge when enabled. Accounts are never created here.
+// @Tags         User
+// @Produce      json
+// @Param        provider  path   string  true  "Provider name"
+// @Param        code      query  string  true  "Authorization code"
+// @Param        state     query  string  true  "State from the start endpoint"
+// @Success      200  {object}  authDTO.LoginUserResponse
+// @Failure      400  {object}  utils.Response[any] "Invalid / expired state or provider error"
+// @Failure      401  {object}  utils.Response[any] "ID token rejected"
+// @Failure      403  {object}  utils.Response[any] "No linked account / unverified email"
+// @Failure      404  {object}  utils.Response[any] "Unknown provider"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/oidc/{provider}/callback [get]
+func (h *Handler) OIDCCallbackHandler(c *gin.Context) error {
+	// provider mengirim ?error=access_denied kalau user membatalkan login
+	if e := c.Query("error"); e != "" {
+		err := utils.MakeError(errorUc.BadRequest, "identity provider returned "+e)
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+	code, state := c.Query("code"), c.Query("state")
+	if code == "" || state == "" {
+		err := utils.MakeError(errorUc.BadRequest, "code and state are required")
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	out, err := h.usecase.CompleteOIDCLogin(c, c.Param("provider"), code, state)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to complete sso login"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	c.JSON(http.StatusOK, out)
+	return nil
+}
@@ -4,6 +4,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	otDTO "payslip-generation-system/internal/dto/overtime"
 	errorUc "payslip-generation-system/internal/error"
@@ -16,13 +17,14 @@ import (
 // SubmitOvertimeHandler godoc
 // @Summary      Submit overtime
 // @Description  Submit overtime hours (<= 3h). Only allowed after 17:00 WIB if submitting for today. Weekend allowed.
+// @Description  Dicocokkan dengan rencana overtime yang disetujui: tanpa / melebihi rencana ditandai (plan_flag) atau ditolak sesuai overtime.planMode.
 // @Tags         Overtime
 // @Accept       json
 // @Produce      json
 // @Param Authorization header string true "Bearer JWT Token"
 // @Param        request  body      otDTO.SubmitOvertimeRequest  true  "Submit Overtime Request"
 // @Success      200      {object}  otDTO.SubmitOvertimeResponse
-// @Failure      400      {object}  utils.Response[any] "Invalid request / hours > 3 / before 17:00"
+// @Failure      400      {object}  utils.Response[any] "Invalid request / hours > 3 / before 17:00 / no or exceeded plan (overtime.planMode = reject)"
 // @Failure      401      {object}  utils.Response[any] "Unauthorized"
 // @Failure      403      {object}  utils.Response[any] "Forbidden"
 // @Failure      408      {object}  utils.Response[any] "Request Process Timeout"
@@ -58,6 +60,175 @@ func (h *Handler) SubmitOvertimeHandler(c *gin.Context) error {
 		Date:   row.Date.Format("2006-01-02"),
 		Hours:  fmt.Sprintf("%.2f", row.Hours),
 		Status: status,
+
+		PlanID:   row.PlanID,
+		PlanFlag: row.PlanFlagReason,
+	})
+	return nil
+}
+
+// SubmitOvertimeOnBehalfHandler godoc
+// @Summary      Submit overtime on behalf of an employee
+// @Description  Admin menginput overtime untuk karyawan. Validasi sama dengan submit biasa (<= 3h, setelah 17:00 WIB jika hari ini, period belum terkunci). Admin & alasan dicatat.
+// @Tags         Overtime
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body      otDTO.OnBehalfOvertimeRequest  true  "On-behalf Overtime Request"
+// @Success      200      {object}  otDTO.SubmitOvertimeResponse
+// @Failure      400      {object}  utils.Response[any] "Invalid request / hours > 3 / before 17:00 / period locked / employee terminated"
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      403      {object}  utils.Response[any] "Admin only"
+// @Failure      404      {object}  utils.Response[any] "Employee not found"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/overtime/submit [post]
+func (h *Handler) SubmitOvertimeOnBehalfHandler(c *gin.Context) error {
+	var req otDTO.OnBehalfOvertimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, existed, err := h.usecase.SubmitOvertimeOnBehalf(c, c.GetUint("user_id"), req.UserID, req.Date, req.Hours, req.Reason)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to submit overtime on behalf"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	status := "created"
+	if existed {
+		status = "already_exists"
+	}
+	c.JSON(http.StatusOK, otDTO.SubmitOvertimeResponse{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		Date:           row.Date.Format("2006-01-02"),
+		Hours:          fmt.Sprintf("%.2f", row.Hours),
+		Status:         status,
+		SubmittedBy:    row.SubmittedBy,
+		OnBehalfReason: row.OnBehalfReason,
+		PlanID:         row.PlanID,
+		PlanFlag:       row.PlanFlagReason,
 	})
 	return nil
 }
+
+// ListMyOvertimesHandler godoc
+// @Summary      List my overtime
+// @Description  Riwayat milik sendiri per period_id atau start_date & end_date (maks 366 hari); tanpa keduanya dipakai period yang memuat hari ini. status = status period tanggal tsb: open | running | closed | no_period.
+// @Tags         Overtime
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id   query  int     false  "Attendance Period ID"
+// @Param        start_date  query  string  false  "YYYY-MM-DD (with end_date)"
+// @Param        end_date    query  string  false  "YYYY-MM-DD (with start_date)"
+// @Param        page        query  int     false  "Page (default 1)"
+// @Param        pageSize    query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]otDTO.OvertimeHistoryResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query / no period covers today"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/overtime [get]
+func (h *Handler) ListMyOvertimesHandler(c *gin.Context) error {
+	var q otDTO.HistoryQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListMyOvertimes(c, c.GetUint("user_id"), q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list overtimes"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]otDTO.OvertimeHistoryResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// UpdateMyOvertimeHandler godoc
+// @Summary      Update my overtime
+// @Description  Ubah jam overtime milik sendiri (<= 3h). Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).
+// @Tags         Overtime
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        overtime_id  path  int                          true  "Overtime ID"
+// @Param        request      body  otDTO.UpdateOvertimeRequest  true  "New hours"
+// @Success      200  {object}  utils.Response[otDTO.OvertimeHistoryResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Submitted by an admin"
+// @Failure      404  {object}  utils.Response[any] "Overtime not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/overtime/{overtime_id} [patch]
+func (h *Handler) UpdateMyOvertimeHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("overtime_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid overtime_id")
+	}
+	var req otDTO.UpdateOvertimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.UpdateMyOvertime(c, c.GetUint("user_id"), uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to update overtime"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[otDTO.OvertimeHistoryResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// DeleteMyOvertimeHandler godoc
+// @Summary      Delete my overtime
+// @Description  Hapus overtime milik sendiri. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).
+// @Tags         Overtime
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        overtime_id  path  int  true  "Overtime ID"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid overtime_id / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Submitted by an admin"
+// @Failure      404  {object}  utils.Response[any] "Overtime not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/overtime/{overtime_id} [delete]
+func (h *Handler) DeleteMyOvertimeHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("overtime_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid overtime_id")
+	}
+
+	if err := h.usecase.DeleteMyOvertime(c, c.GetUint("user_id"), uint(id64)); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to delete overtime"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
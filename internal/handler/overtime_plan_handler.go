@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	otDTO "payslip-generation-system/internal/dto/overtime"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateOvertimePlanHandler godoc
+// @Summary      Request an overtime plan
+// @Description  Ajukan rencana overtime (tanggal, jam yang direncanakan, justifikasi) sebelum dijalankan: tanggal hari ini paling lambat 17:00 WIB atau tanggal mendatang. Disetujui admin; overtime yang disubmit kemudian dicocokkan dengan rencana ini.
+// @Tags         Overtime
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  otDTO.CreateOvertimePlanRequest  true  "Overtime Plan Request"
+// @Success      201  {object}  utils.Response[otDTO.OvertimePlanResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / past date / after 17:00 / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      409  {object}  utils.Response[any] "Plan already pending or approved for that date"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/overtime/plans [post]
+func (h *Handler) CreateOvertimePlanHandler(c *gin.Context) error {
+	var req otDTO.CreateOvertimePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.RequestOvertimePlan(c, c.GetUint("user_id"), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to request overtime plan"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[otDTO.OvertimePlanResponse]{Data: *row}
+	resp.SetToSuccessCreated()
+	c.JSON(http.StatusCreated, resp)
+	return nil
+}
+
+// ListMyOvertimePlansHandler godoc
+// @Summary      List my overtime plans
+// @Tags         Overtime
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        status    query  string  false  "pending | approved | rejected"
+// @Param        page      query  int     false  "Page (default 1)"
+// @Param        pageSize  query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]otDTO.OvertimePlanResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/overtime/plans [get]
+func (h *Handler) ListMyOvertimePlansHandler(c *gin.Context) error {
+	return h.listOvertimePlans(c, c.GetUint("user_id"))
+}
+
+// ListOvertimePlansHandler godoc
+// @Summary      List overtime plans
+// @Description  Admin: semua rencana overtime, tanggal terbaru lebih dulu.
+// @Tags         Overtime
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        status    query  string  false  "pending | approved | rejected"
+// @Param        user_id   query  int     false  "Filter by employee"
+// @Param        page      query  int     false  "Page (default 1)"
+// @Param        pageSize  query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]otDTO.OvertimePlanResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/overtime/plans [get]
+func (h *Handler) ListOvertimePlansHandler(c *gin.Context) error {
+	return h.listOvertimePlans(c, 0)
+}
+
+func (h *Handler) listOvertimePlans(c *gin.Context, userID uint) error {
+	var q otDTO.ListOvertimePlansQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListOvertimePlans(c, userID, q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list overtime plans"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]otDTO.OvertimePlanResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ApproveOvertimePlanHandler godoc
+// @Summary      Approve an overtime plan
+// @Description  Overtime yang sudah terlanjur disubmit pada tanggal tsb dicocokkan ulang. Pengaju tidak bisa menyetujui rencananya sendiri.
+// @Tags         Overtime
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        plan_id  path  int                              true   "Overtime Plan ID"
+// @Param        request  body  otDTO.ReviewOvertimePlanRequest  false  "Review note"
+// @Success      200  {object}  utils.Response[otDTO.OvertimePlanResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own plan"
+// @Failure      404  {object}  utils.Response[any] "Plan not found"
+// @Failure      409  {object}  utils.Response[any] "Already reviewed"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/overtime/plans/{plan_id}/approve [post]
+func (h *Handler) ApproveOvertimePlanHandler(c *gin.Context) error {
+	return h.reviewOvertimePlan(c, h.usecase.ApproveOvertimePlan, "Failed to approve overtime plan")
+}
+
+// RejectOvertimePlanHandler godoc
+// @Summary      Reject an overtime plan
+// @Tags         Overtime
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        plan_id  path  int                              true  "Overtime Plan ID"
+// @Param        request  body  otDTO.ReviewOvertimePlanRequest  true  "Rejection note (required)"
+// @Success      200  {object}  utils.Response[otDTO.OvertimePlanResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / missing note"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only / own plan"
+// @Failure      404  {object}  utils.Response[any] "Plan not found"
+// @Failure      409  {object}  utils.Response[any] "Already reviewed"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/overtime/plans/{plan_id}/reject [post]
+func (h *Handler) RejectOvertimePlanHandler(c *gin.Context) error {
+	return h.reviewOvertimePlan(c, h.usecase.RejectOvertimePlan, "Failed to reject overtime plan")
+}
+
+func (h *Handler) reviewOvertimePlan(c *gin.Context,
+	action func(*gin.Context, uint, uint, otDTO.ReviewOvertimePlanRequest) (*otDTO.OvertimePlanResponse, error),
+	failMsg string,
+) error {
+	id64, err := strconv.ParseUint(c.Param("plan_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid plan_id")
+	}
+	var req otDTO.ReviewOvertimePlanRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+			utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+			c.Abort()
+			return err
+		}
+	}
+
+	row, err := action(c, c.GetUint("user_id"), uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: failMsg})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[otDTO.OvertimePlanResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ListFlaggedOvertimesHandler godoc
+// @Summary      List overtime outside the approved plan
+// @Description  Laporan overtime pada rentang period tanpa rencana yang disetujui (no_plan) atau melebihi jam rencana (exceeds_plan); terisi saat overtime.planMode = flag.
+// @Tags         Overtime
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path   int  true   "Attendance Period ID"
+// @Param        page       query  int  false  "Page (default 1)"
+// @Param        pageSize   query  int  false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]otDTO.FlaggedOvertimeResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid period_id / query"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/overtime/flagged [get]
+func (h *Handler) ListFlaggedOvertimesHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+	var q otDTO.FlaggedOvertimeQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListFlaggedOvertimes(c, uint(pid64), q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list flagged overtimes"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]otDTO.FlaggedOvertimeResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ForgotPasswordHandler godoc
+// @Summary      Request password reset
+// @Description  Sends a single-use, expiring reset link to the email if it is registered. Always responds with success so registered emails cannot be discovered.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request  body      authDTO.ForgotPasswordRequest  true  "Forgot Password Request"
+// @Success      200      {object}  utils.Response[any]
+// @Failure      400      {object}  utils.Response[any] "Invalid request body"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/password/forgot [post]
+func (h *Handler) ForgotPasswordHandler(c *gin.Context) error {
+	var req authDTO.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	if err := h.usecase.ForgotPassword(c, req.Email); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to process forgot password"})
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	resp.ResponseMessage = "If the email is registered, reset instructions have been sent"
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ResetPasswordHandler godoc
+// @Summary      Reset password
+// @Description  Sets a new password using a reset token. The token can only be used once; all existing sessions are revoked.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request  body      authDTO.ResetPasswordRequest  true  "Reset Password Request"
+// @Success      200      {object}  utils.Response[any]
+// @Failure      400      {object}  utils.Response[any] "Invalid / expired token or password policy violation"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/password/reset [post]
+func (h *Handler) ResetPasswordHandler(c *gin.Context) error {
+	var req authDTO.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	if err := h.usecase.ResetPassword(c, req.Token, req.NewPassword); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to reset password"})
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ChangePasswordHandler godoc
+// @Summary      Change password
+// @Description  Changes the password of the logged-in user. All sessions (including the current one) are revoked; login again afterwards.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body      authDTO.ChangePasswordRequest  true  "Change Password Request"
+// @Success      200      {object}  utils.Response[any]
+// @Failure      400      {object}  utils.Response[any] "Wrong current password or password policy violation"
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/password/change [post]
+func (h *Handler) ChangePasswordHandler(c *gin.Context) error {
+	var req authDTO.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	if err := h.usecase.ChangePassword(c, userID, req.CurrentPassword, req.NewPassword); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to change password"})
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	pDTO "payslip-generation-system/internal/dto/payroll"
 	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
 	"payslip-generation-system/pkg/log"
 	"payslip-generation-system/utils"
 
@@ -16,14 +17,14 @@ import (
 
 // RunPayrollHandler godoc
 // @Summary      Run payroll for a period (admin only)
-// @Description  Processes payslips for the specified attendance period. After run, submissions in that period won't affect payslip. Can only run once per period.
+// @Description  Calculates payroll for the specified attendance period as a draft run. A draft can be re-run to recalculate; once submitted for approval it can no longer be re-run. Submissions in the period are locked when the run is approved.
 // @Tags         Payroll
 // @Accept       json
 // @Produce      json
 // @Param Authorization header string true "Bearer JWT Token"
 // @Param        period_id  path  int  true  "Attendance Period ID"
 // @Success      200  {object}  pDTO.RunPayrollResponse
-// @Failure      400  {object}  utils.Response[any] "Invalid period / already run / no working days"
+// @Failure      400  {object}  utils.Response[any] "Invalid period / run no longer draft / no working days"
 // @Failure      401  {object}  utils.Response[any] "Unauthorized"
 // @Failure      403  {object}  utils.Response[any] "Admin only"
 // @Failure      408  {object}  utils.Response[any] "Request Process Timeout"
@@ -36,7 +37,7 @@ func (h *Handler) RunPayrollHandler(c *gin.Context) error {
 		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
 	}
 
-	run, items, err := h.usecase.RunPayroll(c, uint(pid64))
+	run, items, err := h.usecase.RunPayroll(c, c.GetUint("user_id"), uint(pid64))
 	if err != nil {
 		h.log.Error(log.LogData{Err: err, Description: "failed to run payroll"})
 		return err
@@ -45,6 +46,7 @@ func (h *Handler) RunPayrollHandler(c *gin.Context) error {
 	resp := pDTO.RunPayrollResponse{
 		RunID:    run.ID,
 		PeriodID: run.PeriodID,
+		Status:   run.Status,
 		Items:    make([]pDTO.PayrollItemSummary, 0, len(items)),
 	}
 	for _, it := range items {
@@ -53,14 +55,386 @@ func (h *Handler) RunPayrollHandler(c *gin.Context) error {
 			SnapshotSalary:     fmt.Sprintf("%.2f", it.SnapshotSalary),
 			WorkingDays:        it.WorkingDays,
 			AttendanceDays:     it.AttendanceDays,
+			PaidHours:          fmt.Sprintf("%.2f", it.PaidHours),
 			OvertimeHours:      fmt.Sprintf("%.2f", it.OvertimeHours),
 			BasePay:            fmt.Sprintf("%.2f", it.BasePay),
 			OvertimePay:        fmt.Sprintf("%.2f", it.OvertimePay),
 			ReimbursementTotal: fmt.Sprintf("%.2f", it.ReimbursementTotal),
 			GrandTotal:         fmt.Sprintf("%.2f", it.GrandTotal),
+			BankAccountStatus:  it.BankAccountStatus,
 		})
+		if it.BankAccountStatus != model.BankAccountStatusOK {
+			resp.BankAccountIssues++
+		}
 	}
 
 	c.JSON(http.StatusOK, resp)
 	return nil
 }
+
+// GetPayrollRunHandler godoc
+// @Summary      Get payroll run status and history (admin only)
+// @Tags         Payroll
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[pDTO.PayrollRunResponse]
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/run [get]
+func (h *Handler) GetPayrollRunHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	run, err := h.usecase.GetPayrollRun(c, uint(pid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to get payroll run"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.PayrollRunResponse]{Data: *run}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// TransitionPayrollRunHandler godoc
+// @Summary      Change payroll run status (admin only)
+// @Description  Moves an approved run to paid (requires a generated disbursement file) and a paid run to closed. Submitting, approving and rejecting go through the approval endpoints. Every change is recorded in the run history with the acting admin.
+// @Tags         Payroll
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Param        request    body  pDTO.TransitionRunRequest  true  "Target status"
+// @Success      200  {object}  utils.Response[pDTO.PayrollRunResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid transition"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run"
+// @Failure      409  {object}  utils.Response[any] "Status changed concurrently"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/run/status [post]
+func (h *Handler) TransitionPayrollRunHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	var req pDTO.TransitionRunRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	run, err := h.usecase.TransitionPayrollRun(c, c.GetUint("user_id"), uint(pid64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to change payroll run status"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.PayrollRunResponse]{Data: *run}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// RequestRunApprovalHandler godoc
+// @Summary      Submit payroll run for approval (admin only)
+// @Description  Moves a draft run to pending_approval and opens a new approval round. A stale run (inputs changed after it was calculated) must be recalculated first.
+// @Tags         Payroll
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Param        request    body  pDTO.RunApprovalRequest  false  "Comment"
+// @Success      200  {object}  utils.Response[pDTO.PayrollRunResponse]
+// @Failure      400  {object}  utils.Response[any] "Run is not a draft / run is stale"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run"
+// @Failure      409  {object}  utils.Response[any] "Status changed concurrently"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/run/approval-request [post]
+func (h *Handler) RequestRunApprovalHandler(c *gin.Context) error {
+	return h.runApprovalAction(c, h.usecase.RequestRunApproval, "Failed to request payroll run approval")
+}
+
+// ApproveRunHandler godoc
+// @Summary      Approve payroll run (admin only)
+// @Description  Records the approval of the calling admin. The run creator cannot approve. The run becomes approved once payroll.approval.requiredApprovers admins have approved in the current round.
+// @Tags         Payroll
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Param        request    body  pDTO.RunApprovalRequest  false  "Comment"
+// @Success      200  {object}  utils.Response[pDTO.PayrollRunResponse]
+// @Failure      400  {object}  utils.Response[any] "Run is not pending approval"
+// @Failure      403  {object}  utils.Response[any] "Admin only / run creator"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run"
+// @Failure      409  {object}  utils.Response[any] "Already decided in this round"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/run/approve [post]
+func (h *Handler) ApproveRunHandler(c *gin.Context) error {
+	return h.runApprovalAction(c, h.usecase.ApproveRun, "Failed to approve payroll run")
+}
+
+// RejectRunHandler godoc
+// @Summary      Reject payroll run (admin only)
+// @Description  Sends a pending run back to draft with a mandatory comment; approvals of the current round no longer count.
+// @Tags         Payroll
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Param        request    body  pDTO.RunApprovalRequest  true  "Comment"
+// @Success      200  {object}  utils.Response[pDTO.PayrollRunResponse]
+// @Failure      400  {object}  utils.Response[any] "Missing comment / run is not pending approval"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run"
+// @Failure      409  {object}  utils.Response[any] "Already decided in this round"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/run/reject [post]
+func (h *Handler) RejectRunHandler(c *gin.Context) error {
+	return h.runApprovalAction(c, h.usecase.RejectRun, "Failed to reject payroll run")
+}
+
+func (h *Handler) runApprovalAction(c *gin.Context,
+	action func(*gin.Context, uint, uint, pDTO.RunApprovalRequest) (*pDTO.PayrollRunResponse, error),
+	desc string,
+) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	var req pDTO.RunApprovalRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+			utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+			c.Abort()
+			return err
+		}
+	}
+
+	run, err := action(c, c.GetUint("user_id"), uint(pid64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: desc})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.PayrollRunResponse]{Data: *run}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// GenerateDisbursementHandler godoc
+// @Summary      Generate bank disbursement file (admin only)
+// @Description  Builds the bulk-transfer file for the period's approved payroll run (formats: csv, bca_fixed) and records the batch with its totals and SHA-256 checksum. Only items still pending are included. Items without a verified bank account are rejected unless skip_unpayable is true; once verified they are paid through a supplementary batch of the same run.
+// @Tags         Payroll
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Param        request    body  pDTO.GenerateDisbursementRequest  false  "Options"
+// @Success      201  {object}  utils.Response[pDTO.DisbursementBatchResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid format / run not approved / unverified bank accounts / nothing to pay"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run"
+// @Failure      409  {object}  utils.Response[any] "All items already in a disbursement file"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/disbursement [post]
+func (h *Handler) GenerateDisbursementHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	var req pDTO.GenerateDisbursementRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+			utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+			c.Abort()
+			return err
+		}
+	}
+
+	batch, err := h.usecase.GenerateDisbursement(c, c.GetUint("user_id"), uint(pid64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to generate disbursement"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.DisbursementBatchResponse]{Data: *batch}
+	resp.SetToSuccessCreated()
+	c.JSON(http.StatusCreated, resp)
+	return nil
+}
+
+// GetDisbursementHandler godoc
+// @Summary      Get latest disbursement batch (admin only)
+// @Tags         Payroll
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[pDTO.DisbursementBatchResponse]
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Not generated yet"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/disbursement [get]
+func (h *Handler) GetDisbursementHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	batch, err := h.usecase.GetDisbursement(c, uint(pid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to get disbursement"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.DisbursementBatchResponse]{Data: *batch}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// DownloadDisbursementHandler godoc
+// @Summary      Download disbursement file (admin only)
+// @Description  Returns the latest generated file as an attachment; the X-Checksum-SHA256 header carries its checksum.
+// @Tags         Payroll
+// @Produce      octet-stream
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {file}    file
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Not generated yet"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/disbursement/file [get]
+func (h *Handler) DownloadDisbursementHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	batch, err := h.usecase.DisbursementFile(c, uint(pid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to download disbursement"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", batch.FileName))
+	c.Header("X-Checksum-SHA256", batch.Checksum)
+	c.Data(http.StatusOK, batch.ContentType, batch.Content)
+	return nil
+}
+
+// ListPaymentsHandler godoc
+// @Summary      List payment status of a payroll run (admin only)
+// @Description  Returns the payment status (pending, sent, paid, failed, returned) of every payroll item in the period's run, with count and amount per status.
+// @Tags         Payroll
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path  int  true  "Attendance Period ID"
+// @Success      200  {object}  utils.Response[pDTO.PaymentListResponse]
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/payments [get]
+func (h *Handler) ListPaymentsHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	payments, err := h.usecase.ListPayments(c, uint(pid64))
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list payments"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.PaymentListResponse]{Data: *payments}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// maxPaymentResultSize batas ukuran file hasil bank yang diimpor
+const maxPaymentResultSize = 5 << 20
+
+// ImportPaymentResultsHandler godoc
+// @Summary      Import bank transfer results (admin only)
+// @Description  Reconciles payment status from the bank result file (CSV with header; required columns: no, status; optional: account_number, amount, bank_reference, note). Rows are matched by their sequence number in the disbursement file. Status accepts paid/success, failed/rejected and returned. Invalid rows are reported per line without aborting the others.
+// @Tags         Payroll
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id  path      int   true  "Attendance Period ID"
+// @Param        file       formData  file  true  "Bank result file (CSV, max 5MB)"
+// @Success      200  {object}  utils.Response[pDTO.ImportPaymentResultsResponse]
+// @Failure      400  {object}  utils.Response[any] "Missing / invalid result file"
+// @Failure      403  {object}  utils.Response[any] "Admin only"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run / disbursement not generated"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/payroll/periods/{period_id}/disbursement/results [post]
+func (h *Handler) ImportPaymentResultsHandler(c *gin.Context) error {
+	pid64, err := strconv.ParseUint(c.Param("period_id"), 10, 64)
+	if err != nil || pid64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid period_id")
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Missing result file"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.InvalidMandatory, "file"))))
+		c.Abort()
+		return err
+	}
+	if fh.Size > maxPaymentResultSize {
+		err = utils.MakeError(errorUc.BadRequest, "result file is too large")
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+	f, err := fh.Open()
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to open result file"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to read result file")
+	}
+	defer f.Close()
+
+	result, err := h.usecase.ImportPaymentResults(c, c.GetUint("user_id"), uint(pid64), f)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to import payment results"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[pDTO.ImportPaymentResultsResponse]{Data: *result}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -14,16 +14,17 @@ import (
 
 // GeneratePayslipHandler godoc
 // @Summary      Generate payslip for a period (employee)
-// @Description  Generates a payslip with attendance, overtime, reimbursements and totals. If payroll already ran for the period, snapshot values are used.
+// @Description  Returns the payslip (attendance, overtime, reimbursements, adjustments and totals) from the approved payroll run snapshot.
 // @Tags         Payslip
 // @Accept       json
 // @Produce      json
 // @Param 		 Authorization header string true "Bearer JWT Token"
 // @Param        period_id  path  int  true  "Attendance Period ID"
 // @Success      200  {object}  psDTO.PayslipResponse
-// @Failure      400  {object}  utils.Response[any] "Invalid period / no working days"
+// @Failure      400  {object}  utils.Response[any] "Invalid period"
 // @Failure      401  {object}  utils.Response[any] "Unauthorized"
 // @Failure      403  {object}  utils.Response[any] "Forbidden"
+// @Failure      404  {object}  utils.Response[any] "Payroll not run or not approved yet"
 // @Failure      408  {object}  utils.Response[any] "Request Process Timeout"
 // @Failure      500  {object}  utils.Response[any] "Internal Server Error"
 // @Router       /v1/payslips/periods/{period_id} [get]
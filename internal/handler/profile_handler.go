@@ -0,0 +1,134 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	profileDTO "payslip-generation-system/internal/dto/profile"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMyProfileHandler godoc
+// @Summary      Get my profile
+// @Description  Returns the profile of the logged-in employee. Salary and role are read-only.
+// @Tags         Profile
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Success      200      {object}  utils.Response[profileDTO.ProfileResponse]
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      404      {object}  utils.Response[any] "User not found"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/me [get]
+func (h *Handler) GetMyProfileHandler(c *gin.Context) error {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	profile, err := h.usecase.GetMyProfile(c, userID)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to get profile"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[profileDTO.ProfileResponse]{Data: *profile}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// UpdateMyProfileHandler godoc
+// @Summary      Update my profile
+// @Description  Partially updates first_name, last_name, age, bio, location and interests of the logged-in employee. Omitted fields are left unchanged; unknown fields (e.g. salary, role, email) are rejected. is_profile_complete is recomputed.
+// @Tags         Profile
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body      profileDTO.UpdateProfileRequest  true  "Update Profile Request"
+// @Success      200      {object}  utils.Response[profileDTO.ProfileResponse]
+// @Failure      400      {object}  utils.Response[any] "Invalid request body / validation error"
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/me [patch]
+func (h *Handler) UpdateMyProfileHandler(c *gin.Context) error {
+	var req profileDTO.UpdateProfileRequest
+	dec := json.NewDecoder(c.Request.Body)
+	dec.DisallowUnknownFields() // salary/role/email hanya bisa diubah admin
+	if err := dec.Decode(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	profile, err := h.usecase.UpdateMyProfile(c, userID, req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to update profile"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[profileDTO.ProfileResponse]{Data: *profile}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// UploadProfileImageHandler godoc
+// @Summary      Upload my profile image
+// @Description  Replaces the profile image of the logged-in employee. Accepts JPEG, PNG or WebP up to 2MB (multipart field "image"); the type is detected from the file content.
+// @Tags         Profile
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        image    formData  file  true  "Profile image"
+// @Success      200      {object}  utils.Response[profileDTO.ProfileResponse]
+// @Failure      400      {object}  utils.Response[any] "Missing / too large / unsupported image"
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/me/profile-image [put]
+func (h *Handler) UploadProfileImageHandler(c *gin.Context) error {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	fh, err := c.FormFile("image")
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Missing image file"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.InvalidMandatory, "image"))))
+		c.Abort()
+		return err
+	}
+	f, err := fh.Open()
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to open image file"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to read image")
+	}
+	defer f.Close()
+
+	profile, err := h.usecase.UploadProfileImage(c, userID, f)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to upload profile image"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[profileDTO.ProfileResponse]{Data: *profile}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -4,6 +4,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	rbDTO "payslip-generation-system/internal/dto/reimbursement"
 	errorUc "payslip-generation-system/internal/error"
@@ -57,3 +58,163 @@ func (h *Handler) CreateReimbursementHandler(c *gin.Context) error {
 	})
 	return nil
 }
+
+// CreateReimbursementOnBehalfHandler godoc
+// @Summary      Create reimbursement on behalf of an employee
+// @Description  Admin menginput reimbursement untuk karyawan. Validasi sama dengan create biasa (amount > 0, period belum terkunci). Admin & alasan dicatat.
+// @Tags         Reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body      rbDTO.OnBehalfReimbursementRequest  true  "On-behalf Reimbursement Request"
+// @Success      201      {object}  rbDTO.ReimbursementResponse
+// @Failure      400      {object}  utils.Response[any] "Invalid request / period locked / employee terminated"
+// @Failure      401      {object}  utils.Response[any] "Unauthorized"
+// @Failure      403      {object}  utils.Response[any] "Admin only"
+// @Failure      404      {object}  utils.Response[any] "Employee not found"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/admin/reimbursements [post]
+func (h *Handler) CreateReimbursementOnBehalfHandler(c *gin.Context) error {
+	var req rbDTO.OnBehalfReimbursementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.CreateReimbursementOnBehalf(c, c.GetUint("user_id"), req.UserID, req.Date, req.Amount, req.Description, req.Reason)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to create reimbursement on behalf"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	c.JSON(http.StatusCreated, rbDTO.ReimbursementResponse{
+		ID:             row.ID,
+		UserID:         row.UserID,
+		Date:           row.Date.Format("2006-01-02"),
+		Amount:         fmt.Sprintf("%.2f", row.Amount),
+		Description:    row.Description,
+		SubmittedBy:    row.SubmittedBy,
+		OnBehalfReason: row.OnBehalfReason,
+	})
+	return nil
+}
+
+// ListMyReimbursementsHandler godoc
+// @Summary      List my reimbursements
+// @Description  Riwayat milik sendiri per period_id atau start_date & end_date (maks 366 hari); tanpa keduanya dipakai period yang memuat hari ini. status = status period tanggal tsb: open | running | closed | no_period.
+// @Tags         Reimbursement
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        period_id   query  int     false  "Attendance Period ID"
+// @Param        start_date  query  string  false  "YYYY-MM-DD (with end_date)"
+// @Param        end_date    query  string  false  "YYYY-MM-DD (with start_date)"
+// @Param        page        query  int     false  "Page (default 1)"
+// @Param        pageSize    query  int     false  "Page size (default 20, max 100)"
+// @Success      200  {object}  utils.Response[[]rbDTO.ReimbursementHistoryResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid query / no period covers today"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      404  {object}  utils.Response[any] "Period not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/reimbursements [get]
+func (h *Handler) ListMyReimbursementsHandler(c *gin.Context) error {
+	var q rbDTO.HistoryQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid query"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid query"))))
+		c.Abort()
+		return err
+	}
+
+	rows, meta, err := h.usecase.ListMyReimbursements(c, c.GetUint("user_id"), q)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to list reimbursements"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[[]rbDTO.ReimbursementHistoryResponse]{Data: rows, Metadata: meta}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// UpdateMyReimbursementHandler godoc
+// @Summary      Update my reimbursement
+// @Description  Ubah amount / description reimbursement milik sendiri; field yang tidak dikirim tidak diubah. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).
+// @Tags         Reimbursement
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        reimbursement_id  path  int                               true  "Reimbursement ID"
+// @Param        request           body  rbDTO.UpdateReimbursementRequest  true  "Fields to change"
+// @Success      200  {object}  utils.Response[rbDTO.ReimbursementHistoryResponse]
+// @Failure      400  {object}  utils.Response[any] "Invalid request / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Submitted by an admin"
+// @Failure      404  {object}  utils.Response[any] "Reimbursement not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/reimbursements/{reimbursement_id} [patch]
+func (h *Handler) UpdateMyReimbursementHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("reimbursement_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid reimbursement_id")
+	}
+	var req rbDTO.UpdateReimbursementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.BadRequest, "invalid request body"))))
+		c.Abort()
+		return err
+	}
+
+	row, err := h.usecase.UpdateMyReimbursement(c, c.GetUint("user_id"), uint(id64), req)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to update reimbursement"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[rbDTO.ReimbursementHistoryResponse]{Data: *row}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// DeleteMyReimbursementHandler godoc
+// @Summary      Delete my reimbursement
+// @Description  Hapus reimbursement milik sendiri. Hanya selama tanggalnya belum masuk period atau period-nya masih open (belum di-run payroll).
+// @Tags         Reimbursement
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        reimbursement_id  path  int  true  "Reimbursement ID"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Invalid reimbursement_id / period locked"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      403  {object}  utils.Response[any] "Submitted by an admin"
+// @Failure      404  {object}  utils.Response[any] "Reimbursement not found"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/reimbursements/{reimbursement_id} [delete]
+func (h *Handler) DeleteMyReimbursementHandler(c *gin.Context) error {
+	id64, err := strconv.ParseUint(c.Param("reimbursement_id"), 10, 64)
+	if err != nil || id64 == 0 {
+		return utils.MakeError(errorUc.BadRequest, "invalid reimbursement_id")
+	}
+
+	if err := h.usecase.DeleteMyReimbursement(c, c.GetUint("user_id"), uint(id64)); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to delete reimbursement"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
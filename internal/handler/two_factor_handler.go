@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"net/http"
+
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// VerifyTwoFactorHandler godoc
+// @Summary      Complete login with a two-factor code
+// @Description  Exchanges the challengeToken from /v1/auth/login plus a 6-digit authenticator code (or a recovery code) for access + refresh tokens.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        request  body      authDTO.TwoFactorVerifyRequest  true  "Two-Factor Verify Request"
+// @Success      200      {object}  authDTO.TokenResponse
+// @Failure      400      {object}  utils.Response[any] "Invalid request body"
+// @Failure      401      {object}  utils.Response[any] "Invalid / expired challenge or wrong code"
+// @Failure      429      {object}  utils.Response[any] "Too many wrong codes"
+// @Failure      500      {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/2fa/verify [post]
+func (h *Handler) VerifyTwoFactorHandler(c *gin.Context) error {
+	var req authDTO.TwoFactorVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+
+	tokens, err := h.usecase.VerifyTwoFactor(c, req.ChallengeToken, req.Code)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to verify two-factor code"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return err
+	}
+
+	c.JSON(http.StatusOK, tokens)
+	return nil
+}
+
+// EnrollTwoFactorHandler godoc
+// @Summary      Start two-factor enrollment
+// @Description  Generates a new TOTP secret and otpauth URI (render it as a QR code). 2FA is not active until /v1/auth/2fa/activate succeeds.
+// @Tags         User
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Success      200  {object}  utils.Response[authDTO.TwoFactorEnrollResponse]
+// @Failure      401  {object}  utils.Response[any] "Unauthorized"
+// @Failure      409  {object}  utils.Response[any] "Already enabled"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/2fa/enroll [post]
+func (h *Handler) EnrollTwoFactorHandler(c *gin.Context) error {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	out, err := h.usecase.EnrollTwoFactor(c, userID)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to enroll two-factor"})
+		return err
+	}
+
+	resp := utils.Response[authDTO.TwoFactorEnrollResponse]{Data: *out}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// ActivateTwoFactorHandler godoc
+// @Summary      Activate two-factor authentication
+// @Description  Confirms enrollment with a code from the authenticator app and returns one-time recovery codes (shown only once). Log in again to get a token that carries the otp method.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  authDTO.TwoFactorCodeRequest  true  "Authenticator code"
+// @Success      200  {object}  utils.Response[authDTO.RecoveryCodesResponse]
+// @Failure      400  {object}  utils.Response[any] "Not enrolled"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized / wrong code"
+// @Failure      409  {object}  utils.Response[any] "Already enabled"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/2fa/activate [post]
+func (h *Handler) ActivateTwoFactorHandler(c *gin.Context) error {
+	var req authDTO.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	out, err := h.usecase.ActivateTwoFactor(c, userID, req.Code)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to activate two-factor"})
+		return err
+	}
+
+	resp := utils.Response[authDTO.RecoveryCodesResponse]{Data: *out}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// RegenerateRecoveryCodesHandler godoc
+// @Summary      Regenerate recovery codes
+// @Description  Replaces all recovery codes; the old ones stop working. Requires a current authenticator or recovery code.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  authDTO.TwoFactorCodeRequest  true  "Authenticator or recovery code"
+// @Success      200  {object}  utils.Response[authDTO.RecoveryCodesResponse]
+// @Failure      400  {object}  utils.Response[any] "2FA not enabled"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized / wrong code"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/2fa/recovery-codes [post]
+func (h *Handler) RegenerateRecoveryCodesHandler(c *gin.Context) error {
+	var req authDTO.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	out, err := h.usecase.RegenerateRecoveryCodes(c, userID, req.Code)
+	if err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to regenerate recovery codes"})
+		return err
+	}
+
+	resp := utils.Response[authDTO.RecoveryCodesResponse]{Data: *out}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
+
+// DisableTwoFactorHandler godoc
+// @Summary      Disable two-factor authentication
+// @Description  Requires the password and a current authenticator or recovery code. Not allowed for admins when 2FA is mandatory.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer JWT Token"
+// @Param        request  body  authDTO.TwoFactorDisableRequest  true  "Disable Two-Factor Request"
+// @Success      200  {object}  utils.Response[any]
+// @Failure      400  {object}  utils.Response[any] "Wrong password / 2FA not enabled"
+// @Failure      401  {object}  utils.Response[any] "Unauthorized / wrong code"
+// @Failure      403  {object}  utils.Response[any] "2FA mandatory for admin"
+// @Failure      500  {object}  utils.Response[any] "Internal Server Error"
+// @Router       /v1/auth/2fa/disable [post]
+func (h *Handler) DisableTwoFactorHandler(c *gin.Context) error {
+	var req authDTO.TwoFactorDisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Invalid request body"})
+		return utils.MakeError(errorUc.BadRequest, "invalid request body")
+	}
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	if err := h.usecase.DisableTwoFactor(c, userID, req.Password, req.Code); err != nil {
+		h.log.Error(log.LogData{Err: err, Description: "Failed to disable two-factor"})
+		return err
+	}
+
+	resp := utils.Response[any]{}
+	resp.SetToSuccess()
+	c.JSON(http.StatusOK, resp)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"payslip-generation-system/config"
 	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/pkg/jwtkey"
 	"payslip-generation-system/pkg/log"
 	"payslip-generation-system/utils"
 
@@ -12,15 +13,28 @@ import (
 	"github.com/golang-jwt/jwt"
 )
 
+// SessionValidator memastikan session & token_version di dalam access token belum dicabut.
+type SessionValidator interface {
+	ValidateSession(ctx *gin.Context, userID, sessionID uint, tokenVersion int) error
+}
+
+// TokenVerifier memverifikasi signature access token (key management ada di usecase/jwtkey).
+type TokenVerifier interface {
+	SessionValidator
+	ParseAccessToken(tokenString string) (jwt.MapClaims, error)
+}
+
 type AuthHandler struct {
-	cfg *config.Config
-	log *log.LogCustom
+	cfg      *config.Config
+	log      *log.LogCustom
+	verifier TokenVerifier
 }
 
-func New(r *gin.RouterGroup, c *config.Config, l *log.LogCustom) {
+func New(r *gin.RouterGroup, c *config.Config, l *log.LogCustom, tv TokenVerifier) {
 	handler := AuthHandler{
-		cfg: c,
-		log: l,
+		cfg:      c,
+		log:      l,
+		verifier: tv,
 	}
 	r.Use(handler.AuthJwt)
 }
@@ -42,57 +56,55 @@ func (au *AuthHandler) AuthJwt(c *gin.Context) {
 	}
 	tokenString := parts[1]
 
-	// Ambil secret dari config (sesuaikan path-nya dengan config kamu)
-	secret := "" // contoh: isi di config kamu
-	if secret == "" {
-		// fallback kalau belum diisi; tapi sebaiknya DIHAPUS untuk production
-		secret = "A7M+TXRMxdz0N3nFLjGaxVKgkELowtbxWipS+IFZkVE="
-	}
-
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		// pastikan HMAC
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			au.log.Error(log.LogData{Err: utils.MakeError(errorUc.ErrUnauthorized)})
-			return nil, utils.MakeError(errorUc.ErrUnauthorized)
-		}
-		return []byte(secret), nil
-	})
-	if err != nil || token == nil || !token.Valid {
-		au.log.Error(log.LogData{Err: utils.MakeError(errorUc.ErrUnauthorized)})
-		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.ErrUnauthorized))))
-		c.Abort()
-		return
-	}
-
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
+	claims, err := au.verifier.ParseAccessToken(tokenString)
+	if err != nil {
+		au.log.Error(log.LogData{Err: err, Description: "invalid access token"})
 		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.ErrUnauthorized))))
 		c.Abort()
 		return
 	}
 
 	// Ambil claims dengan aman
-	var userID uint
-	if v, ok := claims["user_id"]; ok {
-		switch t := v.(type) {
-		case float64:
-			userID = uint(t)
-		case int:
-			userID = uint(t)
-		}
-	}
+	userID := jwtkey.UintClaim(claims, "user_id")
+	sessionID := jwtkey.UintClaim(claims, "sid")
+	tokenVersion := int(jwtkey.UintClaim(claims, "ver"))
 	name, _ := claims["name"].(string)
 	role, _ := claims["role"].(string)
 
-	if userID == 0 || name == "" || role == "" {
+	// token lama tanpa sid tidak bisa dicabut, jadi ditolak (user harus login ulang)
+	if userID == 0 || sessionID == 0 || name == "" || role == "" {
 		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(utils.MakeError(errorUc.ErrUnauthorized))))
 		c.Abort()
 		return
 	}
 
+	if err := au.verifier.ValidateSession(c, userID, sessionID, tokenVersion); err != nil {
+		au.log.Error(log.LogData{Err: err, Description: "access token rejected by session check"})
+		utils.Failed(c, utils.CustomError(errorUc.ErrorCustom(err)))
+		c.Abort()
+		return
+	}
+
+	jti, _ := claims["jti"].(string)
+	amr := stringsClaim(claims, "amr")
+
 	c.Set("user_id", userID)
+	c.Set("session_id", sessionID)
+	c.Set("jti", jti)
+	c.Set("amr", amr)
 	c.Set("name", name)
 	c.Set("role", role)
 
 	c.Next()
 }
+
+func stringsClaim(claims jwt.MapClaims, key string) []string {
+	raw, _ := claims[key].([]interface{})
+	out := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
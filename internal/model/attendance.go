@@ -3,11 +3,31 @@ package model
 import "time"
 
 type Attendance struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	UserID    uint      `gorm:"index;not null"`
-	Date      time.Time `gorm:"type:date;index:user_date_unique,unique;not null"`
-	CreatedAt time.Time `gorm:"type:timestamp;default:now()"`
-	UpdatedAt time.Time `gorm:"type:timestamp;default:now()"`
+	ID     uint      `gorm:"primaryKey;autoIncrement"`
+	UserID uint      `gorm:"index;not null"`
+	Date   time.Time `gorm:"type:date;index:user_date_unique,unique;not null"`
+	// Clock-in/clock-out; nil pada attendance lama (submit harian tanpa jam)
+	CheckInAt         *time.Time `gorm:"type:timestamp"`
+	CheckOutAt        *time.Time `gorm:"type:timestamp"`
+	WorkedMinutes     int        `gorm:"not null;default:0"` // dihitung saat check-out
+	LateMinutes       int        `gorm:"not null;default:0"` // dari jam masuk jadwal, 0 jika masih dalam grace
+	EarlyLeaveMinutes int        `gorm:"not null;default:0"` // sebelum jam pulang jadwal
+	IsLate            bool       `gorm:"not null;default:false"`
+	IsEarlyLeave      bool       `gorm:"not null;default:false"`
+	// Lokasi & IP saat submit / check-in; LocationFlagged jika di luar semua kantor (mode geofence flag)
+	Latitude           *float64 `gorm:"type:numeric(9,6)"`
+	Longitude          *float64 `gorm:"type:numeric(9,6)"`
+	ClientIP           string   `gorm:"type:varchar(45)"`
+	OfficeLocationID   *uint
+	DistanceMeters     *float64  `gorm:"type:numeric(10,1)"` // ke kantor terdekat
+	LocationFlagged    bool      `gorm:"not null;default:false;index"`
+	LocationFlagReason string    `gorm:"type:varchar(100)"` // missing_location,outside_radius,ip_not_allowed
+	CreatedAt          time.Time `gorm:"type:timestamp;default:now()"`
+	UpdatedAt          time.Time `gorm:"type:timestamp;default:now()"`
+
+	// Diisi jika diinput admin atas nama karyawan
+	SubmittedBy    *uint  `gorm:"index"`
+	OnBehalfReason string `gorm:"type:varchar(255)"`
 }
 
 func (Attendance) TableName() string { return "attendances" }
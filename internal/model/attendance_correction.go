@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+const (
+	CorrectionActionAdd    = "add"    // tambah attendance yang lupa disubmit
+	CorrectionActionRemove = "remove" // hapus attendance yang salah tanggal
+
+	CorrectionStatusPending  = "pending"
+	CorrectionStatusApproved = "approved"
+	CorrectionStatusRejected = "rejected"
+)
+
+// AttendanceCorrection: pengajuan koreksi attendance oleh karyawan, diterapkan setelah disetujui admin.
+type AttendanceCorrection struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	UserID     uint      `gorm:"index;not null"`
+	Date       time.Time `gorm:"type:date;not null"`
+	Action     string    `gorm:"type:varchar(10);not null"` // add | remove
+	Reason     string    `gorm:"type:varchar(500);not null"`
+	Status     string    `gorm:"type:varchar(20);not null;default:pending;index"`
+	ReviewedBy *uint
+	ReviewedAt *time.Time `gorm:"type:timestamp"`
+	ReviewNote string     `gorm:"type:varchar(500)"`
+	// AttendanceID: attendance yang dibuat / dihapus saat koreksi diterapkan
+	AttendanceID *uint
+	CreatedAt    time.Time `gorm:"type:timestamp;default:now()"`
+	UpdatedAt    time.Time `gorm:"type:timestamp;default:now()"`
+}
+
+func (AttendanceCorrection) TableName() string { return "attendance_corrections" }
+
+const (
+	AttendanceAuditCreated = "created"
+	AttendanceAuditDeleted = "deleted"
+
+	AttendanceAuditSourceCorrection = "correction"
+	AttendanceAuditSourceSelf       = "self"   // dihapus sendiri oleh karyawan
+	AttendanceAuditSourceDevice     = "device" // dibuat dari punch mesin absensi (SourceID = id device)
+)
+
+// AttendanceAudit: jejak perubahan attendance di luar submit normal (siapa, kapan, dari mana).
+type AttendanceAudit struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	AttendanceID uint      `gorm:"index;not null"`
+	UserID       uint      `gorm:"index;not null"`
+	Date         time.Time `gorm:"type:date;not null"`
+	Action       string    `gorm:"type:varchar(20);not null"` // created | deleted
+	Source       string    `gorm:"type:varchar(20);not null"` // correction | self | device
+	SourceID     *uint     // e.g. id attendance_corrections / devices
+	ActorID      uint      `gorm:"not null"` // 0 untuk mesin absensi
+	Reason       string    `gorm:"type:varchar(500)"`
+	CreatedAt    time.Time `gorm:"type:timestamp;default:now()"`
+}
+
+func (AttendanceAudit) TableName() string { return "attendance_audits" }
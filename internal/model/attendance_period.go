@@ -7,8 +7,18 @@ type AttendancePeriod struct {
 	Name      string    `gorm:"type:varchar(100);not null"` // optional nama payroll period
 	StartDate time.Time `gorm:"type:date;not null"`
 	EndDate   time.Time `gorm:"type:date;not null"`
+	// ClosedAt: ditutup manual oleh admin (submission terkunci walau payroll belum approved)
+	ClosedAt  *time.Time `gorm:"type:timestamp"`
+	ClosedBy  *uint
 	CreatedAt time.Time `gorm:"type:timestamp;default:now()"`
 	UpdatedAt time.Time `gorm:"type:timestamp;default:now()"`
 }
 
 func (AttendancePeriod) TableName() string { return "attendance_periods" }
+
+// Status period (diturunkan dari ClosedAt & status run payroll)
+const (
+	PeriodStatusOpen    = "open"    // belum ada run
+	PeriodStatusRunning = "running" // run draft / menunggu approval
+	PeriodStatusClosed  = "closed"  // run approved ke atas atau ditutup manual
+)
@@ -0,0 +1,33 @@
+package model
+
+import "time"
+
+// AuthSession mewakili satu login per device/session. Refresh token dirotasi di dalam session ini.
+type AuthSession struct {
+	ID            uint       `gorm:"primaryKey;autoIncrement"`
+	UserID        uint       `gorm:"index;not null"`
+	DeviceID      string     `gorm:"type:varchar(255)"`
+	UserAgent     string     `gorm:"type:varchar(512)"`
+	IPAddress     string     `gorm:"type:varchar(64)"`
+	AMR           string     `gorm:"type:varchar(64);default:'pwd'"` // metode login, dipisah spasi: "pwd" / "pwd otp"
+	ExpiresAt     time.Time  `gorm:"type:timestamp;not null"`
+	LastUsedAt    time.Time  `gorm:"type:timestamp"`
+	RevokedAt     *time.Time `gorm:"type:timestamp"`
+	RevokedReason string     `gorm:"type:varchar(100)"` // logout, refresh_token_reuse, revoked_by_admin, ...
+	CreatedAt     time.Time  `gorm:"type:timestamp;default:now()"`
+	UpdatedAt     time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (AuthSession) TableName() string { return "auth_sessions" }
+
+// RefreshToken disimpan dalam bentuk hash (sha256), token mentah hanya dikirim ke client sekali.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement"`
+	SessionID uint       `gorm:"index;not null"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt time.Time  `gorm:"type:timestamp;not null"`
+	RotatedAt *time.Time `gorm:"type:timestamp"` // terisi saat sudah ditukar; dipakai lagi => reuse
+	CreatedAt time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (RefreshToken) TableName() string { return "refresh_tokens" }
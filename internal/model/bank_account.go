@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// EmployeeBankAccount: rekening tujuan transfer gaji (satu per karyawan).
+// Nomor rekening dan nama pemilik disimpan terenkripsi (fieldcrypt); Last4 untuk tampilan masked.
+type EmployeeBankAccount struct {
+	ID               uint       `gorm:"primaryKey;autoIncrement"`
+	UserID           uint       `gorm:"uniqueIndex;not null"`
+	BankCode         string     `gorm:"type:varchar(20);not null"`
+	AccountNumberEnc string     `gorm:"type:text;not null"`
+	AccountLast4     string     `gorm:"type:varchar(4);not null"`
+	HolderNameEnc    string     `gorm:"type:text;not null"`
+	Verified         bool       `gorm:"not null;default:false"` // reset setiap kali data rekening berubah
+	VerifiedAt       *time.Time `gorm:"type:timestamp"`
+	VerifiedBy       *uint
+	CreatedAt        time.Time `gorm:"type:timestamp;default:now()"`
+	UpdatedAt        time.Time `gorm:"type:timestamp;default:now()"`
+}
+
+func (EmployeeBankAccount) TableName() string { return "employee_bank_accounts" }
+
+const (
+	BankAccountActionSet      = "set"
+	BankAccountActionVerified = "verified"
+)
+
+// EmployeeBankAccountHistory: jejak setiap perubahan/verifikasi rekening (nilai tetap terenkripsi).
+type EmployeeBankAccountHistory struct {
+	ID               uint      `gorm:"primaryKey;autoIncrement"`
+	UserID           uint      `gorm:"index;not null"`
+	Action           string    `gorm:"type:varchar(20);not null"` // set | verified
+	BankCode         string    `gorm:"type:varchar(20);not null"`
+	AccountNumberEnc string    `gorm:"type:text;not null"`
+	AccountLast4     string    `gorm:"type:varchar(4);not null"`
+	HolderNameEnc    string    `gorm:"type:text;not null"`
+	ChangedBy        uint      `gorm:"not null"`
+	ChangedAt        time.Time `gorm:"type:timestamp;not null"`
+}
+
+func (EmployeeBankAccountHistory) TableName() string { return "employee_bank_account_histories" }
+
+// status rekening per item payroll
+const (
+	BankAccountStatusOK         = "ok"
+	BankAccountStatusMissing    = "missing"
+	BankAccountStatusUnverified = "unverified"
+)
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// Mesin absensi biometrik yang mengirim log punch. API key hanya disimpan hash-nya (sha256).
+type Device struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement"`
+	Name         string     `gorm:"type:varchar(100);not null"`
+	Vendor       string     `gorm:"type:varchar(30);not null"` // adapter di internal/device, e.g. zkteco
+	SerialNumber string     `gorm:"type:varchar(64);not null;uniqueIndex:idx_devices_serial_number_lower,expression:lower(serial_number)"`
+	APIKeyHash   string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	APIKeyPrefix string     `gorm:"type:varchar(12);not null"` // untuk identifikasi key di UI
+	Active       bool       `gorm:"not null;default:true"`
+	LastSeenAt   *time.Time `gorm:"type:timestamp"`
+	CreatedBy    uint       `gorm:"not null"`
+	CreatedAt    time.Time  `gorm:"type:timestamp;default:now()"`
+	UpdatedAt    time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (Device) TableName() string { return "devices" }
+
+// DeviceUser memetakan nomor / PIN karyawan di mesin ke user.
+type DeviceUser struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	DeviceID     uint      `gorm:"not null;uniqueIndex:device_user_unique"`
+	DeviceUserID string    `gorm:"type:varchar(32);not null;uniqueIndex:device_user_unique"`
+	UserID       uint      `gorm:"not null;index"`
+	CreatedAt    time.Time `gorm:"type:timestamp;default:now()"`
+	UpdatedAt    time.Time `gorm:"type:timestamp;default:now()"`
+}
+
+func (DeviceUser) TableName() string { return "device_users" }
+
+// hasil pemrosesan punch
+const (
+	PunchResultCreated  = "created"  // attendance dibuat
+	PunchResultExisting = "existing" // attendance hari itu sudah ada
+	PunchResultUnmapped = "unmapped" // PIN belum dipetakan ke user
+	PunchResultRejected = "rejected" // ditolak aturan attendance (weekend, period terkunci)
+)
+
+// DevicePunch log setiap punch yang diterima; unik per mesin, PIN & waktu sehingga kiriman ulang diabaikan.
+type DevicePunch struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	DeviceID     uint      `gorm:"not null;uniqueIndex:device_punch_unique"`
+	DeviceUserID string    `gorm:"type:varchar(32);not null;uniqueIndex:device_punch_unique"`
+	PunchedAt    time.Time `gorm:"type:timestamp;not null;uniqueIndex:device_punch_unique"`
+	Direction    string    `gorm:"type:varchar(5)"`
+	UserID       *uint     `gorm:"index"`
+	AttendanceID *uint
+	Result       string    `gorm:"type:varchar(20);not null"`
+	Message      string    `gorm:"type:varchar(255)"`
+	CreatedAt    time.Time `gorm:"type:timestamp;default:now()"`
+}
+
+func (DevicePunch) TableName() string { return "device_punches" }
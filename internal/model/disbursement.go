@@ -0,0 +1,26 @@
+package model
+
+import "time"
+
+// DisbursementBatch: file transfer gaji yang sudah dibuat untuk satu payroll run.
+// Unik per run sehingga gaji satu period tidak bisa dibuatkan file transfer (dibayar) dua kali.
+type DisbursementBatch struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	PayrollRunID uint      `gorm:"not null;uniqueIndex:idx_disbursement_run_batch"`
+	BatchNo      int       `gorm:"not null;default:1;uniqueIndex:idx_disbursement_run_batch"` // 1 = batch utama, >1 = susulan
+	Reference    string    `gorm:"type:varchar(40);uniqueIndex;not null"`
+	Format       string    `gorm:"type:varchar(20);not null"`
+	FileName     string    `gorm:"type:varchar(100);not null"`
+	ContentType  string    `gorm:"type:varchar(50);not null"`
+	Content      []byte    `gorm:"type:bytea;not null"`
+	Checksum     string    `gorm:"type:varchar(64);not null"` // SHA-256 isi file
+	ItemCount    int       `gorm:"not null"`
+	TotalAmount  float64   `gorm:"type:numeric(16,2);not null"`
+	HashTotal    string    `gorm:"type:varchar(40);not null"`
+	SkippedCount int       `gorm:"not null;default:0"` // item tanpa rekening terverifikasi yang tidak ikut
+	ExecutionAt  time.Time `gorm:"type:date;not null"`
+	CreatedBy    uint      `gorm:"not null"`
+	CreatedAt    time.Time `gorm:"type:timestamp;default:now()"`
+}
+
+func (DisbursementBatch) TableName() string { return "disbursement_batches" }
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+const (
+	LoginAttemptScopeAccount = "account"
+	LoginAttemptScopeIP      = "ip"
+	LoginAttemptScopeOTP     = "2fa" // identifier = user id
+)
+
+// LoginAttempt: counter login gagal per akun (email) atau per IP, dipakai untuk lockout.
+// Akun dicatat berdasarkan email yang dicoba, terdaftar ataupun tidak.
+type LoginAttempt struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement"`
+	Scope        string     `gorm:"type:varchar(16);not null;uniqueIndex:uq_login_attempt_key"`
+	Identifier   string     `gorm:"type:varchar(255);not null;uniqueIndex:uq_login_attempt_key"`
+	Failures     int        `gorm:"not null;default:0"`
+	LastFailedAt time.Time  `gorm:"type:timestamp;not null"`
+	LockedUntil  *time.Time `gorm:"type:timestamp;index"`
+	UpdatedAt    time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (LoginAttempt) TableName() string { return "login_attempts" }
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// Lokasi kantor untuk geofence attendance
+type OfficeLocation struct {
+	ID           uint    `gorm:"primaryKey;autoIncrement"`
+	Name         string  `gorm:"type:varchar(100);not null"`
+	Latitude     float64 `gorm:"type:numeric(9,6);not null"`
+	Longitude    float64 `gorm:"type:numeric(9,6);not null"`
+	RadiusMeters int     `gorm:"not null;default:0"` // 0 = hanya dicek lewat IP
+	// AllowedIPRanges: CIDR / IP dipisah koma, e.g. "10.0.0.0/24,203.0.113.7"
+	AllowedIPRanges string    `gorm:"type:varchar(1000)"`
+	Active          bool      `gorm:"not null;default:true;index"`
+	CreatedAt       time.Time `gorm:"type:timestamp;default:now()"`
+	UpdatedAt       time.Time `gorm:"type:timestamp;default:now()"`
+}
+
+func (OfficeLocation) TableName() string { return "office_locations" }
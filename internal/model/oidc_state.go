@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+// OIDCLoginState menyimpan state/nonce/PKCE verifier antara redirect ke provider dan callback.
+// Sekali pakai; state mentah hanya ada di URL, yang disimpan hash-nya.
+type OIDCLoginState struct {
+	ID           uint       `gorm:"primaryKey;autoIncrement"`
+	StateHash    string     `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Provider     string     `gorm:"type:varchar(50);not null"`
+	Nonce        string     `gorm:"type:varchar(64);not null"`
+	CodeVerifier string     `gorm:"type:varchar(128);not null"`
+	DeviceID     string     `gorm:"type:varchar(255)"`
+	ExpiresAt    time.Time  `gorm:"type:timestamp;not null"`
+	UsedAt       *time.Time `gorm:"type:timestamp"`
+	CreatedAt    time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (OIDCLoginState) TableName() string { return "oidc_login_states" }
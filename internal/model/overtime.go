@@ -9,6 +9,15 @@ type Overtime struct {
 	Hours     float64   `gorm:"type:numeric(6,2);not null"`
 	CreatedAt time.Time `gorm:"type:timestamp;default:now()"`
 	UpdatedAt time.Time `gorm:"type:timestamp;default:now()"`
+
+	// Hasil pencocokan dengan overtime_plans (overtime.planMode flag / reject)
+	PlanID         *uint
+	PlanFlagged    bool   `gorm:"not null;default:false;index"`
+	PlanFlagReason string `gorm:"type:varchar(20)"` // no_plan | exceeds_plan
+
+	// Diisi jika diinput admin atas nama karyawan
+	SubmittedBy    *uint  `gorm:"index"`
+	OnBehalfReason string `gorm:"type:varchar(255)"`
 }
 
 func (Overtime) TableName() string { return "overtimes" }
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+const (
+	OvertimePlanStatusPending  = "pending"
+	OvertimePlanStatusApproved = "approved"
+	OvertimePlanStatusRejected = "rejected"
+
+	// mode overtime.planMode: overtime aktual dicocokkan dengan rencana yang disetujui
+	OvertimePlanModeOff    = "off"    // tidak dicocokkan (default)
+	OvertimePlanModeFlag   = "flag"   // tetap disimpan, ditandai untuk laporan admin
+	OvertimePlanModeReject = "reject" // ditolak
+
+	OvertimePlanFlagNoPlan  = "no_plan"      // tidak ada rencana yang disetujui pada tanggal tsb
+	OvertimePlanFlagExceeds = "exceeds_plan" // jam melebihi rencana yang disetujui
+)
+
+// OvertimePlan: rencana overtime diajukan karyawan sebelum dijalankan, disetujui admin.
+type OvertimePlan struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`
+	UserID        uint      `gorm:"index;not null"`
+	Date          time.Time `gorm:"type:date;not null"`
+	Hours         float64   `gorm:"type:numeric(6,2);not null"` // jam yang direncanakan
+	Justification string    `gorm:"type:varchar(500);not null"`
+	Status        string    `gorm:"type:varchar(20);not null;default:pending;index"`
+	ReviewedBy    *uint
+	ReviewedAt    *time.Time `gorm:"type:timestamp"`
+	ReviewNote    string     `gorm:"type:varchar(500)"`
+	CreatedAt     time.Time  `gorm:"type:timestamp;default:now()"`
+	UpdatedAt     time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (OvertimePlan) TableName() string { return "overtime_plans" }
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// PasswordResetToken: single-use, expiring. Hanya hash yang disimpan.
+type PasswordResetToken struct {
+	ID          uint       `gorm:"primaryKey;autoIncrement"`
+	UserID      uint       `gorm:"index;not null"`
+	TokenHash   string     `gorm:"type:varchar(64);uniqueIndex;not null"`
+	ExpiresAt   time.Time  `gorm:"type:timestamp;not null"`
+	UsedAt      *time.Time `gorm:"type:timestamp"`
+	RequestedIP string     `gorm:"type:varchar(64)"`
+	CreatedAt   time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (PasswordResetToken) TableName() string { return "password_reset_tokens" }
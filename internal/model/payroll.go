@@ -4,33 +4,128 @@ import "time"
 
 // Satu run payroll per AttendancePeriod (unik)
 type PayrollRun struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	PeriodID  uint      `gorm:"uniqueIndex;not null"` // unique => setiap period hanya 1x run
-	RunAt     time.Time `gorm:"type:timestamp;not null"`
-	CreatedAt time.Time `gorm:"type:timestamp;default:now()"`
-	UpdatedAt time.Time `gorm:"type:timestamp;default:now()"`
-	// Optional: status, metadata, etc.
+	ID       uint      `gorm:"primaryKey;autoIncrement"`
+	PeriodID uint      `gorm:"uniqueIndex;not null"` // unique => setiap period hanya 1x run
+	RunAt    time.Time `gorm:"type:timestamp;not null"`
+	// default approved: run lama (sebelum ada lifecycle) sudah final
+	Status          string    `gorm:"type:varchar(20);not null;default:'approved';index"`
+	StatusChangedAt time.Time `gorm:"type:timestamp;default:now()"`
+	CreatedBy       uint      `gorm:"index"`
+	CalculatedBy    uint      `gorm:"index"`                  // admin yang terakhir menghitung (run / recalculate) run ini
+	ApprovalRound   int       `gorm:"not null;default:0"`     // naik setiap kali diajukan untuk approval
+	Stale           bool      `gorm:"not null;default:false"` // input berubah setelah dihitung; wajib recalculate sebelum diajukan
+	CreatedAt       time.Time `gorm:"type:timestamp;default:now()"`
+	UpdatedAt       time.Time `gorm:"type:timestamp;default:now()"`
 }
 
 func (PayrollRun) TableName() string { return "payroll_runs" }
 
+const (
+	PayrollRunStatusDraft           = "draft"
+	PayrollRunStatusPendingApproval = "pending_approval"
+	PayrollRunStatusApproved        = "approved"
+	PayrollRunStatusPaid            = "paid"
+	PayrollRunStatusClosed          = "closed"
+)
+
+// PayrollRunLockedStatuses: status run yang sudah final (boleh dilihat karyawan & dibayar).
+var PayrollRunLockedStatuses = []string{PayrollRunStatusApproved, PayrollRunStatusPaid, PayrollRunStatusClosed}
+
+// PayrollRunSubmissionLockedStatuses: status run yang mengunci submission pada period-nya.
+// Sudah terkunci sejak diajukan, supaya yang di-approve sama dengan angka yang dihitung.
+var PayrollRunSubmissionLockedStatuses = []string{PayrollRunStatusPendingApproval, PayrollRunStatusApproved, PayrollRunStatusPaid, PayrollRunStatusClosed}
+
+// Riwayat perubahan status run (siapa, kapan, dari-ke)
+type PayrollRunEvent struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	PayrollRunID uint      `gorm:"index;not null"`
+	Action       string    `gorm:"type:varchar(30);not null"` // created | recalculated | transition
+	FromStatus   string    `gorm:"type:varchar(20)"`
+	ToStatus     string    `gorm:"type:varchar(20);not null"`
+	ActorID      uint      `gorm:"index;not null"`
+	Comment      string    `gorm:"type:varchar(500)"`
+	CreatedAt    time.Time `gorm:"type:timestamp;not null"`
+}
+
+func (PayrollRunEvent) TableName() string { return "payroll_run_events" }
+
+const (
+	PayrollRunActionCreated           = "created"
+	PayrollRunActionRecalculated      = "recalculated"
+	PayrollRunActionTransition        = "transition"
+	PayrollRunActionApprovalRequested = "approval_requested"
+	PayrollRunActionApproved          = "approved"
+	PayrollRunActionRejected          = "rejected"
+)
+
+// Keputusan approver untuk satu putaran pengajuan run (satu keputusan per admin per putaran)
+type PayrollRunApproval struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	PayrollRunID uint      `gorm:"not null;uniqueIndex:idx_run_round_approver"`
+	Round        int       `gorm:"not null;uniqueIndex:idx_run_round_approver"`
+	ApproverID   uint      `gorm:"not null;uniqueIndex:idx_run_round_approver"`
+	Decision     string    `gorm:"type:varchar(10);not null"` // approved | rejected
+	Comment      string    `gorm:"type:varchar(500)"`
+	CreatedAt    time.Time `gorm:"type:timestamp;not null"`
+}
+
+func (PayrollRunApproval) TableName() string { return "payroll_run_approvals" }
+
+const (
+	ApprovalDecisionApproved = "approved"
+	ApprovalDecisionRejected = "rejected"
+)
+
 // Snapshot per karyawan (agar perubahan data setelah run tidak mengubah payslip)
 type PayrollItem struct {
-	ID                 uint      `gorm:"primaryKey;autoIncrement"`
-	PayrollRunID       uint      `gorm:"index;not null"`
-	UserID             uint      `gorm:"index;not null"`
-	SnapshotSalary     float64   `gorm:"type:numeric(12,2);not null"` // gaji bulanan saat run
-	WorkingDays        int       `gorm:"not null"`                    // hari kerja (weekday) dalam period
-	AttendanceDays     int       `gorm:"not null"`                    // jumlah hadir
-	WorkingHours       int       `gorm:"not null"`                    // WorkingDays * 8
-	AttendanceHours    int       `gorm:"not null"`                    // AttendanceDays * 8
-	OvertimeHours      float64   `gorm:"type:numeric(6,2);not null"`  // total jam lembur
-	BasePay            float64   `gorm:"type:numeric(14,2);not null"` // prorate
-	OvertimePay        float64   `gorm:"type:numeric(14,2);not null"` // 2x hourly * hours
-	ReimbursementTotal float64   `gorm:"type:numeric(14,2);not null"`
-	GrandTotal         float64   `gorm:"type:numeric(14,2);not null"`
-	CreatedAt          time.Time `gorm:"type:timestamp;default:now()"`
-	UpdatedAt          time.Time `gorm:"type:timestamp;default:now()"`
+	ID                 uint    `gorm:"primaryKey;autoIncrement"`
+	PayrollRunID       uint    `gorm:"index;not null"`
+	UserID             uint    `gorm:"index;not null"`
+	SnapshotSalary     float64 `gorm:"type:numeric(12,2);not null"`          // gaji bulanan saat run
+	WorkingDays        int     `gorm:"not null"`                             // hari kerja (weekday) dalam period
+	AttendanceDays     int     `gorm:"not null"`                             // jumlah hadir
+	WorkingHours       int     `gorm:"not null"`                             // WorkingDays * 8
+	AttendanceHours    int     `gorm:"not null"`                             // AttendanceDays * 8
+	BasePayMode        string  `gorm:"type:varchar(10)"`                     // days | hours ("" = run lama, setara days)
+	PaidHours          float64 `gorm:"type:numeric(8,2);not null;default:0"` // jam yang dibayar sebagai base pay
+	OvertimeHours      float64 `gorm:"type:numeric(6,2);not null"`           // total jam lembur
+	BasePay            float64 `gorm:"type:numeric(14,2);not null"`          // prorate
+	OvertimePay        float64 `gorm:"type:numeric(14,2);not null"`          // 2x hourly * hours
+	ReimbursementTotal float64 `gorm:"type:numeric(14,2);not null"`
+	GrandTotal         float64 `gorm:"type:numeric(14,2);not null"`
+	BankAccountStatus  string  `gorm:"type:varchar(20)"` // ok | missing | unverified saat run
+
+	// Prior period adjustment (overtime & reimbursement terlambat dari period terkunci), sudah termasuk GrandTotal
+	AdjustmentOvertimeHours float64 `gorm:"type:numeric(6,2);not null;default:0"`
+	AdjustmentTotal         float64 `gorm:"type:numeric(14,2);not null;default:0"`
+
+	// Status pembayaran (diisi saat file disbursement dibuat & saat hasil bank diimpor)
+	PaymentStatus       string     `gorm:"type:varchar(20);not null;default:'pending';index"`
+	PaymentSequence     int        `gorm:"not null;default:0"` // nomor baris di file disbursement; 0 = belum ikut batch
+	PaymentAccountLast4 string     `gorm:"type:varchar(4)"`    // rekening tujuan saat file dibuat
+	BankReference       string     `gorm:"type:varchar(64)"`
+	PaymentNote         string     `gorm:"type:varchar(255)"` // alasan gagal/retur dari bank
+	SentAt              *time.Time `gorm:"type:timestamp"`
+	PaidAt              *time.Time `gorm:"type:timestamp"`
+	FailedAt            *time.Time `gorm:"type:timestamp"`
+	ReturnedAt          *time.Time `gorm:"type:timestamp"`
+
+	CreatedAt time.Time `gorm:"type:timestamp;default:now()"`
+	UpdatedAt time.Time `gorm:"type:timestamp;default:now()"`
 }
 
+const (
+	PaymentStatusPending  = "pending"
+	PaymentStatusSent     = "sent"
+	PaymentStatusPaid     = "paid"
+	PaymentStatusFailed   = "failed"
+	PaymentStatusReturned = "returned"
+)
+
 func (PayrollItem) TableName() string { return "payroll_items" }
+
+// Mode perhitungan base pay (payroll.basePayMode)
+const (
+	BasePayModeDays  = "days"  // setiap hari hadir = 8 jam
+	BasePayModeHours = "hours" // jam kerja aktual dari check-in/check-out (maks 8 jam per hari)
+)
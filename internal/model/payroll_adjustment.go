@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+const (
+	AdjustmentKindOvertime      = "overtime"
+	AdjustmentKindReimbursement = "reimbursement"
+)
+
+// Adjustment dari karyawan harus di-review admin dulu; hanya yang approved ikut dibayar.
+const (
+	AdjustmentStatusPending  = "pending"
+	AdjustmentStatusApproved = "approved"
+	AdjustmentStatusRejected = "rejected"
+)
+
+// PayrollAdjustment: overtime / reimbursement yang terlambat diajukan untuk tanggal di period terkunci.
+// Dibayar sebagai "prior period adjustment" di period open berikutnya (PeriodID).
+type PayrollAdjustment struct {
+	ID               uint      `gorm:"primaryKey;autoIncrement"`
+	UserID           uint      `gorm:"index;not null"`
+	PeriodID         uint      `gorm:"index;not null"`            // period tempat adjustment dibayar
+	OriginalPeriodID *uint     `gorm:"index"`                     // period terkunci asal tanggal
+	OriginalDate     time.Time `gorm:"type:date;not null"`        // tanggal overtime / reimbursement sebenarnya
+	Kind             string    `gorm:"type:varchar(20);not null"` // overtime | reimbursement
+	// Hours untuk overtime (dibayar dengan tarif per jam period tujuan), Amount untuk reimbursement
+	Hours       float64 `gorm:"type:numeric(6,2);not null;default:0"`
+	Amount      float64 `gorm:"type:numeric(14,2);not null;default:0"`
+	Description string  `gorm:"type:varchar(255)"`
+	CreatedBy   uint    `gorm:"not null"`
+	// default approved: adjustment lama (sebelum ada review) sudah dihitung di payroll
+	Status     string     `gorm:"type:varchar(20);not null;default:'approved';index"`
+	ReviewedBy *uint      `gorm:"index"`
+	ReviewedAt *time.Time `gorm:"type:timestamp"`
+	ReviewNote string     `gorm:"type:varchar(500)"`
+	CreatedAt  time.Time  `gorm:"type:timestamp;default:now()"`
+	UpdatedAt  time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (PayrollAdjustment) TableName() string { return "payroll_adjustments" }
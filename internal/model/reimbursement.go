@@ -10,6 +10,10 @@ type Reimbursement struct {
 	Description string    `gorm:"type:varchar(255)"`
 	CreatedAt   time.Time `gorm:"type:timestamp;default:now()"`
 	UpdatedAt   time.Time `gorm:"type:timestamp;default:now()"`
+
+	// Diisi jika diinput admin atas nama karyawan
+	SubmittedBy    *uint  `gorm:"index"`
+	OnBehalfReason string `gorm:"type:varchar(255)"`
 }
 
 func (Reimbursement) TableName() string { return "reimbursements" }
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// RecoveryCode: kode cadangan 2FA sekali pakai. Hanya hash yang disimpan.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey;autoIncrement"`
+	UserID    uint       `gorm:"index;not null"`
+	CodeHash  string     `gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time `gorm:"type:timestamp"`
+	CreatedAt time.Time  `gorm:"type:timestamp;default:now()"`
+}
+
+func (RecoveryCode) TableName() string { return "two_factor_recovery_codes" }
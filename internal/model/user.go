@@ -22,8 +22,25 @@ type User struct {
 	Location          string         `gorm:"column:location;type:varchar(255)" db:"location"`
 	Interests         pq.StringArray `gorm:"column:interests;type:text[]" db:"interests"`
 	Salary            float64        `gorm:"column:salary;type:numeric(12,2)" db:"salary"`
+	Department        string         `gorm:"column:department;type:varchar(100);index" db:"department"`
+	EmploymentStatus  string         `gorm:"column:employment_status;type:varchar(20);not null;default:'active';index" db:"employment_status"`
 	IsProfileComplete bool           `gorm:"column:is_profile_complete;type:boolean;default:false" db:"is_profile_complete"`
+	TokenVersion      int            `gorm:"column:token_version;not null;default:0" db:"token_version"` // naik => semua access token lama invalid
+
+	// TOTP 2FA. Secret terisi saat enroll (terenkripsi fieldcrypt); baru berlaku setelah diaktivasi (TwoFactorEnabled).
+	TOTPSecretEnc      string     `gorm:"column:totp_secret;type:text" db:"totp_secret"`
+	TOTPLastStep       int64      `gorm:"column:totp_last_step;not null;default:0" db:"totp_last_step"` // cegah kode yang sama dipakai dua kali
+	TwoFactorEnabled   bool       `gorm:"column:two_factor_enabled;not null;default:false" db:"two_factor_enabled"`
+	TwoFactorEnabledAt *time.Time `gorm:"column:two_factor_enabled_at;type:timestamp" db:"two_factor_enabled_at"`
 }
 
+// status kepegawaian; terminated tidak bisa login lagi
+const (
+	EmploymentStatusActive     = "active"
+	EmploymentStatusProbation  = "probation"
+	EmploymentStatusOnLeave    = "on_leave"
+	EmploymentStatusTerminated = "terminated"
+)
+
 // TableName optional (kalau mau pastikan nama tabelnya "users")
 func (User) TableName() string { return "users" }
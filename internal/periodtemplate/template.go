@@ -0,0 +1,92 @@
+// Package periodtemplate menghitung rentang tanggal attendance period bulanan dari sebuah aturan cut-off.
+// Setiap period diberi "bulan label": bulan kalender (calendar_month) atau bulan tempat tanggal cut-off jatuh (cutoff).
+package periodtemplate
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	ModeCalendarMonth = "calendar_month" // tanggal 1 s/d akhir bulan
+	ModeCutoff        = "cutoff"         // (CutoffDay+1) bulan sebelumnya s/d CutoffDay bulan ini
+
+	// MaxCutoffDay dibatasi 28 supaya setiap bulan punya tanggal cut-off yang sama.
+	MaxCutoffDay = 28
+
+	DefaultNameFormat = "Payroll Jan 2006"
+)
+
+var (
+	ErrUnknownMode      = errors.New("periodtemplate: unknown mode")
+	ErrInvalidCutoffDay = errors.New("periodtemplate: cutoff day must be between 1 and 28")
+)
+
+// Template aturan pembentukan period. NameFormat memakai layout time Go terhadap bulan label,
+// e.g. "Payroll Jan 2006" -> "Payroll Sep 2025".
+type Template struct {
+	Mode       string
+	CutoffDay  int
+	NameFormat string
+}
+
+type Period struct {
+	Name  string
+	Label time.Time // tanggal 1 bulan label
+	Start time.Time
+	End   time.Time
+}
+
+func (t Template) Validate() error {
+	switch t.Mode {
+	case ModeCalendarMonth:
+		return nil
+	case ModeCutoff:
+		if t.CutoffDay < 1 || t.CutoffDay > MaxCutoffDay {
+			return ErrInvalidCutoffDay
+		}
+		return nil
+	default:
+		return ErrUnknownMode
+	}
+}
+
+// Month mengembalikan period untuk bulan label (hanya tahun & bulan dari label yang dipakai).
+func (t Template) Month(label time.Time) Period {
+	label = time.Date(label.Year(), label.Month(), 1, 0, 0, 0, 0, time.UTC)
+	p := Period{Label: label, Name: label.Format(t.nameFormat())}
+	if t.Mode == ModeCutoff {
+		p.Start = label.AddDate(0, -1, t.CutoffDay)
+		p.End = label.AddDate(0, 0, t.CutoffDay-1)
+	} else {
+		p.Start = label
+		p.End = label.AddDate(0, 1, -1)
+	}
+	return p
+}
+
+// LabelOf: bulan label dari period yang memuat tanggal date.
+func (t Template) LabelOf(date time.Time) time.Time {
+	label := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if t.Mode == ModeCutoff && date.Day() > t.CutoffDay {
+		label = label.AddDate(0, 1, 0)
+	}
+	return label
+}
+
+// Next mengembalikan count period berurutan mulai dari bulan label from.
+func (t Template) Next(from time.Time, count int) []Period {
+	from = t.Month(from).Label
+	out := make([]Period, 0, count)
+	for i := 0; i < count; i++ {
+		out = append(out, t.Month(from.AddDate(0, i, 0)))
+	}
+	return out
+}
+
+func (t Template) nameFormat() string {
+	if t.NameFormat == "" {
+		return DefaultNameFormat
+	}
+	return t.NameFormat
+}
@@ -0,0 +1,106 @@
+package adjustment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+// Decision hasil review admin atas adjustment pending; PeriodID = period tempat adjustment dibayar.
+type Decision struct {
+	Status     string
+	PeriodID   uint
+	ReviewedBy uint
+	ReviewedAt time.Time
+	ReviewNote string
+}
+
+type Repo interface {
+	Create(ctx context.Context, a *model.PayrollAdjustment) error
+	FindByID(ctx context.Context, id uint) (*model.PayrollAdjustment, error)
+	// ListByPeriod: adjustment yang dibayar di period; userID 0 = semua user, status kosong = semua status
+	ListByPeriod(ctx context.Context, periodID, userID uint, status string) ([]model.PayrollAdjustment, error)
+	ListByUser(ctx context.Context, userID uint, offset, limit int) ([]model.PayrollAdjustment, int64, error)
+	// HasOvertime: sudah ada adjustment overtime (selain yang rejected) untuk user & tanggal asli yang sama
+	HasOvertime(ctx context.Context, userID uint, date time.Time) (bool, error)
+	// Decide hanya berhasil jika adjustment masih pending
+	Decide(ctx context.Context, id uint, d Decision) (bool, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, a *model.PayrollAdjustment) error {
+	return repotx.GetDB(ctx, r.db).Create(a).Error
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.PayrollAdjustment, error) {
+	var a model.PayrollAdjustment
+	if err := repotx.GetDB(ctx, r.db).First(&a, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *repo) ListByPeriod(ctx context.Context, periodID, userID uint, status string) ([]model.PayrollAdjustment, error) {
+	q := repotx.GetDB(ctx, r.db).Where("period_id = ?", periodID)
+	if userID != 0 {
+		q = q.Where("user_id = ?", userID)
+	}
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var rows []model.PayrollAdjustment
+	if err := q.Order("original_date ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repo) ListByUser(ctx context.Context, userID uint, offset, limit int) ([]model.PayrollAdjustment, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.PayrollAdjustment{}).Where("user_id = ?", userID)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.PayrollAdjustment
+	if err := q.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) HasOvertime(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	var count int64
+	err := repotx.GetDB(ctx, r.db).Model(&model.PayrollAdjustment{}).
+		Where("user_id = ? AND original_date = ? AND kind = ? AND status <> ?",
+			userID, date, model.AdjustmentKindOvertime, model.AdjustmentStatusRejected).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repo) Decide(ctx context.Context, id uint, d Decision) (bool, error) {
+	res := repotx.GetDB(ctx, r.db).Model(&model.PayrollAdjustment{}).
+		Where("id = ? AND status = ?", id, model.AdjustmentStatusPending).
+		Updates(map[string]any{
+			"status":      d.Status,
+			"period_id":   d.PeriodID,
+			"reviewed_by": d.ReviewedBy,
+			"reviewed_at": d.ReviewedAt,
+			"review_note": d.ReviewNote,
+			"updated_at":  d.ReviewedAt,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
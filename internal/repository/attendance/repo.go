@@ -12,6 +12,36 @@ import (
 
 type Repo interface {
 	CreateIfNotExists(ctx context.Context, userID uint, date time.Time) (*model.Attendance, bool, error)
+
+	// FindByID: nil jika tidak ada
+	FindByID(ctx context.Context, id uint) (*model.Attendance, error)
+	// FindByUserDate: nil jika belum ada attendance pada tanggal tsb
+	FindByUserDate(ctx context.Context, userID uint, date time.Time) (*model.Attendance, error)
+	// CheckIn membuat attendance baru atau mengisi jam masuk attendance lama yang belum punya check-in.
+	// false jika sudah check-in.
+	CheckIn(ctx context.Context, row *model.Attendance) (bool, error)
+	// CheckOut hanya berhasil jika sudah check-in dan belum check-out
+	CheckOut(ctx context.Context, row *model.Attendance) (bool, error)
+
+	// SetLocation menyimpan lokasi, IP & hasil geofence attendance
+	SetLocation(ctx context.Context, row *model.Attendance) error
+	// ListFlagged: attendance yang ditandai di luar geofence dalam rentang tanggal
+	ListFlagged(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error)
+
+	// SetSubmitter mencatat admin yang menginput attendance atas nama karyawan
+	SetSubmitter(ctx context.Context, id, adminID uint, reason string) error
+
+	// ListByDateRange: user_id & date attendance dalam rentang (untuk cek duplikat import)
+	ListByDateRange(ctx context.Context, start, end time.Time) ([]model.Attendance, error)
+	// CreateBatch insert banyak attendance sekaligus per batchSize baris
+	CreateBatch(ctx context.Context, rows []*model.Attendance, batchSize int) error
+	// ListByUser: attendance milik user dalam rentang tanggal, terbaru dulu
+	ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error)
+
+	Delete(ctx context.Context, id uint) error
+	AddAudit(ctx context.Context, a *model.AttendanceAudit) error
+	// HasAudit: ada jejak audit dengan action & source tsb untuk attendance (mis. dibuat lewat koreksi)
+	HasAudit(ctx context.Context, attendanceID uint, action, source string) (bool, error)
 }
 
 type repo struct {
@@ -42,3 +72,151 @@ func (r *repo) CreateIfNotExists(ctx context.Context, userID uint, date time.Tim
 	}
 	return row, false, nil
 }
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.Attendance, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.Attendance
+	if err := db.Where("id = ?", id).Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) FindByUserDate(ctx context.Context, userID uint, date time.Time) (*model.Attendance, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.Attendance
+	if err := db.Where("user_id = ? AND date = ?", userID, date).Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) CheckIn(ctx context.Context, row *model.Attendance) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	if row.ID == 0 {
+		if err := db.Create(row).Error; err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	res := db.Model(&model.Attendance{}).
+		Where("id = ? AND check_in_at IS NULL", row.ID).
+		Updates(map[string]any{
+			"check_in_at":  row.CheckInAt,
+			"late_minutes": row.LateMinutes,
+			"is_late":      row.IsLate,
+			"updated_at":   time.Now().UTC(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	return true, r.SetLocation(ctx, row)
+}
+
+func (r *repo) SetLocation(ctx context.Context, row *model.Attendance) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.Attendance{}).Where("id = ?", row.ID).
+		Updates(map[string]any{
+			"latitude":             row.Latitude,
+			"longitude":            row.Longitude,
+			"client_ip":            row.ClientIP,
+			"office_location_id":   row.OfficeLocationID,
+			"distance_meters":      row.DistanceMeters,
+			"location_flagged":     row.LocationFlagged,
+			"location_flag_reason": row.LocationFlagReason,
+		}).Error
+}
+
+func (r *repo) SetSubmitter(ctx context.Context, id, adminID uint, reason string) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.Attendance{}).Where("id = ?", id).
+		Updates(map[string]any{"submitted_by": adminID, "on_behalf_reason": reason}).Error
+}
+
+func (r *repo) ListFlagged(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error) {
+	db := repotx.GetDB(ctx, r.db)
+	q := db.Model(&model.Attendance{}).Where("date BETWEEN ? AND ? AND location_flagged = ?", start, end, true)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.Attendance
+	if err := q.Order("date ASC, user_id ASC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) CheckOut(ctx context.Context, row *model.Attendance) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.Attendance{}).
+		Where("id = ? AND check_in_at IS NOT NULL AND check_out_at IS NULL", row.ID).
+		Updates(map[string]any{
+			"check_out_at":        row.CheckOutAt,
+			"worked_minutes":      row.WorkedMinutes,
+			"early_leave_minutes": row.EarlyLeaveMinutes,
+			"is_early_leave":      row.IsEarlyLeave,
+			"updated_at":          time.Now().UTC(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *repo) Delete(ctx context.Context, id uint) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Delete(&model.Attendance{}, id).Error
+}
+
+func (r *repo) AddAudit(ctx context.Context, a *model.AttendanceAudit) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Create(a).Error
+}
+
+func (r *repo) HasAudit(ctx context.Context, attendanceID uint, action, source string) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var count int64
+	err := db.Model(&model.AttendanceAudit{}).
+		Where("attendance_id = ? AND action = ? AND source = ?", attendanceID, action, source).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repo) ListByDateRange(ctx context.Context, start, end time.Time) ([]model.Attendance, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.Attendance
+	err := db.Select("id", "user_id", "date").
+		Where("date BETWEEN ? AND ?", start, end).
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *repo) CreateBatch(ctx context.Context, rows []*model.Attendance, batchSize int) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return repotx.GetDB(ctx, r.db).CreateInBatches(rows, batchSize).Error
+}
+
+func (r *repo) ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.Attendance{}).Where("user_id = ? AND date BETWEEN ? AND ?", userID, start, end)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.Attendance
+	if err := q.Order("date DESC, id DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
@@ -0,0 +1,103 @@
+package attendancecorrection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+// ListFilter: UserID 0 = semua karyawan, Status kosong = semua status.
+type ListFilter struct {
+	UserID uint
+	Status string
+	Offset int
+	Limit  int
+}
+
+// Decision hasil review admin atas koreksi pending.
+type Decision struct {
+	Status       string
+	ReviewedBy   uint
+	ReviewedAt   time.Time
+	ReviewNote   string
+	AttendanceID *uint
+}
+
+type Repo interface {
+	Create(ctx context.Context, c *model.AttendanceCorrection) error
+	FindByID(ctx context.Context, id uint) (*model.AttendanceCorrection, error)
+	List(ctx context.Context, f ListFilter) ([]model.AttendanceCorrection, int64, error)
+	// HasPending: masih ada koreksi pending untuk user & tanggal yang sama
+	HasPending(ctx context.Context, userID uint, date time.Time) (bool, error)
+	// Decide hanya berhasil jika koreksi masih pending
+	Decide(ctx context.Context, id uint, d Decision) (bool, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, c *model.AttendanceCorrection) error {
+	return repotx.GetDB(ctx, r.db).Create(c).Error
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.AttendanceCorrection, error) {
+	var c model.AttendanceCorrection
+	if err := repotx.GetDB(ctx, r.db).First(&c, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *repo) List(ctx context.Context, f ListFilter) ([]model.AttendanceCorrection, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.AttendanceCorrection{})
+	if f.UserID != 0 {
+		q = q.Where("user_id = ?", f.UserID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.AttendanceCorrection
+	if err := q.Order("created_at DESC, id DESC").Offset(f.Offset).Limit(f.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) HasPending(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	var count int64
+	err := repotx.GetDB(ctx, r.db).Model(&model.AttendanceCorrection{}).
+		Where("user_id = ? AND date = ? AND status = ?", userID, date, model.CorrectionStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repo) Decide(ctx context.Context, id uint, d Decision) (bool, error) {
+	res := repotx.GetDB(ctx, r.db).Model(&model.AttendanceCorrection{}).
+		Where("id = ? AND status = ?", id, model.CorrectionStatusPending).
+		Updates(map[string]any{
+			"status":        d.Status,
+			"reviewed_by":   d.ReviewedBy,
+			"reviewed_at":   d.ReviewedAt,
+			"review_note":   d.ReviewNote,
+			"attendance_id": d.AttendanceID,
+			"updated_at":    d.ReviewedAt,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
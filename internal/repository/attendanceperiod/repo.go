@@ -10,9 +10,40 @@ import (
 	"gorm.io/gorm"
 )
 
+// PeriodRow: period beserta status run payroll-nya ("" jika belum run).
+type PeriodRow struct {
+	model.AttendancePeriod `gorm:"embedded"`
+	RunStatus              string
+}
+
+// ListFilter: Status open | running | closed (kosong = semua).
+type ListFilter struct {
+	Status string
+	Offset int
+	Limit  int
+}
+
 type Repo interface {
 	Create(ctx context.Context, p *model.AttendancePeriod) error
 	IsOverlapping(ctx context.Context, start, end time.Time) (bool, error)
+	// IsOverlappingExcluding sama dengan IsOverlapping tapi mengabaikan period excludeID (untuk update)
+	IsOverlappingExcluding(ctx context.Context, start, end time.Time, excludeID uint) (bool, error)
+
+	// Latest: period dengan end_date paling akhir (nil jika belum ada period)
+	Latest(ctx context.Context) (*model.AttendancePeriod, error)
+	// Containing: period yang memuat tanggal date (nil jika tidak ada)
+	Containing(ctx context.Context, date time.Time) (*model.AttendancePeriod, error)
+	// NextOpen: period pertama setelah tanggal after yang belum ditutup dan belum di-run atau run-nya masih draft
+	NextOpen(ctx context.Context, after time.Time) (*PeriodRow, error)
+	List(ctx context.Context, f ListFilter) ([]PeriodRow, int64, error)
+	// ListOverlapping: period (beserta status run) yang beririsan dengan rentang tanggal
+	ListOverlapping(ctx context.Context, start, end time.Time) ([]PeriodRow, error)
+	FindByID(ctx context.Context, id uint) (*PeriodRow, error)
+	Update(ctx context.Context, p *model.AttendancePeriod) error
+	Close(ctx context.Context, id, actorID uint, at time.Time) (bool, error)
+	Delete(ctx context.Context, id uint) error
+	// HasSubmissions: ada attendance/overtime/reimbursement di rentang tanggal
+	HasSubmissions(ctx context.Context, start, end time.Time) (bool, error)
 }
 
 type repo struct {
@@ -34,3 +65,157 @@ func (r *repo) IsOverlapping(ctx context.Context, start, end time.Time) (bool, e
 		Count(&count).Error
 	return count > 0, err
 }
+
+func (r *repo) IsOverlappingExcluding(ctx context.Context, start, end time.Time, excludeID uint) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var count int64
+	err := db.Model(&model.AttendancePeriod{}).
+		Where("start_date <= ? AND end_date >= ? AND id <> ?", end, start, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repo) Latest(ctx context.Context) (*model.AttendancePeriod, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.AttendancePeriod
+	if err := db.Order("end_date DESC").Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) Containing(ctx context.Context, date time.Time) (*model.AttendancePeriod, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.AttendancePeriod
+	if err := db.Where("start_date <= ? AND end_date >= ?", date, date).Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) NextOpen(ctx context.Context, after time.Time) (*PeriodRow, error) {
+	var rows []PeriodRow
+	if err := r.baseQuery(ctx).
+		Select("ap.*, COALESCE(pr.status, '') AS run_status").
+		Where("ap.start_date > ? AND ap.closed_at IS NULL", after).
+		// sama dengan kunci HasRunOnDate: hanya run draft yang belum mengunci period
+		Where("pr.id IS NULL OR pr.status = ?", model.PayrollRunStatusDraft).
+		Order("ap.start_date ASC").
+		Limit(1).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) baseQuery(ctx context.Context) *gorm.DB {
+	return repotx.GetDB(ctx, r.db).
+		Table((model.AttendancePeriod{}).TableName() + " ap").
+		Joins("LEFT JOIN " + (model.PayrollRun{}).TableName() + " pr ON pr.period_id = ap.id")
+}
+
+func (r *repo) List(ctx context.Context, f ListFilter) ([]PeriodRow, int64, error) {
+	q := r.baseQuery(ctx)
+	switch f.Status {
+	case model.PeriodStatusOpen:
+		q = q.Where("ap.closed_at IS NULL AND pr.id IS NULL")
+	case model.PeriodStatusRunning:
+		q = q.Where("ap.closed_at IS NULL AND pr.status NOT IN ?", model.PayrollRunLockedStatuses)
+	case model.PeriodStatusClosed:
+		q = q.Where("ap.closed_at IS NOT NULL OR pr.status IN ?", model.PayrollRunLockedStatuses)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []PeriodRow
+	if err := q.Select("ap.*, COALESCE(pr.status, '') AS run_status").
+		Order("ap.start_date DESC, ap.id DESC").
+		Offset(f.Offset).Limit(f.Limit).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) ListOverlapping(ctx context.Context, start, end time.Time) ([]PeriodRow, error) {
+	var rows []PeriodRow
+	err := r.baseQuery(ctx).
+		Select("ap.*, COALESCE(pr.status, '') AS run_status").
+		Where("ap.start_date <= ? AND ap.end_date >= ?", end, start).
+		Order("ap.start_date ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*PeriodRow, error) {
+	var rows []PeriodRow
+	if err := r.baseQuery(ctx).
+		Select("ap.*, COALESCE(pr.status, '') AS run_status").
+		Where("ap.id = ?", id).
+		Limit(1).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) Update(ctx context.Context, p *model.AttendancePeriod) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.AttendancePeriod{}).Where("id = ?", p.ID).
+		Updates(map[string]any{
+			"name":       p.Name,
+			"start_date": p.StartDate,
+			"end_date":   p.EndDate,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+func (r *repo) Close(ctx context.Context, id, actorID uint, at time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.AttendancePeriod{}).
+		Where("id = ? AND closed_at IS NULL", id).
+		Updates(map[string]any{"closed_at": at, "closed_by": actorID, "updated_at": at})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *repo) Delete(ctx context.Context, id uint) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Delete(&model.AttendancePeriod{}, id).Error
+}
+
+func (r *repo) HasSubmissions(ctx context.Context, start, end time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	for _, table := range []string{
+		(model.Attendance{}).TableName(),
+		(model.Overtime{}).TableName(),
+		(model.Reimbursement{}).TableName(),
+	} {
+		var found []int
+		if err := db.Table(table).Select("1").
+			Where("date BETWEEN ? AND ?", start, end).
+			Limit(1).Scan(&found).Error; err != nil {
+			return false, err
+		}
+		if len(found) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"payslip-generation-system/internal/model"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/lib/pq"
@@ -48,6 +49,120 @@ func (r *AuthRepo) CreateUser(ctx context.Context, user *model.User) error {
 	return nil
 }
 
+func (r *AuthRepo) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	var user model.User
+	if err := db.First(&user, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil // User not found
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// BumpTokenVersion menaikkan token_version sehingga semua access token lama ditolak middleware.
+func (r *AuthRepo) BumpTokenVersion(ctx context.Context, userID uint) error {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	return db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Update("token_version", gorm.Expr("token_version + 1")).Error
+}
+
+func (r *AuthRepo) UpdatePassword(ctx context.Context, userID uint, passwordHash string) error {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	return db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{"password_hash": passwordHash, "updated_at": gorm.Expr("now()")}).Error
+}
+
+// SetTOTPSecret menyimpan secret hasil enroll (sudah terenkripsi); 2FA belum aktif sampai EnableTwoFactor.
+func (r *AuthRepo) SetTOTPSecret(ctx context.Context, userID uint, secretEnc string) error {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	return db.Model(&model.User{}).
+		Where("id = ? AND two_factor_enabled = ?", userID, false).
+		Updates(map[string]any{"totp_secret": secretEnc, "totp_last_step": 0, "updated_at": gorm.Expr("now()")}).Error
+}
+
+func (r *AuthRepo) EnableTwoFactor(ctx context.Context, userID uint, step int64, at time.Time) error {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	return db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"two_factor_enabled":    true,
+			"two_factor_enabled_at": at,
+			"totp_last_step":        step,
+			"updated_at":            gorm.Expr("now()"),
+		}).Error
+}
+
+func (r *AuthRepo) DisableTwoFactor(ctx context.Context, userID uint) error {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	return db.Model(&model.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]any{
+			"two_factor_enabled":    false,
+			"two_factor_enabled_at": nil,
+			"totp_secret":           "",
+			"totp_last_step":        0,
+			"updated_at":            gorm.Expr("now()"),
+		}).Error
+}
+
+// MarkTOTPStepUsed hanya berhasil kalau step lebih baru dari yang terakhir dipakai (anti replay).
+func (r *AuthRepo) MarkTOTPStepUsed(ctx context.Context, userID uint, step int64) (bool, error) {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	res := db.Model(&model.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *AuthRepo) FindByGoogleID(ctx context.Context, googleID string) (*model.User, error) {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	var user model.User
+	if err := db.Where("google_id = ?", googleID).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SetGoogleID menautkan akun Google; hanya berhasil kalau user belum tertaut (false => sudah ada).
+func (r *AuthRepo) SetGoogleID(ctx context.Context, userID uint, googleID string) (bool, error) {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	res := db.Model(&model.User{}).
+		Where("id = ? AND (google_id IS NULL OR google_id = '')", userID).
+		Updates(map[string]any{"google_id": googleID, "updated_at": gorm.Expr("now()")})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// UpdateProfile hanya menulis kolom yang boleh diubah karyawan sendiri; salary/role/email tidak pernah tersentuh.
+func (r *AuthRepo) UpdateProfile(ctx context.Context, user *model.User) error {
+	db := repotx.GetDB(ctx, r.Infra.DB)
+	return db.Model(&model.User{ID: user.ID}).
+		Select("first_name", "last_name", "age", "bio", "location", "interests", "profile_image_url", "is_profile_complete", "updated_at").
+		Updates(map[string]any{
+			"first_name":          user.FirstName,
+			"last_name":           user.LastName,
+			"age":                 user.Age,
+			"bio":                 user.Bio,
+			"location":            user.Location,
+			"interests":           user.Interests,
+			"profile_image_url":   user.ProfileImageURL,
+			"is_profile_complete": user.IsProfileComplete,
+			"updated_at":          gorm.Expr("now()"),
+		}).Error
+}
+
 var ErrEmailAlreadyExists = errors.New("email already registered")
 
 func isUniqueViolation(err error) bool {
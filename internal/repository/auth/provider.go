@@ -4,12 +4,25 @@ import (
 	"context"
 	"payslip-generation-system/config/infra"
 	"payslip-generation-system/internal/model"
+	"time"
 )
 
 type IAuthRepo interface {
 	FindByEmailAndPassword(ctx context.Context, email, password string) (*model.User, error)
 	FindByEmail(ctx context.Context, email string) (*model.User, error)
 	CreateUser(ctx context.Context, user *model.User) error
+	FindByID(ctx context.Context, id uint) (*model.User, error)
+	BumpTokenVersion(ctx context.Context, userID uint) error
+	UpdatePassword(ctx context.Context, userID uint, passwordHash string) error
+	UpdateProfile(ctx context.Context, user *model.User) error
+
+	SetTOTPSecret(ctx context.Context, userID uint, secretEnc string) error
+	EnableTwoFactor(ctx context.Context, userID uint, step int64, at time.Time) error
+	DisableTwoFactor(ctx context.Context, userID uint) error
+	MarkTOTPStepUsed(ctx context.Context, userID uint, step int64) (bool, error)
+
+	FindByGoogleID(ctx context.Context, googleID string) (*model.User, error)
+	SetGoogleID(ctx context.Context, userID uint, googleID string) (bool, error)
 }
 
 type AuthRepo struct {
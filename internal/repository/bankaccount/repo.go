@@ -0,0 +1,98 @@
+package bankaccount
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repo interface {
+	Get(ctx context.Context, userID uint) (*model.EmployeeBankAccount, error)
+	// Upsert menyimpan rekening user; data baru selalu berstatus belum terverifikasi.
+	Upsert(ctx context.Context, acc *model.EmployeeBankAccount) error
+	// MarkVerified hanya berhasil jika rekening ada dan belum terverifikasi.
+	MarkVerified(ctx context.Context, userID, by uint, at time.Time) (bool, error)
+	AddHistory(ctx context.Context, h *model.EmployeeBankAccountHistory) error
+	ListHistory(ctx context.Context, userID uint) ([]model.EmployeeBankAccountHistory, error)
+	ListByUsers(ctx context.Context, userIDs []uint) (map[uint]*model.EmployeeBankAccount, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Get(ctx context.Context, userID uint) (*model.EmployeeBankAccount, error) {
+	var acc model.EmployeeBankAccount
+	if err := repotx.GetDB(ctx, r.db).Where("user_id = ?", userID).First(&acc).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &acc, nil
+}
+
+func (r *repo) Upsert(ctx context.Context, acc *model.EmployeeBankAccount) error {
+	acc.Verified = false
+	acc.VerifiedAt = nil
+	acc.VerifiedBy = nil
+	return repotx.GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"bank_code":          acc.BankCode,
+			"account_number_enc": acc.AccountNumberEnc,
+			"account_last4":      acc.AccountLast4,
+			"holder_name_enc":    acc.HolderNameEnc,
+			"verified":           false,
+			"verified_at":        nil,
+			"verified_by":        nil,
+			"updated_at":         gorm.Expr("now()"),
+		}),
+	}).Create(acc).Error
+}
+
+func (r *repo) MarkVerified(ctx context.Context, userID, by uint, at time.Time) (bool, error) {
+	res := repotx.GetDB(ctx, r.db).Model(&model.EmployeeBankAccount{}).
+		Where("user_id = ? AND verified = false", userID).
+		Updates(map[string]any{"verified": true, "verified_at": at, "verified_by": by, "updated_at": gorm.Expr("now()")})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *repo) AddHistory(ctx context.Context, h *model.EmployeeBankAccountHistory) error {
+	return repotx.GetDB(ctx, r.db).Create(h).Error
+}
+
+func (r *repo) ListHistory(ctx context.Context, userID uint) ([]model.EmployeeBankAccountHistory, error) {
+	var rows []model.EmployeeBankAccountHistory
+	if err := repotx.GetDB(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("changed_at DESC, id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repo) ListByUsers(ctx context.Context, userIDs []uint) (map[uint]*model.EmployeeBankAccount, error) {
+	out := make(map[uint]*model.EmployeeBankAccount, len(userIDs))
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+	var rows []model.EmployeeBankAccount
+	if err := repotx.GetDB(ctx, r.db).Where("user_id IN ?", userIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		out[rows[i].UserID] = &rows[i]
+	}
+	return out, nil
+}
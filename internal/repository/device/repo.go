@@ -0,0 +1,135 @@
+package device
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrSerialExists: serial number (tanpa beda huruf besar/kecil) sudah dipakai mesin lain.
+var ErrSerialExists = errors.New("device serial number already registered")
+
+type Repo interface {
+	// Create mengembalikan ErrSerialExists jika serial number sudah terdaftar
+	Create(ctx context.Context, d *model.Device) error
+	List(ctx context.Context) ([]model.Device, error)
+	FindByID(ctx context.Context, id uint) (*model.Device, error)
+	// FindByKeyHash: nil jika tidak ada device dengan API key tsb
+	FindByKeyHash(ctx context.Context, hash string) (*model.Device, error)
+	Update(ctx context.Context, d *model.Device) error
+	SetKey(ctx context.Context, id uint, hash, prefix string) error
+	Touch(ctx context.Context, id uint, at time.Time) error
+
+	// mapping PIN mesin -> user
+	UpsertUser(ctx context.Context, m *model.DeviceUser) error
+	ListUsers(ctx context.Context, deviceID uint) ([]model.DeviceUser, error)
+	DeleteUser(ctx context.Context, deviceID uint, deviceUserID string) (bool, error)
+
+	// HasPunch: punch (mesin, PIN, waktu) yang sama sudah pernah diterima
+	HasPunch(ctx context.Context, deviceID uint, deviceUserID string, at time.Time) (bool, error)
+	// CreatePunch: false jika punch yang sama sudah pernah diterima
+	CreatePunch(ctx context.Context, p *model.DevicePunch) (bool, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, d *model.Device) error {
+	if err := repotx.GetDB(ctx, r.db).Create(d).Error; err != nil {
+		if strings.Contains(err.Error(), "idx_devices_serial_number_lower") {
+			return ErrSerialExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *repo) List(ctx context.Context) ([]model.Device, error) {
+	var rows []model.Device
+	if err := repotx.GetDB(ctx, r.db).Order("id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.Device, error) {
+	return r.findOne(ctx, "id = ?", id)
+}
+
+func (r *repo) FindByKeyHash(ctx context.Context, hash string) (*model.Device, error) {
+	return r.findOne(ctx, "api_key_hash = ?", hash)
+}
+
+func (r *repo) findOne(ctx context.Context, query string, arg any) (*model.Device, error) {
+	var d model.Device
+	if err := repotx.GetDB(ctx, r.db).Where(query, arg).First(&d).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *repo) Update(ctx context.Context, d *model.Device) error {
+	return repotx.GetDB(ctx, r.db).Model(&model.Device{}).Where("id = ?", d.ID).
+		Updates(map[string]any{
+			"name":       d.Name,
+			"active":     d.Active,
+			"updated_at": time.Now().UTC(),
+		}).Error
+}
+
+func (r *repo) SetKey(ctx context.Context, id uint, hash, prefix string) error {
+	return repotx.GetDB(ctx, r.db).Model(&model.Device{}).Where("id = ?", id).
+		Updates(map[string]any{
+			"api_key_hash":   hash,
+			"api_key_prefix": prefix,
+			"updated_at":     time.Now().UTC(),
+		}).Error
+}
+
+func (r *repo) Touch(ctx context.Context, id uint, at time.Time) error {
+	return repotx.GetDB(ctx, r.db).Model(&model.Device{}).Where("id = ?", id).
+		Update("last_seen_at", at).Error
+}
+
+func (r *repo) UpsertUser(ctx context.Context, m *model.DeviceUser) error {
+	return repotx.GetDB(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "device_id"}, {Name: "device_user_id"}},
+		DoUpdates: clause.Assignments(map[string]any{"user_id": m.UserID, "updated_at": time.Now().UTC()}),
+	}).Create(m).Error
+}
+
+func (r *repo) ListUsers(ctx context.Context, deviceID uint) ([]model.DeviceUser, error) {
+	var rows []model.DeviceUser
+	err := repotx.GetDB(ctx, r.db).Where("device_id = ?", deviceID).Order("device_user_id ASC").Find(&rows).Error
+	return rows, err
+}
+
+func (r *repo) DeleteUser(ctx context.Context, deviceID uint, deviceUserID string) (bool, error) {
+	res := repotx.GetDB(ctx, r.db).Where("device_id = ? AND device_user_id = ?", deviceID, deviceUserID).
+		Delete(&model.DeviceUser{})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (r *repo) HasPunch(ctx context.Context, deviceID uint, deviceUserID string, at time.Time) (bool, error) {
+	var c int64
+	err := repotx.GetDB(ctx, r.db).Model(&model.DevicePunch{}).
+		Where("device_id = ? AND device_user_id = ? AND punched_at = ?", deviceID, deviceUserID, at).
+		Count(&c).Error
+	return c > 0, err
+}
+
+func (r *repo) CreatePunch(ctx context.Context, p *model.DevicePunch) (bool, error) {
+	res := repotx.GetDB(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(p)
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,47 @@
+package disbursement
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+// ErrBatchExists: nomor batch ini sudah dipakai run yang sama (unique payroll_run_id + batch_no).
+var ErrBatchExists = errors.New("disbursement batch already exists for this payroll run")
+
+type Repo interface {
+	Create(ctx context.Context, b *model.DisbursementBatch) error
+	// GetByRun: batch terakhir run ini (nil jika belum ada)
+	GetByRun(ctx context.Context, runID uint) (*model.DisbursementBatch, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, b *model.DisbursementBatch) error {
+	if err := repotx.GetDB(ctx, r.db).Create(b).Error; err != nil {
+		msg := strings.ToLower(err.Error())
+		if strings.Contains(msg, "duplicate key") || strings.Contains(msg, "unique constraint") {
+			return ErrBatchExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *repo) GetByRun(ctx context.Context, runID uint) (*model.DisbursementBatch, error) {
+	var b model.DisbursementBatch
+	if err := repotx.GetDB(ctx, r.db).Where("payroll_run_id = ?", runID).Order("batch_no DESC").First(&b).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &b, nil
+}
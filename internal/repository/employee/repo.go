@@ -0,0 +1,138 @@
+package employee
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+// kolom yang boleh dipakai untuk sort (key = nilai query ?sort=)
+var sortColumns = map[string]string{
+	"name":       "first_name %s, last_name %s",
+	"email":      "email %s",
+	"department": "department %s",
+	"salary":     "salary %s",
+	"created_at": "created_at %s",
+}
+
+func IsSortable(key string) bool {
+	_, ok := sortColumns[key]
+	return ok
+}
+
+type ListFilter struct {
+	Search           string // cocok sebagian dengan nama depan/belakang atau email (case-insensitive)
+	Role             string
+	Department       string
+	EmploymentStatus string
+	Sort             string // lihat sortColumns; default id
+	Desc             bool
+	Offset           int
+	Limit            int
+}
+
+// UpdateFields: kolom yang diubah admin; nil berarti tidak diubah.
+type UpdateFields struct {
+	FirstName         *string
+	LastName          *string
+	Role              *string
+	Salary            *float64
+	Department        *string
+	EmploymentStatus  *string
+	IsProfileComplete *bool
+}
+
+type Repo interface {
+	List(ctx context.Context, f ListFilter) ([]model.User, int64, error)
+	FindByID(ctx context.Context, id uint) (*model.User, error)
+	Update(ctx context.Context, id uint, f UpdateFields) error
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) List(ctx context.Context, f ListFilter) ([]model.User, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.User{})
+	if s := strings.TrimSpace(f.Search); s != "" {
+		like := "%" + escapeLike(strings.ToLower(s)) + "%"
+		q = q.Where("LOWER(first_name) LIKE ? OR LOWER(last_name) LIKE ? OR LOWER(email) LIKE ? OR LOWER(first_name || ' ' || last_name) LIKE ?",
+			like, like, like, like)
+	}
+	if f.Role != "" {
+		q = q.Where("role = ?", f.Role)
+	}
+	if f.Department != "" {
+		q = q.Where("LOWER(department) = ?", strings.ToLower(f.Department))
+	}
+	if f.EmploymentStatus != "" {
+		q = q.Where("employment_status = ?", f.EmploymentStatus)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	dir := "ASC"
+	if f.Desc {
+		dir = "DESC"
+	}
+	if col, ok := sortColumns[f.Sort]; ok {
+		q = q.Order(strings.ReplaceAll(col, "%s", dir))
+	}
+	// id sebagai tie-breaker supaya urutan antar halaman stabil
+	q = q.Order("id " + dir)
+
+	var rows []model.User
+	if err := q.Offset(f.Offset).Limit(f.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	var u model.User
+	if err := repotx.GetDB(ctx, r.db).First(&u, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *repo) Update(ctx context.Context, id uint, f UpdateFields) error {
+	updates := map[string]any{"updated_at": gorm.Expr("now()")}
+	if f.FirstName != nil {
+		updates["first_name"] = *f.FirstName
+	}
+	if f.LastName != nil {
+		updates["last_name"] = *f.LastName
+	}
+	if f.Role != nil {
+		updates["role"] = *f.Role
+	}
+	if f.Salary != nil {
+		updates["salary"] = *f.Salary
+	}
+	if f.Department != nil {
+		updates["department"] = *f.Department
+	}
+	if f.EmploymentStatus != nil {
+		updates["employment_status"] = *f.EmploymentStatus
+	}
+	if f.IsProfileComplete != nil {
+		updates["is_profile_complete"] = *f.IsProfileComplete
+	}
+	return repotx.GetDB(ctx, r.db).Model(&model.User{}).Where("id = ?", id).Updates(updates).Error
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,76 @@
+package loginattempt
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type dbRepo struct{ db *gorm.DB }
+
+func NewDB(db *gorm.DB) Repo { return &dbRepo{db: db} }
+
+func (r *dbRepo) Get(ctx context.Context, scope, identifier string) (*model.LoginAttempt, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var a model.LoginAttempt
+	if err := db.Where("scope = ? AND identifier = ?", scope, identifier).First(&a).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *dbRepo) RecordFailure(ctx context.Context, scope, identifier string, at time.Time, window time.Duration) (*model.LoginAttempt, error) {
+	var a model.LoginAttempt
+	err := repotx.GetDB(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// pastikan baris ada, lalu kunci supaya increment dari instance lain tidak hilang
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&model.LoginAttempt{Scope: scope, Identifier: identifier, LastFailedAt: at}).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("scope = ? AND identifier = ?", scope, identifier).
+			First(&a).Error; err != nil {
+			return err
+		}
+		applyFailure(&a, at, window)
+		return tx.Save(&a).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *dbRepo) Lock(ctx context.Context, scope, identifier string, until time.Time) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.LoginAttempt{}).
+		Where("scope = ? AND identifier = ?", scope, identifier).
+		Updates(map[string]any{"locked_until": until, "updated_at": time.Now().UTC()}).Error
+}
+
+func (r *dbRepo) Clear(ctx context.Context, scope, identifier string) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Where("scope = ? AND identifier = ?", scope, identifier).
+		Delete(&model.LoginAttempt{}).Error
+}
+
+func (r *dbRepo) List(ctx context.Context, lockedOnly bool, now time.Time) ([]model.LoginAttempt, error) {
+	db := repotx.GetDB(ctx, r.db)
+	q := db.Model(&model.LoginAttempt{})
+	if lockedOnly {
+		q = q.Where("locked_until > ?", now)
+	}
+	var out []model.LoginAttempt
+	if err := q.Order("last_failed_at DESC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
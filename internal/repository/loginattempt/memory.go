@@ -0,0 +1,78 @@
+package loginattempt
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"payslip-generation-system/internal/model"
+)
+
+type memoryRepo struct {
+	mu    sync.Mutex
+	items map[string]*model.LoginAttempt
+}
+
+// NewMemory: state hilang saat restart dan tidak dibagi antar instance.
+func NewMemory() Repo {
+	return &memoryRepo{items: make(map[string]*model.LoginAttempt)}
+}
+
+func memKey(scope, identifier string) string { return scope + "|" + identifier }
+
+func (r *memoryRepo) Get(_ context.Context, scope, identifier string) (*model.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.items[memKey(scope, identifier)]
+	if !ok {
+		return nil, nil
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (r *memoryRepo) RecordFailure(_ context.Context, scope, identifier string, at time.Time, window time.Duration) (*model.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := memKey(scope, identifier)
+	a, ok := r.items[k]
+	if !ok {
+		a = &model.LoginAttempt{Scope: scope, Identifier: identifier}
+		r.items[k] = a
+	}
+	applyFailure(a, at, window)
+	cp := *a
+	return &cp, nil
+}
+
+func (r *memoryRepo) Lock(_ context.Context, scope, identifier string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.items[memKey(scope, identifier)]; ok {
+		u := until
+		a.LockedUntil = &u
+	}
+	return nil
+}
+
+func (r *memoryRepo) Clear(_ context.Context, scope, identifier string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.items, memKey(scope, identifier))
+	return nil
+}
+
+func (r *memoryRepo) List(_ context.Context, lockedOnly bool, now time.Time) ([]model.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]model.LoginAttempt, 0, len(r.items))
+	for _, a := range r.items {
+		if lockedOnly && (a.LockedUntil == nil || !a.LockedUntil.After(now)) {
+			continue
+		}
+		out = append(out, *a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].LastFailedAt.After(out[j].LastFailedAt) })
+	return out, nil
+}
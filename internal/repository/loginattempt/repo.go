@@ -0,0 +1,50 @@
+package loginattempt
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StoreMemory = "memory"
+	StoreDB     = "db"
+)
+
+// Repo menyimpan counter login gagal. Pakai StoreMemory untuk single instance,
+// StoreDB kalau service jalan lebih dari satu instance.
+type Repo interface {
+	Get(ctx context.Context, scope, identifier string) (*model.LoginAttempt, error)
+	// RecordFailure menambah counter; counter mulai dari nol lagi kalau aktivitas terakhir
+	// (gagal atau akhir lockout) sudah lebih lama dari window.
+	RecordFailure(ctx context.Context, scope, identifier string, at time.Time, window time.Duration) (*model.LoginAttempt, error)
+	Lock(ctx context.Context, scope, identifier string, until time.Time) error
+	Clear(ctx context.Context, scope, identifier string) error
+	// List mengembalikan semua counter; lockedOnly hanya yang masih terkunci pada now.
+	List(ctx context.Context, lockedOnly bool, now time.Time) ([]model.LoginAttempt, error)
+}
+
+// New memilih implementasi berdasarkan config auth.lockout.store (default memory).
+func New(store string, db *gorm.DB) Repo {
+	if store == StoreDB {
+		return NewDB(db)
+	}
+	return NewMemory()
+}
+
+func applyFailure(a *model.LoginAttempt, at time.Time, window time.Duration) {
+	last := a.LastFailedAt
+	if a.LockedUntil != nil && a.LockedUntil.After(last) {
+		last = *a.LockedUntil
+	}
+	if a.Failures > 0 && at.Sub(last) > window {
+		a.Failures = 0
+		a.LockedUntil = nil
+	}
+	a.Failures++
+	a.LastFailedAt = at
+	a.UpdatedAt = at
+}
@@ -0,0 +1,69 @@
+package officelocation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+type Repo interface {
+	Create(ctx context.Context, o *model.OfficeLocation) error
+	// List: activeOnly=true hanya kantor aktif (dipakai saat cek geofence)
+	List(ctx context.Context, activeOnly bool) ([]model.OfficeLocation, error)
+	FindByID(ctx context.Context, id uint) (*model.OfficeLocation, error)
+	Update(ctx context.Context, o *model.OfficeLocation) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, o *model.OfficeLocation) error {
+	return repotx.GetDB(ctx, r.db).Create(o).Error
+}
+
+func (r *repo) List(ctx context.Context, activeOnly bool) ([]model.OfficeLocation, error) {
+	q := repotx.GetDB(ctx, r.db).Order("id ASC")
+	if activeOnly {
+		q = q.Where("active = ?", true)
+	}
+	var rows []model.OfficeLocation
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.OfficeLocation, error) {
+	var o model.OfficeLocation
+	if err := repotx.GetDB(ctx, r.db).First(&o, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *repo) Update(ctx context.Context, o *model.OfficeLocation) error {
+	return repotx.GetDB(ctx, r.db).Model(&model.OfficeLocation{}).Where("id = ?", o.ID).
+		Updates(map[string]any{
+			"name":              o.Name,
+			"latitude":          o.Latitude,
+			"longitude":         o.Longitude,
+			"radius_meters":     o.RadiusMeters,
+			"allowed_ip_ranges": o.AllowedIPRanges,
+			"active":            o.Active,
+			"updated_at":        time.Now().UTC(),
+		}).Error
+}
+
+func (r *repo) Delete(ctx context.Context, id uint) error {
+	return repotx.GetDB(ctx, r.db).Delete(&model.OfficeLocation{}, id).Error
+}
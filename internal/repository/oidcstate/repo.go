@@ -0,0 +1,43 @@
+package oidcstate
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+type Repo interface {
+	Create(ctx context.Context, s *model.OIDCLoginState) error
+	// Consume menandai state terpakai dan mengembalikannya; nil jika tidak ada atau sudah dipakai.
+	Consume(ctx context.Context, stateHash string, at time.Time) (*model.OIDCLoginState, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, s *model.OIDCLoginState) error {
+	return repotx.GetDB(ctx, r.db).Create(s).Error
+}
+
+func (r *repo) Consume(ctx context.Context, stateHash string, at time.Time) (*model.OIDCLoginState, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.OIDCLoginState{}).
+		Where("state_hash = ? AND used_at IS NULL", stateHash).
+		Update("used_at", at)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	var s model.OIDCLoginState
+	if err := db.Where("state_hash = ?", stateHash).First(&s).Error; err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
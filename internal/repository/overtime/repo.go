@@ -12,6 +12,22 @@ import (
 
 type Repo interface {
 	CreateIfNotExists(ctx context.Context, userID uint, date time.Time, hours float64) (*model.Overtime, bool, error)
+	Exists(ctx context.Context, userID uint, date time.Time) (bool, error)
+	// SetSubmitter mencatat admin yang menginput overtime atas nama karyawan
+	SetSubmitter(ctx context.Context, id, adminID uint, reason string) error
+	// ListByDateRange: user_id & date overtime dalam rentang (untuk cek duplikat import)
+	ListByDateRange(ctx context.Context, start, end time.Time) ([]model.Overtime, error)
+	CreateBatch(ctx context.Context, rows []*model.Overtime, batchSize int) error
+	// FindByID: nil jika tidak ada
+	FindByID(ctx context.Context, id uint) (*model.Overtime, error)
+	UpdateHours(ctx context.Context, id uint, hours float64) error
+	Delete(ctx context.Context, id uint) error
+	// SetPlanMatch menyimpan rencana yang cocok & tanda jika tidak sesuai rencana
+	SetPlanMatch(ctx context.Context, id uint, planID *uint, flagReason string) error
+	// ListFlagged: overtime yang ditandai tidak sesuai rencana dalam rentang tanggal
+	ListFlagged(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error)
+	// ListByUser: overtime milik user dalam rentang tanggal, terbaru dulu
+	ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error)
 }
 
 type repo struct{ db *gorm.DB }
@@ -34,3 +50,83 @@ func (r *repo) CreateIfNotExists(ctx context.Context, userID uint, date time.Tim
 	}
 	return row, false, nil
 }
+
+func (r *repo) Exists(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var count int64
+	err := db.Model(&model.Overtime{}).Where("user_id = ? AND date = ?", userID, date).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repo) SetSubmitter(ctx context.Context, id, adminID uint, reason string) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.Overtime{}).Where("id = ?", id).
+		Updates(map[string]any{"submitted_by": adminID, "on_behalf_reason": reason}).Error
+}
+
+func (r *repo) ListByDateRange(ctx context.Context, start, end time.Time) ([]model.Overtime, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.Overtime
+	err := db.Select("id", "user_id", "date").
+		Where("date BETWEEN ? AND ?", start, end).
+		Find(&rows).Error
+	return rows, err
+}
+
+func (r *repo) CreateBatch(ctx context.Context, rows []*model.Overtime, batchSize int) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return repotx.GetDB(ctx, r.db).CreateInBatches(rows, batchSize).Error
+}
+
+func (r *repo) ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.Overtime{}).Where("user_id = ? AND date BETWEEN ? AND ?", userID, start, end)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.Overtime
+	if err := q.Order("date DESC, id DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.Overtime, error) {
+	var rows []model.Overtime
+	if err := repotx.GetDB(ctx, r.db).Where("id = ?", id).Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) UpdateHours(ctx context.Context, id uint, hours float64) error {
+	return repotx.GetDB(ctx, r.db).Model(&model.Overtime{}).Where("id = ?", id).
+		Updates(map[string]any{"hours": hours, "updated_at": time.Now().UTC()}).Error
+}
+
+func (r *repo) Delete(ctx context.Context, id uint) error {
+	return repotx.GetDB(ctx, r.db).Delete(&model.Overtime{}, id).Error
+}
+
+func (r *repo) SetPlanMatch(ctx context.Context, id uint, planID *uint, flagReason string) error {
+	return repotx.GetDB(ctx, r.db).Model(&model.Overtime{}).Where("id = ?", id).
+		Updates(map[string]any{"plan_id": planID, "plan_flagged": flagReason != "", "plan_flag_reason": flagReason}).Error
+}
+
+func (r *repo) ListFlagged(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.Overtime{}).Where("date BETWEEN ? AND ? AND plan_flagged = ?", start, end, true)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.Overtime
+	if err := q.Order("date ASC, user_id ASC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
@@ -0,0 +1,118 @@
+package overtimeplan
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+// ListFilter: UserID 0 = semua karyawan, Status kosong = semua status.
+type ListFilter struct {
+	UserID uint
+	Status string
+	Offset int
+	Limit  int
+}
+
+// Decision hasil review admin atas rencana pending.
+type Decision struct {
+	Status     string
+	ReviewedBy uint
+	ReviewedAt time.Time
+	ReviewNote string
+}
+
+type Repo interface {
+	Create(ctx context.Context, p *model.OvertimePlan) error
+	FindByID(ctx context.Context, id uint) (*model.OvertimePlan, error)
+	List(ctx context.Context, f ListFilter) ([]model.OvertimePlan, int64, error)
+	// HasActive: sudah ada rencana pending / approved untuk user & tanggal yang sama
+	HasActive(ctx context.Context, userID uint, date time.Time) (bool, error)
+	// FindApproved: rencana approved untuk user & tanggal, nil jika tidak ada
+	FindApproved(ctx context.Context, userID uint, date time.Time) (*model.OvertimePlan, error)
+	// Decide hanya berhasil jika rencana masih pending
+	Decide(ctx context.Context, id uint, d Decision) (bool, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, p *model.OvertimePlan) error {
+	return repotx.GetDB(ctx, r.db).Create(p).Error
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.OvertimePlan, error) {
+	var p model.OvertimePlan
+	if err := repotx.GetDB(ctx, r.db).First(&p, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *repo) List(ctx context.Context, f ListFilter) ([]model.OvertimePlan, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.OvertimePlan{})
+	if f.UserID != 0 {
+		q = q.Where("user_id = ?", f.UserID)
+	}
+	if f.Status != "" {
+		q = q.Where("status = ?", f.Status)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.OvertimePlan
+	if err := q.Order("date DESC, id DESC").Offset(f.Offset).Limit(f.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) HasActive(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	var count int64
+	err := repotx.GetDB(ctx, r.db).Model(&model.OvertimePlan{}).
+		Where("user_id = ? AND date = ? AND status IN ?", userID, date,
+			[]string{model.OvertimePlanStatusPending, model.OvertimePlanStatusApproved}).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repo) FindApproved(ctx context.Context, userID uint, date time.Time) (*model.OvertimePlan, error) {
+	var rows []model.OvertimePlan
+	err := repotx.GetDB(ctx, r.db).
+		Where("user_id = ? AND date = ? AND status = ?", userID, date, model.OvertimePlanStatusApproved).
+		Order("id DESC").Limit(1).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) Decide(ctx context.Context, id uint, d Decision) (bool, error) {
+	res := repotx.GetDB(ctx, r.db).Model(&model.OvertimePlan{}).
+		Where("id = ? AND status = ?", id, model.OvertimePlanStatusPending).
+		Updates(map[string]any{
+			"status":      d.Status,
+			"reviewed_by": d.ReviewedBy,
+			"reviewed_at": d.ReviewedAt,
+			"review_note": d.ReviewNote,
+			"updated_at":  d.ReviewedAt,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
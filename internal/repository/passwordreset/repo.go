@@ -0,0 +1,58 @@
+package passwordreset
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+type Repo interface {
+	Create(ctx context.Context, t *model.PasswordResetToken) error
+	GetByHash(ctx context.Context, hash string) (*model.PasswordResetToken, error)
+	// MarkUsed hanya berhasil sekali (false => token sudah dipakai).
+	MarkUsed(ctx context.Context, id uint, at time.Time) (bool, error)
+	// InvalidateForUser menandai semua token user yang belum terpakai sebagai used.
+	InvalidateForUser(ctx context.Context, userID uint, at time.Time) error
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) Create(ctx context.Context, t *model.PasswordResetToken) error {
+	return repotx.GetDB(ctx, r.db).Create(t).Error
+}
+
+func (r *repo) GetByHash(ctx context.Context, hash string) (*model.PasswordResetToken, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var t model.PasswordResetToken
+	if err := db.Where("token_hash = ?", hash).First(&t).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *repo) MarkUsed(ctx context.Context, id uint, at time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *repo) InvalidateForUser(ctx context.Context, userID uint, at time.Time) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", at).Error
+}
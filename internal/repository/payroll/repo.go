@@ -2,33 +2,63 @@ package payroll
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"payslip-generation-system/internal/model"
 	repotx "payslip-generation-system/internal/repository/tx"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrApprovalExists: admin sudah memberi keputusan pada putaran approval ini.
+var ErrApprovalExists = errors.New("approval already recorded for this round")
+
 type Repo interface {
 	HasRunForPeriod(ctx context.Context, periodID uint) (bool, error)
 	CreateRun(ctx context.Context, run *model.PayrollRun, items []*model.PayrollItem) error
+	// ReplaceRunItems menghitung ulang run draft: item lama dihapus, diganti items.
+	// false jika run sudah tidak draft.
+	ReplaceRunItems(ctx context.Context, run *model.PayrollRun, items []*model.PayrollItem) (bool, error)
+	// MarkRunStale menandai run draft pada period sebagai stale; false jika period tidak punya run draft.
+	MarkRunStale(ctx context.Context, periodID uint) (bool, error)
+
+	// Lifecycle
+	UpdateRunStatus(ctx context.Context, runID uint, from, to string, at time.Time) (bool, error)
+	AddRunEvent(ctx context.Context, ev *model.PayrollRunEvent) error
+	ListRunEvents(ctx context.Context, runID uint) ([]model.PayrollRunEvent, error)
+
+	// Approval (four-eyes)
+	// LockRun membaca run dengan SELECT ... FOR UPDATE (harus di dalam transaksi)
+	LockRun(ctx context.Context, runID uint) (*model.PayrollRun, error)
+	// StartApprovalRound: draft -> pending_approval dan approval_round = round (hanya jika round sebelumnya round-1
+	// dan run tidak stale)
+	StartApprovalRound(ctx context.Context, runID uint, round int, at time.Time) (bool, error)
+	AddRunApproval(ctx context.Context, a *model.PayrollRunApproval) error
+	ListRunApprovals(ctx context.Context, runID uint, round int) ([]model.PayrollRunApproval, error)
 
 	// Aggregations
 	GetAttendanceDaysByUser(ctx context.Context, start, end time.Time) (map[uint]int, error)
 	GetOvertimeHoursByUser(ctx context.Context, start, end time.Time) (map[uint]float64, error)
 	GetReimbTotalByUser(ctx context.Context, start, end time.Time) (map[uint]float64, error)
+	// ListTimedAttendances: attendance yang punya check-in dalam rentang; userID 0 = semua user
+	ListTimedAttendances(ctx context.Context, userID uint, start, end time.Time) ([]model.Attendance, error)
 
 	GetUserSalaries(ctx context.Context) (map[uint]float64, error)
 
 	// Period lookup
 	GetPeriodByID(ctx context.Context, id uint) (*model.AttendancePeriod, error)
 
-	// Check if a date falls into a period that already has payroll run (for locking)
+	// Check if a date falls into a closed period or one whose payroll run is approved or later (for locking)
 	HasRunOnDate(ctx context.Context, date time.Time) (bool, error)
 
 	// Payslip related methods
 	GetPayrollItemByUser(ctx context.Context, runID uint, userID uint) (*model.PayrollItem, error)
+	ListItemsByRun(ctx context.Context, runID uint) ([]model.PayrollItem, error)
+	// UpdateItemPayment menulis kolom pembayaran item hanya jika status saat ini masih fromStatus
+	UpdateItemPayment(ctx context.Context, item *model.PayrollItem, fromStatus string) (bool, error)
 	GetRunByPeriod(ctx context.Context, periodID uint) (*model.PayrollRun, error)
 	GetUserSalary(ctx context.Context, userID uint) (float64, error)
 	GetAttendanceDaysForUser(ctx context.Context, userID uint, start, end time.Time) (int, error)
@@ -60,6 +90,123 @@ func (r *repo) CreateRun(ctx context.Context, run *model.PayrollRun, items []*mo
 	return db.Create(&items).Error
 }
 
+func (r *repo) ReplaceRunItems(ctx context.Context, run *model.PayrollRun, items []*model.PayrollItem) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.PayrollRun{}).
+		Where("id = ? AND status = ?", run.ID, model.PayrollRunStatusDraft).
+		Updates(map[string]any{"run_at": run.RunAt, "calculated_by": run.CalculatedBy, "stale": false, "updated_at": time.Now().UTC()})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	if err := db.Where("payroll_run_id = ?", run.ID).Delete(&model.PayrollItem{}).Error; err != nil {
+		return false, err
+	}
+	if len(items) == 0 {
+		return true, nil
+	}
+	for _, it := range items {
+		it.PayrollRunID = run.ID
+	}
+	return true, db.Create(&items).Error
+}
+
+func (r *repo) UpdateRunStatus(ctx context.Context, runID uint, from, to string, at time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.PayrollRun{}).
+		Where("id = ? AND status = ?", runID, from).
+		Updates(map[string]any{"status": to, "status_changed_at": at, "updated_at": at})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *repo) AddRunEvent(ctx context.Context, ev *model.PayrollRunEvent) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Create(ev).Error
+}
+
+func (r *repo) ListRunEvents(ctx context.Context, runID uint) ([]model.PayrollRunEvent, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.PayrollRunEvent
+	if err := db.Where("payroll_run_id = ?", runID).Order("created_at ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repo) LockRun(ctx context.Context, runID uint) (*model.PayrollRun, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var run model.PayrollRun
+	if err := db.Clauses(clause.Locking{Strength: "UPDATE"}).First(&run, runID).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *repo) MarkRunStale(ctx context.Context, periodID uint) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.PayrollRun{}).
+		Where("period_id = ? AND status = ?", periodID, model.PayrollRunStatusDraft).
+		Updates(map[string]any{"stale": true, "updated_at": time.Now().UTC()})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *repo) StartApprovalRound(ctx context.Context, runID uint, round int, at time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.PayrollRun{}).
+		Where("id = ? AND status = ? AND approval_round = ? AND stale = ?", runID, model.PayrollRunStatusDraft, round-1, false).
+		Updates(map[string]any{
+			"status":            model.PayrollRunStatusPendingApproval,
+			"approval_round":    round,
+			"status_changed_at": at,
+			"updated_at":        at,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
+func (r *repo) AddRunApproval(ctx context.Context, a *model.PayrollRunApproval) error {
+	if err := repotx.GetDB(ctx, r.db).Create(a).Error; err != nil {
+		msg := strings.ToLower(err.Error())
+		if strings.Contains(msg, "duplicate key") || strings.Contains(msg, "unique constraint") {
+			return ErrApprovalExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (r *repo) ListRunApprovals(ctx context.Context, runID uint, round int) ([]model.PayrollRunApproval, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var rows []model.PayrollRunApproval
+	if err := db.Where("payroll_run_id = ? AND round = ?", runID, round).Order("created_at ASC, id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *repo) ListTimedAttendances(ctx context.Context, userID uint, start, end time.Time) ([]model.Attendance, error) {
+	db := repotx.GetDB(ctx, r.db)
+	q := db.Where("date BETWEEN ? AND ? AND check_in_at IS NOT NULL", start, end)
+	if userID != 0 {
+		q = q.Where("user_id = ?", userID)
+	}
+	var rows []model.Attendance
+	if err := q.Order("user_id ASC, date ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 func (r *repo) GetAttendanceDaysByUser(ctx context.Context, start, end time.Time) (map[uint]int, error) {
 	db := repotx.GetDB(ctx, r.db)
 	type row struct {
@@ -157,12 +304,13 @@ func (r *repo) GetPeriodByID(ctx context.Context, id uint) (*model.AttendancePer
 
 func (r *repo) HasRunOnDate(ctx context.Context, date time.Time) (bool, error) {
 	db := repotx.GetDB(ctx, r.db)
-	// payroll_runs join attendance_periods; cek apakah date berada dalam period yang sudah di-run
-	type row struct{ Count int64 }
+	// cek apakah date berada dalam period yang ditutup manual atau run-nya sudah diajukan / approved
+	// (hanya run draft yang belum mengunci submission)
 	var c int64
-	err := db.Table((model.PayrollRun{}).TableName()+" pr").
-		Joins("JOIN "+(model.AttendancePeriod{}).TableName()+" ap ON ap.id = pr.period_id").
+	err := db.Table((model.AttendancePeriod{}).TableName()+" ap").
+		Joins("LEFT JOIN "+(model.PayrollRun{}).TableName()+" pr ON pr.period_id = ap.id").
 		Where("? BETWEEN ap.start_date AND ap.end_date", date).
+		Where("ap.closed_at IS NOT NULL OR pr.status IN ?", model.PayrollRunSubmissionLockedStatuses).
 		Count(&c).Error
 	return c > 0, err
 }
@@ -177,6 +325,37 @@ func (r *repo) GetPayrollItemByUser(ctx context.Context, runID uint, userID uint
 	return &it, nil
 }
 
+func (r *repo) ListItemsByRun(ctx context.Context, runID uint) ([]model.PayrollItem, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var items []model.PayrollItem
+	if err := db.Where("payroll_run_id = ?", runID).Order("user_id").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *repo) UpdateItemPayment(ctx context.Context, item *model.PayrollItem, fromStatus string) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.PayrollItem{}).
+		Where("id = ? AND payment_status = ?", item.ID, fromStatus).
+		Updates(map[string]any{
+			"payment_status":        item.PaymentStatus,
+			"payment_sequence":      item.PaymentSequence,
+			"payment_account_last4": item.PaymentAccountLast4,
+			"bank_reference":        item.BankReference,
+			"payment_note":          item.PaymentNote,
+			"sent_at":               item.SentAt,
+			"paid_at":               item.PaidAt,
+			"failed_at":             item.FailedAt,
+			"returned_at":           item.ReturnedAt,
+			"updated_at":            time.Now().UTC(),
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
+
 func (r *repo) GetRunByPeriod(ctx context.Context, periodID uint) (*model.PayrollRun, error) {
 	db := repotx.GetDB(ctx, r.db)
 	var run model.PayrollRun
@@ -3,6 +3,7 @@ package reimbursement
 
 import (
 	"context"
+	"time"
 
 	"payslip-generation-system/internal/model"
 	repotx "payslip-generation-system/internal/repository/tx"
@@ -12,6 +13,14 @@ import (
 
 type Repo interface {
 	Create(ctx context.Context, r *model.Reimbursement) error
+	CreateBatch(ctx context.Context, rows []*model.Reimbursement, batchSize int) error
+	// FindByID: nil jika tidak ada
+	FindByID(ctx context.Context, id uint) (*model.Reimbursement, error)
+	// Update menyimpan amount & description
+	Update(ctx context.Context, m *model.Reimbursement) error
+	Delete(ctx context.Context, id uint) error
+	// ListByUser: reimbursement milik user dalam rentang tanggal, terbaru dulu
+	ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Reimbursement, int64, error)
 }
 
 type repo struct{ db *gorm.DB }
@@ -21,3 +30,43 @@ func New(db *gorm.DB) Repo { return &repo{db: db} }
 func (r *repo) Create(ctx context.Context, m *model.Reimbursement) error {
 	return repotx.GetDB(ctx, r.db).Create(m).Error
 }
+
+func (r *repo) CreateBatch(ctx context.Context, rows []*model.Reimbursement, batchSize int) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return repotx.GetDB(ctx, r.db).CreateInBatches(rows, batchSize).Error
+}
+
+func (r *repo) ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Reimbursement, int64, error) {
+	q := repotx.GetDB(ctx, r.db).Model(&model.Reimbursement{}).Where("user_id = ? AND date BETWEEN ? AND ?", userID, start, end)
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []model.Reimbursement
+	if err := q.Order("date DESC, id DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	return rows, total, nil
+}
+
+func (r *repo) FindByID(ctx context.Context, id uint) (*model.Reimbursement, error) {
+	var rows []model.Reimbursement
+	if err := repotx.GetDB(ctx, r.db).Where("id = ?", id).Limit(1).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+func (r *repo) Update(ctx context.Context, m *model.Reimbursement) error {
+	return repotx.GetDB(ctx, r.db).Model(&model.Reimbursement{}).Where("id = ?", m.ID).
+		Updates(map[string]any{"amount": m.Amount, "description": m.Description, "updated_at": time.Now().UTC()}).Error
+}
+
+func (r *repo) Delete(ctx context.Context, id uint) error {
+	return repotx.GetDB(ctx, r.db).Delete(&model.Reimbursement{}, id).Error
+}
@@ -0,0 +1,92 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+type Repo interface {
+	CreateSession(ctx context.Context, s *model.AuthSession) error
+	GetSession(ctx context.Context, id uint) (*model.AuthSession, error)
+	TouchSession(ctx context.Context, id uint, at time.Time) error
+	RevokeSession(ctx context.Context, id uint, reason string, at time.Time) error
+	RevokeAllForUser(ctx context.Context, userID uint, reason string, at time.Time) error
+
+	CreateRefreshToken(ctx context.Context, t *model.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*model.RefreshToken, error)
+	// MarkRefreshTokenRotated hanya berhasil sekali per token (false => sudah pernah dirotasi).
+	MarkRefreshTokenRotated(ctx context.Context, id uint, at time.Time) (bool, error)
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) CreateSession(ctx context.Context, s *model.AuthSession) error {
+	return repotx.GetDB(ctx, r.db).Create(s).Error
+}
+
+func (r *repo) GetSession(ctx context.Context, id uint) (*model.AuthSession, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var s model.AuthSession
+	if err := db.First(&s, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *repo) TouchSession(ctx context.Context, id uint, at time.Time) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.AuthSession{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"last_used_at": at, "updated_at": at}).Error
+}
+
+func (r *repo) RevokeSession(ctx context.Context, id uint, reason string, at time.Time) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.AuthSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{"revoked_at": at, "revoked_reason": reason, "updated_at": at}).Error
+}
+
+func (r *repo) RevokeAllForUser(ctx context.Context, userID uint, reason string, at time.Time) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Model(&model.AuthSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]any{"revoked_at": at, "revoked_reason": reason, "updated_at": at}).Error
+}
+
+func (r *repo) CreateRefreshToken(ctx context.Context, t *model.RefreshToken) error {
+	return repotx.GetDB(ctx, r.db).Create(t).Error
+}
+
+func (r *repo) GetRefreshTokenByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	db := repotx.GetDB(ctx, r.db)
+	var t model.RefreshToken
+	if err := db.Where("token_hash = ?", hash).First(&t).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *repo) MarkRefreshTokenRotated(ctx context.Context, id uint, at time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL", id).
+		Update("rotated_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
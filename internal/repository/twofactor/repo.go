@@ -0,0 +1,54 @@
+package twofactor
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repotx "payslip-generation-system/internal/repository/tx"
+
+	"gorm.io/gorm"
+)
+
+type Repo interface {
+	// ReplaceRecoveryCodes menghapus kode lama user lalu menyimpan hash kode baru.
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, hashes []string) error
+	// UseRecoveryCode hanya berhasil sekali per kode (false => tidak ada / sudah dipakai).
+	UseRecoveryCode(ctx context.Context, userID uint, hash string, at time.Time) (bool, error)
+	DeleteRecoveryCodes(ctx context.Context, userID uint) error
+}
+
+type repo struct{ db *gorm.DB }
+
+func New(db *gorm.DB) Repo { return &repo{db: db} }
+
+func (r *repo) ReplaceRecoveryCodes(ctx context.Context, userID uint, hashes []string) error {
+	db := repotx.GetDB(ctx, r.db)
+	if err := db.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+	rows := make([]model.RecoveryCode, 0, len(hashes))
+	for _, h := range hashes {
+		rows = append(rows, model.RecoveryCode{UserID: userID, CodeHash: h})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return db.Create(&rows).Error
+}
+
+func (r *repo) UseRecoveryCode(ctx context.Context, userID uint, hash string, at time.Time) (bool, error) {
+	db := repotx.GetDB(ctx, r.db)
+	res := db.Model(&model.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", at)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *repo) DeleteRecoveryCodes(ctx context.Context, userID uint) error {
+	db := repotx.GetDB(ctx, r.db)
+	return db.Where("user_id = ?", userID).Delete(&model.RecoveryCode{}).Error
+}
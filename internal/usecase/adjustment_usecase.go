@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	adjDTO "payslip-generation-system/internal/dto/adjustment"
+	"payslip-generation-system/internal/dto/payslip"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	adjRepo "payslip-generation-system/internal/repository/adjustment"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func toAdjustmentResponse(a *model.PayrollAdjustment) adjDTO.AdjustmentResponse {
+	return adjDTO.AdjustmentResponse{
+		ID:               a.ID,
+		UserID:           a.UserID,
+		Kind:             a.Kind,
+		OriginalDate:     a.OriginalDate.Format("2006-01-02"),
+		OriginalPeriodID: a.OriginalPeriodID,
+		PeriodID:         a.PeriodID,
+		Hours:            a.Hours,
+		Amount:           a.Amount,
+		Description:      a.Description,
+		Status:           a.Status,
+		ReviewedBy:       a.ReviewedBy,
+		ReviewNote:       a.ReviewNote,
+		CreatedAt:        a.CreatedAt,
+	}
+}
+
+// adjustmentPay: overtime dibayar 2x tarif per jam period tujuan, reimbursement sesuai nominal.
+func adjustmentPay(a model.PayrollAdjustment, hourly float64) float64 {
+	if a.Kind == model.AdjustmentKindOvertime {
+		return round2(a.Hours * hourly * 2)
+	}
+	return round2(a.Amount)
+}
+
+// adjustmentLines: baris payslip beserta total jam overtime & nominal adjustment.
+func adjustmentLines(adjs []model.PayrollAdjustment, hourly float64) ([]payslip.AdjustmentLine, float64, float64) {
+	lines := make([]payslip.AdjustmentLine, 0, len(adjs))
+	otHours, total := 0.0, 0.0
+	for _, a := range adjs {
+		pay := adjustmentPay(a, hourly)
+		line := payslip.AdjustmentLine{
+			ID:           a.ID,
+			Kind:         a.Kind,
+			OriginalDate: a.OriginalDate.Format("2006-01-02"),
+			Amount:       fmt.Sprintf("%.2f", pay),
+			Description:  a.Description,
+		}
+		if a.Kind == model.AdjustmentKindOvertime {
+			line.Hours = fmt.Sprintf("%.2f", round2(a.Hours))
+			otHours += a.Hours
+		}
+		total += pay
+		lines = append(lines, line)
+	}
+	return lines, round2(otHours), round2(total)
+}
+
+// CreatePriorPeriodAdjustment: overtime / reimbursement untuk tanggal di period terkunci
+// dicatat sebagai adjustment pending di period open berikutnya; baru dibayar setelah di-approve admin.
+func (u *usecase) CreatePriorPeriodAdjustment(ctx *gin.Context, userID uint, req adjDTO.CreateAdjustmentRequest) (*adjDTO.AdjustmentResponse, error) {
+	date, err := time.ParseInLocation("2006-01-02", req.Date, time.FixedZone("WIB", 7*3600))
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "date")
+	}
+	row := &model.PayrollAdjustment{
+		UserID:       userID,
+		OriginalDate: date,
+		Kind:         req.Kind,
+		Description:  strings.TrimSpace(req.Description),
+		CreatedBy:    userID,
+		Status:       model.AdjustmentStatusPending,
+	}
+	switch req.Kind {
+	case model.AdjustmentKindOvertime:
+		if req.Hours <= 0 || req.Hours > 3 {
+			return nil, utils.MakeError(errorUc.BadRequest, "hours must be > 0 and <= 3")
+		}
+		row.Hours = req.Hours
+	case model.AdjustmentKindReimbursement:
+		if req.Amount <= 0 {
+			return nil, utils.MakeError(errorUc.BadRequest, "amount must be > 0")
+		}
+		row.Amount = req.Amount
+	}
+
+	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if !locked {
+		return nil, utils.MakeError(errorUc.BadRequest, "date is not in a locked period; submit it normally")
+	}
+
+	if row.Kind == model.AdjustmentKindOvertime {
+		// overtime tetap satu per hari: yang sudah dibayar / sudah diajukan sebagai adjustment ditolak
+		var claimed bool
+		claimed, err = u.otRepo.Exists(ctx, userID, date)
+		if err == nil && !claimed {
+			claimed, err = u.adjustmentRepo.HasOvertime(ctx, userID, date)
+		}
+		if err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if claimed {
+			return nil, utils.MakeError(errorUc.ConflictError, "overtime for that date has already been submitted")
+		}
+	}
+
+	origin, err := u.apRepo.Containing(ctx, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	after := date
+	if origin != nil {
+		row.OriginalPeriodID = &origin.ID
+		after = origin.EndDate
+	}
+	next, err := u.apRepo.NextOpen(ctx, after)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if next == nil {
+		return nil, utils.MakeError(errorUc.BadRequest, "no open payroll period to carry the adjustment into")
+	}
+	row.PeriodID = next.ID
+
+	if err = u.adjustmentRepo.Create(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to create payroll adjustment"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to create adjustment")
+	}
+	resp := toAdjustmentResponse(row)
+	return &resp, nil
+}
+
+func (u *usecase) ListMyAdjustments(ctx *gin.Context, userID uint, q adjDTO.ListAdjustmentsQuery) ([]adjDTO.AdjustmentResponse, *utils.Metadata, error) {
+	page := q.Pagination.Normalize()
+	rows, total, err := u.adjustmentRepo.ListByUser(ctx, userID, page.Offset(), page.PageSize)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list payroll adjustments"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	out := make([]adjDTO.AdjustmentResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toAdjustmentResponse(&rows[i]))
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+// ListPeriodAdjustments: semua adjustment di period termasuk yang masih pending / rejected.
+func (u *usecase) ListPeriodAdjustments(ctx *gin.Context, periodID uint) ([]adjDTO.AdjustmentResponse, error) {
+	if _, err := u.findPeriod(ctx, periodID); err != nil {
+		return nil, err
+	}
+	rows, err := u.adjustmentRepo.ListByPeriod(ctx, periodID, 0, "")
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list payroll adjustments"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	out := make([]adjDTO.AdjustmentResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toAdjustmentResponse(&rows[i]))
+	}
+	return out, nil
+}
+
+// adjustmentTargetOpen: adjustment hanya boleh masuk period yang belum di-run atau run-nya masih draft.
+func adjustmentTargetOpen(row *apRepo.PeriodRow) bool {
+	return row.ClosedAt == nil && (row.RunStatus == "" || row.RunStatus == model.PayrollRunStatusDraft)
+}
+
+// ApprovePriorPeriodAdjustment: adjustment ikut dibayar. Jika run period tujuannya sudah diajukan / terkunci
+// selama menunggu review, adjustment dipindah ke period open berikutnya. Run draft di period tujuan
+// ditandai stale supaya dihitung ulang sebelum diajukan.
+func (u *usecase) ApprovePriorPeriodAdjustment(ctx *gin.Context, actorID, id uint, req adjDTO.ReviewAdjustmentRequest) (resp *adjDTO.AdjustmentResponse, err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	a, err := u.findPendingAdjustment(txCtx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+	period, err := u.findPeriod(txCtx, a.PeriodID)
+	if err != nil {
+		return nil, err
+	}
+	if !adjustmentTargetOpen(period) {
+		period, err = u.apRepo.NextOpen(txCtx, period.EndDate)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if period == nil {
+			return nil, utils.MakeError(errorUc.BadRequest, "no open payroll period to carry the adjustment into")
+		}
+	}
+	if period.RunStatus == model.PayrollRunStatusDraft {
+		var marked bool
+		marked, err = u.payrollRepo.MarkRunStale(txCtx, period.ID)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to mark payroll run stale"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if !marked {
+			// run baru saja diajukan oleh admin lain
+			err = utils.MakeError(errorUc.ConflictError, "payroll run status changed concurrently")
+			return nil, err
+		}
+	}
+	return u.decideAdjustment(txCtx, a, adjRepo.Decision{
+		Status:     model.AdjustmentStatusApproved,
+		PeriodID:   period.ID,
+		ReviewedBy: actorID,
+		ReviewedAt: u.clock().UTC(),
+		ReviewNote: strings.TrimSpace(req.Note),
+	})
+}
+
+// RejectPriorPeriodAdjustment: adjustment tidak dibayar; note wajib.
+func (u *usecase) RejectPriorPeriodAdjustment(ctx *gin.Context, actorID, id uint, req adjDTO.ReviewAdjustmentRequest) (*adjDTO.AdjustmentResponse, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, utils.MakeError(errorUc.InvalidMandatory, "note")
+	}
+	a, err := u.findPendingAdjustment(ctx, actorID, id)
+	if err != nil {
+		return nil, err
+	}
+	return u.decideAdjustment(ctx, a, adjRepo.Decision{
+		Status:     model.AdjustmentStatusRejected,
+		PeriodID:   a.PeriodID,
+		ReviewedBy: actorID,
+		ReviewedAt: u.clock().UTC(),
+		ReviewNote: note,
+	})
+}
+
+func (u *usecase) findPendingAdjustment(ctx context.Context, actorID, id uint) (*model.PayrollAdjustment, error) {
+	a, err := u.adjustmentRepo.FindByID(ctx, id)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load payroll adjustment"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if a == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "adjustment not found")
+	}
+	if a.Status != model.AdjustmentStatusPending {
+		return nil, utils.MakeError(errorUc.ConflictError, "adjustment already reviewed")
+	}
+	if a.UserID == actorID {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "you cannot review your own adjustment")
+	}
+	return a, nil
+}
+
+func (u *usecase) decideAdjustment(ctx context.Context, a *model.PayrollAdjustment, d adjRepo.Decision) (*adjDTO.AdjustmentResponse, error) {
+	ok, err := u.adjustmentRepo.Decide(ctx, a.ID, d)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update payroll adjustment"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update adjustment")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "adjustment already reviewed")
+	}
+
+	u.log.Info(log.LogData{Description: "payroll adjustment " + d.Status, Response: map[string]any{
+		"adjustment_id": a.ID, "user_id": a.UserID, "period_id": d.PeriodID, "by": d.ReviewedBy,
+	}})
+	a.Status = d.Status
+	a.PeriodID = d.PeriodID
+	a.ReviewedBy = &d.ReviewedBy
+	a.ReviewedAt = &d.ReviewedAt
+	a.ReviewNote = d.ReviewNote
+	resp := toAdjustmentResponse(a)
+	return &resp, nil
+}
@@ -0,0 +1,192 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	adjDTO "payslip-generation-system/internal/dto/adjustment"
+	"payslip-generation-system/internal/model"
+	adjRepo "payslip-generation-system/internal/repository/adjustment"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func TestCreatePriorPeriodAdjustment_CarriedIntoNextOpenPeriod(t *testing.T) {
+	july := &model.AttendancePeriod{
+		ID:        1,
+		StartDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+	}
+	aug := &model.AttendancePeriod{
+		ID:        2,
+		StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+	}
+	locked := true
+	apMock := &testm.APRepoMock{
+		ContainingFn: func(_ context.Context, date time.Time) (*model.AttendancePeriod, error) { return july, nil },
+		NextOpenFn: func(_ context.Context, after time.Time) (*apRepo.PeriodRow, error) {
+			require.True(t, after.Equal(july.EndDate))
+			return &apRepo.PeriodRow{AttendancePeriod: *aug}, nil
+		},
+	}
+	otMock := &testm.OTRepoMock{
+		ExistsFn: func(_ context.Context, userID uint, date time.Time) (bool, error) { return date.Day() == 15, nil },
+	}
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return locked, nil },
+	}
+	var created *model.PayrollAdjustment
+	adjMock := &testm.AdjustmentRepoMock{
+		HasOvertimeFn: func(_ context.Context, userID uint, date time.Time) (bool, error) { return false, nil },
+		CreateFn: func(_ context.Context, a *model.PayrollAdjustment) error {
+			a.ID = 3
+			created = a
+			return nil
+		},
+	}
+	u := usecase.NewForTest()
+	usecase.InjectForTest(u, apMock, nil, otMock, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, adjMock)
+	ctx := makeGinCtx()
+
+	// overtime tanggal itu sudah dibayar di run sebelumnya
+	_, err := u.CreatePriorPeriodAdjustment(ctx, 7, adjDTO.CreateAdjustmentRequest{Kind: "overtime", Date: "2025-07-15", Hours: 2})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already been submitted")
+
+	resp, err := u.CreatePriorPeriodAdjustment(ctx, 7, adjDTO.CreateAdjustmentRequest{Kind: "overtime", Date: "2025-07-16", Hours: 2, Description: "late submission"})
+	require.NoError(t, err)
+	require.Equal(t, uint(2), resp.PeriodID)
+	require.Equal(t, uint(1), *resp.OriginalPeriodID)
+	require.Equal(t, 2.0, created.Hours)
+	require.Equal(t, uint(7), created.CreatedBy)
+	require.Equal(t, model.AdjustmentStatusPending, resp.Status)
+
+	// period belum terkunci: submit biasa saja
+	locked = false
+	_, err = u.CreatePriorPeriodAdjustment(ctx, 7, adjDTO.CreateAdjustmentRequest{Kind: "reimbursement", Date: "2025-08-04", Amount: 50000})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not in a locked period")
+}
+
+func TestRunPayroll_IncludesPriorPeriodAdjustments(t *testing.T) {
+	u := usecase.NewForTest()
+	payMock := &testm.PayRepoMock{
+		GetPeriodByIDFn: func(_ context.Context, id uint) (*model.AttendancePeriod, error) {
+			return &model.AttendancePeriod{
+				ID:        id,
+				StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+			}, nil
+		},
+		HasRunForPeriodFn:         func(_ context.Context, periodID uint) (bool, error) { return false, nil },
+		GetAttendanceDaysByUserFn: func(_ context.Context, s, e time.Time) (map[uint]int, error) { return map[uint]int{7: 20}, nil },
+		GetOvertimeHoursByUserFn:  func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return map[uint]float64{}, nil },
+		GetReimbTotalByUserFn:     func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return map[uint]float64{}, nil },
+		GetUserSalariesFn:         func(_ context.Context) (map[uint]float64, error) { return map[uint]float64{7: 7000000}, nil },
+		CreateRunFn:               func(_ context.Context, run *model.PayrollRun, items []*model.PayrollItem) error { return nil },
+		AddRunEventFn:             func(_ context.Context, ev *model.PayrollRunEvent) error { return nil },
+	}
+	adjMock := &testm.AdjustmentRepoMock{
+		ListByPeriodFn: func(_ context.Context, periodID, userID uint, status string) ([]model.PayrollAdjustment, error) {
+			require.Equal(t, model.AdjustmentStatusApproved, status)
+			return []model.PayrollAdjustment{
+				{ID: 1, UserID: 7, PeriodID: periodID, Kind: model.AdjustmentKindOvertime, Hours: 2, OriginalDate: time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC)},
+				{ID: 2, UserID: 7, PeriodID: periodID, Kind: model.AdjustmentKindReimbursement, Amount: 50000, OriginalDate: time.Date(2025, 7, 18, 0, 0, 0, 0, time.UTC)},
+			}, nil
+		},
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, adjMock)
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{
+		ListByUsersFn: func(_ context.Context, ids []uint) (map[uint]*model.EmployeeBankAccount, error) {
+			return map[uint]*model.EmployeeBankAccount{}, nil
+		},
+	}, testm.NewFieldCipher())
+
+	ctx := makeGinCtx()
+	_, items, err := u.RunPayroll(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	item := items[0]
+	hourly := 7000000 / float64(item.WorkingHours)
+	require.Equal(t, 2.0, item.AdjustmentOvertimeHours)
+	require.InDelta(t, 50000+2*hourly*2, item.AdjustmentTotal, 0.01)
+	require.Zero(t, item.OvertimePay)
+	require.Zero(t, item.ReimbursementTotal)
+	require.InDelta(t, item.BasePay+item.AdjustmentTotal, item.GrandTotal, 0.01)
+}
+
+func TestReviewPriorPeriodAdjustment_AdminDecidesAndCarriesPastSubmittedRun(t *testing.T) {
+	rows := map[uint]*model.PayrollAdjustment{
+		4: {ID: 4, UserID: 7, PeriodID: 3, Kind: model.AdjustmentKindReimbursement, Amount: 50000, Status: model.AdjustmentStatusPending},
+		5: {ID: 5, UserID: 7, PeriodID: 3, Kind: model.AdjustmentKindOvertime, Hours: 2, Status: model.AdjustmentStatusPending},
+	}
+	var decided []adjRepo.Decision
+	adjMock := &testm.AdjustmentRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.PayrollAdjustment, error) {
+			if a, ok := rows[id]; ok {
+				cp := *a
+				return &cp, nil
+			}
+			return nil, nil
+		},
+		DecideFn: func(_ context.Context, id uint, d adjRepo.Decision) (bool, error) {
+			rows[id].Status, rows[id].PeriodID = d.Status, d.PeriodID
+			decided = append(decided, d)
+			return true, nil
+		},
+	}
+	// run period 3 (Agustus) sudah diajukan selama adjustment menunggu review; period 4 punya run draft
+	apMock := &testm.APRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*apRepo.PeriodRow, error) {
+			p := historyPeriod(model.PayrollRunStatusPendingApproval)
+			return &p, nil
+		},
+		NextOpenFn: func(_ context.Context, after time.Time) (*apRepo.PeriodRow, error) {
+			require.Equal(t, "2025-08-31", after.Format("2006-01-02"))
+			return &apRepo.PeriodRow{AttendancePeriod: model.AttendancePeriod{ID: 4}, RunStatus: model.PayrollRunStatusDraft}, nil
+		},
+	}
+	var stale []uint
+	payMock := &testm.PayRepoMock{
+		MarkRunStaleFn: func(_ context.Context, periodID uint) (bool, error) {
+			stale = append(stale, periodID)
+			return true, nil
+		},
+	}
+	u := usecase.NewForTest()
+	usecase.InjectForTest(u, apMock, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, adjMock)
+	ctx := makeGinCtx()
+
+	_, err := u.ApprovePriorPeriodAdjustment(ctx, 7, 4, adjDTO.ReviewAdjustmentRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "your own")
+	_, err = u.RejectPriorPeriodAdjustment(ctx, 1, 5, adjDTO.ReviewAdjustmentRequest{Note: " "})
+	require.Error(t, err)
+
+	resp, err := u.ApprovePriorPeriodAdjustment(ctx, 1, 4, adjDTO.ReviewAdjustmentRequest{Note: "receipt ok"})
+	require.NoError(t, err)
+	require.Equal(t, model.AdjustmentStatusApproved, resp.Status)
+	require.Equal(t, uint(4), resp.PeriodID)
+	require.Equal(t, uint(1), *resp.ReviewedBy)
+	require.Equal(t, []uint{4}, stale)
+
+	resp, err = u.RejectPriorPeriodAdjustment(ctx, 1, 5, adjDTO.ReviewAdjustmentRequest{Note: "no overtime plan"})
+	require.NoError(t, err)
+	require.Equal(t, model.AdjustmentStatusRejected, resp.Status)
+	require.Equal(t, uint(3), resp.PeriodID)
+	require.Len(t, decided, 2)
+	require.Len(t, stale, 1)
+
+	_, err = u.ApprovePriorPeriodAdjustment(ctx, 1, 5, adjDTO.ReviewAdjustmentRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already reviewed")
+}
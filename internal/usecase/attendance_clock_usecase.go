@@ -0,0 +1,229 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// jam kerja standar per hari hadir
+const hoursPerDay = 8
+
+func (u *usecase) clock() time.Time {
+	if u.now != nil {
+		return u.now()
+	}
+	return time.Now()
+}
+
+// workSchedule: jam masuk & pulang (WIB) pada tanggal day dari config attendance (default 09:00-17:00).
+func (u *usecase) workSchedule(day time.Time) (start, end time.Time, grace time.Duration) {
+	startStr, endStr := "09:00", "17:00"
+	if u.cfg != nil {
+		if u.cfg.Attendance.WorkStart != "" {
+			startStr = u.cfg.Attendance.WorkStart
+		}
+		if u.cfg.Attendance.WorkEnd != "" {
+			endStr = u.cfg.Attendance.WorkEnd
+		}
+		grace = u.cfg.Attendance.LateGrace
+	}
+	at := func(hhmm, fallback string) time.Time {
+		t, err := time.Parse("15:04", hhmm)
+		if err != nil {
+			t, _ = time.Parse("15:04", fallback)
+		}
+		return time.Date(day.Year(), day.Month(), day.Day(), t.Hour(), t.Minute(), 0, 0, day.Location())
+	}
+	return at(startStr, "09:00"), at(endStr, "17:00"), grace
+}
+
+// CheckIn mencatat jam masuk hari ini (WIB). Terlambat jika lewat jam masuk + grace.
+// Lokasi & IP dicatat dan dicek terhadap geofence kantor (attendance.geofence.mode).
+func (u *usecase) CheckIn(ctx *gin.Context, userID uint, loc atDTO.Location) (resp *atDTO.AttendanceResponse, err error) {
+	now := u.clock().In(time.FixedZone("WIB", 7*3600))
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if wd := date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		return nil, utils.MakeError(errorUc.BadRequest, "cannot submit attendance on weekend")
+	}
+	if err = u.ensureDateOpen(ctx, date); err != nil {
+		return nil, err
+	}
+	located := &model.Attendance{}
+	if err = u.locateAttendance(ctx, located, loc); err != nil {
+		return nil, err
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	row, err := u.atRepo.FindByUserDate(txCtx, userID, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row != nil && row.CheckInAt != nil {
+		return nil, utils.MakeError(errorUc.ConflictError, "already checked in today")
+	}
+	if row == nil {
+		// attendance lama (submit tanpa jam) pada hari yang sama dilengkapi jam masuknya
+		row = &model.Attendance{UserID: userID, Date: date}
+	}
+
+	copyLocation(row, located)
+	checkIn := now.UTC()
+	row.CheckInAt = &checkIn
+	start, _, grace := u.workSchedule(date)
+	if now.After(start.Add(grace)) {
+		row.IsLate = true
+		row.LateMinutes = int(now.Sub(start).Minutes())
+	}
+
+	ok, err := u.atRepo.CheckIn(txCtx, row)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to check in")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "already checked in today")
+	}
+
+	out := toAttendanceResponse(row)
+	return &out, nil
+}
+
+// CheckOut mencatat jam pulang hari ini dan menghitung jam kerja; pulang cepat jika sebelum jam pulang jadwal.
+func (u *usecase) CheckOut(ctx *gin.Context, userID uint) (resp *atDTO.AttendanceResponse, err error) {
+	now := u.clock().In(time.FixedZone("WIB", 7*3600))
+	date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if err = u.ensureDateOpen(ctx, date); err != nil {
+		return nil, err
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	row, err := u.atRepo.FindByUserDate(txCtx, userID, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil || row.CheckInAt == nil {
+		return nil, utils.MakeError(errorUc.BadRequest, "you have not checked in today")
+	}
+	if row.CheckOutAt != nil {
+		return nil, utils.MakeError(errorUc.ConflictError, "already checked out today")
+	}
+
+	checkOut := now.UTC()
+	row.CheckOutAt = &checkOut
+	row.WorkedMinutes = int(checkOut.Sub(*row.CheckInAt).Minutes())
+	_, end, _ := u.workSchedule(date)
+	if now.Before(end) {
+		row.IsEarlyLeave = true
+		row.EarlyLeaveMinutes = int(math.Ceil(end.Sub(now).Minutes()))
+	}
+
+	ok, err := u.atRepo.CheckOut(txCtx, row)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to check out")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "already checked out today")
+	}
+
+	out := toAttendanceResponse(row)
+	return &out, nil
+}
+
+func (u *usecase) ensureDateOpen(ctx context.Context, date time.Time) error {
+	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if locked {
+		return utils.MakeError(errorUc.BadRequest, "attendance period is closed; submissions are locked")
+	}
+	return nil
+}
+
+func toAttendanceResponse(a *model.Attendance) atDTO.AttendanceResponse {
+	return atDTO.AttendanceResponse{
+		ID:                a.ID,
+		UserID:            a.UserID,
+		Date:              a.Date.Format("2006-01-02"),
+		CheckInAt:         a.CheckInAt,
+		CheckOutAt:        a.CheckOutAt,
+		WorkedHours:       round2(float64(a.WorkedMinutes) / 60),
+		IsLate:            a.IsLate,
+		LateMinutes:       a.LateMinutes,
+		IsEarlyLeave:      a.IsEarlyLeave,
+		EarlyLeaveMinutes: a.EarlyLeaveMinutes,
+	}
+}
+
+// basePayMode: payroll.basePayMode, default days.
+func (u *usecase) basePayMode() string {
+	if u.cfg != nil && u.cfg.Payroll.BasePayMode == model.BasePayModeHours {
+		return model.BasePayModeHours
+	}
+	return model.BasePayModeDays
+}
+
+// unpaidHours menghitung jam yang dikurangi dari (hari hadir * 8) per user berdasarkan attendance ber-jam.
+// Mode hours: hari tanpa check-out tidak dibayar, selain itu dibayar jam kerja aktual (maks 8 jam).
+// Mode days + proratePartialDays: dipotong menit terlambat & pulang cepat.
+// Attendance lama tanpa check-in tetap dihitung 8 jam. Mengembalikan nil jika tidak ada yang perlu dipotong.
+func (u *usecase) unpaidHours(ctx context.Context, userID uint, start, end time.Time) (map[uint]float64, error) {
+	mode := u.basePayMode()
+	prorate := u.cfg != nil && u.cfg.Attendance.ProratePartialDays
+	if mode == model.BasePayModeDays && !prorate {
+		return nil, nil
+	}
+	rows, err := u.payrollRepo.ListTimedAttendances(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	out := map[uint]float64{}
+	for _, a := range rows {
+		var paid float64
+		if mode == model.BasePayModeHours {
+			if a.CheckOutAt != nil {
+				paid = math.Min(float64(a.WorkedMinutes)/60, hoursPerDay)
+			}
+		} else {
+			paid = math.Max(hoursPerDay-float64(a.LateMinutes+a.EarlyLeaveMinutes)/60, 0)
+		}
+		out[a.UserID] += hoursPerDay - paid
+	}
+	return out, nil
+}
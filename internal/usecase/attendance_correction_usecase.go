@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	acRepo "payslip-generation-system/internal/repository/attendancecorrection"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func toCorrectionResponse(c *model.AttendanceCorrection) atDTO.CorrectionResponse {
+	return atDTO.CorrectionResponse{
+		ID:           c.ID,
+		UserID:       c.UserID,
+		Date:         c.Date.Format("2006-01-02"),
+		Action:       c.Action,
+		Reason:       c.Reason,
+		Status:       c.Status,
+		ReviewedBy:   c.ReviewedBy,
+		ReviewedAt:   c.ReviewedAt,
+		ReviewNote:   c.ReviewNote,
+		AttendanceID: c.AttendanceID,
+		CreatedAt:    c.CreatedAt,
+	}
+}
+
+// ensureCorrectionOpen: koreksi untuk tanggal di period yang sudah terkunci ditolak.
+func (u *usecase) ensureCorrectionOpen(ctx context.Context, date time.Time) error {
+	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if locked {
+		return utils.MakeError(errorUc.BadRequest, "attendance period is closed; corrections are not allowed")
+	}
+	return nil
+}
+
+// RequestAttendanceCorrection: karyawan mengajukan tambah / hapus attendance pada satu tanggal.
+func (u *usecase) RequestAttendanceCorrection(ctx *gin.Context, userID uint, req atDTO.CreateCorrectionRequest) (*atDTO.CorrectionResponse, error) {
+	wib := time.FixedZone("WIB", 7*3600)
+	date, err := time.ParseInLocation("2006-01-02", req.Date, wib)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "date")
+	}
+	now := u.clock().In(wib)
+	if date.After(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, wib)) {
+		return nil, utils.MakeError(errorUc.BadRequest, "cannot correct attendance for a future date")
+	}
+	if err = u.ensureCorrectionOpen(ctx, date); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.atRepo.FindByUserDate(ctx, userID, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	switch req.Action {
+	case model.CorrectionActionAdd:
+		if wd := date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			return nil, utils.MakeError(errorUc.BadRequest, "cannot submit attendance on weekend")
+		}
+		if existing != nil {
+			return nil, utils.MakeError(errorUc.ConflictError, "attendance already exists on that date")
+		}
+	case model.CorrectionActionRemove:
+		if existing == nil {
+			return nil, utils.MakeError(errorUc.BadRequest, "there is no attendance on that date")
+		}
+	}
+
+	pending, err := u.correctionRepo.HasPending(ctx, userID, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if pending {
+		return nil, utils.MakeError(errorUc.ConflictError, "a correction for that date is already pending")
+	}
+
+	row := &model.AttendanceCorrection{
+		UserID: userID,
+		Date:   date,
+		Action: req.Action,
+		Reason: strings.TrimSpace(req.Reason),
+		Status: model.CorrectionStatusPending,
+	}
+	if err = u.correctionRepo.Create(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to create attendance correction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to create correction request")
+	}
+	resp := toCorrectionResponse(row)
+	return &resp, nil
+}
+
+// ListAttendanceCorrections: userID != 0 membatasi ke koreksi milik user tsb (endpoint karyawan).
+func (u *usecase) ListAttendanceCorrections(ctx *gin.Context, userID uint, q atDTO.ListCorrectionsQuery) ([]atDTO.CorrectionResponse, *utils.Metadata, error) {
+	page := q.Pagination.Normalize()
+	f := acRepo.ListFilter{UserID: q.UserID, Status: q.Status, Offset: page.Offset(), Limit: page.PageSize}
+	if userID != 0 {
+		f.UserID = userID
+	}
+	rows, total, err := u.correctionRepo.List(ctx, f)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list attendance corrections"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	out := make([]atDTO.CorrectionResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toCorrectionResponse(&rows[i]))
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+// ApproveAttendanceCorrection menerapkan koreksi (buat / hapus attendance) dan mencatat audit dalam satu transaksi.
+// Peninjau tidak boleh karyawan yang mengajukan.
+func (u *usecase) ApproveAttendanceCorrection(ctx *gin.Context, actorID, correctionID uint, req atDTO.ReviewCorrectionRequest) (resp *atDTO.CorrectionResponse, err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	c, err := u.findPendingCorrection(txCtx, actorID, correctionID)
+	if err != nil {
+		return nil, err
+	}
+	// period bisa saja terkunci setelah koreksi diajukan
+	if err = u.ensureCorrectionOpen(txCtx, c.Date); err != nil {
+		return nil, err
+	}
+
+	var attendanceID uint
+	auditAction := model.AttendanceAuditCreated
+	switch c.Action {
+	case model.CorrectionActionAdd:
+		var row *model.Attendance
+		var existed bool
+		row, existed, err = u.atRepo.CreateIfNotExists(txCtx, c.UserID, c.Date)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to create attendance")
+		}
+		if existed {
+			return nil, utils.MakeError(errorUc.ConflictError, "attendance already exists on that date")
+		}
+		attendanceID = row.ID
+	case model.CorrectionActionRemove:
+		var row *model.Attendance
+		row, err = u.atRepo.FindByUserDate(txCtx, c.UserID, c.Date)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if row == nil {
+			return nil, utils.MakeError(errorUc.ConflictError, "attendance no longer exists on that date")
+		}
+		if err = u.atRepo.Delete(txCtx, row.ID); err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to delete attendance")
+		}
+		attendanceID = row.ID
+		auditAction = model.AttendanceAuditDeleted
+	}
+
+	if err = u.atRepo.AddAudit(txCtx, &model.AttendanceAudit{
+		AttendanceID: attendanceID,
+		UserID:       c.UserID,
+		Date:         c.Date,
+		Action:       auditAction,
+		Source:       model.AttendanceAuditSourceCorrection,
+		SourceID:     &c.ID,
+		ActorID:      actorID,
+		Reason:       c.Reason,
+	}); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to write attendance audit"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to write attendance audit")
+	}
+
+	return u.decideCorrection(txCtx, c, acRepo.Decision{
+		Status:       model.CorrectionStatusApproved,
+		ReviewedBy:   actorID,
+		ReviewedAt:   time.Now().UTC(),
+		ReviewNote:   strings.TrimSpace(req.Note),
+		AttendanceID: &attendanceID,
+	})
+}
+
+// RejectAttendanceCorrection: attendance tidak diubah; alasan penolakan wajib diisi.
+func (u *usecase) RejectAttendanceCorrection(ctx *gin.Context, actorID, correctionID uint, req atDTO.ReviewCorrectionRequest) (*atDTO.CorrectionResponse, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, utils.MakeError(errorUc.InvalidMandatory, "note")
+	}
+	c, err := u.findPendingCorrection(ctx, actorID, correctionID)
+	if err != nil {
+		return nil, err
+	}
+	return u.decideCorrection(ctx, c, acRepo.Decision{
+		Status:     model.CorrectionStatusRejected,
+		ReviewedBy: actorID,
+		ReviewedAt: time.Now().UTC(),
+		ReviewNote: note,
+	})
+}
+
+func (u *usecase) findPendingCorrection(ctx context.Context, actorID, correctionID uint) (*model.AttendanceCorrection, error) {
+	c, err := u.correctionRepo.FindByID(ctx, correctionID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendance correction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if c == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "correction request not found")
+	}
+	if c.Status != model.CorrectionStatusPending {
+		return nil, utils.MakeError(errorUc.ConflictError, "correction request already reviewed")
+	}
+	if c.UserID == actorID {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "you cannot review your own correction request")
+	}
+	return c, nil
+}
+
+func (u *usecase) decideCorrection(ctx context.Context, c *model.AttendanceCorrection, d acRepo.Decision) (*atDTO.CorrectionResponse, error) {
+	ok, err := u.correctionRepo.Decide(ctx, c.ID, d)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update attendance correction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update correction request")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "correction request already reviewed")
+	}
+
+	u.log.Info(log.LogData{Description: "attendance correction " + d.Status, Response: map[string]any{
+		"correction_id": c.ID, "user_id": c.UserID, "action": c.Action, "by": d.ReviewedBy,
+	}})
+	c.Status = d.Status
+	c.ReviewedBy = &d.ReviewedBy
+	c.ReviewedAt = &d.ReviewedAt
+	c.ReviewNote = d.ReviewNote
+	c.AttendanceID = d.AttendanceID
+	resp := toCorrectionResponse(c)
+	return &resp, nil
+}
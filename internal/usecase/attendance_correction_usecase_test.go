@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	"payslip-generation-system/internal/model"
+	acRepo "payslip-generation-system/internal/repository/attendancecorrection"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func correctionUsecase(at *testm.ATRepoMock, corrections *testm.CorrectionRepoMock, locked bool) usecase.IUsecase {
+	u := usecase.NewForTest()
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return locked, nil },
+	}
+	usecase.InjectForTest(u, nil, at, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectCorrectionForTest(u, corrections)
+	usecase.InjectClockForTest(u, func() time.Time { return time.Date(2025, 8, 20, 10, 0, 0, 0, time.UTC) })
+	return u
+}
+
+func TestRequestAttendanceCorrection_Validation(t *testing.T) {
+	var created *model.AttendanceCorrection
+	atMock := &testm.ATRepoMock{
+		FindByUserDateFn: func(_ context.Context, userID uint, date time.Time) (*model.Attendance, error) {
+			if date.Day() == 19 {
+				return &model.Attendance{ID: 5, UserID: userID, Date: date}, nil
+			}
+			return nil, nil
+		},
+	}
+	corrections := &testm.CorrectionRepoMock{
+		HasPendingFn: func(_ context.Context, userID uint, date time.Time) (bool, error) { return false, nil },
+		CreateFn: func(_ context.Context, c *model.AttendanceCorrection) error {
+			c.ID = 1
+			created = c
+			return nil
+		},
+	}
+	u := correctionUsecase(atMock, corrections, false)
+	ctx := makeGinCtx()
+
+	_, err := u.RequestAttendanceCorrection(ctx, 42, atDTO.CreateCorrectionRequest{Date: "2025-08-19", Action: "add", Reason: "forgot to clock in"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already exists")
+
+	_, err = u.RequestAttendanceCorrection(ctx, 42, atDTO.CreateCorrectionRequest{Date: "2025-08-21", Action: "add", Reason: "forgot to clock in"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "future date")
+
+	_, err = u.RequestAttendanceCorrection(ctx, 42, atDTO.CreateCorrectionRequest{Date: "2025-08-18", Action: "remove", Reason: "submitted wrong day"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no attendance")
+
+	resp, err := u.RequestAttendanceCorrection(ctx, 42, atDTO.CreateCorrectionRequest{Date: "2025-08-18", Action: "add", Reason: "forgot to clock in"})
+	require.NoError(t, err)
+	require.Equal(t, model.CorrectionStatusPending, resp.Status)
+	require.Equal(t, "2025-08-18", created.Date.Format("2006-01-02"))
+
+	// period terkunci
+	u = correctionUsecase(atMock, corrections, true)
+	_, err = u.RequestAttendanceCorrection(ctx, 42, atDTO.CreateCorrectionRequest{Date: "2025-08-18", Action: "add", Reason: "forgot to clock in"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "corrections are not allowed")
+}
+
+func TestApproveAttendanceCorrection_AppliesWithAudit(t *testing.T) {
+	date := time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC)
+	pending := model.AttendanceCorrection{ID: 9, UserID: 42, Date: date, Action: model.CorrectionActionRemove, Reason: "submitted wrong day", Status: model.CorrectionStatusPending}
+
+	var deleted uint
+	var audit *model.AttendanceAudit
+	atMock := &testm.ATRepoMock{
+		FindByUserDateFn: func(_ context.Context, userID uint, d time.Time) (*model.Attendance, error) {
+			return &model.Attendance{ID: 77, UserID: userID, Date: d}, nil
+		},
+		DeleteFn: func(_ context.Context, id uint) error {
+			deleted = id
+			return nil
+		},
+		AddAuditFn: func(_ context.Context, a *model.AttendanceAudit) error {
+			audit = a
+			return nil
+		},
+	}
+	var decision acRepo.Decision
+	corrections := &testm.CorrectionRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.AttendanceCorrection, error) {
+			c := pending
+			return &c, nil
+		},
+		DecideFn: func(_ context.Context, id uint, d acRepo.Decision) (bool, error) {
+			decision = d
+			return true, nil
+		},
+	}
+	u := correctionUsecase(atMock, corrections, false)
+	ctx := makeGinCtx()
+
+	// pengaju tidak boleh menyetujui koreksinya sendiri
+	_, err := u.ApproveAttendanceCorrection(ctx, 42, 9, atDTO.ReviewCorrectionRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "your own")
+	require.Zero(t, deleted)
+
+	_, err = u.RejectAttendanceCorrection(ctx, 1, 9, atDTO.ReviewCorrectionRequest{})
+	require.Error(t, err)
+
+	resp, err := u.ApproveAttendanceCorrection(ctx, 1, 9, atDTO.ReviewCorrectionRequest{Note: "checked with team lead"})
+	require.NoError(t, err)
+	require.Equal(t, model.CorrectionStatusApproved, resp.Status)
+	require.Equal(t, uint(77), deleted)
+	require.Equal(t, model.AttendanceAuditDeleted, audit.Action)
+	require.Equal(t, model.AttendanceAuditSourceCorrection, audit.Source)
+	require.Equal(t, uint(9), *audit.SourceID)
+	require.Equal(t, uint(1), audit.ActorID)
+	require.Equal(t, uint(77), *decision.AttendanceID)
+	require.Equal(t, uint(1), decision.ReviewedBy)
+}
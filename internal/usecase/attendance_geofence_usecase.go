@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"math"
+
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/geofence"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// geofenceMode: attendance.geofence.mode, default off (lokasi & IP tetap dicatat).
+func (u *usecase) geofenceMode() string {
+	if u.cfg == nil {
+		return geofence.ModeOff
+	}
+	switch u.cfg.Attendance.Geofence.Mode {
+	case geofence.ModeFlag, geofence.ModeReject:
+		return u.cfg.Attendance.Geofence.Mode
+	default:
+		return geofence.ModeOff
+	}
+}
+
+// locateAttendance mengisi lokasi, IP & hasil geofence ke row.
+// Mode reject menolak submission di luar semua kantor aktif; mode flag hanya menandainya.
+func (u *usecase) locateAttendance(ctx context.Context, row *model.Attendance, loc atDTO.Location) error {
+	row.Latitude, row.Longitude, row.ClientIP = loc.Latitude, loc.Longitude, loc.ClientIP
+
+	mode := u.geofenceMode()
+	if mode == geofence.ModeOff {
+		return nil
+	}
+	offices, err := u.officeLocRepo.List(ctx, true)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load office locations"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	zones := make([]geofence.Zone, 0, len(offices))
+	for _, o := range offices {
+		ranges, perr := geofence.ParseIPRanges(o.AllowedIPRanges)
+		if perr != nil {
+			// sudah divalidasi saat disimpan; data lama yang rusak cukup di-log
+			u.log.Error(log.LogData{Err: perr, Description: "invalid office location ip ranges", Response: o.ID})
+		}
+		zones = append(zones, geofence.Zone{
+			ID:           o.ID,
+			Latitude:     o.Latitude,
+			Longitude:    o.Longitude,
+			RadiusMeters: float64(o.RadiusMeters),
+			IPRanges:     ranges,
+		})
+	}
+
+	res := geofence.Evaluate(zones, loc.Latitude, loc.Longitude, loc.ClientIP)
+	if res.ZoneID != 0 {
+		zoneID := res.ZoneID
+		row.OfficeLocationID = &zoneID
+	}
+	if res.DistanceMeters != nil {
+		d := math.Round(*res.DistanceMeters*10) / 10
+		row.DistanceMeters = &d
+	}
+	if res.Allowed {
+		return nil
+	}
+	if mode == geofence.ModeReject {
+		return utils.MakeError(errorUc.BadRequest, "attendance location is outside allowed office locations")
+	}
+	row.LocationFlagged = true
+	row.LocationFlagReason = res.Reason()
+	return nil
+}
+
+// hasLocation: ada data lokasi / IP / hasil geofence yang perlu disimpan.
+func hasLocation(a *model.Attendance) bool {
+	return a.Latitude != nil || a.ClientIP != "" || a.OfficeLocationID != nil || a.LocationFlagged
+}
+
+func copyLocation(dst, src *model.Attendance) {
+	dst.Latitude, dst.Longitude, dst.ClientIP = src.Latitude, src.Longitude, src.ClientIP
+	dst.OfficeLocationID, dst.DistanceMeters = src.OfficeLocationID, src.DistanceMeters
+	dst.LocationFlagged, dst.LocationFlagReason = src.LocationFlagged, src.LocationFlagReason
+}
+
+// ListFlaggedAttendances: laporan attendance di luar geofence dalam rentang tanggal period.
+func (u *usecase) ListFlaggedAttendances(ctx *gin.Context, periodID uint, q atDTO.FlaggedAttendanceQuery) ([]atDTO.FlaggedAttendanceResponse, *utils.Metadata, error) {
+	period, err := u.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := q.Pagination.Normalize()
+	rows, total, err := u.atRepo.ListFlagged(ctx, period.StartDate, period.EndDate, page.Offset(), page.PageSize)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list flagged attendances"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	out := make([]atDTO.FlaggedAttendanceResponse, 0, len(rows))
+	for _, a := range rows {
+		out = append(out, atDTO.FlaggedAttendanceResponse{
+			ID:               a.ID,
+			UserID:           a.UserID,
+			Date:             a.Date.Format("2006-01-02"),
+			CheckInAt:        a.CheckInAt,
+			Latitude:         a.Latitude,
+			Longitude:        a.Longitude,
+			ClientIP:         a.ClientIP,
+			OfficeLocationID: a.OfficeLocationID,
+			DistanceMeters:   a.DistanceMeters,
+			Reason:           a.LocationFlagReason,
+		})
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	apDTO "payslip-generation-system/internal/dto/attendance_period"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// periodStatus: closed jika ditutup manual atau run sudah approved ke atas,
+// running jika run masih draft/menunggu approval, selain itu open.
+func periodStatus(row *apRepo.PeriodRow) string {
+	switch {
+	case row.ClosedAt != nil:
+		return model.PeriodStatusClosed
+	case row.RunStatus == "":
+		return model.PeriodStatusOpen
+	case runIsFinal(&model.PayrollRun{Status: row.RunStatus}):
+		return model.PeriodStatusClosed
+	default:
+		return model.PeriodStatusRunning
+	}
+}
+
+func toPeriodResponse(row *apRepo.PeriodRow) apDTO.PeriodResponse {
+	return apDTO.PeriodResponse{
+		ID:        row.ID,
+		Name:      row.Name,
+		StartDate: row.StartDate.Format("2006-01-02"),
+		EndDate:   row.EndDate.Format("2006-01-02"),
+		Status:    periodStatus(row),
+		RunStatus: row.RunStatus,
+		ClosedAt:  row.ClosedAt,
+		ClosedBy:  row.ClosedBy,
+	}
+}
+
+func (u *usecase) ListAttendancePeriods(ctx *gin.Context, q apDTO.ListPeriodsQuery) ([]apDTO.PeriodResponse, *utils.Metadata, error) {
+	page := q.Pagination.Normalize()
+	rows, total, err := u.apRepo.List(ctx, apRepo.ListFilter{
+		Status: q.Status,
+		Offset: page.Offset(),
+		Limit:  page.PageSize,
+	})
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list attendance periods"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	out := make([]apDTO.PeriodResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toPeriodResponse(&rows[i]))
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+func (u *usecase) GetAttendancePeriod(ctx *gin.Context, periodID uint) (*apDTO.PeriodResponse, error) {
+	row, err := u.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	resp := toPeriodResponse(row)
+	return &resp, nil
+}
+
+// UpdateAttendancePeriod mengubah nama/tanggal period yang belum di-run dan belum ditutup.
+func (u *usecase) UpdateAttendancePeriod(ctx *gin.Context, periodID uint, req apDTO.UpdatePeriodRequest) (resp *apDTO.PeriodResponse, err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	row, err := u.findPeriod(txCtx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if periodStatus(row) != model.PeriodStatusOpen {
+		return nil, utils.MakeError(errorUc.ConflictError, "attendance period can only be changed before payroll is run")
+	}
+
+	if req.Name != nil {
+		row.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.StartDate != nil {
+		var d time.Time
+		d, err = time.Parse("2006-01-02", *req.StartDate)
+		if err != nil {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "start_date")
+		}
+		row.StartDate = d
+	}
+	if req.EndDate != nil {
+		var d time.Time
+		d, err = time.Parse("2006-01-02", *req.EndDate)
+		if err != nil {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "end_date")
+		}
+		row.EndDate = d
+	}
+	if row.EndDate.Before(row.StartDate) {
+		return nil, utils.MakeError(errorUc.BadRequest, "end_date must be >= start_date")
+	}
+
+	overlap, err := u.apRepo.IsOverlappingExcluding(txCtx, row.StartDate, row.EndDate, row.ID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if overlap {
+		return nil, utils.MakeError(errorUc.BadRequest, "period overlaps existing payroll period")
+	}
+	if err = u.apRepo.Update(txCtx, &row.AttendancePeriod); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update attendance period"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update period")
+	}
+
+	out := toPeriodResponse(row)
+	return &out, nil
+}
+
+// CloseAttendancePeriod menutup period secara manual; submission di rentang period langsung terkunci.
+func (u *usecase) CloseAttendancePeriod(ctx *gin.Context, actorID, periodID uint) (*apDTO.PeriodResponse, error) {
+	row, err := u.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	if periodStatus(row) == model.PeriodStatusClosed {
+		return nil, utils.MakeError(errorUc.ConflictError, "attendance period is already closed")
+	}
+
+	now := time.Now().UTC()
+	ok, err := u.apRepo.Close(ctx, periodID, actorID, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to close attendance period"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to close period")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "attendance period is already closed")
+	}
+
+	u.log.Info(log.LogData{Description: "attendance period closed", Response: map[string]any{
+		"period_id": periodID, "by": actorID,
+	}})
+	row.ClosedAt = &now
+	row.ClosedBy = &actorID
+	resp := toPeriodResponse(row)
+	return &resp, nil
+}
+
+// DeleteAttendancePeriod hanya untuk period yang salah dibuat: belum di-run dan belum ada submission.
+func (u *usecase) DeleteAttendancePeriod(ctx *gin.Context, periodID uint) (err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	row, err := u.findPeriod(txCtx, periodID)
+	if err != nil {
+		return err
+	}
+	if row.RunStatus != "" {
+		return utils.MakeError(errorUc.ConflictError, "attendance period already has a payroll run")
+	}
+	used, err := u.apRepo.HasSubmissions(txCtx, row.StartDate, row.EndDate)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to check period submissions"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if used {
+		return utils.MakeError(errorUc.ConflictError, "attendance period already has submissions")
+	}
+	if err = u.apRepo.Delete(txCtx, periodID); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to delete attendance period"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to delete period")
+	}
+	return nil
+}
+
+func (u *usecase) findPeriod(ctx context.Context, periodID uint) (*apRepo.PeriodRow, error) {
+	row, err := u.apRepo.FindByID(ctx, periodID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendance period"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "attendance period not found")
+	}
+	return row, nil
+}
@@ -7,7 +7,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	apDTO "payslip-generation-system/internal/dto/attendance_period"
 	"payslip-generation-system/internal/model"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
 	"payslip-generation-system/internal/usecase"
 	testm "payslip-generation-system/internal/usecase/test"
 )
@@ -62,3 +64,109 @@ func TestCreateAttendancePeriod_Overlap(t *testing.T) {
 	require.Nil(t, row)
 	require.Contains(t, err.Error(), "overlap")
 }
+
+func TestUpdateAttendancePeriod_OnlyBeforeRun(t *testing.T) {
+	u := usecase.NewForTest()
+	row := &apRepo.PeriodRow{AttendancePeriod: model.AttendancePeriod{
+		ID:        3,
+		Name:      "Agustus",
+		StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+	}}
+	var updated *model.AttendancePeriod
+	apMock := &testm.APRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*apRepo.PeriodRow, error) {
+			cp := *row
+			return &cp, nil
+		},
+		OverlapExcludingFn: func(_ context.Context, start, end time.Time, excludeID uint) (bool, error) {
+			require.Equal(t, uint(3), excludeID)
+			return end.After(time.Date(2025, 9, 5, 0, 0, 0, 0, time.UTC)), nil
+		},
+		UpdateFn: func(_ context.Context, p *model.AttendancePeriod) error {
+			updated = p
+			return nil
+		},
+	}
+	usecase.InjectForTest(u, apMock, nil, nil, nil, nil, testm.FakeTxManager{})
+	ctx := makeGinCtx()
+
+	end := "2025-08-30"
+	resp, err := u.UpdateAttendancePeriod(ctx, 3, apDTO.UpdatePeriodRequest{EndDate: &end})
+	require.NoError(t, err)
+	require.Equal(t, "2025-08-30", resp.EndDate)
+	require.Equal(t, model.PeriodStatusOpen, resp.Status)
+	require.Equal(t, "Agustus", updated.Name)
+
+	// tidak boleh overlap period berikutnya
+	end = "2025-09-10"
+	_, err = u.UpdateAttendancePeriod(ctx, 3, apDTO.UpdatePeriodRequest{EndDate: &end})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overlap")
+
+	// setelah di-run tidak bisa diubah lagi
+	row.RunStatus = model.PayrollRunStatusDraft
+	name := "Agustus 2025"
+	_, err = u.UpdateAttendancePeriod(ctx, 3, apDTO.UpdatePeriodRequest{Name: &name})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "before payroll is run")
+}
+
+func TestDeleteAttendancePeriod_RejectsRunOrSubmissions(t *testing.T) {
+	u := usecase.NewForTest()
+	row := &apRepo.PeriodRow{AttendancePeriod: model.AttendancePeriod{ID: 3}, RunStatus: model.PayrollRunStatusApproved}
+	hasSubmissions := true
+	deleted := false
+	apMock := &testm.APRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*apRepo.PeriodRow, error) {
+			cp := *row
+			return &cp, nil
+		},
+		HasSubmissionsFn: func(_ context.Context, start, end time.Time) (bool, error) { return hasSubmissions, nil },
+		DeleteFn: func(_ context.Context, id uint) error {
+			deleted = true
+			return nil
+		},
+	}
+	usecase.InjectForTest(u, apMock, nil, nil, nil, nil, testm.FakeTxManager{})
+	ctx := makeGinCtx()
+
+	err := u.DeleteAttendancePeriod(ctx, 3)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "payroll run")
+
+	row.RunStatus = ""
+	err = u.DeleteAttendancePeriod(ctx, 3)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "submissions")
+	require.False(t, deleted)
+
+	hasSubmissions = false
+	require.NoError(t, u.DeleteAttendancePeriod(ctx, 3))
+	require.True(t, deleted)
+}
+
+func TestListAttendancePeriods_DerivesStatus(t *testing.T) {
+	u := usecase.NewForTest()
+	closedAt := time.Date(2025, 7, 31, 10, 0, 0, 0, time.UTC)
+	apMock := &testm.APRepoMock{
+		ListFn: func(_ context.Context, f apRepo.ListFilter) ([]apRepo.PeriodRow, int64, error) {
+			require.Equal(t, 20, f.Limit)
+			return []apRepo.PeriodRow{
+				{AttendancePeriod: model.AttendancePeriod{ID: 4}},
+				{AttendancePeriod: model.AttendancePeriod{ID: 3}, RunStatus: model.PayrollRunStatusPendingApproval},
+				{AttendancePeriod: model.AttendancePeriod{ID: 2}, RunStatus: model.PayrollRunStatusPaid},
+				{AttendancePeriod: model.AttendancePeriod{ID: 1, ClosedAt: &closedAt}},
+			}, 4, nil
+		},
+	}
+	usecase.InjectForTest(u, apMock, nil, nil, nil, nil, testm.FakeTxManager{})
+
+	rows, meta, err := u.ListAttendancePeriods(makeGinCtx(), apDTO.ListPeriodsQuery{})
+	require.NoError(t, err)
+	require.Equal(t, 4, meta.TotalData)
+	require.Equal(t, model.PeriodStatusOpen, rows[0].Status)
+	require.Equal(t, model.PeriodStatusRunning, rows[1].Status)
+	require.Equal(t, model.PeriodStatusClosed, rows[2].Status)
+	require.Equal(t, model.PeriodStatusClosed, rows[3].Status)
+}
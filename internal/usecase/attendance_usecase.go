@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	atDTO "payslip-generation-system/internal/dto/attendance"
 	errorUc "payslip-generation-system/internal/error"
 	"payslip-generation-system/internal/model"
 	"payslip-generation-system/pkg/log"
@@ -61,18 +62,16 @@ func (u *usecase) CreateAttendancePeriod(ctx *gin.Context, name, start, end stri
 	return row, nil
 }
 
-func (u *usecase) SubmitAttendance(ctx *gin.Context, userID uint, dateStr string) (*model.Attendance, bool, error) {
+func (u *usecase) SubmitAttendance(ctx *gin.Context, userID uint, dateStr string, loc atDTO.Location) (*model.Attendance, bool, error) {
+	return u.submitAttendance(ctx, userID, dateStr, &loc, nil, 0)
+}
+
+// submitAttendance: loc nil untuk sumber tepercaya tanpa lokasi (input admin / mesin absensi), geofence dilewati.
+// deviceID != 0: dibuat dari punch mesin absensi, dicatat di attendance_audits dengan source device.
+func (u *usecase) submitAttendance(ctx *gin.Context, userID uint, dateStr string, loc *atDTO.Location, by *onBehalf, deviceID uint) (*model.Attendance, bool, error) {
 	// default ke "hari ini" (WIB)
 	var date time.Time
 	var err error
-	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
-	if err != nil {
-		return nil, false, utils.MakeError(errorUc.InternalServerError, "db error")
-	}
-	if locked {
-		return nil, false, utils.MakeError(errorUc.BadRequest, "payroll already run for this period; submissions are locked")
-	}
-
 	if dateStr == "" {
 		now := time.Now().In(time.FixedZone("WIB", 7*3600))
 		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
@@ -89,6 +88,15 @@ func (u *usecase) SubmitAttendance(ctx *gin.Context, userID uint, dateStr string
 	if wd == time.Saturday || wd == time.Sunday {
 		return nil, false, utils.MakeError(errorUc.BadRequest, "cannot submit attendance on weekend")
 	}
+	if err = u.ensureDateOpen(ctx, date); err != nil {
+		return nil, false, err
+	}
+	located := &model.Attendance{}
+	if loc != nil {
+		if err = u.locateAttendance(ctx, located, *loc); err != nil {
+			return nil, false, err
+		}
+	}
 
 	txCtx, err := u.txManager.Begin(ctx)
 	if err != nil {
@@ -110,6 +118,34 @@ func (u *usecase) SubmitAttendance(ctx *gin.Context, userID uint, dateStr string
 		u.log.Error(log.LogData{Err: err})
 		return nil, false, utils.MakeError(errorUc.InternalServerError, "db error")
 	}
+	// lokasi hanya dicatat untuk submission pertama di hari itu
+	if !existed && hasLocation(located) {
+		copyLocation(row, located)
+		if err = u.atRepo.SetLocation(txCtx, row); err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, false, utils.MakeError(errorUc.InternalServerError, "failed to save attendance location")
+		}
+	}
+	if !existed && by != nil {
+		if err = u.atRepo.SetSubmitter(txCtx, row.ID, by.adminID, by.reason); err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, false, utils.MakeError(errorUc.InternalServerError, "failed to save attendance submitter")
+		}
+		by.apply(&row.SubmittedBy, &row.OnBehalfReason)
+	}
+	if !existed && deviceID != 0 {
+		if err = u.atRepo.AddAudit(txCtx, &model.AttendanceAudit{
+			AttendanceID: row.ID,
+			UserID:       userID,
+			Date:         date,
+			Action:       model.AttendanceAuditCreated,
+			Source:       model.AttendanceAuditSourceDevice,
+			SourceID:     &deviceID,
+		}); err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to write attendance audit"})
+			return nil, false, utils.MakeError(errorUc.InternalServerError, "failed to write attendance audit")
+		}
+	}
 
 	return row, existed, nil
 }
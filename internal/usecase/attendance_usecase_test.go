@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"payslip-generation-system/config"
+	atDTO "payslip-generation-system/internal/dto/attendance"
 	"payslip-generation-system/internal/model"
 	"payslip-generation-system/internal/usecase"
 	testm "payslip-generation-system/internal/usecase/test"
@@ -28,7 +30,7 @@ func TestSubmitAttendance_WeekendBlocked(t *testing.T) {
 	usecase.InjectForTest(u, nil, atMock, nil, nil, payMock, testm.FakeTxManager{})
 
 	ctx := makeGinCtx()
-	_, _, err := u.SubmitAttendance(ctx, 1, "2025-08-17") // Minggu
+	_, _, err := u.SubmitAttendance(ctx, 1, "2025-08-17", atDTO.Location{}) // Minggu
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "weekend")
 }
@@ -48,8 +50,144 @@ func TestSubmitAttendance_IdempotentSameDay(t *testing.T) {
 	usecase.InjectForTest(u, nil, atMock, nil, nil, payMock, testm.FakeTxManager{})
 
 	ctx := makeGinCtx()
-	row, existed, err := u.SubmitAttendance(ctx, 42, "2025-08-18")
+	row, existed, err := u.SubmitAttendance(ctx, 42, "2025-08-18", atDTO.Location{})
 	require.NoError(t, err)
 	require.True(t, existed)
 	require.Equal(t, uint(42), row.UserID)
 }
+
+func TestCheckInCheckOut_FlagsLateAndEarlyLeave(t *testing.T) {
+	u := usecase.NewForTest()
+	wib := time.FixedZone("WIB", 7*3600)
+	now := time.Date(2025, 8, 18, 9, 25, 0, 0, wib) // Senin
+	var stored *model.Attendance
+	atMock := &testm.ATRepoMock{
+		FindByUserDateFn: func(_ context.Context, userID uint, date time.Time) (*model.Attendance, error) {
+			if stored == nil {
+				return nil, nil
+			}
+			cp := *stored
+			return &cp, nil
+		},
+		CheckInFn: func(_ context.Context, row *model.Attendance) (bool, error) {
+			row.ID = 11
+			cp := *row
+			stored = &cp
+			return true, nil
+		},
+		CheckOutFn: func(_ context.Context, row *model.Attendance) (bool, error) {
+			cp := *row
+			stored = &cp
+			return true, nil
+		},
+	}
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return false, nil },
+	}
+	usecase.InjectForTest(u, nil, atMock, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectClockForTest(u, func() time.Time { return now })
+	cfg := &config.Config{}
+	cfg.Attendance.LateGrace = 10 * time.Minute
+	usecase.InjectConfigForTest(u, cfg)
+	ctx := makeGinCtx()
+
+	_, err := u.CheckOut(ctx, 42)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not checked in")
+
+	resp, err := u.CheckIn(ctx, 42, atDTO.Location{})
+	require.NoError(t, err)
+	require.Equal(t, "2025-08-18", resp.Date)
+	require.True(t, resp.IsLate)
+	require.Equal(t, 25, resp.LateMinutes)
+
+	_, err = u.CheckIn(ctx, 42, atDTO.Location{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already checked in")
+
+	now = time.Date(2025, 8, 18, 16, 30, 0, 0, wib)
+	resp, err = u.CheckOut(ctx, 42)
+	require.NoError(t, err)
+	require.True(t, resp.IsEarlyLeave)
+	require.Equal(t, 30, resp.EarlyLeaveMinutes)
+	require.Equal(t, 7.08, resp.WorkedHours)
+	require.Equal(t, 425, stored.WorkedMinutes)
+}
+
+func geofenceUsecase(t *testing.T, mode string, at *testm.ATRepoMock) usecase.IUsecase {
+	t.Helper()
+	u := usecase.NewForTest()
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return false, nil },
+	}
+	offices := &testm.OfficeLocationRepoMock{
+		ListFn: func(_ context.Context, activeOnly bool) ([]model.OfficeLocation, error) {
+			require.True(t, activeOnly)
+			return []model.OfficeLocation{{
+				ID: 3, Name: "Jakarta HQ", Latitude: -6.2, Longitude: 106.816666,
+				RadiusMeters: 200, AllowedIPRanges: "10.10.0.0/16", Active: true,
+			}}, nil
+		},
+	}
+	usecase.InjectForTest(u, nil, at, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectOfficeLocationForTest(u, offices)
+	cfg := &config.Config{}
+	cfg.Attendance.Geofence.Mode = mode
+	usecase.InjectConfigForTest(u, cfg)
+	return u
+}
+
+func TestSubmitAttendance_GeofenceFlagsOutsideOffice(t *testing.T) {
+	var saved *model.Attendance
+	atMock := &testm.ATRepoMock{
+		CreateIfNotExistsFn: func(_ context.Context, userID uint, date time.Time) (*model.Attendance, bool, error) {
+			return &model.Attendance{ID: 9, UserID: userID, Date: date}, false, nil
+		},
+		SetLocationFn: func(_ context.Context, row *model.Attendance) error {
+			cp := *row
+			saved = &cp
+			return nil
+		},
+	}
+	u := geofenceUsecase(t, "flag", atMock)
+	ctx := makeGinCtx()
+
+	// ~1.1 km dari kantor, IP di luar range
+	lat, lng := -6.21, 106.816666
+	_, existed, err := u.SubmitAttendance(ctx, 42, "2025-08-18", atDTO.Location{Latitude: &lat, Longitude: &lng, ClientIP: "203.0.113.9"})
+	require.NoError(t, err)
+	require.False(t, existed)
+	require.NotNil(t, saved)
+	require.True(t, saved.LocationFlagged)
+	require.Equal(t, "outside_radius,ip_not_allowed", saved.LocationFlagReason)
+	require.Equal(t, "203.0.113.9", saved.ClientIP)
+	require.Equal(t, uint(3), *saved.OfficeLocationID)
+	require.InDelta(t, 1112, *saved.DistanceMeters, 5)
+
+	// IP kantor lolos walau tanpa koordinat
+	saved = nil
+	_, _, err = u.SubmitAttendance(ctx, 42, "2025-08-19", atDTO.Location{ClientIP: "10.10.4.20"})
+	require.NoError(t, err)
+	require.False(t, saved.LocationFlagged)
+	require.Equal(t, uint(3), *saved.OfficeLocationID)
+}
+
+func TestCheckIn_GeofenceRejectsOutsideOffice(t *testing.T) {
+	atMock := &testm.ATRepoMock{
+		FindByUserDateFn: func(_ context.Context, userID uint, date time.Time) (*model.Attendance, error) {
+			return nil, nil
+		},
+		CheckInFn: func(_ context.Context, row *model.Attendance) (bool, error) {
+			t.Fatal("check-in outside the office must not be stored")
+			return false, nil
+		},
+	}
+	u := geofenceUsecase(t, "reject", atMock)
+	usecase.InjectClockForTest(u, func() time.Time {
+		return time.Date(2025, 8, 18, 8, 55, 0, 0, time.FixedZone("WIB", 7*3600))
+	})
+
+	_, err := u.CheckIn(makeGinCtx(), 42, atDTO.Location{ClientIP: "203.0.113.9"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "outside allowed office locations")
+}
@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+	tokenTypeAccess = "access"
+	amrPassword     = "pwd"
+	amrOTP          = "otp"
+
+	revokeReasonLogout     = "logout"
+	revokeReasonLogoutAll  = "logout_all_devices"
+	revokeReasonTokenReuse = "refresh_token_reuse"
+)
+
+func (u *usecase) accessTokenTTL() time.Duration {
+	if u.cfg != nil && u.cfg.Auth.AccessTokenTTL > 0 {
+		return u.cfg.Auth.AccessTokenTTL
+	}
+	return defaultAccessTokenTTL
+}
+
+func (u *usecase) refreshTokenTTL() time.Duration {
+	if u.cfg != nil && u.cfg.Auth.RefreshTokenTTL > 0 {
+		return u.cfg.Auth.RefreshTokenTTL
+	}
+	return defaultRefreshTokenTTL
+}
+
+// newRefreshToken menghasilkan token opaque untuk client dan hash-nya untuk disimpan di DB.
+func newRefreshToken() (raw, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", err
+	}
+	raw = base64.RawURLEncoding.EncodeToString(b)
+	return raw, hashToken(raw), nil
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func displayName(user *model.User) string {
+	return user.FirstName + " " + user.LastName
+}
+
+// StartSession membuat session baru (per device) lalu mengembalikan access + refresh token.
+func (u *usecase) StartSession(ctx *gin.Context, user *model.User, deviceID string) (*authDTO.TokenResponse, error) {
+	return u.startSession(ctx, user, deviceID, []string{amrPassword})
+}
+
+func (u *usecase) startSession(ctx *gin.Context, user *model.User, deviceID string, amr []string) (resp *authDTO.TokenResponse, err error) {
+	now := time.Now().UTC()
+	raw, hash, err := newRefreshToken()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate refresh token"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to generate refresh token")
+	}
+
+	sess := &model.AuthSession{
+		UserID:     user.ID,
+		DeviceID:   deviceID,
+		AMR:        strings.Join(amr, " "),
+		ExpiresAt:  now.Add(u.refreshTokenTTL()),
+		LastUsedAt: now,
+	}
+	if ctx.Request != nil {
+		sess.UserAgent = ctx.Request.UserAgent()
+		sess.IPAddress = ctx.ClientIP()
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			resp, err = nil, utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	if err = u.sessionRepo.CreateSession(txCtx, sess); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to create session"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to create session")
+	}
+	if err = u.sessionRepo.CreateRefreshToken(txCtx, &model.RefreshToken{
+		SessionID: sess.ID,
+		TokenHash: hash,
+		ExpiresAt: sess.ExpiresAt,
+	}); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store refresh token"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to create session")
+	}
+
+	access, err := u.signAccessToken(user.ID, displayName(user), user.Role, sess.ID, user.TokenVersion, amr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authDTO.TokenResponse{
+		AccessToken:  access,
+		RefreshToken: raw,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(u.accessTokenTTL().Seconds()),
+	}, nil
+}
+
+// RefreshSession menukar refresh token dengan pasangan token baru (rotation).
+// Refresh token yang sudah pernah ditukar lalu dipakai lagi dianggap bocor: seluruh session dicabut.
+func (u *usecase) RefreshSession(ctx *gin.Context, refreshToken string) (*authDTO.TokenResponse, error) {
+	now := time.Now().UTC()
+
+	tok, err := u.sessionRepo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load refresh token"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if tok == nil {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized, "invalid refresh token")
+	}
+
+	sess, err := u.sessionRepo.GetSession(ctx, tok.SessionID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load session"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if sess == nil || sess.RevokedAt != nil {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized, "session has been revoked")
+	}
+
+	if tok.RotatedAt != nil {
+		u.revokeOnReuse(ctx, sess.ID, now)
+		return nil, utils.MakeError(errorUc.ErrUnauthorized, "refresh token reuse detected; session revoked")
+	}
+	if now.After(tok.ExpiresAt) || now.After(sess.ExpiresAt) {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized, "refresh token expired")
+	}
+
+	user, err := u.authRepo.FindByID(ctx, sess.UserID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load user for refresh"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized, "invalid refresh token")
+	}
+
+	raw, reused, err := u.rotateRefreshToken(ctx, tok, sess, now)
+	if reused {
+		u.revokeOnReuse(ctx, sess.ID, now)
+		return nil, utils.MakeError(errorUc.ErrUnauthorized, "refresh token reuse detected; session revoked")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// amr ikut session, jadi token hasil refresh tetap membawa metode login awal
+	amr := strings.Fields(sess.AMR)
+	if len(amr) == 0 {
+		amr = []string{amrPassword}
+	}
+	access, err := u.signAccessToken(user.ID, displayName(user), user.Role, sess.ID, user.TokenVersion, amr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &authDTO.TokenResponse{
+		AccessToken:  access,
+		RefreshToken: raw,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(u.accessTokenTTL().Seconds()),
+	}, nil
+}
+
+// rotateRefreshToken menandai token lama sebagai rotated dan menerbitkan token baru dalam satu transaksi.
+func (u *usecase) rotateRefreshToken(ctx *gin.Context, tok *model.RefreshToken, sess *model.AuthSession, now time.Time) (raw string, reused bool, err error) {
+	raw, hash, err := newRefreshToken()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate refresh token"})
+		return "", false, utils.MakeError(errorUc.InternalServerError, "failed to generate refresh token")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return "", false, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil || reused {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			raw, err = "", utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	ok, err := u.sessionRepo.MarkRefreshTokenRotated(txCtx, tok.ID, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to rotate refresh token"})
+		return "", false, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if !ok {
+		// request lain sudah menukar token yang sama lebih dulu
+		return "", true, nil
+	}
+	if err = u.sessionRepo.CreateRefreshToken(txCtx, &model.RefreshToken{
+		SessionID: sess.ID,
+		TokenHash: hash,
+		ExpiresAt: sess.ExpiresAt,
+	}); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store refresh token"})
+		return "", false, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if err = u.sessionRepo.TouchSession(txCtx, sess.ID, now); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to touch session"})
+		return "", false, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	return raw, false, nil
+}
+
+func (u *usecase) revokeOnReuse(ctx *gin.Context, sessionID uint, now time.Time) {
+	u.log.Error(log.LogData{Description: "refresh token reuse detected; revoking session", Response: sessionID})
+	if err := u.sessionRepo.RevokeSession(ctx, sessionID, revokeReasonTokenReuse, now); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to revoke session after token reuse"})
+	}
+}
+
+// Logout mencabut session saat ini, atau semua session + access token user jika allDevices.
+func (u *usecase) Logout(ctx *gin.Context, userID, sessionID uint, allDevices bool) error {
+	if allDevices {
+		return u.revokeAllSessions(ctx, userID, revokeReasonLogoutAll)
+	}
+
+	sess, err := u.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load session"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if sess == nil || sess.UserID != userID {
+		return utils.MakeError(errorUc.ErrUnauthorized, "session not found")
+	}
+	if err := u.sessionRepo.RevokeSession(ctx, sessionID, revokeReasonLogout, time.Now().UTC()); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to revoke session"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to revoke session")
+	}
+	return nil
+}
+
+// RevokeUserSessions dipakai admin (mis. karyawan resign/terminated) untuk mencabut semua token user.
+func (u *usecase) RevokeUserSessions(ctx *gin.Context, userID uint, reason string) error {
+	user, err := u.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load user"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return utils.MakeError(errorUc.NotFoundError, "user not found")
+	}
+	if reason == "" {
+		reason = "revoked_by_admin"
+	}
+	return u.revokeAllSessions(ctx, userID, reason)
+}
+
+func (u *usecase) revokeAllSessions(ctx *gin.Context, userID uint, reason string) (err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	return u.revokeAllSessionsInTx(txCtx, userID, reason)
+}
+
+// revokeAllSessionsInTx dipakai di dalam transaksi milik caller (mis. reset/change password).
+func (u *usecase) revokeAllSessionsInTx(txCtx context.Context, userID uint, reason string) error {
+	if err := u.authRepo.BumpTokenVersion(txCtx, userID); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to bump token version"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to revoke sessions")
+	}
+	if err := u.sessionRepo.RevokeAllForUser(txCtx, userID, reason, time.Now().UTC()); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to revoke sessions"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to revoke sessions")
+	}
+	return nil
+}
+
+// ValidateSession dipanggil middleware setiap request: token ditolak jika session dicabut/expired
+// atau token_version user sudah naik (logout all / password change / terminated).
+func (u *usecase) ValidateSession(ctx *gin.Context, userID, sessionID uint, tokenVersion int) error {
+	user, err := u.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil || user.TokenVersion != tokenVersion {
+		return utils.MakeError(errorUc.ErrUnauthorized, "token has been revoked")
+	}
+
+	sess, err := u.sessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if sess == nil || sess.UserID != userID || sess.RevokedAt != nil || time.Now().UTC().After(sess.ExpiresAt) {
+		return utils.MakeError(errorUc.ErrUnauthorized, "session has been revoked")
+	}
+	return nil
+}
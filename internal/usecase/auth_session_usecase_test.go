@@ -0,0 +1,92 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func TestRefreshSession_RotatesToken(t *testing.T) {
+	u := usecase.NewForTest()
+
+	var created []*model.RefreshToken
+	sessMock := &testm.SessionRepoMock{
+		GetRefreshTokenByHashFn: func(_ context.Context, hash string) (*model.RefreshToken, error) {
+			return &model.RefreshToken{ID: 1, SessionID: 10, TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		GetSessionFn: func(_ context.Context, id uint) (*model.AuthSession, error) {
+			return &model.AuthSession{ID: id, UserID: 7, ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		MarkRefreshTokenRotatedFn: func(_ context.Context, id uint, at time.Time) (bool, error) { return true, nil },
+		CreateRefreshTokenFn: func(_ context.Context, tok *model.RefreshToken) error {
+			created = append(created, tok)
+			return nil
+		},
+		TouchSessionFn: func(_ context.Context, id uint, at time.Time) error { return nil },
+	}
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, FirstName: "Budi", Role: "user"}, nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, sessMock, testm.FakeTxManager{})
+
+	ctx := makeGinCtx()
+	resp, err := u.RefreshSession(ctx, "old-token")
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.AccessToken)
+	require.NotEqual(t, "old-token", resp.RefreshToken)
+	require.Len(t, created, 1)
+	require.Equal(t, uint(10), created[0].SessionID)
+}
+
+func TestRefreshSession_ReuseRevokesSession(t *testing.T) {
+	u := usecase.NewForTest()
+
+	rotated := time.Now().Add(-time.Minute)
+	var revokedID uint
+	var revokedReason string
+	sessMock := &testm.SessionRepoMock{
+		GetRefreshTokenByHashFn: func(_ context.Context, hash string) (*model.RefreshToken, error) {
+			return &model.RefreshToken{ID: 1, SessionID: 10, ExpiresAt: time.Now().Add(time.Hour), RotatedAt: &rotated}, nil
+		},
+		GetSessionFn: func(_ context.Context, id uint) (*model.AuthSession, error) {
+			return &model.AuthSession{ID: id, UserID: 7, ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+		RevokeSessionFn: func(_ context.Context, id uint, reason string, at time.Time) error {
+			revokedID, revokedReason = id, reason
+			return nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), &testm.AuthRepoMock{}, sessMock, testm.FakeTxManager{})
+
+	ctx := makeGinCtx()
+	resp, err := u.RefreshSession(ctx, "stolen-token")
+	require.Error(t, err)
+	require.Nil(t, resp)
+	require.Contains(t, err.Error(), "reuse")
+	require.Equal(t, uint(10), revokedID)
+	require.Equal(t, "refresh_token_reuse", revokedReason)
+}
+
+func TestValidateSession_TokenVersionBumped(t *testing.T) {
+	u := usecase.NewForTest()
+
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, TokenVersion: 2}, nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+
+	ctx := makeGinCtx()
+	err := u.ValidateSession(ctx, 7, 10, 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "revoked")
+}
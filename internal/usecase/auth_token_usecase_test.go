@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+	"payslip-generation-system/pkg/jwtkey"
+)
+
+func edKeyPEM(t *testing.T) (string, string) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	privDER, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+}
+
+func TestParseAccessToken_AcceptsRotatedKey(t *testing.T) {
+	_, oldPub := edKeyPEM(t)
+
+	// token ditandatangani dengan key lama (HS256)
+	signer := usecase.NewForTest()
+	usecase.InjectAuthForTest(signer, testm.NewKeyManager(), nil, nil, nil)
+	token, err := signer.GenerateToken(7, "Budi", "user", 10, 0)
+	require.NoError(t, err)
+
+	// key aktif pindah ke EdDSA; HS256 lama masih diterima untuk verifikasi
+	newPriv, _ := edKeyPEM(t)
+	km, err := jwtkey.New(jwtkey.Config{
+		Issuer:    "payslip-test",
+		ActiveKid: "ed-new",
+		Keys: []jwtkey.KeyConfig{
+			{Kid: "ed-new", Alg: jwtkey.AlgEdDSA, PrivateKeyPEM: newPriv},
+			{Kid: "test-hs", Alg: jwtkey.AlgHS256, Secret: testm.TestHSSecret},
+			{Kid: "ed-old", Alg: jwtkey.AlgEdDSA, PublicKeyPEM: oldPub},
+		},
+	})
+	require.NoError(t, err)
+
+	verifier := usecase.NewForTest()
+	usecase.InjectAuthForTest(verifier, km, nil, nil, nil)
+	claims, err := verifier.ParseAccessToken(token)
+	require.NoError(t, err)
+	require.Equal(t, float64(7), claims["user_id"])
+
+	// JWKS hanya berisi key asimetris, secret HS256 tidak ikut
+	jwks := verifier.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	require.Equal(t, "ed-new", jwks.Keys[0].Kid)
+	require.Equal(t, "OKP", jwks.Keys[0].Kty)
+}
+
+func TestParseAccessToken_UnknownKidRejected(t *testing.T) {
+	signer := usecase.NewForTest()
+	usecase.InjectAuthForTest(signer, testm.NewKeyManager(), nil, nil, nil)
+	token, err := signer.GenerateToken(7, "Budi", "user", 10, 0)
+	require.NoError(t, err)
+
+	priv, _ := edKeyPEM(t)
+	km, err := jwtkey.New(jwtkey.Config{
+		ActiveKid: "ed-only",
+		Keys:      []jwtkey.KeyConfig{{Kid: "ed-only", Alg: jwtkey.AlgEdDSA, PrivateKeyPEM: priv}},
+	})
+	require.NoError(t, err)
+
+	verifier := usecase.NewForTest()
+	usecase.InjectAuthForTest(verifier, km, nil, nil, nil)
+	_, err = verifier.ParseAccessToken(token)
+	require.Error(t, err)
+}
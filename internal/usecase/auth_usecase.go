@@ -3,9 +3,11 @@ package usecase
 import (
 	"fmt"
 	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/jwtkey"
 	"payslip-generation-system/pkg/log"
 	"payslip-generation-system/utils"
 	"strings"
+	"sync"
 	"time"
 
 	errorUc "payslip-generation-system/internal/error"
@@ -22,6 +24,10 @@ import (
 func (u *usecase) RegisterUser(ctx *gin.Context, req authDTO.RegisterUserRequest) (*model.User, error) {
 	email := strings.TrimSpace(strings.ToLower(req.Email))
 
+	if err := validatePassword(u.passwordPolicy(), req.Password); err != nil {
+		return nil, err
+	}
+
 	// --- BEGIN TX ---
 	txCtx, err := u.txManager.Begin(ctx)
 	if err != nil {
@@ -59,28 +65,22 @@ func (u *usecase) RegisterUser(ctx *gin.Context, req authDTO.RegisterUserRequest
 		return nil, utils.MakeError(errorUc.InternalServerError, "failed to hash password")
 	}
 
-	// Tentukan profile complete
-	isComplete := req.IsProfileComplete
-	if !isComplete {
-		isComplete = email != "" && req.FirstName != "" && req.LastName != ""
-	}
-
 	user := &model.User{
-		Email:             email,
-		FirstName:         req.FirstName,
-		LastName:          req.LastName,
-		ProfileImageURL:   req.ProfileImageURL,
-		PasswordHash:      string(hashed),
-		GoogleID:          req.GoogleID,
-		Age:               req.Age,
-		Bio:               req.Bio,
-		Location:          req.Location,
-		Interests:         req.Interests, // pq.StringArray -> text[]
-		Role:              req.Role,
-		Salary:            req.Salary,
-		IsProfileComplete: isComplete,
+		Email:           email,
+		FirstName:       req.FirstName,
+		LastName:        req.LastName,
+		ProfileImageURL: req.ProfileImageURL,
+		PasswordHash:    string(hashed),
+		GoogleID:        req.GoogleID,
+		Age:             req.Age,
+		Bio:             req.Bio,
+		Location:        req.Location,
+		Interests:       req.Interests, // pq.StringArray -> text[]
+		Role:            req.Role,
+		Salary:          req.Salary,
 		// CreatedAt/UpdatedAt by GORM
 	}
+	user.IsProfileComplete = req.IsProfileComplete || isProfileComplete(user)
 
 	// Simpan user (masih dalam tx)
 	if err = u.authRepo.CreateUser(txCtx, user); err != nil {
@@ -96,7 +96,29 @@ func (u *usecase) RegisterUser(ctx *gin.Context, req authDTO.RegisterUserRequest
 	return user, nil
 }
 
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// compareDummyHash menyamakan waktu respon untuk email yang tidak terdaftar.
+func compareDummyHash(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password-for-timing"), bcrypt.DefaultCost)
+	})
+	_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
+
+// LoginUser: email tidak terdaftar dan password salah menghasilkan error yang sama (InvalidCredentials)
+// supaya akun tidak bisa ditebak. Kegagalan dihitung per email dan per IP untuk lockout.
 func (u *usecase) LoginUser(ctx *gin.Context, email, password string) (*model.User, error) {
+	email = strings.TrimSpace(strings.ToLower(email))
+	ip := clientIP(ctx)
+	now := time.Now().UTC()
+
+	if err := u.checkLoginLocked(ctx, email, ip, now); err != nil {
+		return nil, err
+	}
 
 	user, err := u.authRepo.FindByEmail(ctx, email)
 	if err != nil {
@@ -104,21 +126,34 @@ func (u *usecase) LoginUser(ctx *gin.Context, email, password string) (*model.Us
 			Err:         err,
 			Description: "Failed to retrieve user",
 		})
-		return nil, utils.MakeError(errorUc.InternalServerError, err.Error())
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
 	}
 	if user == nil {
-		u.log.Error(log.LogData{
-			Description: "User not found",
+		compareDummyHash(password)
+		u.recordLoginFailure(ctx, email, ip, now)
+		u.log.Info(log.LogData{
+			Description: "Login failed: unknown email",
 		})
-		return nil, utils.MakeError(errorUc.NotFoundError, "user not found")
+		return nil, utils.MakeError(errorUc.InvalidCredentials)
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		u.log.Error(log.LogData{
-			Description: "Invalid password",
-			Err:         err,
+		u.recordLoginFailure(ctx, email, ip, now)
+		u.log.Info(log.LogData{
+			Description: "Login failed: invalid password",
+			Response:    user.ID,
 		})
-		return nil, utils.MakeError(errorUc.ErrUnauthorized, "invalid email or password")
+		return nil, utils.MakeError(errorUc.InvalidCredentials)
+	}
+
+	if user.EmploymentStatus == model.EmploymentStatusTerminated {
+		u.log.Info(log.LogData{Description: "Login rejected: employee terminated", Response: user.ID})
+		return nil, utils.MakeError(errorUc.ErrForbidden, "account is terminated")
+	}
+
+	// counter IP sengaja tidak di-reset: satu akun valid tidak boleh membuka kunci IP penyerang
+	if err := u.attemptRepo.Clear(ctx, model.LoginAttemptScopeAccount, email); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to reset login attempts"})
 	}
 
 	u.log.Info(log.LogData{
@@ -129,8 +164,35 @@ func (u *usecase) LoginUser(ctx *gin.Context, email, password string) (*model.Us
 	return user, nil
 }
 
-func (u *usecase) GenerateToken(userID uint, name, role string) (string, error) {
-	token, err := GenerateToken(userID, name, role)
+func (u *usecase) GenerateToken(userID uint, name, role string, sessionID uint, tokenVersion int) (string, error) {
+	return u.signAccessToken(userID, name, role, sessionID, tokenVersion, []string{amrPassword})
+}
+
+// signAccessToken: amr (RFC 8176) mencatat metode login, dipakai RequireAdmin saat 2FA wajib.
+func (u *usecase) signAccessToken(userID uint, name, role string, sessionID uint, tokenVersion int, amr []string) (string, error) {
+	now := time.Now()
+
+	jti, err := utils.GenerateRandomString(24)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "Failed to generate token id"})
+		return "", utils.MakeError(errorUc.InternalServerError, err.Error())
+	}
+
+	// sid & ver dipakai middleware untuk cek revocation
+	claims := jwt.MapClaims{
+		"typ":     tokenTypeAccess,
+		"user_id": userID,
+		"name":    name,
+		"role":    role,
+		"sid":     sessionID,
+		"ver":     tokenVersion,
+		"amr":     amr,
+		"jti":     jti,
+		"exp":     now.Add(u.accessTokenTTL()).Unix(),
+		"iat":     now.Unix(),
+	}
+
+	token, err := u.keys.Sign(claims)
 	if err != nil {
 		u.log.Error(log.LogData{
 			Err:         err,
@@ -140,34 +202,24 @@ func (u *usecase) GenerateToken(userID uint, name, role string) (string, error)
 	}
 	u.log.Info(log.LogData{
 		Description: "Token generated successfully",
-		Response:    token,
 	})
 	return token, nil
 }
 
-var jwtSecret = []byte("A7M+TXRMxdz0N3nFLjGaxVKgkELowtbxWipS+IFZkVE=") // Ganti dengan env di production
-
-func GenerateToken(userID uint, name, role string) (string, error) {
-	// Define token expiration (e.g., 24 hours)
-	expirationTime := time.Now().Add(24 * time.Hour)
-
-	// Create claims
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"name":    name,
-		"role":    role,
-		"exp":     expirationTime.Unix(),
-		"iat":     time.Now().Unix(),
-	}
-
-	// Create token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	// Sign token
-	tokenString, err := token.SignedString(jwtSecret)
+// ParseAccessToken memverifikasi signature (berdasarkan kid) dan expiry access token.
+func (u *usecase) ParseAccessToken(tokenString string) (jwt.MapClaims, error) {
+	claims, err := u.keys.Parse(tokenString)
 	if err != nil {
-		return "", err
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
 	}
+	// token lain (mis. challenge 2FA) ditandatangani key yang sama, jadi typ wajib dicek
+	if typ, ok := claims["typ"]; ok && typ != tokenTypeAccess {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	return claims, nil
+}
 
-	return tokenString, nil
+// JWKS mengembalikan public key JWT untuk diverifikasi service lain.
+func (u *usecase) JWKS() jwtkey.JWKSet {
+	return u.keys.JWKS()
 }
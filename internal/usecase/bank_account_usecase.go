@@ -0,0 +1,251 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	bankDTO "payslip-generation-system/internal/dto/bankaccount"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/notify"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	bankCodePattern      = regexp.MustCompile(`^[A-Z0-9]{2,20}$`)
+	accountNumberPattern = regexp.MustCompile(`^[0-9]{6,20}$`)
+)
+
+// aad mengikat ciphertext ke pemilik rekening; ciphertext yang disalin ke user lain gagal didekripsi.
+func bankAccountAAD(userID uint, field string) string {
+	return fmt.Sprintf("bank_account:%d:%s", userID, field)
+}
+
+func maskAccountNumber(last4 string) string {
+	return "******" + last4
+}
+
+func (u *usecase) GetBankAccount(ctx *gin.Context, userID uint) (*bankDTO.BankAccountResponse, error) {
+	acc, err := u.bankAccountRepo.Get(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load bank account"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if acc == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError)
+	}
+	return u.toBankAccountResponse(acc)
+}
+
+// SetBankAccount dipakai karyawan (actorID == userID) maupun admin. Setiap perubahan mereset status verifikasi,
+// dicatat di history dan diberitahukan ke email karyawan.
+func (u *usecase) SetBankAccount(ctx *gin.Context, actorID, userID uint, req bankDTO.SetBankAccountRequest) (resp *bankDTO.BankAccountResponse, err error) {
+	bankCode := strings.ToUpper(strings.TrimSpace(req.BankCode))
+	number := strings.NewReplacer(" ", "", "-", "").Replace(req.AccountNumber)
+	holder := strings.Join(strings.Fields(req.HolderName), " ")
+	if !bankCodePattern.MatchString(bankCode) {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "bank_code")
+	}
+	if !accountNumberPattern.MatchString(number) {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "account_number")
+	}
+	if holder == "" || utf8.RuneCountInString(holder) > 100 {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "holder_name")
+	}
+
+	user, err := u.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load user"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError)
+	}
+
+	numberEnc, err := u.fieldCipher.Encrypt(number, bankAccountAAD(userID, "account_number"))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to encrypt account number"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to save bank account")
+	}
+	holderEnc, err := u.fieldCipher.Encrypt(holder, bankAccountAAD(userID, "holder_name"))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to encrypt holder name"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to save bank account")
+	}
+	acc := &model.EmployeeBankAccount{
+		UserID:           userID,
+		BankCode:         bankCode,
+		AccountNumberEnc: numberEnc,
+		AccountLast4:     number[len(number)-4:],
+		HolderNameEnc:    holderEnc,
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	if err = u.bankAccountRepo.Upsert(txCtx, acc); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to save bank account"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to save bank account")
+	}
+	if err = u.bankAccountRepo.AddHistory(txCtx, bankAccountHistory(acc, model.BankAccountActionSet, actorID)); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to save bank account history"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to save bank account")
+	}
+
+	// perubahan rekening gaji adalah target penipuan, jadi pemilik selalu diberi tahu
+	if sendErr := u.notifier.Send(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "Your salary bank account was changed",
+		Body: fmt.Sprintf("The bank account for your salary was changed to %s %s. If you did not make this change contact HR immediately.",
+			bankCode, maskAccountNumber(acc.AccountLast4)),
+	}); sendErr != nil {
+		u.log.Error(log.LogData{Err: sendErr, Description: "failed to send bank account change notification"})
+	}
+
+	u.log.Info(log.LogData{Description: "bank account updated", Response: map[string]any{"user_id": userID, "by": actorID}})
+	return &bankDTO.BankAccountResponse{
+		UserID:        userID,
+		BankCode:      bankCode,
+		AccountNumber: maskAccountNumber(acc.AccountLast4),
+		HolderName:    holder,
+		UpdatedAt:     time.Now().UTC(),
+	}, nil
+}
+
+// VerifyBankAccount: admin menandai rekening sudah dicek (mis. lewat inquiry bank). Tidak bisa untuk rekening sendiri.
+func (u *usecase) VerifyBankAccount(ctx *gin.Context, actorID, userID uint) (resp *bankDTO.BankAccountResponse, err error) {
+	if actorID == userID {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "cannot verify your own bank account")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	now := time.Now().UTC()
+	ok, err := u.bankAccountRepo.MarkVerified(txCtx, userID, actorID, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to verify bank account"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	acc, err := u.bankAccountRepo.Get(txCtx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load bank account"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if acc == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError)
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "bank account is already verified")
+	}
+	if err = u.bankAccountRepo.AddHistory(txCtx, bankAccountHistory(acc, model.BankAccountActionVerified, actorID)); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to save bank account history"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to verify bank account")
+	}
+
+	return u.toBankAccountResponse(acc)
+}
+
+func (u *usecase) ListBankAccountHistory(ctx *gin.Context, userID uint) ([]bankDTO.BankAccountHistoryResponse, error) {
+	rows, err := u.bankAccountRepo.ListHistory(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load bank account history"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	out := make([]bankDTO.BankAccountHistoryResponse, 0, len(rows))
+	for _, h := range rows {
+		holder, err := u.fieldCipher.Decrypt(h.HolderNameEnc, bankAccountAAD(h.UserID, "holder_name"))
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to decrypt bank account history"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to read bank account")
+		}
+		out = append(out, bankDTO.BankAccountHistoryResponse{
+			Action:        h.Action,
+			BankCode:      h.BankCode,
+			AccountNumber: maskAccountNumber(h.AccountLast4),
+			HolderName:    holder,
+			ChangedBy:     h.ChangedBy,
+			ChangedAt:     h.ChangedAt,
+		})
+	}
+	return out, nil
+}
+
+// bankAccountStatuses dipakai RunPayroll untuk menandai karyawan yang belum bisa ditransfer.
+func (u *usecase) bankAccountStatuses(ctx *gin.Context, userIDs []uint) (map[uint]string, error) {
+	accounts, err := u.bankAccountRepo.ListByUsers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uint]string, len(userIDs))
+	for _, id := range userIDs {
+		acc, ok := accounts[id]
+		switch {
+		case !ok:
+			out[id] = model.BankAccountStatusMissing
+		case !acc.Verified:
+			out[id] = model.BankAccountStatusUnverified
+		default:
+			out[id] = model.BankAccountStatusOK
+		}
+	}
+	return out, nil
+}
+
+func (u *usecase) toBankAccountResponse(acc *model.EmployeeBankAccount) (*bankDTO.BankAccountResponse, error) {
+	holder, err := u.fieldCipher.Decrypt(acc.HolderNameEnc, bankAccountAAD(acc.UserID, "holder_name"))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to decrypt bank account"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to read bank account")
+	}
+	return &bankDTO.BankAccountResponse{
+		UserID:        acc.UserID,
+		BankCode:      acc.BankCode,
+		AccountNumber: maskAccountNumber(acc.AccountLast4),
+		HolderName:    holder,
+		Verified:      acc.Verified,
+		VerifiedAt:    acc.VerifiedAt,
+		UpdatedAt:     acc.UpdatedAt,
+	}, nil
+}
+
+func bankAccountHistory(acc *model.EmployeeBankAccount, action string, by uint) *model.EmployeeBankAccountHistory {
+	return &model.EmployeeBankAccountHistory{
+		UserID:           acc.UserID,
+		Action:           action,
+		BankCode:         acc.BankCode,
+		AccountNumberEnc: acc.AccountNumberEnc,
+		AccountLast4:     acc.AccountLast4,
+		HolderNameEnc:    acc.HolderNameEnc,
+		ChangedBy:        by,
+		ChangedAt:        time.Now().UTC(),
+	}
+}
@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	bankDTO "payslip-generation-system/internal/dto/bankaccount"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func TestSetBankAccount_EncryptsAndMasks(t *testing.T) {
+	u := usecase.NewForTest()
+
+	var saved *model.EmployeeBankAccount
+	var history []*model.EmployeeBankAccountHistory
+	accounts := &testm.BankAccountRepoMock{
+		UpsertFn: func(_ context.Context, acc *model.EmployeeBankAccount) error {
+			saved = acc
+			return nil
+		},
+		AddHistoryFn: func(_ context.Context, h *model.EmployeeBankAccountHistory) error {
+			history = append(history, h)
+			return nil
+		},
+	}
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, Email: "c@example.com"}, nil
+		},
+	}
+	notifier := &testm.NotifierMock{}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+	usecase.InjectPasswordForTest(u, &testm.PasswordResetRepoMock{}, notifier)
+	usecase.InjectBankAccountForTest(u, accounts, testm.NewFieldCipher())
+
+	resp, err := u.SetBankAccount(makeGinCtx(), 7, 7, bankDTO.SetBankAccountRequest{
+		BankCode: "bca", AccountNumber: "1234-567 890", HolderName: "  Citra   Dewi ",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "BCA", resp.BankCode)
+	require.Equal(t, "******7890", resp.AccountNumber)
+	require.Equal(t, "Citra Dewi", resp.HolderName)
+	require.False(t, resp.Verified)
+
+	require.NotContains(t, saved.AccountNumberEnc, "1234567890")
+	require.NotContains(t, saved.HolderNameEnc, "Citra")
+	require.Equal(t, "7890", saved.AccountLast4)
+	require.Len(t, history, 1)
+	require.Equal(t, model.BankAccountActionSet, history[0].Action)
+	require.Len(t, notifier.Sent, 1)
+	require.False(t, strings.Contains(notifier.Sent[0].Body, "1234567890"))
+
+	// ciphertext terikat ke user: dibaca sebagai milik user lain harus gagal
+	accounts.GetFn = func(_ context.Context, userID uint) (*model.EmployeeBankAccount, error) {
+		acc := *saved
+		acc.UserID = userID
+		return &acc, nil
+	}
+	_, err = u.GetBankAccount(makeGinCtx(), 8)
+	require.Error(t, err)
+	got, err := u.GetBankAccount(makeGinCtx(), 7)
+	require.NoError(t, err)
+	require.Equal(t, "Citra Dewi", got.HolderName)
+}
+
+func TestSetBankAccount_InvalidNumber(t *testing.T) {
+	u := usecase.NewForTest()
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{}, testm.NewFieldCipher())
+
+	_, err := u.SetBankAccount(makeGinCtx(), 7, 7, bankDTO.SetBankAccountRequest{
+		BankCode: "BCA", AccountNumber: "12ab34", HolderName: "Citra",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "account_number")
+}
+
+func TestVerifyBankAccount_NotOwnAccount(t *testing.T) {
+	u := usecase.NewForTest()
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{
+		MarkVerifiedFn: func(_ context.Context, userID, by uint, at time.Time) (bool, error) {
+			t.Fatal("must not verify")
+			return false, nil
+		},
+	}, testm.NewFieldCipher())
+
+	_, err := u.VerifyBankAccount(makeGinCtx(), 3, 3)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "own bank account")
+}
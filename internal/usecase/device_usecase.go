@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"payslip-generation-system/internal/device"
+	devDTO "payslip-generation-system/internal/dto/device"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	devRepo "payslip-generation-system/internal/repository/device"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// punch yang sama (mesin, PIN, waktu) sudah pernah diterima
+const punchResultDuplicate = "duplicate"
+
+func toDeviceResponse(d *model.Device) devDTO.DeviceResponse {
+	return devDTO.DeviceResponse{
+		ID:           d.ID,
+		Name:         d.Name,
+		Vendor:       d.Vendor,
+		SerialNumber: d.SerialNumber,
+		APIKeyPrefix: d.APIKeyPrefix,
+		Active:       d.Active,
+		LastSeenAt:   d.LastSeenAt,
+		CreatedAt:    d.CreatedAt,
+	}
+}
+
+// newDeviceKey menghasilkan API key mesin; yang disimpan hanya hash & prefix-nya.
+func newDeviceKey() (raw, hash, prefix string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	raw = "dev_" + base64.RawURLEncoding.EncodeToString(b)
+	return raw, hashToken(raw), raw[:12], nil
+}
+
+func (u *usecase) ListDevices(ctx *gin.Context) ([]devDTO.DeviceResponse, error) {
+	rows, err := u.deviceRepo.List(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list devices"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	out := make([]devDTO.DeviceResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toDeviceResponse(&rows[i]))
+	}
+	return out, nil
+}
+
+func (u *usecase) CreateDevice(ctx *gin.Context, actorID uint, req devDTO.CreateDeviceRequest) (*devDTO.DeviceKeyResponse, error) {
+	vendor := strings.ToLower(strings.TrimSpace(req.Vendor))
+	if _, err := device.Get(vendor); err != nil {
+		return nil, utils.MakeError(errorUc.BadRequest, "unknown vendor (available: "+strings.Join(device.Vendors(), " ")+")")
+	}
+	raw, hash, prefix, err := newDeviceKey()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate device key"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to generate device key")
+	}
+	row := &model.Device{
+		Name:         strings.TrimSpace(req.Name),
+		Vendor:       vendor,
+		SerialNumber: strings.TrimSpace(req.SerialNumber),
+		APIKeyHash:   hash,
+		APIKeyPrefix: prefix,
+		Active:       true,
+		CreatedBy:    actorID,
+	}
+	if err = u.deviceRepo.Create(ctx, row); err != nil {
+		// serial unik lewat index lower(serial_number)
+		if errors.Is(err, devRepo.ErrSerialExists) {
+			return nil, utils.MakeError(errorUc.ConflictError, "device with that serial number is already registered")
+		}
+		u.log.Error(log.LogData{Err: err, Description: "failed to create device"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to create device")
+	}
+	return &devDTO.DeviceKeyResponse{DeviceResponse: toDeviceResponse(row), APIKey: raw}, nil
+}
+
+// UpdateDevice: field nil tidak diubah; active=false menolak kiriman dari mesin tsb.
+func (u *usecase) UpdateDevice(ctx *gin.Context, id uint, req devDTO.UpdateDeviceRequest) (*devDTO.DeviceResponse, error) {
+	row, err := u.findDevice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		row.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Active != nil {
+		row.Active = *req.Active
+	}
+	if err = u.deviceRepo.Update(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update device"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update device")
+	}
+	resp := toDeviceResponse(row)
+	return &resp, nil
+}
+
+// RotateDeviceKey mengganti API key; key lama langsung tidak berlaku.
+func (u *usecase) RotateDeviceKey(ctx *gin.Context, id uint) (*devDTO.DeviceKeyResponse, error) {
+	row, err := u.findDevice(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	raw, hash, prefix, err := newDeviceKey()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate device key"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to generate device key")
+	}
+	if err = u.deviceRepo.SetKey(ctx, id, hash, prefix); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to rotate device key"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to rotate device key")
+	}
+	row.APIKeyHash, row.APIKeyPrefix = hash, prefix
+	return &devDTO.DeviceKeyResponse{DeviceResponse: toDeviceResponse(row), APIKey: raw}, nil
+}
+
+func (u *usecase) ListDeviceUsers(ctx *gin.Context, deviceID uint) ([]devDTO.DeviceUserResponse, error) {
+	if _, err := u.findDevice(ctx, deviceID); err != nil {
+		return nil, err
+	}
+	rows, err := u.deviceRepo.ListUsers(ctx, deviceID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list device users"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	out := make([]devDTO.DeviceUserResponse, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, devDTO.DeviceUserResponse{DeviceUserID: r.DeviceUserID, UserID: r.UserID})
+	}
+	return out, nil
+}
+
+func (u *usecase) MapDeviceUser(ctx *gin.Context, deviceID uint, req devDTO.MapDeviceUserRequest) (*devDTO.DeviceUserResponse, error) {
+	if _, err := u.findDevice(ctx, deviceID); err != nil {
+		return nil, err
+	}
+	user, err := u.employeeRepo.FindByID(ctx, req.UserID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load employee"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "employee not found")
+	}
+	row := &model.DeviceUser{DeviceID: deviceID, DeviceUserID: strings.TrimSpace(req.DeviceUserID), UserID: req.UserID}
+	if err = u.deviceRepo.UpsertUser(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to map device user"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to map device user")
+	}
+	return &devDTO.DeviceUserResponse{DeviceUserID: row.DeviceUserID, UserID: row.UserID}, nil
+}
+
+func (u *usecase) UnmapDeviceUser(ctx *gin.Context, deviceID uint, deviceUserID string) error {
+	deleted, err := u.deviceRepo.DeleteUser(ctx, deviceID, deviceUserID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to unmap device user"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to unmap device user")
+	}
+	if !deleted {
+		return utils.MakeError(errorUc.NotFoundError, "device user mapping not found")
+	}
+	return nil
+}
+
+// IngestDevicePunches menerima log punch dari mesin (diautentikasi dengan API key mesin), memetakan PIN ke user
+// dan membuat attendance lewat aturan submit attendance (weekend & period terkunci ditolak, tanpa geofence).
+// Idempoten: attendance satu per hari, punch yang sudah pernah diterima dilewati sebelum diproses.
+func (u *usecase) IngestDevicePunches(ctx *gin.Context, apiKey string, body []byte) (*devDTO.IngestPunchesResponse, error) {
+	if apiKey == "" {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	dev, err := u.deviceRepo.FindByKeyHash(ctx, hashToken(apiKey))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load device"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if dev == nil || !dev.Active {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	adapter, err := device.Get(dev.Vendor)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "device vendor has no adapter"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "device vendor is not supported")
+	}
+	punches, err := adapter.Parse(body)
+	if err != nil {
+		if errors.Is(err, device.ErrInvalidPayload) {
+			return nil, utils.MakeError(errorUc.BadRequest, err.Error())
+		}
+		u.log.Error(log.LogData{Err: err, Description: "failed to parse device payload"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to read punches")
+	}
+	// punch pertama di hari itu yang membuat attendance
+	sort.SliceStable(punches, func(i, j int) bool { return punches[i].Time.Before(punches[j].Time) })
+
+	mappings, err := u.deviceRepo.ListUsers(ctx, dev.ID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list device users"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	users := make(map[string]uint, len(mappings))
+	for _, m := range mappings {
+		users[m.DeviceUserID] = m.UserID
+	}
+
+	resp := &devDTO.IngestPunchesResponse{DeviceID: dev.ID, Punches: make([]devDTO.PunchLine, 0, len(punches))}
+	for _, p := range punches {
+		line, err := u.ingestPunch(ctx, dev, p, users)
+		if err != nil {
+			return nil, err
+		}
+		switch line.Result {
+		case model.PunchResultCreated:
+			resp.Created++
+		case model.PunchResultExisting:
+			resp.Existing++
+		case punchResultDuplicate:
+			resp.Duplicate++
+		case model.PunchResultUnmapped:
+			resp.Unmapped++
+		default:
+			resp.Rejected++
+		}
+		resp.Received++
+		resp.Punches = append(resp.Punches, line)
+	}
+
+	if err = u.deviceRepo.Touch(ctx, dev.ID, u.clock().UTC()); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update device last seen"})
+	}
+	return resp, nil
+}
+
+// ingestPunch memproses satu punch. Punch yang sudah pernah diterima (kiriman ulang) tidak diproses lagi
+// sehingga tidak membuat attendance untuk kedua kalinya. error hanya untuk kegagalan db.
+func (u *usecase) ingestPunch(ctx *gin.Context, dev *model.Device, p device.Punch, users map[string]uint) (devDTO.PunchLine, error) {
+	line := devDTO.PunchLine{DeviceUserID: p.DeviceUserID, Time: p.Time, Direction: p.Direction, Result: punchResultDuplicate}
+	seen, err := u.deviceRepo.HasPunch(ctx, dev.ID, p.DeviceUserID, p.Time)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to check device punch"})
+		return line, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if seen {
+		return line, nil
+	}
+
+	row := &model.DevicePunch{DeviceID: dev.ID, DeviceUserID: p.DeviceUserID, PunchedAt: p.Time, Direction: p.Direction}
+	if err = u.applyPunch(ctx, row, dev.ID, users); err != nil {
+		return line, err
+	}
+	inserted, err := u.deviceRepo.CreatePunch(ctx, row)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to save device punch"})
+		return line, utils.MakeError(errorUc.InternalServerError, "failed to save punch")
+	}
+	if row.UserID != nil {
+		line.UserID = *row.UserID
+	}
+	if row.AttendanceID != nil {
+		line.AttendanceID = *row.AttendanceID
+	}
+	// kiriman bersamaan: punch sudah dicatat request lain
+	if inserted {
+		line.Result, line.Message = row.Result, row.Message
+	}
+	return line, nil
+}
+
+// applyPunch membuat attendance untuk punch & mengisi hasilnya. error hanya untuk kegagalan db.
+func (u *usecase) applyPunch(ctx *gin.Context, row *model.DevicePunch, deviceID uint, users map[string]uint) error {
+	userID, ok := users[row.DeviceUserID]
+	if !ok {
+		row.Result, row.Message = model.PunchResultUnmapped, "device user is not mapped to an employee"
+		return nil
+	}
+	row.UserID = &userID
+
+	date := row.PunchedAt.In(time.FixedZone("WIB", 7*3600)).Format("2006-01-02")
+	att, existed, err := u.submitAttendance(ctx, userID, date, nil, nil, deviceID)
+	if err != nil {
+		code, args := utils.GenerateError(err)
+		if code == errorUc.InternalServerError {
+			return err
+		}
+		row.Result = model.PunchResultRejected
+		row.Message = code
+		if len(args) > 0 {
+			if msg, ok := args[0].(string); ok {
+				row.Message = msg
+			}
+		}
+		return nil
+	}
+	row.AttendanceID = &att.ID
+	row.Result = model.PunchResultCreated
+	if existed {
+		row.Result = model.PunchResultExisting
+	}
+	return nil
+}
+
+func (u *usecase) findDevice(ctx *gin.Context, id uint) (*model.Device, error) {
+	row, err := u.deviceRepo.FindByID(ctx, id)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load device"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "device not found")
+	}
+	return row, nil
+}
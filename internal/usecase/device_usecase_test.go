@@ -0,0 +1,208 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	devDTO "payslip-generation-system/internal/dto/device"
+	"payslip-generation-system/internal/model"
+	devRepo "payslip-generation-system/internal/repository/device"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+type deviceStore struct {
+	attendances map[string]*model.Attendance // user|date
+	punches     map[string]bool
+	submits     int // panggilan CreateIfNotExists
+	audits      []model.AttendanceAudit
+	lastSeen    time.Time
+}
+
+// deviceUsecase mendaftarkan satu mesin zkteco (PIN 1001 -> user 42, PIN 1002 -> user 43)
+// dan mengembalikan API key-nya.
+func deviceUsecase(t *testing.T) (usecase.IUsecase, *deviceStore, string) {
+	t.Helper()
+	s := &deviceStore{attendances: map[string]*model.Attendance{}, punches: map[string]bool{}}
+	u := usecase.NewForTest()
+
+	var registered *model.Device
+	devices := &testm.DeviceRepoMock{
+		CreateFn: func(_ context.Context, d *model.Device) error {
+			// meniru unique index lower(serial_number)
+			if registered != nil && strings.EqualFold(registered.SerialNumber, d.SerialNumber) {
+				return devRepo.ErrSerialExists
+			}
+			d.ID = 7
+			registered = d
+			return nil
+		},
+		FindByKeyHashFn: func(_ context.Context, hash string) (*model.Device, error) {
+			if registered != nil && registered.APIKeyHash == hash {
+				return registered, nil
+			}
+			return nil, nil
+		},
+		ListUsersFn: func(_ context.Context, deviceID uint) ([]model.DeviceUser, error) {
+			require.Equal(t, uint(7), deviceID)
+			return []model.DeviceUser{
+				{DeviceID: 7, DeviceUserID: "1001", UserID: 42},
+				{DeviceID: 7, DeviceUserID: "1002", UserID: 43},
+			}, nil
+		},
+		HasPunchFn: func(_ context.Context, deviceID uint, deviceUserID string, at time.Time) (bool, error) {
+			return s.punches[fmt.Sprintf("%d|%s|%s", deviceID, deviceUserID, at.UTC())], nil
+		},
+		CreatePunchFn: func(_ context.Context, p *model.DevicePunch) (bool, error) {
+			k := fmt.Sprintf("%d|%s|%s", p.DeviceID, p.DeviceUserID, p.PunchedAt.UTC())
+			if s.punches[k] {
+				return false, nil
+			}
+			s.punches[k] = true
+			return true, nil
+		},
+		TouchFn: func(_ context.Context, id uint, at time.Time) error {
+			s.lastSeen = at
+			return nil
+		},
+	}
+	atMock := &testm.ATRepoMock{
+		CreateIfNotExistsFn: func(_ context.Context, userID uint, date time.Time) (*model.Attendance, bool, error) {
+			s.submits++
+			k := fmt.Sprintf("%d|%s", userID, date.Format("2006-01-02"))
+			if a, ok := s.attendances[k]; ok {
+				return a, true, nil
+			}
+			a := &model.Attendance{ID: uint(len(s.attendances) + 1), UserID: userID, Date: date}
+			s.attendances[k] = a
+			return a, false, nil
+		},
+		AddAuditFn: func(_ context.Context, a *model.AttendanceAudit) error {
+			s.audits = append(s.audits, *a)
+			return nil
+		},
+	}
+	payMock := &testm.PayRepoMock{
+		// Juli sudah di-run payroll
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return date.Month() == time.July, nil },
+	}
+	usecase.InjectForTest(u, nil, atMock, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectDeviceForTest(u, devices)
+
+	resp, err := u.CreateDevice(makeGinCtx(), 1, devDTO.CreateDeviceRequest{Name: "Lobby", Vendor: "ZKTeco", SerialNumber: "ZK-01"})
+	require.NoError(t, err)
+	require.Equal(t, "zkteco", resp.Vendor)
+	require.Equal(t, resp.APIKey[:12], resp.APIKeyPrefix)
+	require.NotEqual(t, resp.APIKey, registered.APIKeyHash)
+	return u, s, resp.APIKey
+}
+
+// ingestServer menjalankan endpoint ingest di httptest agar bisa dipanggil SimulatedDevice.
+func ingestServer(u usecase.IUsecase) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/v1/devices/punches", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		resp, err := u.IngestDevicePunches(c, c.GetHeader("X-Device-Key"), body)
+		if err != nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+	return httptest.NewServer(r)
+}
+
+func TestIngestDevicePunches_SimulatedDeviceIsIdempotent(t *testing.T) {
+	u, s, key := deviceUsecase(t)
+	srv := ingestServer(u)
+	defer srv.Close()
+	wib := time.FixedZone("WIB", 7*3600)
+
+	dev := &testm.SimulatedDevice{URL: srv.URL + "/v1/devices/punches", APIKey: key}
+	dev.Punch("1001", time.Date(2025, 8, 5, 17, 2, 0, 0, wib), 1) // pulang, dikirim sebelum punch masuk
+	dev.Punch("1001", time.Date(2025, 8, 5, 7, 58, 0, 0, wib), 0)
+	dev.Punch("1002", time.Date(2025, 8, 5, 8, 10, 0, 0, wib), 0)
+	dev.Punch("2000", time.Date(2025, 8, 5, 8, 11, 0, 0, wib), 0) // PIN belum dipetakan
+	dev.Punch("1002", time.Date(2025, 8, 9, 9, 0, 0, 0, wib), 0)  // Sabtu
+	dev.Punch("1001", time.Date(2025, 7, 31, 8, 0, 0, 0, wib), 0) // period Juli terkunci
+
+	status, body, err := dev.Push()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, status)
+	var first devDTO.IngestPunchesResponse
+	require.NoError(t, json.Unmarshal(body, &first))
+	require.Equal(t, uint(7), first.DeviceID)
+	require.Equal(t, 6, first.Received)
+	require.Equal(t, 2, first.Created)
+	require.Equal(t, 1, first.Existing)
+	require.Equal(t, 1, first.Unmapped)
+	require.Equal(t, 2, first.Rejected)
+	require.Len(t, s.attendances, 2)
+	require.Contains(t, s.attendances, "42|2025-08-05")
+	require.Contains(t, s.attendances, "43|2025-08-05")
+	require.False(t, s.lastSeen.IsZero())
+
+	// diurutkan berdasarkan waktu: punch masuk 1001 yang membuat attendance
+	require.Equal(t, "1001", first.Punches[1].DeviceUserID)
+	require.Equal(t, "created", first.Punches[1].Result)
+	require.Equal(t, "in", first.Punches[1].Direction)
+	require.Contains(t, first.Punches[0].Message, "locked")
+	require.Equal(t, "existing", first.Punches[4].Result)
+	require.Equal(t, "out", first.Punches[4].Direction)
+	require.Equal(t, "cannot submit attendance on weekend", first.Punches[5].Message)
+	// attendance dari mesin tercatat dengan source device
+	require.Len(t, s.audits, 2)
+	require.Equal(t, model.AttendanceAuditSourceDevice, s.audits[0].Source)
+	require.Equal(t, uint(7), *s.audits[0].SourceID)
+	submits := s.submits
+
+	// mesin mengirim ulang batch yang sama ditambah satu punch baru
+	dev.Punch("1002", time.Date(2025, 8, 6, 8, 5, 0, 0, wib), 0)
+	_, body, err = dev.Push()
+	require.NoError(t, err)
+	var second devDTO.IngestPunchesResponse
+	require.NoError(t, json.Unmarshal(body, &second))
+	require.Equal(t, 7, second.Received)
+	require.Equal(t, 6, second.Duplicate)
+	require.Equal(t, 1, second.Created)
+	require.Len(t, s.attendances, 3)
+	// punch kiriman ulang tidak diproses lagi
+	require.Equal(t, submits+1, s.submits)
+	require.Len(t, s.audits, 3)
+}
+
+func TestIngestDevicePunches_RejectsBadKeyAndPayload(t *testing.T) {
+	u, s, key := deviceUsecase(t)
+	srv := ingestServer(u)
+	defer srv.Close()
+
+	dev := &testm.SimulatedDevice{URL: srv.URL + "/v1/devices/punches", APIKey: "dev_wrong"}
+	dev.Punch("1001", time.Date(2025, 8, 5, 8, 0, 0, 0, time.FixedZone("WIB", 7*3600)), 0)
+	status, _, err := dev.Push()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, status)
+	require.Empty(t, s.attendances)
+
+	_, err = u.IngestDevicePunches(makeGinCtx(), key, []byte("1001\tnot-a-time\t0\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid time")
+
+	_, err = u.CreateDevice(makeGinCtx(), 1, devDTO.CreateDeviceRequest{Name: "Gate", Vendor: "acme", SerialNumber: "AC-1"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown vendor")
+
+	_, err = u.CreateDevice(makeGinCtx(), 1, devDTO.CreateDeviceRequest{Name: "Gate", Vendor: "zkteco", SerialNumber: " zk-01 "})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already registered")
+}
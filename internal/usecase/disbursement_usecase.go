@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"payslip-generation-system/config"
+	"payslip-generation-system/internal/disbursement"
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	disbursementRepo "payslip-generation-system/internal/repository/disbursement"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GenerateDisbursement membuat file bulk transfer untuk item run payroll period ini yang masih pending.
+// Item dengan rekening belum terverifikasi menolak pembuatan kecuali SkipUnpayable; item yang dilewati
+// dibayar lewat batch susulan setelah rekeningnya diverifikasi. Item yang masuk batch ditandai sent
+// pada transaksi yang sama.
+func (u *usecase) GenerateDisbursement(ctx *gin.Context, actorID, periodID uint, req pDTO.GenerateDisbursementRequest) (resp *pDTO.DisbursementBatchResponse, err error) {
+	dcfg := u.disbursementConfig()
+	format := req.Format
+	if format == "" {
+		format = dcfg.DefaultFormat
+	}
+	formatter, err := disbursement.Get(format)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "format")
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	execDate := today
+	if req.ExecutionDate != "" {
+		execDate, err = time.Parse("2006-01-02", req.ExecutionDate)
+		if err != nil || execDate.Before(today) {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "execution_date")
+		}
+	}
+
+	period, err := u.payrollRepo.GetPeriodByID(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.BadRequest, "attendance period not found")
+	}
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+	if runStatusOf(run) != model.PayrollRunStatusApproved {
+		return nil, utils.MakeError(errorUc.BadRequest, "payroll run must be approved before disbursement")
+	}
+	latest, err := u.disbursementRepo.GetByRun(ctx, run.ID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load disbursement batch"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	items, err := u.payrollRepo.ListItemsByRun(ctx, run.ID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load payroll items"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error (payroll items)")
+	}
+	// hanya item yang belum pernah masuk file; nomor urut batch susulan melanjutkan batch sebelumnya
+	payable := make([]model.PayrollItem, 0, len(items))
+	userIDs := make([]uint, 0, len(items))
+	nextSeq := 1
+	for _, it := range items {
+		if it.PaymentSequence >= nextSeq {
+			nextSeq = it.PaymentSequence + 1
+		}
+		if it.PaymentStatus == model.PaymentStatusPending && disbursement.ToMinorUnits(it.GrandTotal) > 0 {
+			payable = append(payable, it)
+			userIDs = append(userIDs, it.UserID)
+		}
+	}
+	batchNo := 1
+	if latest != nil {
+		if len(payable) == 0 {
+			return nil, utils.MakeError(errorUc.ConflictError, "disbursement file already generated for this payroll run")
+		}
+		batchNo = latest.BatchNo + 1
+	}
+	// status rekening dicek ulang saat ini: bisa saja diverifikasi setelah run
+	accounts, err := u.bankAccountRepo.ListByUsers(ctx, userIDs)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load bank accounts"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error (bank accounts)")
+	}
+
+	remark := "GAJI " + period.Name
+	entries := make([]disbursement.Entry, 0, len(payable))
+	var skipped []uint
+	for _, it := range payable {
+		acc, ok := accounts[it.UserID]
+		if !ok || !acc.Verified {
+			skipped = append(skipped, it.UserID)
+			continue
+		}
+		number, err := u.fieldCipher.Decrypt(acc.AccountNumberEnc, bankAccountAAD(acc.UserID, "account_number"))
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to decrypt bank account"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to read bank account")
+		}
+		holder, err := u.fieldCipher.Decrypt(acc.HolderNameEnc, bankAccountAAD(acc.UserID, "holder_name"))
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to decrypt bank account"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to read bank account")
+		}
+		entries = append(entries, disbursement.Entry{
+			UserID:        it.UserID,
+			BankCode:      acc.BankCode,
+			AccountNumber: number,
+			HolderName:    holder,
+			Amount:        disbursement.ToMinorUnits(it.GrandTotal),
+			Reference:     remark,
+		})
+	}
+	if len(skipped) > 0 && !req.SkipUnpayable {
+		return nil, utils.MakeError(errorUc.BadRequest,
+			fmt.Sprintf("%d employees have no verified bank account (user ids %v)", len(skipped), skipped))
+	}
+
+	reference := fmt.Sprintf("PR%08d%s", run.ID, execDate.Format("20060102"))
+	if batchNo > 1 {
+		reference += fmt.Sprintf("S%d", batchNo)
+	}
+	batch, err := disbursement.NewBatch(reference, dcfg.CompanyCode, dcfg.SourceAccount, execDate, entries)
+	if err != nil {
+		if errors.Is(err, disbursement.ErrEmptyBatch) {
+			return nil, utils.MakeError(errorUc.BadRequest, "no payable items in this payroll run")
+		}
+		u.log.Error(log.LogData{Err: err, Description: "invalid disbursement batch"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to build disbursement batch")
+	}
+	batch.StartAt(nextSeq)
+	file, err := disbursement.Generate(formatter, batch)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to format disbursement file"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to build disbursement file")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	now := time.Now().UTC()
+	row := &model.DisbursementBatch{
+		PayrollRunID: run.ID,
+		BatchNo:      batchNo,
+		Reference:    reference,
+		Format:       file.Format,
+		FileName:     file.Name,
+		ContentType:  file.ContentType,
+		Content:      file.Content,
+		Checksum:     file.Checksum,
+		ItemCount:    batch.Count(),
+		TotalAmount:  float64(batch.TotalAmount) / 100,
+		HashTotal:    batch.HashTotal,
+		SkippedCount: len(skipped),
+		ExecutionAt:  execDate,
+		CreatedBy:    actorID,
+		CreatedAt:    now,
+	}
+	if err := u.disbursementRepo.Create(txCtx, row); err != nil {
+		if errors.Is(err, disbursementRepo.ErrBatchExists) {
+			return nil, utils.MakeError(errorUc.ConflictError, "disbursement file was generated concurrently for this payroll run")
+		}
+		u.log.Error(log.LogData{Err: err, Description: "failed to save disbursement batch"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to save disbursement batch")
+	}
+	if err := u.markItemsSent(txCtx, payable, batch, now); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to mark payroll items as sent"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update payment status")
+	}
+
+	u.log.Info(log.LogData{Description: "disbursement batch generated", Response: map[string]any{
+		"run_id": run.ID, "batch_no": batchNo, "reference": reference, "items": batch.Count(), "skipped": len(skipped), "by": actorID,
+	}})
+	resp = toDisbursementResponse(row, periodID)
+	resp.SkippedUserIDs = skipped
+	return resp, nil
+}
+
+func (u *usecase) GetDisbursement(ctx *gin.Context, periodID uint) (*pDTO.DisbursementBatchResponse, error) {
+	row, err := u.findDisbursement(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	return toDisbursementResponse(row, periodID), nil
+}
+
+// DisbursementFile mengembalikan batch beserta isi file untuk diunduh.
+func (u *usecase) DisbursementFile(ctx *gin.Context, periodID uint) (*model.DisbursementBatch, error) {
+	return u.findDisbursement(ctx, periodID)
+}
+
+func (u *usecase) findDisbursement(ctx *gin.Context, periodID uint) (*model.DisbursementBatch, error) {
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+	row, err := u.disbursementRepo.GetByRun(ctx, run.ID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load disbursement batch"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError)
+	}
+	return row, nil
+}
+
+func (u *usecase) disbursementConfig() config.DisbursementConfig {
+	var c config.DisbursementConfig
+	if u.cfg != nil {
+		c = u.cfg.Payroll.Disbursement
+	}
+	if c.DefaultFormat == "" {
+		c.DefaultFormat = disbursement.FormatCSV
+	}
+	return c
+}
+
+func toDisbursementResponse(row *model.DisbursementBatch, periodID uint) *pDTO.DisbursementBatchResponse {
+	return &pDTO.DisbursementBatchResponse{
+		ID:            row.ID,
+		PeriodID:      periodID,
+		RunID:         row.PayrollRunID,
+		BatchNo:       row.BatchNo,
+		Reference:     row.Reference,
+		Format:        row.Format,
+		FileName:      row.FileName,
+		Checksum:      row.Checksum,
+		ItemCount:     row.ItemCount,
+		TotalAmount:   fmt.Sprintf("%.2f", row.TotalAmount),
+		HashTotal:     row.HashTotal,
+		SkippedCount:  row.SkippedCount,
+		ExecutionDate: row.ExecutionAt.Format("2006-01-02"),
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,170 @@
+package usecase_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+	"payslip-generation-system/pkg/fieldcrypt"
+)
+
+func encryptedAccount(t *testing.T, c *fieldcrypt.Cipher, userID uint, number, holder string, verified bool) *model.EmployeeBankAccount {
+	t.Helper()
+	numEnc, err := c.Encrypt(number, fmt.Sprintf("bank_account:%d:account_number", userID))
+	require.NoError(t, err)
+	holderEnc, err := c.Encrypt(holder, fmt.Sprintf("bank_account:%d:holder_name", userID))
+	require.NoError(t, err)
+	return &model.EmployeeBankAccount{
+		UserID: userID, BankCode: "BCA", AccountNumberEnc: numEnc, AccountLast4: number[len(number)-4:],
+		HolderNameEnc: holderEnc, Verified: verified,
+	}
+}
+
+func setupDisbursement(t *testing.T, saved **model.DisbursementBatch, sent *[]model.PayrollItem) (usecase.IUsecase, map[uint]*model.EmployeeBankAccount) {
+	u := usecase.NewForTest()
+	cipher := testm.NewFieldCipher()
+	items := []model.PayrollItem{
+		{ID: 70, UserID: 7, GrandTotal: 5000000.50, PaymentStatus: model.PaymentStatusPending},
+		{ID: 80, UserID: 8, GrandTotal: 2500000, PaymentStatus: model.PaymentStatusPending},
+		{ID: 90, UserID: 9, GrandTotal: 0, PaymentStatus: model.PaymentStatusPending}, // tidak ada yang dibayar
+	}
+
+	payMock := &testm.PayRepoMock{
+		GetPeriodByIDFn: func(_ context.Context, id uint) (*model.AttendancePeriod, error) {
+			return &model.AttendancePeriod{ID: id, Name: "Aug 2025"}, nil
+		},
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			return &model.PayrollRun{ID: 12, PeriodID: periodID}, nil
+		},
+		ListItemsByRunFn: func(_ context.Context, runID uint) ([]model.PayrollItem, error) {
+			return append([]model.PayrollItem(nil), items...), nil
+		},
+		UpdateItemPaymentFn: func(_ context.Context, it *model.PayrollItem, from string) (bool, error) {
+			require.Equal(t, model.PaymentStatusPending, from)
+			for i := range items {
+				if items[i].ID == it.ID {
+					items[i] = *it
+				}
+			}
+			*sent = append(*sent, *it)
+			return true, nil
+		},
+	}
+	accounts := map[uint]*model.EmployeeBankAccount{
+		7: encryptedAccount(t, cipher, 7, "1234567890", "Citra Dewi", true),
+		8: encryptedAccount(t, cipher, 8, "9876543210", "Budi", false),
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{
+		ListByUsersFn: func(_ context.Context, ids []uint) (map[uint]*model.EmployeeBankAccount, error) {
+			return accounts, nil
+		},
+	}, cipher)
+	usecase.InjectDisbursementForTest(u, &testm.DisbursementRepoMock{
+		GetByRunFn: func(_ context.Context, runID uint) (*model.DisbursementBatch, error) { return *saved, nil },
+		CreateFn: func(_ context.Context, b *model.DisbursementBatch) error {
+			b.ID = uint(b.BatchNo)
+			*saved = b
+			return nil
+		},
+	})
+	return u, accounts
+}
+
+func TestGenerateDisbursement_UnverifiedAccountsBlockUnlessSkipped(t *testing.T) {
+	var saved *model.DisbursementBatch
+	var sent []model.PayrollItem
+	u, _ := setupDisbursement(t, &saved, &sent)
+
+	_, err := u.GenerateDisbursement(makeGinCtx(), 1, 3, pDTO.GenerateDisbursementRequest{Format: "csv"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no verified bank account")
+	require.Nil(t, saved)
+
+	resp, err := u.GenerateDisbursement(makeGinCtx(), 1, 3, pDTO.GenerateDisbursementRequest{Format: "csv", SkipUnpayable: true})
+	require.NoError(t, err)
+	require.Equal(t, []uint{8}, resp.SkippedUserIDs)
+	require.Equal(t, 1, resp.ItemCount)
+	require.Equal(t, "5000000.50", resp.TotalAmount)
+
+	sum := sha256.Sum256(saved.Content)
+	require.Equal(t, hex.EncodeToString(sum[:]), saved.Checksum)
+	require.Contains(t, string(saved.Content), "1,BCA,1234567890,Citra Dewi,5000000.50,GAJI Aug 2025,")
+	require.NotContains(t, string(saved.Content), "9876543210")
+
+	// hanya item yang masuk file ditandai sent
+	require.Len(t, sent, 1)
+	require.Equal(t, uint(70), sent[0].ID)
+	require.Equal(t, model.PaymentStatusSent, sent[0].PaymentStatus)
+	require.Equal(t, 1, sent[0].PaymentSequence)
+	require.Equal(t, "7890", sent[0].PaymentAccountLast4)
+	require.NotNil(t, sent[0].SentAt)
+
+	// rekening user 8 belum diverifikasi: belum ada yang bisa dibayar lewat batch susulan
+	_, err = u.GenerateDisbursement(makeGinCtx(), 1, 3, pDTO.GenerateDisbursementRequest{SkipUnpayable: true})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no payable items")
+	require.Len(t, sent, 1)
+}
+
+func TestGenerateDisbursement_SupplementaryBatchForSkippedItems(t *testing.T) {
+	var saved *model.DisbursementBatch
+	var sent []model.PayrollItem
+	u, accounts := setupDisbursement(t, &saved, &sent)
+
+	_, err := u.GenerateDisbursement(makeGinCtx(), 1, 3, pDTO.GenerateDisbursementRequest{Format: "csv", SkipUnpayable: true})
+	require.NoError(t, err)
+	first := saved.Reference
+
+	// rekening user 8 diverifikasi setelah batch pertama
+	accounts[8].Verified = true
+	resp, err := u.GenerateDisbursement(makeGinCtx(), 1, 3, pDTO.GenerateDisbursementRequest{Format: "csv"})
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.BatchNo)
+	require.Equal(t, first+"S2", resp.Reference)
+	require.Equal(t, 1, resp.ItemCount)
+	require.Equal(t, "2500000.00", resp.TotalAmount)
+	require.Zero(t, resp.SkippedCount)
+	// nomor urut melanjutkan batch pertama; item 70 tidak dikirim ulang
+	require.Contains(t, string(saved.Content), "2,BCA,9876543210,Budi,2500000.00,GAJI Aug 2025,")
+	require.NotContains(t, string(saved.Content), "1234567890")
+	require.Len(t, sent, 2)
+	require.Equal(t, uint(80), sent[1].ID)
+	require.Equal(t, 2, sent[1].PaymentSequence)
+
+	// semua item sudah masuk file
+	_, err = u.GenerateDisbursement(makeGinCtx(), 1, 3, pDTO.GenerateDisbursementRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already generated")
+}
+
+func TestGenerateDisbursement_FixedWidthTotals(t *testing.T) {
+	var saved *model.DisbursementBatch
+	var sent []model.PayrollItem
+	u, _ := setupDisbursement(t, &saved, &sent)
+
+	exec := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	resp, err := u.GenerateDisbursement(makeGinCtx(), 1, 3, pDTO.GenerateDisbursementRequest{
+		Format: "bca_fixed", ExecutionDate: exec, SkipUnpayable: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "1234567890", resp.HashTotal)
+
+	lines := strings.Split(strings.TrimSuffix(string(saved.Content), "\r\n"), "\r\n")
+	require.Len(t, lines, 3) // header, 1 detail, trailer
+	for _, l := range lines {
+		require.Len(t, l, 120)
+	}
+	require.True(t, strings.HasPrefix(lines[1], "1000001BCA       1234567890          CITRA DEWI"))
+	require.Equal(t, "9"+"000001"+"00000000500000050"+"00000000001234567890", strings.TrimRight(lines[2], " "))
+}
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"strings"
+
+	employeeDTO "payslip-generation-system/internal/dto/employee"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	employeeRepo "payslip-generation-system/internal/repository/employee"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+const revokeReasonEmployeeUpdate = "employee_updated"
+
+// ListEmployees: direktori karyawan untuk admin (search, filter, sort, paginasi).
+func (u *usecase) ListEmployees(ctx *gin.Context, q employeeDTO.ListEmployeesQuery) ([]employeeDTO.EmployeeResponse, *utils.Metadata, error) {
+	page := q.Pagination.Normalize()
+	sort := q.Sort
+	if sort != "" && !employeeRepo.IsSortable(sort) {
+		return nil, nil, utils.MakeError(errorUc.InvalidFormat, "sort")
+	}
+
+	rows, total, err := u.employeeRepo.List(ctx, employeeRepo.ListFilter{
+		Search:           q.Search,
+		Role:             q.Role,
+		Department:       strings.TrimSpace(q.Department),
+		EmploymentStatus: q.EmploymentStatus,
+		Sort:             sort,
+		Desc:             q.Order == "desc",
+		Offset:           page.Offset(),
+		Limit:            page.PageSize,
+	})
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list employees"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	out := make([]employeeDTO.EmployeeResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toEmployeeResponse(&rows[i]))
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+func (u *usecase) GetEmployee(ctx *gin.Context, userID uint) (*employeeDTO.EmployeeResponse, error) {
+	user, err := u.employeeRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load employee"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError)
+	}
+	resp := toEmployeeResponse(user)
+	return &resp, nil
+}
+
+// UpdateEmployee dipakai admin. Role disimpan di access token, jadi perubahan role (dan status terminated)
+// mencabut semua session supaya langsung berlaku. Admin tidak bisa mengubah role/status dirinya sendiri.
+func (u *usecase) UpdateEmployee(ctx *gin.Context, actorID, userID uint, req employeeDTO.UpdateEmployeeRequest) (resp *employeeDTO.EmployeeResponse, err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	user, err := u.employeeRepo.FindByID(txCtx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load employee"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError)
+	}
+
+	roleChanged := req.Role != nil && *req.Role != user.Role
+	statusChanged := req.EmploymentStatus != nil && *req.EmploymentStatus != user.EmploymentStatus
+	if actorID == userID && (roleChanged || statusChanged) {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "cannot change your own role or employment status")
+	}
+
+	fields := employeeRepo.UpdateFields{
+		Role:             req.Role,
+		Salary:           req.Salary,
+		EmploymentStatus: req.EmploymentStatus,
+	}
+	if req.FirstName != nil {
+		name := strings.TrimSpace(*req.FirstName)
+		if name == "" {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "first_name")
+		}
+		user.FirstName, fields.FirstName = name, &name
+	}
+	if req.LastName != nil {
+		name := strings.TrimSpace(*req.LastName)
+		if name == "" {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "last_name")
+		}
+		user.LastName, fields.LastName = name, &name
+	}
+	if req.Department != nil {
+		dept := strings.TrimSpace(*req.Department)
+		user.Department, fields.Department = dept, &dept
+	}
+	if req.Role != nil {
+		user.Role = *req.Role
+	}
+	if req.Salary != nil {
+		user.Salary = *req.Salary
+	}
+	if req.EmploymentStatus != nil {
+		user.EmploymentStatus = *req.EmploymentStatus
+	}
+	complete := isProfileComplete(user)
+	user.IsProfileComplete, fields.IsProfileComplete = complete, &complete
+
+	if err = u.employeeRepo.Update(txCtx, userID, fields); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update employee"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update employee")
+	}
+
+	if roleChanged || (statusChanged && user.EmploymentStatus == model.EmploymentStatusTerminated) {
+		if err = u.revokeAllSessionsInTx(txCtx, userID, revokeReasonEmployeeUpdate); err != nil {
+			return nil, err
+		}
+	}
+
+	u.log.Info(log.LogData{Description: "employee updated", Response: map[string]any{"user_id": userID, "by": actorID}})
+	out := toEmployeeResponse(user)
+	return &out, nil
+}
+
+func toEmployeeResponse(user *model.User) employeeDTO.EmployeeResponse {
+	return employeeDTO.EmployeeResponse{
+		ID:                user.ID,
+		Email:             user.Email,
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		Role:              user.Role,
+		Department:        user.Department,
+		EmploymentStatus:  user.EmploymentStatus,
+		Salary:            user.Salary,
+		ProfileImageURL:   user.ProfileImageURL,
+		IsProfileComplete: user.IsProfileComplete,
+		TwoFactorEnabled:  user.TwoFactorEnabled,
+		CreatedAt:         user.CreatedAt,
+	}
+}
@@ -0,0 +1,102 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	employeeDTO "payslip-generation-system/internal/dto/employee"
+	"payslip-generation-system/internal/model"
+	employeeRepo "payslip-generation-system/internal/repository/employee"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+	"payslip-generation-system/utils"
+)
+
+func TestListEmployees_FiltersAndMetadata(t *testing.T) {
+	u := usecase.NewForTest()
+
+	var got employeeRepo.ListFilter
+	usecase.InjectEmployeeForTest(u, &testm.EmployeeRepoMock{
+		ListFn: func(_ context.Context, f employeeRepo.ListFilter) ([]model.User, int64, error) {
+			got = f
+			return []model.User{{ID: 21, Email: "x@example.com"}}, 41, nil
+		},
+	})
+
+	rows, meta, err := u.ListEmployees(makeGinCtx(), employeeDTO.ListEmployeesQuery{
+		Search:           "ani",
+		Department:       " Finance ",
+		EmploymentStatus: model.EmploymentStatusActive,
+		Sort:             "name",
+		Order:            "desc",
+		Pagination:       utils.Pagination{Page: 3, PageSize: 10},
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, "Finance", got.Department)
+	require.True(t, got.Desc)
+	require.Equal(t, 20, got.Offset)
+	require.Equal(t, 10, got.Limit)
+	require.Equal(t, utils.Metadata{PageSize: 10, Page: 3, TotalPage: 5, TotalData: 41}, *meta)
+}
+
+func TestUpdateEmployee_TerminateRevokesSessions(t *testing.T) {
+	u := usecase.NewForTest()
+
+	var updated employeeRepo.UpdateFields
+	var bumped bool
+	var revokedReason string
+	usecase.InjectEmployeeForTest(u, &testm.EmployeeRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, Email: "b@example.com", FirstName: "Budi", LastName: "S", Role: "user", EmploymentStatus: model.EmploymentStatusActive}, nil
+		},
+		UpdateFn: func(_ context.Context, id uint, f employeeRepo.UpdateFields) error {
+			updated = f
+			return nil
+		},
+	})
+	authMock := &testm.AuthRepoMock{
+		BumpTokenVersionFn: func(_ context.Context, userID uint) error {
+			bumped = true
+			return nil
+		},
+	}
+	sessMock := &testm.SessionRepoMock{
+		RevokeAllForUserFn: func(_ context.Context, userID uint, reason string, at time.Time) error {
+			revokedReason = reason
+			return nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, sessMock, testm.FakeTxManager{})
+
+	status := model.EmploymentStatusTerminated
+	resp, err := u.UpdateEmployee(makeGinCtx(), 1, 9, employeeDTO.UpdateEmployeeRequest{EmploymentStatus: &status})
+	require.NoError(t, err)
+	require.Equal(t, model.EmploymentStatusTerminated, resp.EmploymentStatus)
+	require.Equal(t, model.EmploymentStatusTerminated, *updated.EmploymentStatus)
+	require.True(t, bumped)
+	require.NotEmpty(t, revokedReason)
+}
+
+func TestUpdateEmployee_CannotChangeOwnRole(t *testing.T) {
+	u := usecase.NewForTest()
+
+	usecase.InjectEmployeeForTest(u, &testm.EmployeeRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, Role: "admin"}, nil
+		},
+		UpdateFn: func(_ context.Context, id uint, f employeeRepo.UpdateFields) error {
+			t.Fatal("must not update")
+			return nil
+		},
+	})
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), &testm.AuthRepoMock{}, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+
+	role := "user"
+	_, err := u.UpdateEmployee(makeGinCtx(), 4, 4, employeeDTO.UpdateEmployeeRequest{Role: &role})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "own role")
+}
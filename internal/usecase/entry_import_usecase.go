@@ -0,0 +1,244 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"payslip-generation-system/internal/bulkimport"
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// importBatchSize jumlah baris per INSERT saat import
+const importBatchSize = 500
+
+// importReason dicatat sebagai alasan input admin pada baris hasil import
+const importReason = "csv import"
+
+// hasil validasi baris import
+const (
+	importResultOK      = "ok"
+	importResultSkipped = "skipped"
+	importResultError   = "error"
+)
+
+type importKey struct {
+	userID uint
+	date   string
+}
+
+// ImportPeriodEntries mengimpor attendance / overtime / reimbursement dari CSV ke satu period.
+// Setiap baris divalidasi dengan aturan yang sama seperti submit biasa; jika ada satu baris error
+// (atau dry-run) tidak ada yang disimpan. Insert dilakukan dalam satu transaksi, per batch.
+func (u *usecase) ImportPeriodEntries(ctx *gin.Context, actorID, periodID uint, file io.Reader, dryRun bool) (resp *pDTO.ImportEntriesResponse, err error) {
+	rows, err := bulkimport.ParseCSV(file)
+	if err != nil {
+		if errors.Is(err, bulkimport.ErrInvalidFile) {
+			return nil, utils.MakeError(errorUc.BadRequest, err.Error())
+		}
+		u.log.Error(log.LogData{Err: err, Description: "failed to read import file"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to read import file")
+	}
+
+	period, err := u.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	// semua baris ada di period yang sama: cukup cek kunci period ini (dicek ulang di dalam transaksi)
+	if err = u.ensureDateOpen(ctx, period.StartDate); err != nil {
+		return nil, err
+	}
+
+	existingAt, err := u.atRepo.ListByDateRange(ctx, period.StartDate, period.EndDate)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendances"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	existingOt, err := u.otRepo.ListByDateRange(ctx, period.StartDate, period.EndDate)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load overtimes"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	inDB := map[string]map[importKey]bool{bulkimport.KindAttendance: {}, bulkimport.KindOvertime: {}}
+	for _, a := range existingAt {
+		inDB[bulkimport.KindAttendance][importKey{a.UserID, a.Date.Format("2006-01-02")}] = true
+	}
+	for _, o := range existingOt {
+		inDB[bulkimport.KindOvertime][importKey{o.UserID, o.Date.Format("2006-01-02")}] = true
+	}
+	inFile := map[string]map[importKey]bool{bulkimport.KindAttendance: {}, bulkimport.KindOvertime: {}}
+
+	resp = &pDTO.ImportEntriesResponse{
+		PeriodID: periodID,
+		DryRun:   dryRun,
+		Lines:    make([]pDTO.ImportEntryLine, 0, len(rows)),
+	}
+	var (
+		ats []*model.Attendance
+		ots []*model.Overtime
+		rbs []*model.Reimbursement
+	)
+	users := map[uint]*model.User{}
+	now := time.Now().In(time.FixedZone("WIB", 7*3600))
+	submittedBy := actorID
+	for _, row := range rows {
+		line := pDTO.ImportEntryLine{Line: row.Line, Type: row.Kind, UserID: row.UserID, Result: importResultOK}
+		if !row.Date.IsZero() {
+			line.Date = row.Date.Format("2006-01-02")
+		}
+
+		var msg string
+		msg, err = u.checkImportRow(ctx, row, period, users, now)
+		if err != nil {
+			return nil, err
+		}
+		key := importKey{row.UserID, line.Date}
+		switch {
+		case msg != "":
+			line.Result, line.Message = importResultError, msg
+		case row.Kind == bulkimport.KindReimbursement:
+			rbs = append(rbs, &model.Reimbursement{
+				UserID: row.UserID, Date: row.Date, Amount: row.Amount, Description: row.Description,
+				SubmittedBy: &submittedBy, OnBehalfReason: importReason,
+			})
+		case inDB[row.Kind][key]:
+			line.Result, line.Message = importResultSkipped, row.Kind+" already exists"
+		case inFile[row.Kind][key] && row.Kind == bulkimport.KindAttendance:
+			// mesin fingerprint bisa mengekspor beberapa scan per hari
+			line.Result, line.Message = importResultSkipped, "duplicate row in file"
+		case inFile[row.Kind][key]:
+			line.Result, line.Message = importResultError, "overtime for that date appears more than once"
+		case row.Kind == bulkimport.KindAttendance:
+			inFile[row.Kind][key] = true
+			ats = append(ats, &model.Attendance{
+				UserID: row.UserID, Date: row.Date,
+				SubmittedBy: &submittedBy, OnBehalfReason: importReason,
+			})
+		default:
+			inFile[row.Kind][key] = true
+			// dicocokkan dengan rencana overtime seperti submit biasa; mode reject menolak barisnya
+			var planID *uint
+			var flag string
+			planID, flag, msg, err = u.checkOvertimePlan(ctx, row.UserID, row.Date, row.Hours)
+			if err != nil {
+				return nil, err
+			}
+			if msg != "" {
+				line.Result, line.Message = importResultError, msg
+			} else {
+				ots = append(ots, &model.Overtime{
+					UserID: row.UserID, Date: row.Date, Hours: row.Hours,
+					SubmittedBy: &submittedBy, OnBehalfReason: importReason,
+					PlanID: planID, PlanFlagged: flag != "", PlanFlagReason: flag,
+				})
+			}
+		}
+
+		resp.Processed++
+		switch line.Result {
+		case importResultOK:
+			resp.Valid++
+		case importResultSkipped:
+			resp.Skipped++
+		default:
+			resp.Errors++
+		}
+		resp.Lines = append(resp.Lines, line)
+	}
+	resp.Attendances, resp.Overtimes, resp.Reimbursements = len(ats), len(ots), len(rbs)
+
+	if dryRun || resp.Errors > 0 {
+		return resp, nil
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	// period bisa saja di-run / diajukan selama validasi: cek ulang kunci tepat sebelum insert
+	if err = u.ensureDateOpen(txCtx, period.StartDate); err != nil {
+		return nil, err
+	}
+	if err = u.atRepo.CreateBatch(txCtx, ats, importBatchSize); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to import attendances"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to import attendances")
+	}
+	if err = u.otRepo.CreateBatch(txCtx, ots, importBatchSize); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to import overtimes"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to import overtimes")
+	}
+	if err = u.rbRepo.CreateBatch(txCtx, rbs, importBatchSize); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to import reimbursements"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to import reimbursements")
+	}
+	resp.Committed = true
+
+	u.log.Info(log.LogData{Description: "period entries imported", Response: map[string]any{
+		"period_id": periodID, "attendances": resp.Attendances, "overtimes": resp.Overtimes,
+		"reimbursements": resp.Reimbursements, "skipped": resp.Skipped, "by": actorID,
+	}})
+	return resp, nil
+}
+
+// checkImportRow mengembalikan pesan error baris (kosong jika valid). error hanya untuk kegagalan db.
+func (u *usecase) checkImportRow(ctx *gin.Context, row bulkimport.Row, period *apRepo.PeriodRow, users map[uint]*model.User, now time.Time) (string, error) {
+	if row.Error != "" {
+		return row.Error, nil
+	}
+	date := row.Date.Format("2006-01-02")
+	if date < period.StartDate.Format("2006-01-02") || date > period.EndDate.Format("2006-01-02") {
+		return "date is outside the period", nil
+	}
+
+	user, cached := users[row.UserID]
+	if !cached {
+		var err error
+		if user, err = u.employeeRepo.FindByID(ctx, row.UserID); err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to load employee"})
+			return "", utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		users[row.UserID] = user
+	}
+	if user == nil {
+		return "employee not found", nil
+	}
+	if user.EmploymentStatus == model.EmploymentStatusTerminated {
+		return "employee is terminated", nil
+	}
+
+	switch row.Kind {
+	case bulkimport.KindAttendance:
+		if wd := row.Date.Weekday(); wd == time.Saturday || wd == time.Sunday {
+			return "cannot submit attendance on weekend", nil
+		}
+	case bulkimport.KindOvertime:
+		if row.Hours <= 0 || row.Hours > 3 {
+			return "hours must be > 0 and <= 3", nil
+		}
+		if overtimeTooEarly(now, row.Date) {
+			return "overtime can only be submitted after 17:00 WIB", nil
+		}
+	case bulkimport.KindReimbursement:
+		if row.Amount <= 0 {
+			return "amount must be > 0", nil
+		}
+	}
+	return "", nil
+}
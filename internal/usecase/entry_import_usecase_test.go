@@ -0,0 +1,218 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/internal/model"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+type importWrites struct {
+	attendances    []*model.Attendance
+	overtimes      []*model.Overtime
+	reimbursements []*model.Reimbursement
+	batchSize      int
+	lockChecks     int // panggilan HasRunOnDate
+	lockedFrom     int // period terkunci mulai cek ke-n (0 = tidak pernah)
+}
+
+func importUsecase(w *importWrites) usecase.IUsecase {
+	u := usecase.NewForTest()
+	apMock := &testm.APRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*apRepo.PeriodRow, error) {
+			return &apRepo.PeriodRow{AttendancePeriod: model.AttendancePeriod{
+				ID:        id,
+				StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+			}}, nil
+		},
+	}
+	atMock := &testm.ATRepoMock{
+		ListByDateRangeFn: func(_ context.Context, start, end time.Time) ([]model.Attendance, error) {
+			return []model.Attendance{{ID: 1, UserID: 42, Date: time.Date(2025, 8, 4, 0, 0, 0, 0, time.UTC)}}, nil
+		},
+		CreateBatchFn: func(_ context.Context, rows []*model.Attendance, batchSize int) error {
+			w.attendances, w.batchSize = rows, batchSize
+			return nil
+		},
+	}
+	otMock := &testm.OTRepoMock{
+		ListByDateRangeFn: func(_ context.Context, start, end time.Time) ([]model.Overtime, error) { return nil, nil },
+		CreateBatchFn: func(_ context.Context, rows []*model.Overtime, batchSize int) error {
+			w.overtimes = rows
+			return nil
+		},
+	}
+	rbMock := &testm.RBRepoMock{
+		CreateBatchFn: func(_ context.Context, rows []*model.Reimbursement, batchSize int) error {
+			w.reimbursements = rows
+			return nil
+		},
+	}
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) {
+			w.lockChecks++
+			return w.lockedFrom > 0 && w.lockChecks >= w.lockedFrom, nil
+		},
+	}
+	usecase.InjectForTest(u, apMock, atMock, otMock, rbMock, payMock, testm.FakeTxManager{})
+	usecase.InjectEmployeeForTest(u, employeeMock(
+		model.User{ID: 42, EmploymentStatus: model.EmploymentStatusActive},
+		model.User{ID: 43, EmploymentStatus: model.EmploymentStatusTerminated},
+	))
+	return u
+}
+
+func TestImportPeriodEntries_RowErrorsWriteNothing(t *testing.T) {
+	w := &importWrites{}
+	u := importUsecase(w)
+
+	file := "user_id,date,type,hours,amount,description\n" +
+		"42,2025-08-05,attendance,,,\n" +
+		"42,2025-08-09,attendance,,,\n" + // Sabtu
+		"42,2025-08-05,overtime,4,,\n" +
+		"43,2025-08-06,attendance,,,\n" +
+		"42,2025-09-01,reimbursement,,50000,taxi\n" +
+		"99,2025-08-06,attendance,,,\n" +
+		"42,2025-08-07,lunch,,,\n"
+	resp, err := u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader(file), false)
+	require.NoError(t, err)
+	require.False(t, resp.Committed)
+	require.Equal(t, 7, resp.Processed)
+	require.Equal(t, 1, resp.Valid)
+	require.Equal(t, 6, resp.Errors)
+	require.Equal(t, "ok", resp.Lines[0].Result)
+	require.Equal(t, "cannot submit attendance on weekend", resp.Lines[1].Message)
+	require.Equal(t, 4, resp.Lines[2].Line)
+	require.Equal(t, "hours must be > 0 and <= 3", resp.Lines[2].Message)
+	require.Equal(t, "employee is terminated", resp.Lines[3].Message)
+	require.Equal(t, "date is outside the period", resp.Lines[4].Message)
+	require.Equal(t, "employee not found", resp.Lines[5].Message)
+	require.Contains(t, resp.Lines[6].Message, "unknown type")
+	require.Nil(t, w.attendances)
+
+	// file tanpa kolom wajib ditolak seluruhnya
+	_, err = u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader("date\n2025-08-05\n"), false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing column user_id")
+}
+
+func TestImportPeriodEntries_DryRunThenCommit(t *testing.T) {
+	w := &importWrites{}
+	u := importUsecase(w)
+
+	file := "user_id,date,type,hours,amount,description\n" +
+		"42,2025-08-04 08:01:10,,,,\n" + // sudah ada di db
+		"42,2025-08-05 08:00:00,,,,\n" +
+		"42,2025-08-05 17:03:00,,,,\n" + // scan pulang hari yang sama
+		"42,2025-08-05,overtime,2.5,,\n" +
+		"42,2025-08-05,reimbursement,,75000,parking\n"
+
+	resp, err := u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader(file), true)
+	require.NoError(t, err)
+	require.True(t, resp.DryRun)
+	require.False(t, resp.Committed)
+	require.Equal(t, 3, resp.Valid)
+	require.Equal(t, 2, resp.Skipped)
+	require.Equal(t, "attendance already exists", resp.Lines[0].Message)
+	require.Equal(t, "duplicate row in file", resp.Lines[2].Message)
+	require.Nil(t, w.attendances)
+
+	resp, err = u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader(file), false)
+	require.NoError(t, err)
+	require.True(t, resp.Committed)
+	require.Len(t, w.attendances, 1)
+	require.Equal(t, "2025-08-05", w.attendances[0].Date.Format("2006-01-02"))
+	require.Equal(t, uint(1), *w.attendances[0].SubmittedBy)
+	require.Equal(t, "csv import", w.attendances[0].OnBehalfReason)
+	require.Positive(t, w.batchSize)
+	require.Len(t, w.overtimes, 1)
+	require.Equal(t, 2.5, w.overtimes[0].Hours)
+	require.Len(t, w.reimbursements, 1)
+	require.Equal(t, 75000.0, w.reimbursements[0].Amount)
+}
+
+func TestImportPeriodEntries_PeriodLockedDuringValidationWritesNothing(t *testing.T) {
+	// payroll di-run setelah validasi baris, sebelum insert
+	w := &importWrites{lockedFrom: 2}
+	u := importUsecase(w)
+
+	file := "user_id,date,type,hours,amount,description\n" +
+		"42,2025-08-05,attendance,,,\n" +
+		"42,2025-08-05,reimbursement,,75000,parking\n"
+	_, err := u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader(file), false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "locked")
+	require.Equal(t, 2, w.lockChecks)
+	require.Nil(t, w.attendances)
+	require.Nil(t, w.reimbursements)
+}
+
+func TestImportPeriodEntries_RejectsNaNAndInf(t *testing.T) {
+	w := &importWrites{}
+	u := importUsecase(w)
+
+	file := "user_id,date,type,hours,amount,description\n" +
+		"42,2025-08-05,overtime,NaN,,\n" +
+		"42,2025-08-05,overtime,Inf,,\n" +
+		"42,2025-08-06,reimbursement,,NaN,taxi\n" +
+		"42,2025-08-06,reimbursement,,-Inf,taxi\n"
+	resp, err := u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader(file), false)
+	require.NoError(t, err)
+	require.False(t, resp.Committed)
+	require.Equal(t, 4, resp.Errors)
+	require.Equal(t, "invalid hours", resp.Lines[0].Message)
+	require.Equal(t, "invalid hours", resp.Lines[1].Message)
+	require.Equal(t, "invalid amount", resp.Lines[2].Message)
+	require.Equal(t, "invalid amount", resp.Lines[3].Message)
+	require.Nil(t, w.overtimes)
+	require.Nil(t, w.reimbursements)
+}
+
+func TestImportPeriodEntries_MatchesOvertimePlans(t *testing.T) {
+	w := &importWrites{}
+	u := importUsecase(w)
+	usecase.InjectOvertimePlanForTest(u, &testm.OvertimePlanRepoMock{
+		FindApprovedFn: func(_ context.Context, userID uint, date time.Time) (*model.OvertimePlan, error) {
+			if date.Day() == 7 {
+				return nil, nil
+			}
+			return &model.OvertimePlan{ID: 11, UserID: userID, Date: date, Hours: 2, Status: model.OvertimePlanStatusApproved}, nil
+		},
+	})
+	file := "user_id,date,type,hours,amount,description\n" +
+		"42,2025-08-05,overtime,1.5,,\n" +
+		"42,2025-08-06,overtime,3,,\n" +
+		"42,2025-08-07,overtime,1,,\n"
+
+	usecase.InjectConfigForTest(u, planModeConfig(model.OvertimePlanModeFlag))
+	resp, err := u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader(file), false)
+	require.NoError(t, err)
+	require.True(t, resp.Committed)
+	require.Len(t, w.overtimes, 3)
+	require.Equal(t, uint(11), *w.overtimes[0].PlanID)
+	require.False(t, w.overtimes[0].PlanFlagged)
+	require.True(t, w.overtimes[1].PlanFlagged)
+	require.Equal(t, model.OvertimePlanFlagExceeds, w.overtimes[1].PlanFlagReason)
+	require.Nil(t, w.overtimes[2].PlanID)
+	require.Equal(t, model.OvertimePlanFlagNoPlan, w.overtimes[2].PlanFlagReason)
+
+	// mode reject: baris tanpa / melebihi rencana jadi error, file tidak di-commit
+	w.overtimes = nil
+	usecase.InjectConfigForTest(u, planModeConfig(model.OvertimePlanModeReject))
+	resp, err = u.ImportPeriodEntries(makeGinCtx(), 1, 3, strings.NewReader(file), false)
+	require.NoError(t, err)
+	require.False(t, resp.Committed)
+	require.Equal(t, 1, resp.Valid)
+	require.Equal(t, 2, resp.Errors)
+	require.Contains(t, resp.Lines[1].Message, "exceeds the approved plan")
+	require.Contains(t, resp.Lines[2].Message, "no approved overtime plan")
+	require.Nil(t, w.overtimes)
+}
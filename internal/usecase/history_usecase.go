@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	otDTO "payslip-generation-system/internal/dto/overtime"
+	rbDTO "payslip-generation-system/internal/dto/reimbursement"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxHistoryDays batas rentang tanggal riwayat tanpa period_id
+const maxHistoryDays = 366
+
+// status riwayat untuk tanggal yang belum masuk period mana pun
+const historyStatusNoPeriod = "no_period"
+
+// status hari pada kalender attendance
+const (
+	calendarAttended = "attended"
+	calendarMissing  = "missing"
+	calendarWeekend  = "weekend"
+	calendarHoliday  = "holiday"
+	calendarUpcoming = "upcoming"
+)
+
+// ListMyAttendances: attendance milik user per period / rentang tanggal beserta status period-nya.
+func (u *usecase) ListMyAttendances(ctx *gin.Context, userID uint, q atDTO.HistoryQuery) ([]atDTO.AttendanceHistoryResponse, *utils.Metadata, error) {
+	start, end, err := u.historyRange(ctx, q.PeriodID, q.StartDate, q.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	page := q.Pagination.Normalize()
+	rows, total, err := u.atRepo.ListByUser(ctx, userID, start, end, page.Offset(), page.PageSize)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list attendances"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	statusOf, err := u.periodStatusLookup(ctx, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make([]atDTO.AttendanceHistoryResponse, 0, len(rows))
+	for i := range rows {
+		a := &rows[i]
+		out = append(out, atDTO.AttendanceHistoryResponse{
+			AttendanceResponse: toAttendanceResponse(a),
+			LocationFlagged:    a.LocationFlagged,
+			SubmittedBy:        a.SubmittedBy,
+			OnBehalfReason:     a.OnBehalfReason,
+			Status:             statusOf(a.Date),
+		})
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+// ListMyOvertimes: overtime milik user per period / rentang tanggal beserta status period-nya.
+func (u *usecase) ListMyOvertimes(ctx *gin.Context, userID uint, q otDTO.HistoryQuery) ([]otDTO.OvertimeHistoryResponse, *utils.Metadata, error) {
+	start, end, err := u.historyRange(ctx, q.PeriodID, q.StartDate, q.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	page := q.Pagination.Normalize()
+	rows, total, err := u.otRepo.ListByUser(ctx, userID, start, end, page.Offset(), page.PageSize)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list overtimes"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	statusOf, err := u.periodStatusLookup(ctx, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make([]otDTO.OvertimeHistoryResponse, 0, len(rows))
+	for _, o := range rows {
+		out = append(out, otDTO.OvertimeHistoryResponse{
+			ID:             o.ID,
+			Date:           o.Date.Format("2006-01-02"),
+			Hours:          o.Hours,
+			SubmittedBy:    o.SubmittedBy,
+			OnBehalfReason: o.OnBehalfReason,
+			PlanID:         o.PlanID,
+			PlanFlag:       o.PlanFlagReason,
+			Status:         statusOf(o.Date),
+			CreatedAt:      o.CreatedAt,
+		})
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+// ListMyReimbursements: reimbursement milik user per period / rentang tanggal beserta status period-nya.
+func (u *usecase) ListMyReimbursements(ctx *gin.Context, userID uint, q rbDTO.HistoryQuery) ([]rbDTO.ReimbursementHistoryResponse, *utils.Metadata, error) {
+	start, end, err := u.historyRange(ctx, q.PeriodID, q.StartDate, q.EndDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	page := q.Pagination.Normalize()
+	rows, total, err := u.rbRepo.ListByUser(ctx, userID, start, end, page.Offset(), page.PageSize)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list reimbursements"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	statusOf, err := u.periodStatusLookup(ctx, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := make([]rbDTO.ReimbursementHistoryResponse, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, rbDTO.ReimbursementHistoryResponse{
+			ReimbursementResponse: rbDTO.ReimbursementResponse{
+				ID:             r.ID,
+				UserID:         r.UserID,
+				Date:           r.Date.Format("2006-01-02"),
+				Amount:         fmt.Sprintf("%.2f", r.Amount),
+				Description:    r.Description,
+				SubmittedBy:    r.SubmittedBy,
+				OnBehalfReason: r.OnBehalfReason,
+			},
+			Status:    statusOf(r.Date),
+			CreatedAt: r.CreatedAt,
+		})
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+// GetMyAttendanceCalendar: setiap hari dalam period ditandai attended / missing / weekend / holiday / upcoming.
+func (u *usecase) GetMyAttendanceCalendar(ctx *gin.Context, userID uint, q atDTO.CalendarQuery) (*atDTO.AttendanceCalendarResponse, error) {
+	var (
+		period *apRepo.PeriodRow
+		err    error
+	)
+	if q.PeriodID > 0 {
+		period, err = u.findPeriod(ctx, q.PeriodID)
+	} else {
+		period, err = u.currentPeriod(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	days := int(period.EndDate.Sub(period.StartDate).Hours()/24) + 1
+	rows, _, err := u.atRepo.ListByUser(ctx, userID, period.StartDate, period.EndDate, 0, days)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list attendances"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	attended := make(map[string]*model.Attendance, len(rows))
+	for i := range rows {
+		attended[rows[i].Date.Format("2006-01-02")] = &rows[i]
+	}
+	holidays := u.holidays()
+	today := u.clock().In(time.FixedZone("WIB", 7*3600)).Format("2006-01-02")
+
+	resp := &atDTO.AttendanceCalendarResponse{
+		PeriodID:     period.ID,
+		PeriodName:   period.Name,
+		StartDate:    period.StartDate.Format("2006-01-02"),
+		EndDate:      period.EndDate.Format("2006-01-02"),
+		PeriodStatus: periodStatus(period),
+		Days:         make([]atDTO.CalendarDay, 0, days),
+	}
+	for d := period.StartDate; !d.After(period.EndDate); d = d.AddDate(0, 0, 1) {
+		key := d.Format("2006-01-02")
+		day := atDTO.CalendarDay{Date: key, Weekday: d.Weekday().String(), Holiday: holidays[key]}
+		a := attended[key]
+		switch wd := d.Weekday(); {
+		case a != nil:
+			// hadir tetap dihitung walau jatuh di hari libur
+			day.Status = calendarAttended
+			day.AttendanceID, day.CheckInAt, day.CheckOutAt, day.IsLate = &a.ID, a.CheckInAt, a.CheckOutAt, a.IsLate
+			resp.Attended++
+		case day.Holiday != "":
+			day.Status = calendarHoliday
+			resp.Holiday++
+		case wd == time.Saturday || wd == time.Sunday:
+			day.Status = calendarWeekend
+			resp.Weekend++
+		case key > today:
+			day.Status = calendarUpcoming
+			resp.Upcoming++
+		default:
+			day.Status = calendarMissing
+			resp.Missing++
+		}
+		resp.Days = append(resp.Days, day)
+	}
+	return resp, nil
+}
+
+// historyRange: rentang period_id, atau start_date & end_date, atau period yang memuat hari ini.
+func (u *usecase) historyRange(ctx context.Context, periodID uint, startStr, endStr string) (time.Time, time.Time, error) {
+	if periodID > 0 {
+		period, err := u.findPeriod(ctx, periodID)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return period.StartDate, period.EndDate, nil
+	}
+	if startStr == "" && endStr == "" {
+		period, err := u.currentPeriod(ctx)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		return period.StartDate, period.EndDate, nil
+	}
+	if startStr == "" || endStr == "" {
+		return time.Time{}, time.Time{}, utils.MakeError(errorUc.BadRequest, "start_date and end_date must be given together")
+	}
+
+	wib := time.FixedZone("WIB", 7*3600)
+	start, err := time.ParseInLocation("2006-01-02", startStr, wib)
+	if err != nil {
+		return time.Time{}, time.Time{}, utils.MakeError(errorUc.BadRequest, "invalid start_date (YYYY-MM-DD)")
+	}
+	end, err := time.ParseInLocation("2006-01-02", endStr, wib)
+	if err != nil {
+		return time.Time{}, time.Time{}, utils.MakeError(errorUc.BadRequest, "invalid end_date (YYYY-MM-DD)")
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, utils.MakeError(errorUc.BadRequest, "end_date must be on or after start_date")
+	}
+	if end.Sub(start).Hours()/24 >= maxHistoryDays {
+		return time.Time{}, time.Time{}, utils.MakeError(errorUc.BadRequest, "date range must not exceed 366 days")
+	}
+	return start, end, nil
+}
+
+// currentPeriod: period yang memuat hari ini (WIB).
+func (u *usecase) currentPeriod(ctx context.Context) (*apRepo.PeriodRow, error) {
+	now := u.clock().In(time.FixedZone("WIB", 7*3600))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	p, err := u.apRepo.Containing(ctx, today)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendance period"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if p == nil {
+		return nil, utils.MakeError(errorUc.BadRequest, "no attendance period covers today; pass period_id or start_date and end_date")
+	}
+	return u.findPeriod(ctx, p.ID)
+}
+
+// periodStatusLookup memuat period di rentang sekali lalu mengembalikan status period per tanggal.
+func (u *usecase) periodStatusLookup(ctx context.Context, start, end time.Time) (func(time.Time) string, error) {
+	periods, err := u.apRepo.ListOverlapping(ctx, start, end)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list attendance periods"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	return func(date time.Time) string {
+		key := date.Format("2006-01-02")
+		for i := range periods {
+			if key >= periods[i].StartDate.Format("2006-01-02") && key <= periods[i].EndDate.Format("2006-01-02") {
+				return periodStatus(&periods[i])
+			}
+		}
+		return historyStatusNoPeriod
+	}, nil
+}
+
+// holidays: tanggal (YYYY-MM-DD) -> nama hari libur dari konfigurasi attendance.holidays.
+func (u *usecase) holidays() map[string]string {
+	out := map[string]string{}
+	if u.cfg == nil {
+		return out
+	}
+	for _, h := range u.cfg.Attendance.Holidays {
+		name := h.Name
+		if name == "" {
+			name = calendarHoliday
+		}
+		out[h.Date] = name
+	}
+	return out
+}
@@ -0,0 +1,144 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/config"
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	rbDTO "payslip-generation-system/internal/dto/reimbursement"
+	"payslip-generation-system/internal/model"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+	"payslip-generation-system/utils"
+)
+
+func historyPeriod(runStatus string) apRepo.PeriodRow {
+	return apRepo.PeriodRow{
+		AttendancePeriod: model.AttendancePeriod{
+			ID: 3, Name: "Payroll Aug 2025",
+			StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+		},
+		RunStatus: runStatus,
+	}
+}
+
+func TestGetMyAttendanceCalendar_MarksDays(t *testing.T) {
+	u := usecase.NewForTest()
+	apMock := &testm.APRepoMock{
+		ContainingFn: func(_ context.Context, date time.Time) (*model.AttendancePeriod, error) {
+			require.Equal(t, "2025-08-20", date.Format("2006-01-02"))
+			p := historyPeriod("")
+			return &p.AttendancePeriod, nil
+		},
+		FindByIDFn: func(_ context.Context, id uint) (*apRepo.PeriodRow, error) {
+			p := historyPeriod("")
+			return &p, nil
+		},
+	}
+	checkIn := time.Date(2025, 8, 4, 1, 20, 0, 0, time.UTC)
+	atMock := &testm.ATRepoMock{
+		ListByUserFn: func(_ context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error) {
+			require.Equal(t, uint(42), userID)
+			require.GreaterOrEqual(t, limit, 31)
+			return []model.Attendance{
+				{ID: 1, UserID: 42, Date: time.Date(2025, 8, 4, 0, 0, 0, 0, time.UTC), CheckInAt: &checkIn, IsLate: true},
+				{ID: 2, UserID: 42, Date: time.Date(2025, 8, 5, 0, 0, 0, 0, time.UTC)},
+				{ID: 3, UserID: 42, Date: time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC)}, // cuti bersama, tetap hadir
+			}, 3, nil
+		},
+	}
+	usecase.InjectForTest(u, apMock, atMock, nil, nil, nil, testm.FakeTxManager{})
+	cfg := &config.Config{}
+	cfg.Attendance.Holidays = []config.HolidayConfig{
+		{Date: "2025-08-17", Name: "Hari Kemerdekaan RI"}, // Minggu
+		{Date: "2025-08-18", Name: "Cuti bersama"},
+		{Date: "2025-08-19"},
+	}
+	usecase.InjectConfigForTest(u, cfg)
+	usecase.InjectClockForTest(u, func() time.Time { return time.Date(2025, 8, 20, 9, 0, 0, 0, time.FixedZone("WIB", 7*3600)) })
+
+	cal, err := u.GetMyAttendanceCalendar(makeGinCtx(), 42, atDTO.CalendarQuery{})
+	require.NoError(t, err)
+	require.Equal(t, uint(3), cal.PeriodID)
+	require.Equal(t, model.PeriodStatusOpen, cal.PeriodStatus)
+	require.Len(t, cal.Days, 31)
+	require.Equal(t, 3, cal.Attended)
+	require.Equal(t, 2, cal.Holiday)
+	require.Equal(t, 9, cal.Weekend)
+	require.Equal(t, 7, cal.Upcoming) // hari kerja setelah hari ini (21-29 Agustus)
+	require.Equal(t, 10, cal.Missing)
+
+	byDate := map[string]atDTO.CalendarDay{}
+	for _, d := range cal.Days {
+		byDate[d.Date] = d
+	}
+	require.Equal(t, "missing", byDate["2025-08-01"].Status)
+	require.Equal(t, "attended", byDate["2025-08-04"].Status)
+	require.True(t, byDate["2025-08-04"].IsLate)
+	require.Equal(t, uint(1), *byDate["2025-08-04"].AttendanceID)
+	require.Equal(t, "holiday", byDate["2025-08-17"].Status)
+	require.Equal(t, "Hari Kemerdekaan RI", byDate["2025-08-17"].Holiday)
+	require.Equal(t, "attended", byDate["2025-08-18"].Status)
+	require.Equal(t, "Cuti bersama", byDate["2025-08-18"].Holiday)
+	require.Equal(t, "holiday", byDate["2025-08-19"].Status)
+	require.Equal(t, "missing", byDate["2025-08-20"].Status)
+	require.Equal(t, "upcoming", byDate["2025-08-21"].Status)
+	require.Equal(t, "weekend", byDate["2025-08-23"].Status)
+}
+
+func TestListMyReimbursements_DateRangeWithPeriodStatus(t *testing.T) {
+	u := usecase.NewForTest()
+	apMock := &testm.APRepoMock{
+		ListOverlapFn: func(_ context.Context, start, end time.Time) ([]apRepo.PeriodRow, error) {
+			july := historyPeriod(model.PayrollRunStatusApproved)
+			july.ID, july.StartDate, july.EndDate = 2, time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC)
+			return []apRepo.PeriodRow{july, historyPeriod(model.PayrollRunStatusDraft)}, nil
+		},
+	}
+	var gotStart, gotEnd time.Time
+	var gotOffset, gotLimit int
+	rbMock := &testm.RBRepoMock{
+		ListByUserFn: func(_ context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Reimbursement, int64, error) {
+			gotStart, gotEnd, gotOffset, gotLimit = start, end, offset, limit
+			return []model.Reimbursement{
+				{ID: 9, UserID: 42, Date: time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC), Amount: 12500.5},
+				{ID: 8, UserID: 42, Date: time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC), Amount: 75000, Description: "taxi"},
+				{ID: 7, UserID: 42, Date: time.Date(2025, 7, 30, 0, 0, 0, 0, time.UTC), Amount: 30000},
+			}, 13, nil
+		},
+	}
+	usecase.InjectForTest(u, apMock, nil, nil, rbMock, nil, testm.FakeTxManager{})
+	ctx := makeGinCtx()
+
+	q := rbDTO.HistoryQuery{StartDate: "2025-07-15", EndDate: "2025-09-10", Pagination: utils.Pagination{Page: 2, PageSize: 10}}
+	rows, meta, err := u.ListMyReimbursements(ctx, 42, q)
+	require.NoError(t, err)
+	require.Equal(t, "2025-07-15", gotStart.Format("2006-01-02"))
+	require.Equal(t, "2025-09-10", gotEnd.Format("2006-01-02"))
+	require.Equal(t, 10, gotOffset)
+	require.Equal(t, 10, gotLimit)
+	require.Equal(t, 2, meta.TotalPage)
+	require.Equal(t, 13, meta.TotalData)
+	require.Len(t, rows, 3)
+	require.Equal(t, "no_period", rows[0].Status)
+	require.Equal(t, "12500.50", rows[0].Amount)
+	require.Equal(t, model.PeriodStatusRunning, rows[1].Status)
+	require.Equal(t, model.PeriodStatusClosed, rows[2].Status)
+
+	_, _, err = u.ListMyReimbursements(ctx, 42, rbDTO.HistoryQuery{StartDate: "2025-07-15"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "together")
+
+	_, _, err = u.ListMyReimbursements(ctx, 42, rbDTO.HistoryQuery{StartDate: "2025-09-10", EndDate: "2025-07-15"})
+	require.Error(t, err)
+
+	_, _, err = u.ListMyReimbursements(ctx, 42, rbDTO.HistoryQuery{StartDate: "2024-01-01", EndDate: "2025-07-15"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "366 days")
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"payslip-generation-system/config"
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+)
+
+const (
+	defaultMaxAccountFailures = 5
+	defaultMaxIPFailures      = 20
+	defaultBaseLockout        = time.Minute
+	defaultMaxLockout         = time.Hour
+	defaultLockoutWindow      = 15 * time.Minute
+)
+
+func (u *usecase) lockoutPolicy() config.LockoutConfig {
+	var p config.LockoutConfig
+	if u.cfg != nil {
+		p = u.cfg.Auth.Lockout
+	}
+	if p.MaxAccountFailures <= 0 {
+		p.MaxAccountFailures = defaultMaxAccountFailures
+	}
+	if p.MaxIPFailures <= 0 {
+		p.MaxIPFailures = defaultMaxIPFailures
+	}
+	if p.BaseLockout <= 0 {
+		p.BaseLockout = defaultBaseLockout
+	}
+	if p.MaxLockout <= 0 {
+		p.MaxLockout = defaultMaxLockout
+	}
+	if p.Window <= 0 {
+		p.Window = defaultLockoutWindow
+	}
+	return p
+}
+
+// lockoutDuration: BaseLockout saat mencapai batas, lalu x2 per kegagalan berikutnya.
+func lockoutDuration(p config.LockoutConfig, failures, max int) time.Duration {
+	if failures < max {
+		return 0
+	}
+	d := p.BaseLockout
+	for i := max; i < failures && d < p.MaxLockout; i++ {
+		d *= 2
+	}
+	if d > p.MaxLockout {
+		d = p.MaxLockout
+	}
+	return d
+}
+
+func clientIP(ctx *gin.Context) string {
+	if ctx.Request == nil {
+		return ""
+	}
+	return ctx.ClientIP()
+}
+
+// checkLoginLocked mengembalikan error TooManyRequests kalau akun atau IP sedang terkunci.
+func (u *usecase) checkLoginLocked(ctx *gin.Context, email, ip string, now time.Time) error {
+	if err := u.checkLocked(ctx, model.LoginAttemptScopeAccount, email, now); err != nil {
+		return err
+	}
+	if ip != "" {
+		return u.checkLocked(ctx, model.LoginAttemptScopeIP, ip, now)
+	}
+	return nil
+}
+
+// checkLocked: error dari store hanya di-log (fail open) supaya gangguan DB tidak mengunci semua user.
+func (u *usecase) checkLocked(ctx *gin.Context, scope, identifier string, now time.Time) error {
+	a, err := u.attemptRepo.Get(ctx, scope, identifier)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to read login attempts"})
+		return nil
+	}
+	if a != nil && a.LockedUntil != nil && a.LockedUntil.After(now) {
+		u.log.Info(log.LogData{Description: "rejected: locked out", Response: scope})
+		return utils.MakeError(errorUc.TooManyRequests, a.LockedUntil.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (u *usecase) recordLoginFailure(ctx *gin.Context, email, ip string, now time.Time) {
+	p := u.lockoutPolicy()
+	u.recordFailure(ctx, model.LoginAttemptScopeAccount, email, p.MaxAccountFailures, p, now)
+	if ip != "" {
+		u.recordFailure(ctx, model.LoginAttemptScopeIP, ip, p.MaxIPFailures, p, now)
+	}
+}
+
+func (u *usecase) recordFailure(ctx *gin.Context, scope, identifier string, max int, p config.LockoutConfig, now time.Time) {
+	a, err := u.attemptRepo.RecordFailure(ctx, scope, identifier, now, p.Window)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to record login failure"})
+		return
+	}
+	if d := lockoutDuration(p, a.Failures, max); d > 0 {
+		if err := u.attemptRepo.Lock(ctx, scope, identifier, now.Add(d)); err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to lock login key"})
+			return
+		}
+		u.log.Info(log.LogData{Description: "login locked out", Response: map[string]any{
+			"scope": scope, "failures": a.Failures, "duration": d.String(),
+		}})
+	}
+}
+
+// ListLockouts untuk admin; all=false hanya yang masih terkunci.
+func (u *usecase) ListLockouts(ctx *gin.Context, all bool) ([]authDTO.LockoutResponse, error) {
+	now := time.Now().UTC()
+	rows, err := u.attemptRepo.List(ctx, !all, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list lockouts"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	out := make([]authDTO.LockoutResponse, 0, len(rows))
+	for _, a := range rows {
+		out = append(out, authDTO.LockoutResponse{
+			Scope:        a.Scope,
+			Identifier:   a.Identifier,
+			Failures:     a.Failures,
+			LastFailedAt: a.LastFailedAt,
+			LockedUntil:  a.LockedUntil,
+			Locked:       a.LockedUntil != nil && a.LockedUntil.After(now),
+		})
+	}
+	return out, nil
+}
+
+func (u *usecase) ClearLockout(ctx *gin.Context, scope, identifier string) error {
+	identifier = strings.TrimSpace(identifier)
+	if scope == model.LoginAttemptScopeAccount {
+		identifier = strings.ToLower(identifier)
+	}
+	if err := u.attemptRepo.Clear(ctx, scope, identifier); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to clear lockout"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	u.log.Info(log.LogData{Description: "lockout cleared", Response: scope})
+	return nil
+}
@@ -0,0 +1,83 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/repository/loginattempt"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func newLoginTestUsecase(t *testing.T) usecase.IUsecase {
+	t.Helper()
+	u := usecase.NewForTest()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("Secret123"), bcrypt.MinCost)
+	authMock := &testm.AuthRepoMock{
+		FindByEmailFn: func(_ context.Context, email string) (*model.User, error) {
+			if email == "budi@example.com" {
+				return &model.User{ID: 7, Email: email, PasswordHash: string(hash)}, nil
+			}
+			return nil, nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+	usecase.InjectLockoutForTest(u, loginattempt.NewMemory())
+	return u
+}
+
+func TestLoginUser_UniformErrorForUnknownEmail(t *testing.T) {
+	u := newLoginTestUsecase(t)
+	ctx := makeGinCtx()
+
+	_, errUnknown := u.LoginUser(ctx, "nobody@example.com", "Secret123")
+	_, errWrong := u.LoginUser(ctx, "budi@example.com", "wrong-password")
+	require.Error(t, errUnknown)
+	require.Error(t, errWrong)
+	require.Equal(t, errWrong.Error(), errUnknown.Error())
+	require.Equal(t, errorUc.InvalidCredentials, errUnknown.Error())
+}
+
+func TestLoginUser_LockoutAfterRepeatedFailures(t *testing.T) {
+	u := newLoginTestUsecase(t)
+	ctx := makeGinCtx()
+
+	for i := 0; i < 5; i++ {
+		_, err := u.LoginUser(ctx, "budi@example.com", "wrong-password")
+		require.Error(t, err)
+		require.Equal(t, errorUc.InvalidCredentials, err.Error())
+	}
+
+	// terkunci: password benar pun ditolak
+	_, err := u.LoginUser(ctx, "Budi@Example.com", "Secret123")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), errorUc.TooManyRequests)
+
+	locks, err := u.ListLockouts(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	require.Equal(t, model.LoginAttemptScopeAccount, locks[0].Scope)
+	require.Equal(t, "budi@example.com", locks[0].Identifier)
+
+	require.NoError(t, u.ClearLockout(ctx, model.LoginAttemptScopeAccount, "budi@example.com"))
+	user, err := u.LoginUser(ctx, "budi@example.com", "Secret123")
+	require.NoError(t, err)
+	require.Equal(t, uint(7), user.ID)
+}
+
+func TestLoginUser_UnknownEmailIsAlsoLocked(t *testing.T) {
+	u := newLoginTestUsecase(t)
+	ctx := makeGinCtx()
+
+	for i := 0; i < 5; i++ {
+		_, _ = u.LoginUser(ctx, "nobody@example.com", "x")
+	}
+	_, err := u.LoginUser(ctx, "nobody@example.com", "x")
+	require.Contains(t, err.Error(), errorUc.TooManyRequests)
+}
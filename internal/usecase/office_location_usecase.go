@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"strings"
+
+	olDTO "payslip-generation-system/internal/dto/officelocation"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/geofence"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func toOfficeLocationResponse(o *model.OfficeLocation) olDTO.OfficeLocationResponse {
+	return olDTO.OfficeLocationResponse{
+		ID:              o.ID,
+		Name:            o.Name,
+		Latitude:        o.Latitude,
+		Longitude:       o.Longitude,
+		RadiusMeters:    o.RadiusMeters,
+		AllowedIPRanges: o.AllowedIPRanges,
+		Active:          o.Active,
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+	}
+}
+
+// validateOfficeLocation: IP range harus CIDR / IP valid dan kantor harus bisa dicek (radius atau IP).
+func validateOfficeLocation(o *model.OfficeLocation) error {
+	ranges, err := geofence.ParseIPRanges(o.AllowedIPRanges)
+	if err != nil {
+		return utils.MakeError(errorUc.InvalidFormat, "allowed_ip_ranges")
+	}
+	if o.RadiusMeters <= 0 && len(ranges) == 0 {
+		return utils.MakeError(errorUc.BadRequest, "office location needs radius_meters or allowed_ip_ranges")
+	}
+	return nil
+}
+
+func (u *usecase) ListOfficeLocations(ctx *gin.Context) ([]olDTO.OfficeLocationResponse, error) {
+	rows, err := u.officeLocRepo.List(ctx, false)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list office locations"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	out := make([]olDTO.OfficeLocationResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toOfficeLocationResponse(&rows[i]))
+	}
+	return out, nil
+}
+
+func (u *usecase) CreateOfficeLocation(ctx *gin.Context, req olDTO.CreateOfficeLocationRequest) (*olDTO.OfficeLocationResponse, error) {
+	row := &model.OfficeLocation{
+		Name:            strings.TrimSpace(req.Name),
+		Latitude:        *req.Latitude,
+		Longitude:       *req.Longitude,
+		RadiusMeters:    req.RadiusMeters,
+		AllowedIPRanges: strings.TrimSpace(req.AllowedIPRanges),
+		Active:          true,
+	}
+	if req.Active != nil {
+		row.Active = *req.Active
+	}
+	if err := validateOfficeLocation(row); err != nil {
+		return nil, err
+	}
+	if err := u.officeLocRepo.Create(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to create office location"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to create office location")
+	}
+	resp := toOfficeLocationResponse(row)
+	return &resp, nil
+}
+
+// UpdateOfficeLocation: field nil tidak diubah; nonaktifkan (active=false) untuk mengecualikan kantor dari geofence.
+func (u *usecase) UpdateOfficeLocation(ctx *gin.Context, id uint, req olDTO.UpdateOfficeLocationRequest) (*olDTO.OfficeLocationResponse, error) {
+	row, err := u.findOfficeLocation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		row.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Latitude != nil {
+		row.Latitude = *req.Latitude
+	}
+	if req.Longitude != nil {
+		row.Longitude = *req.Longitude
+	}
+	if req.RadiusMeters != nil {
+		row.RadiusMeters = *req.RadiusMeters
+	}
+	if req.AllowedIPRanges != nil {
+		row.AllowedIPRanges = strings.TrimSpace(*req.AllowedIPRanges)
+	}
+	if req.Active != nil {
+		row.Active = *req.Active
+	}
+	if err = validateOfficeLocation(row); err != nil {
+		return nil, err
+	}
+	if err = u.officeLocRepo.Update(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update office location"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update office location")
+	}
+	resp := toOfficeLocationResponse(row)
+	return &resp, nil
+}
+
+func (u *usecase) DeleteOfficeLocation(ctx *gin.Context, id uint) error {
+	if _, err := u.findOfficeLocation(ctx, id); err != nil {
+		return err
+	}
+	if err := u.officeLocRepo.Delete(ctx, id); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to delete office location"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to delete office location")
+	}
+	return nil
+}
+
+func (u *usecase) findOfficeLocation(ctx *gin.Context, id uint) (*model.OfficeLocation, error) {
+	row, err := u.officeLocRepo.FindByID(ctx, id)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load office location"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "office location not found")
+	}
+	return row, nil
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/oidc"
+	"payslip-generation-system/utils"
+)
+
+const (
+	amrOIDC = "oidc"
+
+	// provider dengan nama ini ditautkan lewat kolom users.google_id
+	oidcProviderGoogle = "google"
+)
+
+// StartOIDCLogin menyiapkan state, nonce dan PKCE verifier lalu mengembalikan URL login provider.
+func (u *usecase) StartOIDCLogin(ctx *gin.Context, providerName, deviceID string) (*authDTO.OIDCStartResponse, error) {
+	p, err := u.oidc.Provider(providerName)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "unknown identity provider")
+	}
+
+	state, stateHash, err := newRefreshToken()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate oidc state"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to start sso login")
+	}
+	nonce, err := oidc.NewNonce()
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to start sso login")
+	}
+	verifier, err := oidc.NewCodeVerifier()
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to start sso login")
+	}
+
+	authURL, err := p.AuthCodeURL(ctx, state, nonce, oidc.CodeChallengeS256(verifier))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "oidc discovery failed"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "identity provider unavailable")
+	}
+
+	if err := u.oidcStateRepo.Create(ctx, &model.OIDCLoginState{
+		StateHash:    stateHash,
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		DeviceID:     deviceID,
+		ExpiresAt:    time.Now().UTC().Add(u.oidc.StateTTL()),
+	}); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store oidc state"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	return &authDTO.OIDCStartResponse{AuthorizationURL: authURL, State: state}, nil
+}
+
+// CompleteOIDCLogin menangani callback: validasi state, tukar code (PKCE), verifikasi ID token,
+// tautkan ke user yang sudah ada, lalu terbitkan token kita sendiri (atau challenge 2FA).
+// User baru tidak dibuat otomatis; akun karyawan tetap dibuat oleh HR.
+func (u *usecase) CompleteOIDCLogin(ctx *gin.Context, providerName, code, state string) (*authDTO.LoginUserResponse, error) {
+	p, err := u.oidc.Provider(providerName)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "unknown identity provider")
+	}
+
+	now := time.Now().UTC()
+	st, err := u.oidcStateRepo.Consume(ctx, hashToken(state), now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load oidc state"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if st == nil || st.Provider != providerName || now.After(st.ExpiresAt) {
+		return nil, utils.MakeError(errorUc.BadRequest, "invalid or expired sso state")
+	}
+
+	tokens, err := p.Exchange(ctx, code, st.CodeVerifier)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "oidc code exchange failed"})
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	claims, err := p.VerifyIDToken(ctx, tokens.IDToken, st.Nonce)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "oidc id token rejected"})
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	user, err := u.linkOIDCUser(ctx, providerName, claims)
+	if err != nil {
+		return nil, err
+	}
+	if user.EmploymentStatus == model.EmploymentStatusTerminated {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "account is terminated")
+	}
+
+	if user.TwoFactorEnabled {
+		challenge, err := u.startTwoFactorChallenge(user, st.DeviceID, amrOIDC)
+		if err != nil {
+			return nil, err
+		}
+		return &authDTO.LoginUserResponse{TwoFactorRequired: true, ChallengeToken: challenge}, nil
+	}
+
+	session, err := u.startSession(ctx, user, st.DeviceID, []string{amrOIDC})
+	if err != nil {
+		return nil, err
+	}
+	u.log.Info(log.LogData{Description: "user logged in via sso", Response: map[string]any{"provider": providerName, "user_id": user.ID}})
+
+	return &authDTO.LoginUserResponse{
+		User: &authDTO.UserResponse{
+			Name:   displayName(user),
+			Email:  user.Email,
+			Salary: user.Salary,
+			Role:   user.Role,
+		},
+		Token:                  session.AccessToken,
+		RefreshToken:           session.RefreshToken,
+		ExpiresIn:              session.ExpiresIn,
+		TwoFactorSetupRequired: u.TwoFactorSetupRequired(user),
+	}, nil
+}
+
+// linkOIDCUser: cari berdasarkan GoogleID (provider google), kalau belum tertaut pakai email
+// yang sudah diverifikasi provider lalu simpan GoogleID-nya.
+func (u *usecase) linkOIDCUser(ctx *gin.Context, providerName string, c *oidc.IDClaims) (*model.User, error) {
+	if providerName == oidcProviderGoogle {
+		user, err := u.authRepo.FindByGoogleID(ctx, c.Subject)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to look up user by google id"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if user != nil {
+			return user, nil
+		}
+	}
+
+	if c.Email == "" || !c.EmailVerified {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "email is not verified by the identity provider")
+	}
+	user, err := u.authRepo.FindByEmail(ctx, strings.ToLower(strings.TrimSpace(c.Email)))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to look up user by email"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		u.log.Info(log.LogData{Description: "sso login without matching account", Response: providerName})
+		return nil, utils.MakeError(errorUc.ErrForbidden, "no account is linked to this identity")
+	}
+
+	if providerName == oidcProviderGoogle {
+		if user.GoogleID != "" && user.GoogleID != c.Subject {
+			return nil, utils.MakeError(errorUc.ErrForbidden, "account is linked to a different google identity")
+		}
+		if user.GoogleID == "" {
+			ok, err := u.authRepo.SetGoogleID(ctx, user.ID, c.Subject)
+			if err != nil {
+				u.log.Error(log.LogData{Err: err, Description: "failed to link google id"})
+				return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+			}
+			if !ok {
+				return nil, utils.MakeError(errorUc.ErrForbidden, "account is linked to a different google identity")
+			}
+			user.GoogleID = c.Subject
+			u.log.Info(log.LogData{Description: "google identity linked", Response: user.ID})
+		}
+	}
+	return user, nil
+}
@@ -0,0 +1,119 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+	"payslip-generation-system/pkg/oidc"
+)
+
+// memStateRepo menyimpan state login SSO di memori, sekali pakai seperti repo aslinya.
+func memStateRepo() *testm.OIDCStateRepoMock {
+	states := map[string]*model.OIDCLoginState{}
+	return &testm.OIDCStateRepoMock{
+		CreateFn: func(_ context.Context, s *model.OIDCLoginState) error {
+			states[s.StateHash] = s
+			return nil
+		},
+		ConsumeFn: func(_ context.Context, hash string, at time.Time) (*model.OIDCLoginState, error) {
+			s, ok := states[hash]
+			if !ok || s.UsedAt != nil {
+				return nil, nil
+			}
+			s.UsedAt = &at
+			return s, nil
+		},
+	}
+}
+
+func newOIDCTestUsecase(t *testing.T, idp *testm.MockOIDCProvider, authMock *testm.AuthRepoMock, sess *testm.SessionRepoMock) usecase.IUsecase {
+	t.Helper()
+	reg, err := oidc.New(oidc.Config{Providers: []oidc.ProviderConfig{{
+		Name:        "google",
+		Issuer:      idp.Issuer(),
+		ClientID:    idp.ClientID,
+		RedirectURL: "http://localhost:9898/v1/auth/oidc/google/callback",
+	}}}, nil)
+	require.NoError(t, err)
+
+	u := usecase.NewForTest()
+	if sess == nil {
+		sess = &testm.SessionRepoMock{}
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, sess, testm.FakeTxManager{})
+	usecase.InjectOIDCForTest(u, reg, memStateRepo())
+	return u
+}
+
+func TestOIDCLogin_LinksByVerifiedEmail(t *testing.T) {
+	idp := testm.NewMockOIDCProvider("payslip-client")
+	defer idp.Close()
+	idp.Subject, idp.Email, idp.EmailVerified = "google-sub-123", "Budi@Example.com", true
+
+	var linked string
+	authMock := &testm.AuthRepoMock{
+		FindByGoogleIDFn: func(_ context.Context, googleID string) (*model.User, error) { return nil, nil },
+		FindByEmailFn: func(_ context.Context, email string) (*model.User, error) {
+			require.Equal(t, "budi@example.com", email)
+			return &model.User{ID: 7, Email: email, FirstName: "Budi", Role: "user"}, nil
+		},
+		SetGoogleIDFn: func(_ context.Context, userID uint, googleID string) (bool, error) {
+			linked = googleID
+			return true, nil
+		},
+	}
+	var amr string
+	sessMock := &testm.SessionRepoMock{
+		CreateSessionFn: func(_ context.Context, s *model.AuthSession) error {
+			s.ID, amr = 10, s.AMR
+			return nil
+		},
+		CreateRefreshTokenFn: func(_ context.Context, tok *model.RefreshToken) error { return nil },
+	}
+	u := newOIDCTestUsecase(t, idp, authMock, sessMock)
+	ctx := makeGinCtx()
+
+	start, err := u.StartOIDCLogin(ctx, "google", "laptop")
+	require.NoError(t, err)
+	code, state, err := idp.Authorize(start.AuthorizationURL)
+	require.NoError(t, err)
+	require.Equal(t, start.State, state)
+
+	resp, err := u.CompleteOIDCLogin(ctx, "google", code, state)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Token)
+	require.Equal(t, "google-sub-123", linked)
+	require.Equal(t, "oidc", amr)
+
+	// state hanya bisa dipakai sekali
+	_, err = u.CompleteOIDCLogin(ctx, "google", code, state)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "state")
+}
+
+func TestOIDCLogin_UnverifiedEmailRejected(t *testing.T) {
+	idp := testm.NewMockOIDCProvider("payslip-client")
+	defer idp.Close()
+	idp.Subject, idp.Email, idp.EmailVerified = "google-sub-999", "budi@example.com", false
+
+	authMock := &testm.AuthRepoMock{
+		FindByGoogleIDFn: func(_ context.Context, googleID string) (*model.User, error) { return nil, nil },
+	}
+	u := newOIDCTestUsecase(t, idp, authMock, nil)
+	ctx := makeGinCtx()
+
+	start, err := u.StartOIDCLogin(ctx, "google", "")
+	require.NoError(t, err)
+	code, state, err := idp.Authorize(start.AuthorizationURL)
+	require.NoError(t, err)
+
+	_, err = u.CompleteOIDCLogin(ctx, "google", code, state)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not verified")
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"strings"
+
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// onBehalf: admin yang menginput data atas nama karyawan (tanpa device / sedang cuti)
+type onBehalf struct {
+	adminID uint
+	reason  string
+}
+
+func (b *onBehalf) apply(submittedBy **uint, reason *string) {
+	id := b.adminID
+	*submittedBy = &id
+	*reason = b.reason
+}
+
+// newOnBehalf memastikan karyawan tujuan ada & belum terminated, dan alasan diisi.
+func (u *usecase) newOnBehalf(ctx *gin.Context, adminID, userID uint, reason string) (*onBehalf, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, utils.MakeError(errorUc.InvalidMandatory, "reason")
+	}
+	user, err := u.employeeRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load employee"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "employee not found")
+	}
+	if user.EmploymentStatus == model.EmploymentStatusTerminated {
+		return nil, utils.MakeError(errorUc.BadRequest, "employee is terminated")
+	}
+	return &onBehalf{adminID: adminID, reason: reason}, nil
+}
+
+// SubmitAttendanceOnBehalf: validasi sama dengan SubmitAttendance kecuali geofence.
+func (u *usecase) SubmitAttendanceOnBehalf(ctx *gin.Context, adminID, userID uint, dateStr, reason string) (*model.Attendance, bool, error) {
+	by, err := u.newOnBehalf(ctx, adminID, userID, reason)
+	if err != nil {
+		return nil, false, err
+	}
+	return u.submitAttendance(ctx, userID, dateStr, nil, by, 0)
+}
+
+func (u *usecase) SubmitOvertimeOnBehalf(ctx *gin.Context, adminID, userID uint, dateStr string, hours float64, reason string) (*model.Overtime, bool, error) {
+	by, err := u.newOnBehalf(ctx, adminID, userID, reason)
+	if err != nil {
+		return nil, false, err
+	}
+	return u.submitOvertime(ctx, userID, dateStr, hours, by)
+}
+
+func (u *usecase) CreateReimbursementOnBehalf(ctx *gin.Context, adminID, userID uint, dateStr string, amount float64, description, reason string) (*model.Reimbursement, error) {
+	by, err := u.newOnBehalf(ctx, adminID, userID, reason)
+	if err != nil {
+		return nil, err
+	}
+	return u.createReimbursement(ctx, userID, dateStr, amount, description, by)
+}
@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func employeeMock(users ...model.User) *testm.EmployeeRepoMock {
+	return &testm.EmployeeRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			for i := range users {
+				if users[i].ID == id {
+					return &users[i], nil
+				}
+			}
+			return nil, nil
+		},
+	}
+}
+
+func TestSubmitAttendanceOnBehalf_RecordsAdminAndSkipsGeofence(t *testing.T) {
+	var submitter struct {
+		id, admin uint
+		reason    string
+	}
+	atMock := &testm.ATRepoMock{
+		CreateIfNotExistsFn: func(_ context.Context, userID uint, date time.Time) (*model.Attendance, bool, error) {
+			return &model.Attendance{ID: 11, UserID: userID, Date: date}, false, nil
+		},
+		SetSubmitterFn: func(_ context.Context, id, adminID uint, reason string) error {
+			submitter.id, submitter.admin, submitter.reason = id, adminID, reason
+			return nil
+		},
+	}
+	// mode reject: submit tanpa lokasi oleh karyawan sendiri akan ditolak
+	u := geofenceUsecase(t, "reject", atMock)
+	usecase.InjectEmployeeForTest(u, employeeMock(model.User{ID: 42, EmploymentStatus: model.EmploymentStatusOnLeave}))
+	ctx := makeGinCtx()
+
+	row, existed, err := u.SubmitAttendanceOnBehalf(ctx, 1, 42, "2025-08-18", "  no device on site ")
+	require.NoError(t, err)
+	require.False(t, existed)
+	require.Equal(t, uint(42), row.UserID)
+	require.Equal(t, uint(1), *row.SubmittedBy)
+	require.Equal(t, "no device on site", row.OnBehalfReason)
+	require.Equal(t, uint(11), submitter.id)
+	require.Equal(t, uint(1), submitter.admin)
+
+	// validasi submit biasa tetap berlaku
+	_, _, err = u.SubmitAttendanceOnBehalf(ctx, 1, 42, "2025-08-17", "no device on site")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "weekend")
+
+	_, _, err = u.SubmitAttendanceOnBehalf(ctx, 1, 99, "2025-08-18", "no device on site")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "employee not found")
+}
+
+func TestCreateReimbursementOnBehalf_Validation(t *testing.T) {
+	var created *model.Reimbursement
+	rbMock := &testm.RBRepoMock{
+		CreateFn: func(_ context.Context, r *model.Reimbursement) error {
+			r.ID = 5
+			created = r
+			return nil
+		},
+	}
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return date.Month() == time.July, nil },
+	}
+	u := usecase.NewForTest()
+	usecase.InjectForTest(u, nil, nil, nil, rbMock, payMock, testm.FakeTxManager{})
+	usecase.InjectEmployeeForTest(u, employeeMock(
+		model.User{ID: 42, EmploymentStatus: model.EmploymentStatusActive},
+		model.User{ID: 43, EmploymentStatus: model.EmploymentStatusTerminated},
+	))
+	ctx := makeGinCtx()
+
+	_, err := u.CreateReimbursementOnBehalf(ctx, 1, 42, "2025-08-18", 75000, "taxi", " ")
+	require.Error(t, err)
+
+	_, err = u.CreateReimbursementOnBehalf(ctx, 1, 43, "2025-08-18", 75000, "taxi", "paper receipt")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "terminated")
+
+	_, err = u.CreateReimbursementOnBehalf(ctx, 1, 42, "2025-07-18", 75000, "taxi", "paper receipt")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "prior period adjustment")
+
+	row, err := u.CreateReimbursementOnBehalf(ctx, 1, 42, "2025-08-18", 75000, "taxi", "paper receipt")
+	require.NoError(t, err)
+	require.Equal(t, uint(5), row.ID)
+	require.Equal(t, uint(42), created.UserID)
+	require.Equal(t, uint(1), *created.SubmittedBy)
+	require.Equal(t, "paper receipt", created.OnBehalfReason)
+}
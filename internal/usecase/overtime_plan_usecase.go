@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	otDTO "payslip-generation-system/internal/dto/overtime"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	opRepo "payslip-generation-system/internal/repository/overtimeplan"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+func toOvertimePlanResponse(p *model.OvertimePlan) otDTO.OvertimePlanResponse {
+	return otDTO.OvertimePlanResponse{
+		ID:            p.ID,
+		UserID:        p.UserID,
+		Date:          p.Date.Format("2006-01-02"),
+		Hours:         p.Hours,
+		Justification: p.Justification,
+		Status:        p.Status,
+		ReviewedBy:    p.ReviewedBy,
+		ReviewedAt:    p.ReviewedAt,
+		ReviewNote:    p.ReviewNote,
+		CreatedAt:     p.CreatedAt,
+	}
+}
+
+// overtimePlanMode: overtime.planMode, default off (overtime tidak dicocokkan dengan rencana).
+func (u *usecase) overtimePlanMode() string {
+	if u.cfg == nil {
+		return model.OvertimePlanModeOff
+	}
+	switch u.cfg.Overtime.PlanMode {
+	case model.OvertimePlanModeFlag, model.OvertimePlanModeReject:
+		return u.cfg.Overtime.PlanMode
+	default:
+		return model.OvertimePlanModeOff
+	}
+}
+
+// matchOvertimePlan mencocokkan overtime dengan rencana approved pada tanggal tsb.
+// Mode reject menolak overtime tanpa / melebihi rencana; mode flag mengembalikan alasan penandaan.
+func (u *usecase) matchOvertimePlan(ctx context.Context, userID uint, date time.Time, hours float64) (*uint, string, error) {
+	planID, flag, rejected, err := u.checkOvertimePlan(ctx, userID, date, hours)
+	if err != nil {
+		return nil, "", err
+	}
+	if rejected != "" {
+		return nil, "", utils.MakeError(errorUc.BadRequest, rejected)
+	}
+	return planID, flag, nil
+}
+
+// checkOvertimePlan: seperti matchOvertimePlan, tapi penolakan mode reject dikembalikan sebagai pesan
+// (dipakai import CSV yang melaporkan error per baris). error hanya untuk kegagalan db.
+func (u *usecase) checkOvertimePlan(ctx context.Context, userID uint, date time.Time, hours float64) (*uint, string, string, error) {
+	mode := u.overtimePlanMode()
+	if mode == model.OvertimePlanModeOff {
+		return nil, "", "", nil
+	}
+	plan, err := u.planRepo.FindApproved(ctx, userID, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load overtime plan"})
+		return nil, "", "", utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	planID, flag := planMatch(plan, hours)
+	if flag == "" || mode == model.OvertimePlanModeFlag {
+		return planID, flag, "", nil
+	}
+	if flag == model.OvertimePlanFlagNoPlan {
+		return nil, "", "there is no approved overtime plan for that date", nil
+	}
+	return nil, "", fmt.Sprintf("overtime exceeds the approved plan of %.2f hours", plan.Hours), nil
+}
+
+// planMatch: rencana yang cocok & alasan penandaan (kosong jika sesuai rencana).
+func planMatch(plan *model.OvertimePlan, hours float64) (*uint, string) {
+	if plan == nil {
+		return nil, model.OvertimePlanFlagNoPlan
+	}
+	planID := plan.ID
+	if hours > plan.Hours {
+		return &planID, model.OvertimePlanFlagExceeds
+	}
+	return &planID, ""
+}
+
+// RequestOvertimePlan: karyawan mengajukan rencana overtime sebelum dijalankan (paling lambat 17:00 WIB di hari yang sama).
+func (u *usecase) RequestOvertimePlan(ctx *gin.Context, userID uint, req otDTO.CreateOvertimePlanRequest) (*otDTO.OvertimePlanResponse, error) {
+	if req.Hours <= 0 || req.Hours > 3 {
+		return nil, utils.MakeError(errorUc.BadRequest, "hours must be > 0 and <= 3")
+	}
+	wib := time.FixedZone("WIB", 7*3600)
+	date, err := time.ParseInLocation("2006-01-02", req.Date, wib)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "date")
+	}
+	now := u.clock().In(wib)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, wib)
+	if date.Before(today) || (date.Equal(today) && !overtimeTooEarly(now, date)) {
+		return nil, utils.MakeError(errorUc.BadRequest, "overtime must be planned before it starts (17:00 WIB)")
+	}
+
+	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if locked {
+		return nil, utils.MakeError(errorUc.BadRequest, "attendance period is closed; overtime can no longer be planned")
+	}
+
+	active, err := u.planRepo.HasActive(ctx, userID, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if active {
+		return nil, utils.MakeError(errorUc.ConflictError, "an overtime plan for that date is already pending or approved")
+	}
+
+	row := &model.OvertimePlan{
+		UserID:        userID,
+		Date:          date,
+		Hours:         req.Hours,
+		Justification: strings.TrimSpace(req.Justification),
+		Status:        model.OvertimePlanStatusPending,
+	}
+	if err = u.planRepo.Create(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to create overtime plan"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to create overtime plan")
+	}
+	resp := toOvertimePlanResponse(row)
+	return &resp, nil
+}
+
+// ListOvertimePlans: userID != 0 membatasi ke rencana milik user tsb (endpoint karyawan).
+func (u *usecase) ListOvertimePlans(ctx *gin.Context, userID uint, q otDTO.ListOvertimePlansQuery) ([]otDTO.OvertimePlanResponse, *utils.Metadata, error) {
+	page := q.Pagination.Normalize()
+	f := opRepo.ListFilter{UserID: q.UserID, Status: q.Status, Offset: page.Offset(), Limit: page.PageSize}
+	if userID != 0 {
+		f.UserID = userID
+	}
+	rows, total, err := u.planRepo.List(ctx, f)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list overtime plans"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	out := make([]otDTO.OvertimePlanResponse, 0, len(rows))
+	for i := range rows {
+		out = append(out, toOvertimePlanResponse(&rows[i]))
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+// ApproveOvertimePlan menyetujui rencana; overtime yang sudah terlanjur disubmit pada tanggal tsb dicocokkan ulang.
+// Peninjau tidak boleh karyawan yang mengajukan.
+func (u *usecase) ApproveOvertimePlan(ctx *gin.Context, actorID, planID uint, req otDTO.ReviewOvertimePlanRequest) (resp *otDTO.OvertimePlanResponse, err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	p, err := u.findPendingPlan(txCtx, actorID, planID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = u.decidePlan(txCtx, p, opRepo.Decision{
+		Status:     model.OvertimePlanStatusApproved,
+		ReviewedBy: actorID,
+		ReviewedAt: u.clock().UTC(),
+		ReviewNote: strings.TrimSpace(req.Note),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if u.overtimePlanMode() == model.OvertimePlanModeOff {
+		return resp, nil
+	}
+	rows, _, err := u.otRepo.ListByUser(txCtx, p.UserID, p.Date, p.Date, 0, 1)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load overtime"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if len(rows) > 0 {
+		matchedID, flag := planMatch(p, rows[0].Hours)
+		if err = u.otRepo.SetPlanMatch(txCtx, rows[0].ID, matchedID, flag); err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to update overtime plan match"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to update overtime")
+		}
+	}
+	return resp, nil
+}
+
+// RejectOvertimePlan: alasan penolakan wajib diisi.
+func (u *usecase) RejectOvertimePlan(ctx *gin.Context, actorID, planID uint, req otDTO.ReviewOvertimePlanRequest) (*otDTO.OvertimePlanResponse, error) {
+	note := strings.TrimSpace(req.Note)
+	if note == "" {
+		return nil, utils.MakeError(errorUc.InvalidMandatory, "note")
+	}
+	p, err := u.findPendingPlan(ctx, actorID, planID)
+	if err != nil {
+		return nil, err
+	}
+	return u.decidePlan(ctx, p, opRepo.Decision{
+		Status:     model.OvertimePlanStatusRejected,
+		ReviewedBy: actorID,
+		ReviewedAt: u.clock().UTC(),
+		ReviewNote: note,
+	})
+}
+
+// ListFlaggedOvertimes: laporan overtime tanpa / melebihi rencana dalam rentang tanggal period.
+func (u *usecase) ListFlaggedOvertimes(ctx *gin.Context, periodID uint, q otDTO.FlaggedOvertimeQuery) ([]otDTO.FlaggedOvertimeResponse, *utils.Metadata, error) {
+	period, err := u.findPeriod(ctx, periodID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := q.Pagination.Normalize()
+	rows, total, err := u.otRepo.ListFlagged(ctx, period.StartDate, period.EndDate, page.Offset(), page.PageSize)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to list flagged overtimes"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	out := make([]otDTO.FlaggedOvertimeResponse, 0, len(rows))
+	for _, o := range rows {
+		out = append(out, otDTO.FlaggedOvertimeResponse{
+			ID:          o.ID,
+			UserID:      o.UserID,
+			Date:        o.Date.Format("2006-01-02"),
+			Hours:       o.Hours,
+			PlanID:      o.PlanID,
+			SubmittedBy: o.SubmittedBy,
+			Reason:      o.PlanFlagReason,
+		})
+	}
+	return out, utils.NewMetadata(page, total), nil
+}
+
+func (u *usecase) findPendingPlan(ctx context.Context, actorID, planID uint) (*model.OvertimePlan, error) {
+	p, err := u.planRepo.FindByID(ctx, planID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load overtime plan"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if p == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "overtime plan not found")
+	}
+	if p.Status != model.OvertimePlanStatusPending {
+		return nil, utils.MakeError(errorUc.ConflictError, "overtime plan already reviewed")
+	}
+	if p.UserID == actorID {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "you cannot review your own overtime plan")
+	}
+	return p, nil
+}
+
+func (u *usecase) decidePlan(ctx context.Context, p *model.OvertimePlan, d opRepo.Decision) (*otDTO.OvertimePlanResponse, error) {
+	ok, err := u.planRepo.Decide(ctx, p.ID, d)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update overtime plan"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update overtime plan")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "overtime plan already reviewed")
+	}
+
+	u.log.Info(log.LogData{Description: "overtime plan " + d.Status, Response: map[string]any{
+		"plan_id": p.ID, "user_id": p.UserID, "date": p.Date.Format("2006-01-02"), "by": d.ReviewedBy,
+	}})
+	p.Status = d.Status
+	p.ReviewedBy = &d.ReviewedBy
+	p.ReviewedAt = &d.ReviewedAt
+	p.ReviewNote = d.ReviewNote
+	resp := toOvertimePlanResponse(p)
+	return &resp, nil
+}
@@ -0,0 +1,177 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/config"
+	otDTO "payslip-generation-system/internal/dto/overtime"
+	"payslip-generation-system/internal/model"
+	opRepo "payslip-generation-system/internal/repository/overtimeplan"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+type planMatchCall struct {
+	id     uint
+	planID *uint
+	reason string
+}
+
+func planModeConfig(mode string) *config.Config {
+	cfg := &config.Config{}
+	cfg.Overtime.PlanMode = mode
+	return cfg
+}
+
+func TestOvertimePlan_RequestAndApproveRematchesSubmittedOvertime(t *testing.T) {
+	u := usecase.NewForTest()
+	wib := time.FixedZone("WIB", 7*3600)
+	var created *model.OvertimePlan
+	var decision opRepo.Decision
+	plans := &testm.OvertimePlanRepoMock{
+		HasActiveFn: func(_ context.Context, userID uint, date time.Time) (bool, error) {
+			return date.Day() == 14, nil // sudah ada rencana tgl 14
+		},
+		CreateFn: func(_ context.Context, p *model.OvertimePlan) error {
+			p.ID = 11
+			created = p
+			return nil
+		},
+		FindByIDFn: func(_ context.Context, id uint) (*model.OvertimePlan, error) {
+			if id != 11 {
+				return nil, nil
+			}
+			cp := *created
+			return &cp, nil
+		},
+		DecideFn: func(_ context.Context, id uint, d opRepo.Decision) (bool, error) {
+			decision = d
+			return true, nil
+		},
+	}
+	var matched []planMatchCall
+	otMock := &testm.OTRepoMock{
+		// overtime tgl 12 sudah terlanjur disubmit sebelum rencana disetujui
+		ListByUserFn: func(_ context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error) {
+			require.Equal(t, "2025-08-12", start.Format("2006-01-02"))
+			require.Equal(t, start, end)
+			return []model.Overtime{{ID: 5, UserID: 42, Date: start, Hours: 2.5, PlanFlagged: true, PlanFlagReason: model.OvertimePlanFlagNoPlan}}, 1, nil
+		},
+		SetPlanMatchFn: func(_ context.Context, id uint, planID *uint, reason string) error {
+			matched = append(matched, planMatchCall{id, planID, reason})
+			return nil
+		},
+	}
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return date.Month() == time.July, nil },
+	}
+	usecase.InjectForTest(u, nil, nil, otMock, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectOvertimePlanForTest(u, plans)
+	usecase.InjectConfigForTest(u, planModeConfig(model.OvertimePlanModeFlag))
+	usecase.InjectClockForTest(u, func() time.Time { return time.Date(2025, 8, 12, 10, 0, 0, 0, wib) })
+	ctx := makeGinCtx()
+
+	plan, err := u.RequestOvertimePlan(ctx, 42, otDTO.CreateOvertimePlanRequest{Date: "2025-08-12", Hours: 2, Justification: "release malam ini"})
+	require.NoError(t, err)
+	require.Equal(t, model.OvertimePlanStatusPending, plan.Status)
+	require.Equal(t, 2.0, plan.Hours)
+
+	_, err = u.RequestOvertimePlan(ctx, 42, otDTO.CreateOvertimePlanRequest{Date: "2025-08-11", Hours: 2, Justification: "lupa mengajukan"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "planned before")
+	_, err = u.RequestOvertimePlan(ctx, 42, otDTO.CreateOvertimePlanRequest{Date: "2025-08-14", Hours: 1, Justification: "stock opname"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already pending")
+
+	// setelah 17:00 rencana untuk hari ini sudah terlambat
+	usecase.InjectClockForTest(u, func() time.Time { return time.Date(2025, 8, 12, 17, 30, 0, 0, wib) })
+	_, err = u.RequestOvertimePlan(ctx, 42, otDTO.CreateOvertimePlanRequest{Date: "2025-08-12", Hours: 1, Justification: "release malam ini"})
+	require.Error(t, err)
+
+	_, err = u.ApproveOvertimePlan(ctx, 42, 11, otDTO.ReviewOvertimePlanRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "your own")
+	_, err = u.RejectOvertimePlan(ctx, 1, 11, otDTO.ReviewOvertimePlanRequest{})
+	require.Error(t, err)
+
+	approved, err := u.ApproveOvertimePlan(ctx, 1, 11, otDTO.ReviewOvertimePlanRequest{Note: "ok"})
+	require.NoError(t, err)
+	require.Equal(t, model.OvertimePlanStatusApproved, approved.Status)
+	require.Equal(t, uint(1), decision.ReviewedBy)
+	require.Equal(t, "ok", decision.ReviewNote)
+	// 2.5 jam melebihi rencana 2 jam
+	require.Len(t, matched, 1)
+	require.Equal(t, uint(5), matched[0].id)
+	require.Equal(t, uint(11), *matched[0].planID)
+	require.Equal(t, model.OvertimePlanFlagExceeds, matched[0].reason)
+}
+
+func TestSubmitOvertime_MatchedAgainstApprovedPlan(t *testing.T) {
+	u := usecase.NewForTest()
+	plans := &testm.OvertimePlanRepoMock{
+		FindApprovedFn: func(_ context.Context, userID uint, date time.Time) (*model.OvertimePlan, error) {
+			if date.Day() == 13 {
+				return nil, nil
+			}
+			return &model.OvertimePlan{ID: 11, UserID: userID, Date: date, Hours: 2, Status: model.OvertimePlanStatusApproved}, nil
+		},
+	}
+	var nextID uint
+	var matched []planMatchCall
+	otMock := &testm.OTRepoMock{
+		CreateIfNotExistsFn: func(_ context.Context, userID uint, date time.Time, hours float64) (*model.Overtime, bool, error) {
+			nextID++
+			return &model.Overtime{ID: nextID, UserID: userID, Date: date, Hours: hours}, false, nil
+		},
+		SetPlanMatchFn: func(_ context.Context, id uint, planID *uint, reason string) error {
+			matched = append(matched, planMatchCall{id, planID, reason})
+			return nil
+		},
+	}
+	payMock := &testm.PayRepoMock{
+		HasRunOnDateFn: func(_ context.Context, date time.Time) (bool, error) { return false, nil },
+	}
+	usecase.InjectForTest(u, nil, nil, otMock, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectOvertimePlanForTest(u, plans)
+	ctx := makeGinCtx()
+
+	// mode off (default): tidak dicocokkan sama sekali
+	row, _, err := u.SubmitOvertime(ctx, 42, "2025-08-13", 3)
+	require.NoError(t, err)
+	require.False(t, row.PlanFlagged)
+	require.Empty(t, matched)
+
+	usecase.InjectConfigForTest(u, planModeConfig(model.OvertimePlanModeFlag))
+	row, _, err = u.SubmitOvertime(ctx, 42, "2025-08-12", 1.5)
+	require.NoError(t, err)
+	require.Equal(t, uint(11), *row.PlanID)
+	require.False(t, row.PlanFlagged)
+
+	row, _, err = u.SubmitOvertime(ctx, 42, "2025-08-12", 3)
+	require.NoError(t, err)
+	require.True(t, row.PlanFlagged)
+	require.Equal(t, model.OvertimePlanFlagExceeds, row.PlanFlagReason)
+
+	row, _, err = u.SubmitOvertime(ctx, 42, "2025-08-13", 1)
+	require.NoError(t, err)
+	require.Nil(t, row.PlanID)
+	require.Equal(t, model.OvertimePlanFlagNoPlan, row.PlanFlagReason)
+	require.Len(t, matched, 3)
+
+	usecase.InjectConfigForTest(u, planModeConfig(model.OvertimePlanModeReject))
+	created := nextID
+	_, _, err = u.SubmitOvertime(ctx, 42, "2025-08-13", 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no approved overtime plan")
+	_, _, err = u.SubmitOvertime(ctx, 42, "2025-08-12", 2.5)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds the approved plan")
+	require.Equal(t, created, nextID)
+
+	_, _, err = u.SubmitOvertime(ctx, 42, "2025-08-12", 2)
+	require.NoError(t, err)
+}
@@ -13,6 +13,10 @@ import (
 )
 
 func (u *usecase) SubmitOvertime(ctx *gin.Context, userID uint, dateStr string, hours float64) (*model.Overtime, bool, error) {
+	return u.submitOvertime(ctx, userID, dateStr, hours, nil)
+}
+
+func (u *usecase) submitOvertime(ctx *gin.Context, userID uint, dateStr string, hours float64, by *onBehalf) (*model.Overtime, bool, error) {
 	// Validasi jam
 	if hours <= 0 || hours > 3 {
 		return nil, false, utils.MakeError(errorUc.BadRequest, "hours must be > 0 and <= 3")
@@ -23,14 +27,6 @@ func (u *usecase) SubmitOvertime(ctx *gin.Context, userID uint, dateStr string,
 	var date time.Time
 	var err error
 
-	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
-	if err != nil {
-		return nil, false, utils.MakeError(errorUc.InternalServerError, "db error")
-	}
-	if locked {
-		return nil, false, utils.MakeError(errorUc.BadRequest, "payroll already run for this period; submissions are locked")
-	}
-
 	if dateStr == "" {
 		now := time.Now().In(loc)
 		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
@@ -40,16 +36,18 @@ func (u *usecase) SubmitOvertime(ctx *gin.Context, userID uint, dateStr string,
 			return nil, false, utils.MakeError(errorUc.BadRequest, "invalid date format (YYYY-MM-DD)")
 		}
 	}
+	if err = u.ensureLateItemOpen(ctx, date); err != nil {
+		return nil, false, err
+	}
 
-	// Harus diajukan setelah jam kerja selesai (>= 17:00 WIB) kalau tanggal = hari ini
-	now := time.Now().In(loc)
-	if now.Year() == date.Year() && now.YearDay() == date.YearDay() {
-		after5pm := time.Date(now.Year(), now.Month(), now.Day(), 17, 0, 0, 0, loc)
-		if now.Before(after5pm) {
-			return nil, false, utils.MakeError(errorUc.BadRequest, "overtime can only be submitted after 17:00 WIB")
-		}
+	if overtimeTooEarly(time.Now().In(loc), date) {
+		return nil, false, utils.MakeError(errorUc.BadRequest, "overtime can only be submitted after 17:00 WIB")
 	}
 	// Catatan: Overtime bisa diambil hari apa pun (weekend allowed) → tidak ada cek weekend.
+	planID, planFlag, err := u.matchOvertimePlan(ctx, userID, date, hours)
+	if err != nil {
+		return nil, false, err
+	}
 
 	txCtx, err := u.txManager.Begin(ctx)
 	if err != nil {
@@ -69,10 +67,28 @@ func (u *usecase) SubmitOvertime(ctx *gin.Context, userID uint, dateStr string,
 		u.log.Error(log.LogData{Err: err})
 		return nil, false, utils.MakeError(errorUc.InternalServerError, "db error")
 	}
+	if !existed && by != nil {
+		if err = u.otRepo.SetSubmitter(txCtx, row.ID, by.adminID, by.reason); err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, false, utils.MakeError(errorUc.InternalServerError, "failed to save overtime submitter")
+		}
+		by.apply(&row.SubmittedBy, &row.OnBehalfReason)
+	}
+	if !existed && (planID != nil || planFlag != "") {
+		if err = u.otRepo.SetPlanMatch(txCtx, row.ID, planID, planFlag); err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, false, utils.MakeError(errorUc.InternalServerError, "failed to save overtime plan match")
+		}
+		row.PlanID, row.PlanFlagged, row.PlanFlagReason = planID, planFlag != "", planFlag
+	}
 	return row, existed, nil
 }
 
 func (u *usecase) CreateReimbursement(ctx *gin.Context, userID uint, dateStr string, amount float64, description string) (*model.Reimbursement, error) {
+	return u.createReimbursement(ctx, userID, dateStr, amount, description, nil)
+}
+
+func (u *usecase) createReimbursement(ctx *gin.Context, userID uint, dateStr string, amount float64, description string, by *onBehalf) (*model.Reimbursement, error) {
 	if amount <= 0 {
 		return nil, utils.MakeError(errorUc.BadRequest, "amount must be > 0")
 	}
@@ -80,14 +96,6 @@ func (u *usecase) CreateReimbursement(ctx *gin.Context, userID uint, dateStr str
 	var date time.Time
 	var err error
 
-	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
-	if err != nil {
-		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
-	}
-	if locked {
-		return nil, utils.MakeError(errorUc.BadRequest, "payroll already run for this period; submissions are locked")
-	}
-
 	if dateStr == "" {
 		now := time.Now().In(loc)
 		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
@@ -97,6 +105,9 @@ func (u *usecase) CreateReimbursement(ctx *gin.Context, userID uint, dateStr str
 			return nil, utils.MakeError(errorUc.BadRequest, "invalid date format (YYYY-MM-DD)")
 		}
 	}
+	if err = u.ensureLateItemOpen(ctx, date); err != nil {
+		return nil, err
+	}
 
 	txCtx, err := u.txManager.Begin(ctx)
 	if err != nil {
@@ -117,9 +128,33 @@ func (u *usecase) CreateReimbursement(ctx *gin.Context, userID uint, dateStr str
 		Amount:      amount,
 		Description: description,
 	}
+	if by != nil {
+		by.apply(&row.SubmittedBy, &row.OnBehalfReason)
+	}
 	if err := u.rbRepo.Create(txCtx, row); err != nil {
 		u.log.Error(log.LogData{Err: err})
 		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
 	}
 	return row, nil
 }
+
+// overtimeTooEarly: overtime untuk hari ini harus diajukan setelah jam kerja selesai (>= 17:00 WIB).
+func overtimeTooEarly(now, date time.Time) bool {
+	if now.Year() != date.Year() || now.YearDay() != date.YearDay() {
+		return false
+	}
+	after5pm := time.Date(now.Year(), now.Month(), now.Day(), 17, 0, 0, 0, now.Location())
+	return now.Before(after5pm)
+}
+
+// ensureLateItemOpen: overtime / reimbursement di period terkunci diarahkan ke prior period adjustment.
+func (u *usecase) ensureLateItemOpen(ctx *gin.Context, date time.Time) error {
+	locked, err := u.payrollRepo.HasRunOnDate(ctx, date)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if locked {
+		return utils.MakeError(errorUc.BadRequest, "attendance period is closed; submit it as a prior period adjustment")
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	otDTO "payslip-generation-system/internal/dto/overtime"
+	rbDTO "payslip-generation-system/internal/dto/reimbursement"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Catatan: overtime / reimbursement / attendance belum punya status approval, jadi kuncinya status period
+// tanggalnya. Hanya entry yang disubmit sendiri yang bisa diubah / dihapus: entry yang diinput admin
+// (atas nama / import CSV) atau dibuat lewat koreksi attendance harus diubah lewat admin / koreksi.
+
+// UpdateMyOvertime: ubah jam overtime milik sendiri selama period tanggalnya masih open.
+func (u *usecase) UpdateMyOvertime(ctx *gin.Context, userID, id uint, req otDTO.UpdateOvertimeRequest) (resp *otDTO.OvertimeHistoryResponse, err error) {
+	if req.Hours <= 0 || req.Hours > 3 {
+		return nil, utils.MakeError(errorUc.BadRequest, "hours must be > 0 and <= 3")
+	}
+	row, err := u.findMyOvertime(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	status, err := u.ensureEntryEditable(ctx, row.Date)
+	if err != nil {
+		return nil, err
+	}
+	// jam baru dicocokkan ulang dengan rencana overtime
+	planID, planFlag, err := u.matchOvertimePlan(ctx, userID, row.Date, req.Hours)
+	if err != nil {
+		return nil, err
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	if err = u.otRepo.UpdateHours(txCtx, row.ID, req.Hours); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update overtime"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if u.overtimePlanMode() != model.OvertimePlanModeOff {
+		if err = u.otRepo.SetPlanMatch(txCtx, row.ID, planID, planFlag); err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to update overtime plan match"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		row.PlanID, row.PlanFlagReason = planID, planFlag
+	}
+	return &otDTO.OvertimeHistoryResponse{
+		ID:             row.ID,
+		Date:           row.Date.Format("2006-01-02"),
+		Hours:          req.Hours,
+		SubmittedBy:    row.SubmittedBy,
+		OnBehalfReason: row.OnBehalfReason,
+		PlanID:         row.PlanID,
+		PlanFlag:       row.PlanFlagReason,
+		Status:         status,
+		CreatedAt:      row.CreatedAt,
+	}, nil
+}
+
+// DeleteMyOvertime: hapus overtime milik sendiri selama period tanggalnya masih open.
+func (u *usecase) DeleteMyOvertime(ctx *gin.Context, userID, id uint) error {
+	row, err := u.findMyOvertime(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if _, err = u.ensureEntryEditable(ctx, row.Date); err != nil {
+		return err
+	}
+	if err = u.otRepo.Delete(ctx, row.ID); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to delete overtime"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	return nil
+}
+
+// UpdateMyReimbursement: ubah amount / description reimbursement milik sendiri selama period tanggalnya masih open.
+func (u *usecase) UpdateMyReimbursement(ctx *gin.Context, userID, id uint, req rbDTO.UpdateReimbursementRequest) (*rbDTO.ReimbursementHistoryResponse, error) {
+	if req.Amount == nil && req.Description == nil {
+		return nil, utils.MakeError(errorUc.BadRequest, "nothing to update")
+	}
+	if req.Amount != nil && *req.Amount <= 0 {
+		return nil, utils.MakeError(errorUc.BadRequest, "amount must be > 0")
+	}
+	row, err := u.findMyReimbursement(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	status, err := u.ensureEntryEditable(ctx, row.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Amount != nil {
+		row.Amount = *req.Amount
+	}
+	if req.Description != nil {
+		row.Description = *req.Description
+	}
+	if err = u.rbRepo.Update(ctx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update reimbursement"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	return &rbDTO.ReimbursementHistoryResponse{
+		ReimbursementResponse: rbDTO.ReimbursementResponse{
+			ID:             row.ID,
+			UserID:         row.UserID,
+			Date:           row.Date.Format("2006-01-02"),
+			Amount:         fmt.Sprintf("%.2f", row.Amount),
+			Description:    row.Description,
+			SubmittedBy:    row.SubmittedBy,
+			OnBehalfReason: row.OnBehalfReason,
+		},
+		Status:    status,
+		CreatedAt: row.CreatedAt,
+	}, nil
+}
+
+// DeleteMyReimbursement: hapus reimbursement milik sendiri selama period tanggalnya masih open.
+func (u *usecase) DeleteMyReimbursement(ctx *gin.Context, userID, id uint) error {
+	row, err := u.findMyReimbursement(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if _, err = u.ensureEntryEditable(ctx, row.Date); err != nil {
+		return err
+	}
+	if err = u.rbRepo.Delete(ctx, row.ID); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to delete reimbursement"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	return nil
+}
+
+// DeleteMyAttendance: hapus attendance milik sendiri selama period tanggalnya masih open; tercatat di attendance_audits.
+// Attendance dari admin, koreksi, atau mesin absensi tidak bisa dihapus sendiri.
+func (u *usecase) DeleteMyAttendance(ctx *gin.Context, userID, id uint) (err error) {
+	row, err := u.atRepo.FindByID(ctx, id)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendance"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil || row.UserID != userID {
+		return utils.MakeError(errorUc.NotFoundError, "attendance not found")
+	}
+	if row.SubmittedBy != nil {
+		return utils.MakeError(errorUc.ErrForbidden, "attendance was submitted by an admin and cannot be deleted by you")
+	}
+	corrected, err := u.atRepo.HasAudit(ctx, row.ID, model.AttendanceAuditCreated, model.AttendanceAuditSourceCorrection)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendance audit"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if corrected {
+		return utils.MakeError(errorUc.ErrForbidden, "attendance was added by an approved correction; request a correction to remove it")
+	}
+	punched, err := u.atRepo.HasAudit(ctx, row.ID, model.AttendanceAuditCreated, model.AttendanceAuditSourceDevice)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendance audit"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if punched {
+		return utils.MakeError(errorUc.ErrForbidden, "attendance was recorded by an attendance device; request a correction to remove it")
+	}
+	if _, err = u.ensureEntryEditable(ctx, row.Date); err != nil {
+		return err
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	if err = u.atRepo.Delete(txCtx, row.ID); err != nil {
+		u.log.Error(log.LogData{Err: err})
+		return utils.MakeError(errorUc.InternalServerError, "failed to delete attendance")
+	}
+	if err = u.atRepo.AddAudit(txCtx, &model.AttendanceAudit{
+		AttendanceID: row.ID,
+		UserID:       row.UserID,
+		Date:         row.Date,
+		Action:       model.AttendanceAuditDeleted,
+		Source:       model.AttendanceAuditSourceSelf,
+		ActorID:      userID,
+	}); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to write attendance audit"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to write attendance audit")
+	}
+	return nil
+}
+
+// findMyOvertime: overtime milik user yang disubmit sendiri; milik orang lain diperlakukan sebagai tidak ada.
+func (u *usecase) findMyOvertime(ctx context.Context, userID, id uint) (*model.Overtime, error) {
+	row, err := u.otRepo.FindByID(ctx, id)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load overtime"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil || row.UserID != userID {
+		return nil, utils.MakeError(errorUc.NotFoundError, "overtime not found")
+	}
+	if row.SubmittedBy != nil {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "overtime was submitted by an admin and cannot be changed by you")
+	}
+	return row, nil
+}
+
+// findMyReimbursement: reimbursement milik user yang disubmit sendiri; milik orang lain diperlakukan sebagai tidak ada.
+func (u *usecase) findMyReimbursement(ctx context.Context, userID, id uint) (*model.Reimbursement, error) {
+	row, err := u.rbRepo.FindByID(ctx, id)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load reimbursement"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil || row.UserID != userID {
+		return nil, utils.MakeError(errorUc.NotFoundError, "reimbursement not found")
+	}
+	if row.SubmittedBy != nil {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "reimbursement was submitted by an admin and cannot be changed by you")
+	}
+	return row, nil
+}
+
+// ensureEntryEditable: entry hanya bisa diubah / dihapus jika tanggalnya belum masuk period
+// atau period-nya masih open (belum di-run payroll). Mengembalikan status period tanggal tsb.
+func (u *usecase) ensureEntryEditable(ctx context.Context, date time.Time) (string, error) {
+	p, err := u.apRepo.Containing(ctx, date)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load attendance period"})
+		return "", utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if p == nil {
+		return historyStatusNoPeriod, nil
+	}
+	period, err := u.findPeriod(ctx, p.ID)
+	if err != nil {
+		return "", err
+	}
+	status := periodStatus(period)
+	if status != model.PeriodStatusOpen {
+		return "", utils.MakeError(errorUc.BadRequest, "attendance period is "+status+"; submissions are locked")
+	}
+	return status, nil
+}
@@ -0,0 +1,200 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	otDTO "payslip-generation-system/internal/dto/overtime"
+	rbDTO "payslip-generation-system/internal/dto/reimbursement"
+	"payslip-generation-system/internal/model"
+	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+// ownEntryPeriods: Agustus 2025 dengan status run yang bisa diganti; tanggal di luar Agustus tidak masuk period.
+func ownEntryPeriods(runStatus *string) *testm.APRepoMock {
+	return &testm.APRepoMock{
+		ContainingFn: func(_ context.Context, date time.Time) (*model.AttendancePeriod, error) {
+			if date.Month() != time.August {
+				return nil, nil
+			}
+			p := historyPeriod(*runStatus)
+			return &p.AttendancePeriod, nil
+		},
+		FindByIDFn: func(_ context.Context, id uint) (*apRepo.PeriodRow, error) {
+			p := historyPeriod(*runStatus)
+			return &p, nil
+		},
+	}
+}
+
+func TestUpdateMyOvertime_OnlyOwnRowWhilePeriodOpen(t *testing.T) {
+	u := usecase.NewForTest()
+	runStatus := ""
+	var updatedHours float64
+	otMock := &testm.OTRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.Overtime, error) {
+			row := &model.Overtime{ID: id, UserID: 42, Date: time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC), Hours: 1}
+			switch id {
+			case 5:
+				return row, nil
+			case 7: // diinput admin atas nama karyawan
+				admin := uint(1)
+				row.SubmittedBy = &admin
+				return row, nil
+			}
+			return nil, nil
+		},
+		UpdateHoursFn: func(_ context.Context, id uint, hours float64) error {
+			require.Equal(t, uint(5), id)
+			updatedHours = hours
+			return nil
+		},
+	}
+	usecase.InjectForTest(u, ownEntryPeriods(&runStatus), nil, otMock, nil, nil, testm.FakeTxManager{})
+	ctx := makeGinCtx()
+
+	resp, err := u.UpdateMyOvertime(ctx, 42, 5, otDTO.UpdateOvertimeRequest{Hours: 2.5})
+	require.NoError(t, err)
+	require.Equal(t, 2.5, updatedHours)
+	require.Equal(t, 2.5, resp.Hours)
+	require.Equal(t, model.PeriodStatusOpen, resp.Status)
+
+	// milik user lain / tidak ada -> not found
+	_, err = u.UpdateMyOvertime(ctx, 43, 5, otDTO.UpdateOvertimeRequest{Hours: 2})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overtime not found")
+	_, err = u.UpdateMyOvertime(ctx, 42, 6, otDTO.UpdateOvertimeRequest{Hours: 2})
+	require.Error(t, err)
+
+	_, err = u.UpdateMyOvertime(ctx, 42, 5, otDTO.UpdateOvertimeRequest{Hours: 4})
+	require.Error(t, err)
+
+	// bukan submit sendiri -> forbidden
+	_, err = u.UpdateMyOvertime(ctx, 42, 7, otDTO.UpdateOvertimeRequest{Hours: 2})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "submitted by an admin")
+	require.Error(t, u.DeleteMyOvertime(ctx, 42, 7))
+	require.Equal(t, 2.5, updatedHours)
+
+	// payroll sudah di-run -> terkunci
+	updatedHours = 0
+	runStatus = model.PayrollRunStatusDraft
+	_, err = u.UpdateMyOvertime(ctx, 42, 5, otDTO.UpdateOvertimeRequest{Hours: 1.5})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "locked")
+	require.Zero(t, updatedHours)
+
+	otMock.DeleteFn = func(_ context.Context, id uint) error {
+		t.Fatal("delete must not be called on a locked period")
+		return nil
+	}
+	require.Error(t, u.DeleteMyOvertime(ctx, 42, 5))
+}
+
+func TestDeleteMyEntries_AuditsAttendanceAndRespectsLock(t *testing.T) {
+	u := usecase.NewForTest()
+	runStatus := ""
+	var deleted []uint
+	var audit *model.AttendanceAudit
+	atMock := &testm.ATRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.Attendance, error) {
+			row := &model.Attendance{ID: id, UserID: 42, Date: time.Date(2025, 8, 4, 0, 0, 0, 0, time.UTC)}
+			if id == 4 { // diinput admin atas nama karyawan
+				admin := uint(1)
+				row.SubmittedBy = &admin
+			}
+			return row, nil
+		},
+		HasAuditFn: func(_ context.Context, id uint, action, source string) (bool, error) {
+			// attendance 6 dibuat lewat koreksi yang disetujui, 11 dari mesin absensi
+			return action == model.AttendanceAuditCreated &&
+				(id == 6 && source == model.AttendanceAuditSourceCorrection || id == 11 && source == model.AttendanceAuditSourceDevice), nil
+		},
+		DeleteFn: func(_ context.Context, id uint) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+		AddAuditFn: func(_ context.Context, a *model.AttendanceAudit) error {
+			audit = a
+			return nil
+		},
+	}
+	var saved *model.Reimbursement
+	rbMock := &testm.RBRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.Reimbursement, error) {
+			// 9 belum masuk period mana pun, 8 di Agustus
+			date := time.Date(2025, 9, 2, 0, 0, 0, 0, time.UTC)
+			if id == 8 {
+				date = time.Date(2025, 8, 12, 0, 0, 0, 0, time.UTC)
+			}
+			row := &model.Reimbursement{ID: id, UserID: 42, Date: date, Amount: 50000, Description: "taxi"}
+			if id == 10 {
+				admin := uint(1)
+				row.SubmittedBy = &admin
+			}
+			return row, nil
+		},
+		UpdateFn: func(_ context.Context, r *model.Reimbursement) error {
+			saved = r
+			return nil
+		},
+		DeleteFn: func(_ context.Context, id uint) error {
+			deleted = append(deleted, id)
+			return nil
+		},
+	}
+	usecase.InjectForTest(u, ownEntryPeriods(&runStatus), atMock, nil, rbMock, nil, testm.FakeTxManager{})
+	ctx := makeGinCtx()
+
+	require.NoError(t, u.DeleteMyAttendance(ctx, 42, 3))
+	require.Equal(t, []uint{3}, deleted)
+	require.NotNil(t, audit)
+	require.Equal(t, model.AttendanceAuditDeleted, audit.Action)
+	require.Equal(t, model.AttendanceAuditSourceSelf, audit.Source)
+	require.Equal(t, uint(42), audit.ActorID)
+
+	err := u.DeleteMyAttendance(ctx, 43, 3)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "attendance not found")
+
+	// attendance atas nama admin / hasil koreksi / dari mesin absensi tidak bisa dihapus sendiri
+	err = u.DeleteMyAttendance(ctx, 42, 4)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "submitted by an admin")
+	err = u.DeleteMyAttendance(ctx, 42, 6)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "approved correction")
+	err = u.DeleteMyAttendance(ctx, 42, 11)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "attendance device")
+	require.Equal(t, []uint{3}, deleted)
+
+	amount := 65000.0
+	resp, err := u.UpdateMyReimbursement(ctx, 42, 9, rbDTO.UpdateReimbursementRequest{Amount: &amount})
+	require.NoError(t, err)
+	require.Equal(t, "65000.00", resp.Amount)
+	require.Equal(t, "taxi", saved.Description)
+	require.Equal(t, "no_period", resp.Status)
+
+	_, err = u.UpdateMyReimbursement(ctx, 42, 9, rbDTO.UpdateReimbursementRequest{})
+	require.Error(t, err)
+	_, err = u.UpdateMyReimbursement(ctx, 42, 10, rbDTO.UpdateReimbursementRequest{Amount: &amount})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "submitted by an admin")
+	require.Error(t, u.DeleteMyReimbursement(ctx, 42, 10))
+
+	// period Agustus sudah closed: attendance & reimbursement Agustus terkunci, September tetap bisa
+	runStatus = model.PayrollRunStatusApproved
+	deleted = nil
+	require.Error(t, u.DeleteMyAttendance(ctx, 42, 3))
+	err = u.DeleteMyReimbursement(ctx, 42, 8)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "closed")
+	require.NoError(t, u.DeleteMyReimbursement(ctx, 42, 9))
+	require.Equal(t, []uint{9}, deleted)
+}
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"payslip-generation-system/config"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/notify"
+	"payslip-generation-system/utils"
+)
+
+const (
+	defaultPasswordMinLength = 8
+	defaultResetTokenTTL     = 30 * time.Minute
+
+	revokeReasonPasswordReset  = "password_reset"
+	revokeReasonPasswordChange = "password_change"
+)
+
+func (u *usecase) passwordPolicy() config.PasswordPolicy {
+	var p config.PasswordPolicy
+	if u.cfg != nil {
+		p = u.cfg.Auth.PasswordPolicy
+	}
+	if p.MinLength <= 0 {
+		p.MinLength = defaultPasswordMinLength
+	}
+	return p
+}
+
+func (u *usecase) resetTokenTTL() time.Duration {
+	if u.cfg != nil && u.cfg.Auth.PasswordReset.TokenTTL > 0 {
+		return u.cfg.Auth.PasswordReset.TokenTTL
+	}
+	return defaultResetTokenTTL
+}
+
+// validatePassword mengecek password terhadap policy; pesan error menyebut semua aturan yang gagal.
+func validatePassword(p config.PasswordPolicy, password string) error {
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	var problems []string
+	if len([]rune(password)) < p.MinLength {
+		problems = append(problems, fmt.Sprintf("at least %d characters", p.MinLength))
+	}
+	if p.RequireUpper && !hasUpper {
+		problems = append(problems, "an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		problems = append(problems, "a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		problems = append(problems, "a digit")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		problems = append(problems, "a symbol")
+	}
+	if len(problems) > 0 {
+		// jangan pakai koma: MakeError memecah argumen berdasarkan koma
+		return utils.MakeError(errorUc.BadRequest, "password must contain "+strings.Join(problems, " and "))
+	}
+	return nil
+}
+
+// ForgotPassword selalu "berhasil" dari sisi client agar email yang terdaftar tidak bisa ditebak.
+func (u *usecase) ForgotPassword(ctx *gin.Context, email string) (err error) {
+	email = strings.TrimSpace(strings.ToLower(email))
+
+	user, err := u.authRepo.FindByEmail(ctx, email)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to look up user for password reset"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		u.log.Info(log.LogData{Description: "password reset requested for unknown email"})
+		return nil
+	}
+
+	raw, hash, err := newRefreshToken()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate reset token"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to generate reset token")
+	}
+	now := time.Now().UTC()
+	row := &model.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: hash,
+		ExpiresAt: now.Add(u.resetTokenTTL()),
+	}
+	if ctx.Request != nil {
+		row.RequestedIP = ctx.ClientIP()
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	// hanya token terbaru yang berlaku
+	if err = u.resetRepo.InvalidateForUser(txCtx, user.ID, now); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to invalidate old reset tokens"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if err = u.resetRepo.Create(txCtx, row); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store reset token"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	link := raw
+	if u.cfg != nil && u.cfg.Auth.PasswordReset.URLTemplate != "" {
+		link = fmt.Sprintf(u.cfg.Auth.PasswordReset.URLTemplate, raw)
+	}
+	if err = u.notifier.Send(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Use the link below to reset your password. It expires in %s and can only be used once.\n\n%s\n\nIf you did not request this you can ignore this message.",
+			u.resetTokenTTL(), link),
+	}); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to send password reset notification"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to send reset instructions")
+	}
+	return nil
+}
+
+// ResetPassword memakai token reset (sekali pakai) lalu mencabut semua session user.
+func (u *usecase) ResetPassword(ctx *gin.Context, token, newPassword string) (err error) {
+	if err := validatePassword(u.passwordPolicy(), newPassword); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	row, err := u.resetRepo.GetByHash(ctx, hashToken(token))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load reset token"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if row == nil || row.UsedAt != nil || now.After(row.ExpiresAt) {
+		return utils.MakeError(errorUc.BadRequest, "invalid or expired reset token")
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to hash password"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to hash password")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	ok, err := u.resetRepo.MarkUsed(txCtx, row.ID, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to mark reset token used"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if !ok {
+		return utils.MakeError(errorUc.BadRequest, "invalid or expired reset token")
+	}
+	if err = u.authRepo.UpdatePassword(txCtx, row.UserID, string(hashed)); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update password"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to update password")
+	}
+	if err = u.revokeAllSessionsInTx(txCtx, row.UserID, revokeReasonPasswordReset); err != nil {
+		return err
+	}
+
+	u.log.Info(log.LogData{Description: "password reset completed", Response: row.UserID})
+	return nil
+}
+
+// ChangePassword untuk user yang sedang login; semua session (termasuk yang sekarang) dicabut.
+func (u *usecase) ChangePassword(ctx *gin.Context, userID uint, currentPassword, newPassword string) (err error) {
+	user, err := u.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load user"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)) != nil {
+		return utils.MakeError(errorUc.BadRequest, "current password is incorrect")
+	}
+	if currentPassword == newPassword {
+		return utils.MakeError(errorUc.BadRequest, "new password must be different from the current password")
+	}
+	if err := validatePassword(u.passwordPolicy(), newPassword); err != nil {
+		return err
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to hash password"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to hash password")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	if err = u.authRepo.UpdatePassword(txCtx, userID, string(hashed)); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update password"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to update password")
+	}
+	if err = u.revokeAllSessionsInTx(txCtx, userID, revokeReasonPasswordChange); err != nil {
+		return err
+	}
+
+	u.log.Info(log.LogData{Description: "password changed", Response: userID})
+	return nil
+}
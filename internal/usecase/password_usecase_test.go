@@ -0,0 +1,95 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func TestForgotPassword_UnknownEmailSendsNothing(t *testing.T) {
+	u := usecase.NewForTest()
+
+	authMock := &testm.AuthRepoMock{
+		FindByEmailFn: func(_ context.Context, email string) (*model.User, error) { return nil, nil },
+	}
+	notifier := &testm.NotifierMock{}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+	usecase.InjectPasswordForTest(u, &testm.PasswordResetRepoMock{}, notifier)
+
+	err := u.ForgotPassword(makeGinCtx(), "nobody@example.com")
+	require.NoError(t, err)
+	require.Empty(t, notifier.Sent)
+}
+
+func TestResetPassword_ExpiredToken(t *testing.T) {
+	u := usecase.NewForTest()
+
+	resetMock := &testm.PasswordResetRepoMock{
+		GetByHashFn: func(_ context.Context, hash string) (*model.PasswordResetToken, error) {
+			return &model.PasswordResetToken{ID: 1, UserID: 7, ExpiresAt: time.Now().Add(-time.Minute)}, nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), &testm.AuthRepoMock{}, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+	usecase.InjectPasswordForTest(u, resetMock, &testm.NotifierMock{})
+
+	err := u.ResetPassword(makeGinCtx(), "some-token", "NewPassw0rd!")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "expired")
+}
+
+func TestChangePassword_RevokesSessions(t *testing.T) {
+	u := usecase.NewForTest()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("OldPassw0rd!"), bcrypt.MinCost)
+	var updated, bumped bool
+	var revokedReason string
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, PasswordHash: string(hash)}, nil
+		},
+		UpdatePasswordFn: func(_ context.Context, userID uint, passwordHash string) error {
+			updated = true
+			return nil
+		},
+		BumpTokenVersionFn: func(_ context.Context, userID uint) error {
+			bumped = true
+			return nil
+		},
+	}
+	sessMock := &testm.SessionRepoMock{
+		RevokeAllForUserFn: func(_ context.Context, userID uint, reason string, at time.Time) error {
+			revokedReason = reason
+			return nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, sessMock, testm.FakeTxManager{})
+
+	err := u.ChangePassword(makeGinCtx(), 7, "OldPassw0rd!", "NewPassw0rd!")
+	require.NoError(t, err)
+	require.True(t, updated)
+	require.True(t, bumped)
+	require.Equal(t, "password_change", revokedReason)
+}
+
+func TestChangePassword_PolicyViolation(t *testing.T) {
+	u := usecase.NewForTest()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("OldPassw0rd!"), bcrypt.MinCost)
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, PasswordHash: string(hash)}, nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+
+	err := u.ChangePassword(makeGinCtx(), 7, "OldPassw0rd!", "short")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "at least 8 characters")
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"payslip-generation-system/internal/disbursement"
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	"payslip-generation-system/internal/dto/payslip"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Transisi status pembayaran yang boleh datang dari file hasil bank.
+// failed -> paid diizinkan karena bank bisa melaporkan retry yang berhasil di file berikutnya.
+var paymentTransitions = map[string][]string{
+	model.PaymentStatusSent:   {model.PaymentStatusPaid, model.PaymentStatusFailed},
+	model.PaymentStatusFailed: {model.PaymentStatusPaid},
+	model.PaymentStatusPaid:   {model.PaymentStatusReturned},
+}
+
+func canTransitionPayment(from, to string) bool {
+	for _, s := range paymentTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// markItemsSent menandai item yang masuk batch sebagai sent (dipanggil di dalam transaksi pembuatan batch).
+func (u *usecase) markItemsSent(ctx context.Context, items []model.PayrollItem, batch *disbursement.Batch, at time.Time) error {
+	byUser := make(map[uint]model.PayrollItem, len(items))
+	for _, it := range items {
+		byUser[it.UserID] = it
+	}
+	for _, e := range batch.Entries {
+		it, ok := byUser[e.UserID]
+		if !ok {
+			return fmt.Errorf("payroll item for user %d not found", e.UserID)
+		}
+		it.PaymentStatus = model.PaymentStatusSent
+		it.PaymentSequence = e.Sequence
+		it.PaymentAccountLast4 = e.AccountNumber[len(e.AccountNumber)-4:]
+		it.SentAt = &at
+		ok, err := u.payrollRepo.UpdateItemPayment(ctx, &it, model.PaymentStatusPending)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("payroll item %d is no longer pending", it.ID)
+		}
+	}
+	return nil
+}
+
+// ListPayments menampilkan status pembayaran tiap item run payroll beserta rekap per status.
+func (u *usecase) ListPayments(ctx *gin.Context, periodID uint) (*pDTO.PaymentListResponse, error) {
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+	items, err := u.payrollRepo.ListItemsByRun(ctx, run.ID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load payroll items"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error (payroll items)")
+	}
+
+	totals := map[string]int64{}
+	counts := map[string]int{}
+	out := make([]pDTO.PaymentItemResponse, 0, len(items))
+	for _, it := range items {
+		status := paymentStatusOf(it)
+		counts[status]++
+		totals[status] += disbursement.ToMinorUnits(it.GrandTotal)
+		out = append(out, pDTO.PaymentItemResponse{
+			UserID:        it.UserID,
+			GrandTotal:    fmt.Sprintf("%.2f", round2(it.GrandTotal)),
+			Status:        status,
+			Sequence:      it.PaymentSequence,
+			AccountLast4:  it.PaymentAccountLast4,
+			BankReference: it.BankReference,
+			Note:          it.PaymentNote,
+			SentAt:        it.SentAt,
+			PaidAt:        it.PaidAt,
+			FailedAt:      it.FailedAt,
+			ReturnedAt:    it.ReturnedAt,
+		})
+	}
+	summary := make(map[string]pDTO.PaymentStatusSummary, len(counts))
+	for status, n := range counts {
+		summary[status] = pDTO.PaymentStatusSummary{Count: n, Amount: disbursement.FormatAmount(totals[status])}
+	}
+	return &pDTO.PaymentListResponse{PeriodID: periodID, RunID: run.ID, Summary: summary, Items: out}, nil
+}
+
+// ImportPaymentResults membaca file hasil transfer dari bank dan merekonsiliasi status item.
+// Baris dicocokkan lewat nomor urut di file disbursement; nomor rekening & nominal (jika ada) harus cocok.
+// Baris yang tidak valid dilaporkan tanpa membatalkan baris lain.
+func (u *usecase) ImportPaymentResults(ctx *gin.Context, actorID, periodID uint, file io.Reader) (resp *pDTO.ImportPaymentResultsResponse, err error) {
+	rows, err := disbursement.ParseResultCSV(file)
+	if err != nil {
+		if errors.Is(err, disbursement.ErrInvalidResultFile) {
+			return nil, utils.MakeError(errorUc.BadRequest, err.Error())
+		}
+		u.log.Error(log.LogData{Err: err, Description: "failed to read payment result file"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to read result file")
+	}
+
+	batch, err := u.findDisbursement(ctx, periodID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := u.payrollRepo.ListItemsByRun(ctx, batch.PayrollRunID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load payroll items"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error (payroll items)")
+	}
+	bySeq := make(map[int]*model.PayrollItem, len(items))
+	for i := range items {
+		if items[i].PaymentSequence > 0 {
+			bySeq[items[i].PaymentSequence] = &items[i]
+		}
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	resp = &pDTO.ImportPaymentResultsResponse{
+		PeriodID:       periodID,
+		RunID:          batch.PayrollRunID,
+		BatchReference: batch.Reference,
+		Lines:          make([]pDTO.PaymentResultLine, 0, len(rows)),
+	}
+	now := time.Now().UTC()
+	for _, row := range rows {
+		line := pDTO.PaymentResultLine{Line: row.Line, Sequence: row.Sequence, Status: row.Status}
+		it, ok := bySeq[row.Sequence]
+		switch {
+		case !ok:
+			line.Result, line.Message = "error", "sequence not found in disbursement batch"
+		case row.AccountNumber != "" && !hasSuffix4(row.AccountNumber, it.PaymentAccountLast4):
+			line.UserID = it.UserID
+			line.Result, line.Message = "error", "account number does not match"
+		case row.Amount >= 0 && row.Amount != disbursement.ToMinorUnits(it.GrandTotal):
+			line.UserID = it.UserID
+			line.Result, line.Message = "error", "amount does not match"
+		case it.PaymentStatus == row.Status:
+			line.UserID = it.UserID
+			line.Result = "unchanged"
+		case !canTransitionPayment(it.PaymentStatus, row.Status):
+			line.UserID = it.UserID
+			line.Result, line.Message = "error", fmt.Sprintf("cannot change payment status from %s to %s", it.PaymentStatus, row.Status)
+		default:
+			line.UserID = it.UserID
+			next := *it
+			applyPaymentResult(&next, row, now)
+			updated, err := u.payrollRepo.UpdateItemPayment(txCtx, &next, it.PaymentStatus)
+			if err != nil {
+				u.log.Error(log.LogData{Err: err, Description: "failed to update payment status"})
+				return nil, utils.MakeError(errorUc.InternalServerError, "failed to update payment status")
+			}
+			if !updated {
+				line.Result, line.Message = "error", "payment status changed concurrently"
+				break
+			}
+			*it = next
+			line.Result = "updated"
+		}
+
+		resp.Processed++
+		switch line.Result {
+		case "updated":
+			resp.Updated++
+		case "unchanged":
+			resp.Unchanged++
+		default:
+			resp.Errors++
+		}
+		resp.Lines = append(resp.Lines, line)
+	}
+
+	u.log.Info(log.LogData{Description: "payment results imported", Response: map[string]any{
+		"run_id": batch.PayrollRunID, "processed": resp.Processed, "updated": resp.Updated, "errors": resp.Errors, "by": actorID,
+	}})
+	return resp, nil
+}
+
+func applyPaymentResult(it *model.PayrollItem, row disbursement.ResultRow, at time.Time) {
+	it.PaymentStatus = row.Status
+	if row.BankReference != "" {
+		it.BankReference = row.BankReference
+	}
+	it.PaymentNote = row.Note
+	switch row.Status {
+	case model.PaymentStatusPaid:
+		it.PaidAt = &at
+	case model.PaymentStatusFailed:
+		it.FailedAt = &at
+	case model.PaymentStatusReturned:
+		it.ReturnedAt = &at
+	}
+}
+
+func hasSuffix4(account, last4 string) bool {
+	return len(account) >= 4 && account[len(account)-4:] == last4
+}
+
+// item lama (sebelum kolom pembayaran ada) dianggap pending
+func paymentStatusOf(it model.PayrollItem) string {
+	if it.PaymentStatus == "" {
+		return model.PaymentStatusPending
+	}
+	return it.PaymentStatus
+}
+
+func toPaymentInfo(it *model.PayrollItem) *payslip.PaymentInfo {
+	return &payslip.PaymentInfo{
+		Status:        paymentStatusOf(*it),
+		BankReference: it.BankReference,
+		Note:          it.PaymentNote,
+		SentAt:        it.SentAt,
+		PaidAt:        it.PaidAt,
+		FailedAt:      it.FailedAt,
+		ReturnedAt:    it.ReturnedAt,
+	}
+}
@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func setupPaymentImport(t *testing.T, updates *[]model.PayrollItem) usecase.IUsecase {
+	u := usecase.NewForTest()
+	sentAt := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	payMock := &testm.PayRepoMock{
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			return &model.PayrollRun{ID: 12, PeriodID: periodID}, nil
+		},
+		ListItemsByRunFn: func(_ context.Context, runID uint) ([]model.PayrollItem, error) {
+			return []model.PayrollItem{
+				{ID: 70, UserID: 7, GrandTotal: 5000000.50, PaymentStatus: model.PaymentStatusSent, PaymentSequence: 1, PaymentAccountLast4: "7890", SentAt: &sentAt},
+				{ID: 80, UserID: 8, GrandTotal: 2500000, PaymentStatus: model.PaymentStatusSent, PaymentSequence: 2, PaymentAccountLast4: "3210", SentAt: &sentAt},
+				{ID: 90, UserID: 9, GrandTotal: 1000000, PaymentStatus: model.PaymentStatusSent, PaymentSequence: 3, PaymentAccountLast4: "5555", SentAt: &sentAt},
+				{ID: 100, UserID: 10, GrandTotal: 0, PaymentStatus: model.PaymentStatusPending},
+			}, nil
+		},
+		UpdateItemPaymentFn: func(_ context.Context, it *model.PayrollItem, from string) (bool, error) {
+			*updates = append(*updates, *it)
+			return true, nil
+		},
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectDisbursementForTest(u, &testm.DisbursementRepoMock{
+		GetByRunFn: func(_ context.Context, runID uint) (*model.DisbursementBatch, error) {
+			return &model.DisbursementBatch{ID: 1, PayrollRunID: runID, Reference: "PR0000001220250901"}, nil
+		},
+	})
+	return u
+}
+
+func TestImportPaymentResults_ReconcilesPerLine(t *testing.T) {
+	var updates []model.PayrollItem
+	u := setupPaymentImport(t, &updates)
+
+	file := strings.Join([]string{
+		"no,account_number,amount,status,bank_reference,note",
+		"1,1234567890,5000000.50,SUCCESS,TRX-001,",
+		"2,9876543210,2400000.00,success,TRX-002,",        // nominal beda
+		"3,0000005555,1000000.00,gagal,,Rekening ditutup", // gagal dengan alasan
+		"4,1111111111,1.00,success,TRX-004,",              // tidak ada di batch
+		"3,0000005555,1000000.00,returned,,",              // failed -> returned tidak boleh
+		"1,1234567890,5000000.50,paid,TRX-001,",           // sudah paid
+	}, "\n")
+	resp, err := u.ImportPaymentResults(makeGinCtx(), 1, 3, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Equal(t, 6, resp.Processed)
+	require.Equal(t, 2, resp.Updated)
+	require.Equal(t, 1, resp.Unchanged)
+	require.Equal(t, 3, resp.Errors)
+	require.Equal(t, "amount does not match", resp.Lines[1].Message)
+	require.Equal(t, "sequence not found in disbursement batch", resp.Lines[3].Message)
+	require.Contains(t, resp.Lines[4].Message, "from failed to returned")
+	require.Equal(t, "unchanged", resp.Lines[5].Result)
+
+	require.Len(t, updates, 2)
+	require.Equal(t, uint(70), updates[0].ID)
+	require.Equal(t, model.PaymentStatusPaid, updates[0].PaymentStatus)
+	require.Equal(t, "TRX-001", updates[0].BankReference)
+	require.NotNil(t, updates[0].PaidAt)
+	require.Equal(t, uint(90), updates[1].ID)
+	require.Equal(t, model.PaymentStatusFailed, updates[1].PaymentStatus)
+	require.Equal(t, "Rekening ditutup", updates[1].PaymentNote)
+	require.NotNil(t, updates[1].FailedAt)
+}
+
+func TestImportPaymentResults_RejectsInvalidFile(t *testing.T) {
+	var updates []model.PayrollItem
+	u := setupPaymentImport(t, &updates)
+
+	_, err := u.ImportPaymentResults(makeGinCtx(), 1, 3, strings.NewReader("no,amount\n1,10.00\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing column status")
+
+	_, err = u.ImportPaymentResults(makeGinCtx(), 1, 3, strings.NewReader("no,status\n1,maybe\n"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unknown status")
+	require.Empty(t, updates)
+}
+
+func TestGeneratePayslip_ShowsPaymentStatus(t *testing.T) {
+	u := usecase.NewForTest()
+	paidAt := time.Date(2025, 9, 2, 3, 0, 0, 0, time.UTC)
+	payMock := &testm.PayRepoMock{
+		GetPeriodByIDFn: func(_ context.Context, id uint) (*model.AttendancePeriod, error) {
+			return &model.AttendancePeriod{
+				ID:        id,
+				StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+				EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+			}, nil
+		},
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			return &model.PayrollRun{ID: 5, PeriodID: periodID}, nil
+		},
+		GetPayrollItemByUserFn: func(_ context.Context, runID uint, userID uint) (*model.PayrollItem, error) {
+			return &model.PayrollItem{
+				PayrollRunID: runID, UserID: userID, GrandTotal: 100,
+				PaymentStatus: model.PaymentStatusPaid, BankReference: "TRX-001", PaidAt: &paidAt,
+			}, nil
+		},
+		ListReimbursementsForUserFn: func(_ context.Context, uid uint, s, e time.Time) ([]model.Reimbursement, error) {
+			return nil, nil
+		},
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
+
+	resp, err := u.GeneratePayslip(makeGinCtx(), 7, 1)
+	require.NoError(t, err)
+	require.NotNil(t, resp.Payment)
+	require.Equal(t, model.PaymentStatusPaid, resp.Payment.Status)
+	require.Equal(t, "TRX-001", resp.Payment.BankReference)
+	require.Equal(t, paidAt, *resp.Payment.PaidAt)
+}
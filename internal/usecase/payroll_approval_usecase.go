@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	payRepo "payslip-generation-system/internal/repository/payroll"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requiredApprovers: jumlah admin selain pembuat run yang harus approve (default 1).
+func (u *usecase) requiredApprovers() int {
+	if u.cfg != nil && u.cfg.Payroll.Approval.RequiredApprovers > 0 {
+		return u.cfg.Payroll.Approval.RequiredApprovers
+	}
+	return 1
+}
+
+// RequestRunApproval mengajukan run draft untuk approval dan membuka putaran approval baru.
+func (u *usecase) RequestRunApproval(ctx *gin.Context, actorID, periodID uint, req pDTO.RunApprovalRequest) (resp *pDTO.PayrollRunResponse, err error) {
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+	if runStatusOf(run) != model.PayrollRunStatusDraft {
+		return nil, utils.MakeError(errorUc.BadRequest, "only a draft payroll run can be submitted for approval")
+	}
+	if run.Stale {
+		return nil, utils.MakeError(errorUc.BadRequest, "payroll run is out of date; recalculate it before submitting for approval")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	now := time.Now().UTC()
+	round := run.ApprovalRound + 1
+	ok, err := u.payrollRepo.StartApprovalRound(txCtx, run.ID, round, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to submit payroll run"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update payroll run status")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "payroll run status changed concurrently")
+	}
+	if err = u.recordRunEvent(txCtx, run.ID, model.PayrollRunActionApprovalRequested,
+		model.PayrollRunStatusDraft, model.PayrollRunStatusPendingApproval, actorID, req.Comment); err != nil {
+		return nil, err
+	}
+
+	run.Status = model.PayrollRunStatusPendingApproval
+	run.ApprovalRound = round
+	run.StatusChangedAt = now
+	return u.toPayrollRunResponse(txCtx, run)
+}
+
+// ApproveRun mencatat approval seorang admin (bukan pembuat maupun penghitung ulang run). Run menjadi approved
+// setelah jumlah approval pada putaran ini mencapai requiredApprovers.
+func (u *usecase) ApproveRun(ctx *gin.Context, actorID, periodID uint, req pDTO.RunApprovalRequest) (resp *pDTO.PayrollRunResponse, err error) {
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	// kunci run agar hitungan approval serentak tidak saling terlewat
+	run, err = u.lockPendingRun(txCtx, run.ID)
+	if err != nil {
+		return nil, err
+	}
+	if run.CreatedBy == actorID {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "the creator of a payroll run cannot approve it")
+	}
+	if run.CalculatedBy == actorID {
+		return nil, utils.MakeError(errorUc.ErrForbidden, "the admin who recalculated a payroll run cannot approve it")
+	}
+
+	now := time.Now().UTC()
+	if err = u.addRunDecision(txCtx, run, actorID, model.ApprovalDecisionApproved, req.Comment, now); err != nil {
+		return nil, err
+	}
+	approvals, err := u.payrollRepo.ListRunApprovals(txCtx, run.ID, run.ApprovalRound)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load payroll run approvals"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error (payroll run approvals)")
+	}
+	approved := 0
+	for _, a := range approvals {
+		if a.Decision == model.ApprovalDecisionApproved && a.ApproverID != run.CreatedBy && a.ApproverID != run.CalculatedBy {
+			approved++
+		}
+	}
+
+	to := model.PayrollRunStatusPendingApproval
+	if approved >= u.requiredApprovers() {
+		to = model.PayrollRunStatusApproved
+		ok, err := u.payrollRepo.UpdateRunStatus(txCtx, run.ID, model.PayrollRunStatusPendingApproval, to, now)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to approve payroll run"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to update payroll run status")
+		}
+		if !ok {
+			return nil, utils.MakeError(errorUc.ConflictError, "payroll run status changed concurrently")
+		}
+		run.Status = to
+		run.StatusChangedAt = now
+	}
+	if err = u.recordRunEvent(txCtx, run.ID, model.PayrollRunActionApproved,
+		model.PayrollRunStatusPendingApproval, to, actorID, req.Comment); err != nil {
+		return nil, err
+	}
+
+	u.log.Info(log.LogData{Description: "payroll run approval recorded", Response: map[string]any{
+		"run_id": run.ID, "round": run.ApprovalRound, "approvals": approved, "required": u.requiredApprovers(), "by": actorID,
+	}})
+	return u.toPayrollRunResponse(txCtx, run)
+}
+
+// RejectRun mengembalikan run ke draft dengan alasan (wajib); approval pada putaran ini gugur.
+func (u *usecase) RejectRun(ctx *gin.Context, actorID, periodID uint, req pDTO.RunApprovalRequest) (resp *pDTO.PayrollRunResponse, err error) {
+	req.Comment = strings.TrimSpace(req.Comment)
+	if req.Comment == "" {
+		return nil, utils.MakeError(errorUc.InvalidMandatory, "comment")
+	}
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	run, err = u.lockPendingRun(txCtx, run.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if err = u.addRunDecision(txCtx, run, actorID, model.ApprovalDecisionRejected, req.Comment, now); err != nil {
+		return nil, err
+	}
+	ok, err := u.payrollRepo.UpdateRunStatus(txCtx, run.ID, model.PayrollRunStatusPendingApproval, model.PayrollRunStatusDraft, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to reject payroll run"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update payroll run status")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "payroll run status changed concurrently")
+	}
+	if err = u.recordRunEvent(txCtx, run.ID, model.PayrollRunActionRejected,
+		model.PayrollRunStatusPendingApproval, model.PayrollRunStatusDraft, actorID, req.Comment); err != nil {
+		return nil, err
+	}
+
+	run.Status = model.PayrollRunStatusDraft
+	run.StatusChangedAt = now
+	return u.toPayrollRunResponse(txCtx, run)
+}
+
+func (u *usecase) lockPendingRun(ctx context.Context, runID uint) (*model.PayrollRun, error) {
+	run, err := u.payrollRepo.LockRun(ctx, runID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to lock payroll run"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if runStatusOf(run) != model.PayrollRunStatusPendingApproval {
+		return nil, utils.MakeError(errorUc.BadRequest, "payroll run is not pending approval")
+	}
+	return run, nil
+}
+
+func (u *usecase) addRunDecision(ctx context.Context, run *model.PayrollRun, actorID uint, decision, comment string, at time.Time) error {
+	err := u.payrollRepo.AddRunApproval(ctx, &model.PayrollRunApproval{
+		PayrollRunID: run.ID,
+		Round:        run.ApprovalRound,
+		ApproverID:   actorID,
+		Decision:     decision,
+		Comment:      comment,
+		CreatedAt:    at,
+	})
+	if errors.Is(err, payRepo.ErrApprovalExists) {
+		return utils.MakeError(errorUc.ConflictError, "you have already decided on this approval round")
+	}
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to record payroll run approval"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to record approval")
+	}
+	return nil
+}
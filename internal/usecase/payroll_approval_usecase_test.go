@@ -0,0 +1,214 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/config"
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	"payslip-generation-system/internal/model"
+	payRepo "payslip-generation-system/internal/repository/payroll"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+// setupApproval: run draft milik admin 1 dengan state in-memory untuk status, approval & history.
+func setupApproval(t *testing.T, required int) (usecase.IUsecase, *model.PayrollRun, *[]model.PayrollRunEvent) {
+	u := usecase.NewForTest()
+	run := &model.PayrollRun{ID: 4, PeriodID: 1, Status: model.PayrollRunStatusDraft, CreatedBy: 1}
+	var approvals []model.PayrollRunApproval
+	var events []model.PayrollRunEvent
+	payMock := &testm.PayRepoMock{
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			cp := *run
+			return &cp, nil
+		},
+		LockRunFn: func(_ context.Context, runID uint) (*model.PayrollRun, error) {
+			cp := *run
+			return &cp, nil
+		},
+		StartApprovalRoundFn: func(_ context.Context, runID uint, round int, at time.Time) (bool, error) {
+			if run.Status != model.PayrollRunStatusDraft || run.ApprovalRound != round-1 || run.Stale {
+				return false, nil
+			}
+			run.Status, run.ApprovalRound = model.PayrollRunStatusPendingApproval, round
+			return true, nil
+		},
+		UpdateRunStatusFn: func(_ context.Context, runID uint, from, to string, at time.Time) (bool, error) {
+			if run.Status != from {
+				return false, nil
+			}
+			run.Status = to
+			return true, nil
+		},
+		AddRunApprovalFn: func(_ context.Context, a *model.PayrollRunApproval) error {
+			for _, x := range approvals {
+				if x.Round == a.Round && x.ApproverID == a.ApproverID {
+					return payRepo.ErrApprovalExists
+				}
+			}
+			approvals = append(approvals, *a)
+			return nil
+		},
+		ListRunApprovalsFn: func(_ context.Context, runID uint, round int) ([]model.PayrollRunApproval, error) {
+			var out []model.PayrollRunApproval
+			for _, a := range approvals {
+				if a.Round == round {
+					out = append(out, a)
+				}
+			}
+			return out, nil
+		},
+		AddRunEventFn: func(_ context.Context, ev *model.PayrollRunEvent) error {
+			events = append(events, *ev)
+			return nil
+		},
+		ListRunEventsFn: func(_ context.Context, runID uint) ([]model.PayrollRunEvent, error) { return events, nil },
+
+		// hitung ulang draft lewat RunPayroll
+		GetPeriodByIDFn:           augustPeriod,
+		HasRunForPeriodFn:         func(_ context.Context, periodID uint) (bool, error) { return true, nil },
+		GetAttendanceDaysByUserFn: func(_ context.Context, s, e time.Time) (map[uint]int, error) { return map[uint]int{7: 21}, nil },
+		GetOvertimeHoursByUserFn:  func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return nil, nil },
+		GetReimbTotalByUserFn:     func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return nil, nil },
+		GetUserSalariesFn:         func(_ context.Context) (map[uint]float64, error) { return map[uint]float64{7: 7000000}, nil },
+		ReplaceRunItemsFn: func(_ context.Context, r *model.PayrollRun, items []*model.PayrollItem) (bool, error) {
+			if run.Status != model.PayrollRunStatusDraft {
+				return false, nil
+			}
+			run.CalculatedBy, run.Stale = r.CalculatedBy, false
+			return true, nil
+		},
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{
+		ListByUsersFn: func(_ context.Context, ids []uint) (map[uint]*model.EmployeeBankAccount, error) {
+			return map[uint]*model.EmployeeBankAccount{}, nil
+		},
+	}, testm.NewFieldCipher())
+	cfg := &config.Config{}
+	cfg.Payroll.Approval.RequiredApprovers = required
+	usecase.InjectConfigForTest(u, cfg)
+	return u, run, &events
+}
+
+func TestApproveRun_RequiresApproversOtherThanCreator(t *testing.T) {
+	u, run, events := setupApproval(t, 2)
+	ctx := makeGinCtx()
+
+	// belum diajukan
+	_, err := u.ApproveRun(ctx, 2, 1, pDTO.RunApprovalRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not pending approval")
+
+	resp, err := u.RequestRunApproval(ctx, 1, 1, pDTO.RunApprovalRequest{Comment: "please review"})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusPendingApproval, resp.Status)
+	require.Equal(t, 1, resp.ApprovalRound)
+	require.Equal(t, 2, resp.RequiredApprovers)
+
+	_, err = u.ApproveRun(ctx, 1, 1, pDTO.RunApprovalRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "creator")
+
+	resp, err = u.ApproveRun(ctx, 2, 1, pDTO.RunApprovalRequest{Comment: "ok"})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusPendingApproval, resp.Status)
+	require.Len(t, resp.Approvals, 1)
+
+	_, err = u.ApproveRun(ctx, 2, 1, pDTO.RunApprovalRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "already decided")
+
+	resp, err = u.ApproveRun(ctx, 3, 1, pDTO.RunApprovalRequest{Comment: "ok too"})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusApproved, resp.Status)
+	require.Equal(t, model.PayrollRunStatusApproved, run.Status)
+
+	require.Len(t, *events, 3)
+	last := (*events)[2]
+	require.Equal(t, model.PayrollRunActionApproved, last.Action)
+	require.Equal(t, model.PayrollRunStatusApproved, last.ToStatus)
+	require.Equal(t, uint(3), last.ActorID)
+	require.Equal(t, "ok too", last.Comment)
+}
+
+func TestRejectRun_ReturnsToDraftAndResetsRound(t *testing.T) {
+	u, run, events := setupApproval(t, 1)
+	ctx := makeGinCtx()
+
+	_, err := u.RequestRunApproval(ctx, 1, 1, pDTO.RunApprovalRequest{})
+	require.NoError(t, err)
+
+	_, err = u.RejectRun(ctx, 2, 1, pDTO.RunApprovalRequest{Comment: "  "})
+	require.Error(t, err)
+
+	resp, err := u.RejectRun(ctx, 2, 1, pDTO.RunApprovalRequest{Comment: "overtime for Aug 18 missing"})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusDraft, resp.Status)
+	require.Equal(t, model.ApprovalDecisionRejected, resp.Approvals[0].Decision)
+	require.Equal(t, model.PayrollRunActionRejected, (*events)[1].Action)
+	require.Equal(t, "overtime for Aug 18 missing", (*events)[1].Comment)
+
+	// pengajuan ulang membuka putaran baru; keputusan admin 2 sebelumnya tidak menghalangi
+	resp, err = u.RequestRunApproval(ctx, 1, 1, pDTO.RunApprovalRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.ApprovalRound)
+	require.Empty(t, resp.Approvals)
+
+	resp, err = u.ApproveRun(ctx, 2, 1, pDTO.RunApprovalRequest{})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusApproved, resp.Status)
+	require.Equal(t, model.PayrollRunStatusApproved, run.Status)
+}
+
+func TestApproveRun_RecalculatingAdminCannotApprove(t *testing.T) {
+	u, run, _ := setupApproval(t, 1)
+	ctx := makeGinCtx()
+
+	_, err := u.RequestRunApproval(ctx, 1, 1, pDTO.RunApprovalRequest{})
+	require.NoError(t, err)
+	_, err = u.RejectRun(ctx, 2, 1, pDTO.RunApprovalRequest{Comment: "overtime for Aug 18 missing"})
+	require.NoError(t, err)
+
+	// admin 2 menghitung ulang draft milik admin 1 lalu mencoba meng-approve hasilnya sendiri
+	_, _, err = u.RunPayroll(ctx, 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint(2), run.CalculatedBy)
+	_, err = u.RequestRunApproval(ctx, 2, 1, pDTO.RunApprovalRequest{})
+	require.NoError(t, err)
+
+	_, err = u.ApproveRun(ctx, 2, 1, pDTO.RunApprovalRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "cannot approve")
+	require.Equal(t, model.PayrollRunStatusPendingApproval, run.Status)
+
+	resp, err := u.ApproveRun(ctx, 3, 1, pDTO.RunApprovalRequest{})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusApproved, resp.Status)
+	require.Equal(t, uint(2), resp.CalculatedBy)
+}
+
+func TestRequestRunApproval_StaleRunMustBeRecalculated(t *testing.T) {
+	u, run, _ := setupApproval(t, 1)
+	ctx := makeGinCtx()
+
+	// adjustment di-approve ke period ini setelah draft dihitung
+	run.Stale = true
+	_, err := u.RequestRunApproval(ctx, 1, 1, pDTO.RunApprovalRequest{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "recalculate")
+	require.Equal(t, model.PayrollRunStatusDraft, run.Status)
+
+	_, _, err = u.RunPayroll(ctx, 1, 1)
+	require.NoError(t, err)
+	require.False(t, run.Stale)
+	resp, err := u.RequestRunApproval(ctx, 1, 1, pDTO.RunApprovalRequest{})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusPendingApproval, resp.Status)
+	require.False(t, resp.Stale)
+}
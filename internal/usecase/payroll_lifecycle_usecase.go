@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Transisi status run yang diizinkan. pending_approval -> draft dipakai untuk menarik kembali / menolak pengajuan.
+// draft -> pending_approval -> approved/draft hanya lewat alur approval (payroll_approval_usecase.go).
+var runTransitions = map[string][]string{
+	model.PayrollRunStatusDraft:           {model.PayrollRunStatusPendingApproval},
+	model.PayrollRunStatusPendingApproval: {model.PayrollRunStatusApproved, model.PayrollRunStatusDraft},
+	model.PayrollRunStatusApproved:        {model.PayrollRunStatusPaid},
+	model.PayrollRunStatusPaid:            {model.PayrollRunStatusClosed},
+}
+
+func canTransitionRun(from, to string) bool {
+	for _, s := range runTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// run lama (sebelum kolom status ada) dianggap approved
+func runStatusOf(run *model.PayrollRun) string {
+	if run.Status == "" {
+		return model.PayrollRunStatusApproved
+	}
+	return run.Status
+}
+
+// runIsFinal: angka run sudah disetujui sehingga boleh dilihat karyawan & dibayar.
+func runIsFinal(run *model.PayrollRun) bool {
+	for _, s := range model.PayrollRunLockedStatuses {
+		if runStatusOf(run) == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *usecase) recordRunEvent(ctx context.Context, runID uint, action, from, to string, actorID uint, comment string) error {
+	ev := &model.PayrollRunEvent{
+		PayrollRunID: runID,
+		Action:       action,
+		FromStatus:   from,
+		ToStatus:     to,
+		ActorID:      actorID,
+		Comment:      comment,
+		CreatedAt:    time.Now().UTC(),
+	}
+	if err := u.payrollRepo.AddRunEvent(ctx, ev); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to record payroll run event"})
+		return utils.MakeError(errorUc.InternalServerError, "failed to record payroll run history")
+	}
+	return nil
+}
+
+func (u *usecase) GetPayrollRun(ctx *gin.Context, periodID uint) (*pDTO.PayrollRunResponse, error) {
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+	return u.toPayrollRunResponse(ctx, run)
+}
+
+// TransitionPayrollRun memindahkan run yang sudah approved ke paid/closed dan mencatat pelakunya di history.
+func (u *usecase) TransitionPayrollRun(ctx *gin.Context, actorID, periodID uint, req pDTO.TransitionRunRequest) (resp *pDTO.PayrollRunResponse, err error) {
+	run, err := u.payrollRepo.GetRunByPeriod(ctx, periodID)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payroll has not been run for this period")
+	}
+	from := runStatusOf(run)
+	if req.Status != model.PayrollRunStatusPaid && req.Status != model.PayrollRunStatusClosed {
+		return nil, utils.MakeError(errorUc.BadRequest, "use the approval endpoints to submit or approve or reject a payroll run")
+	}
+	if !canTransitionRun(from, req.Status) {
+		return nil, utils.MakeError(errorUc.BadRequest, fmt.Sprintf("cannot change payroll run status from %s to %s", from, req.Status))
+	}
+	if req.Status == model.PayrollRunStatusPaid {
+		batch, err := u.disbursementRepo.GetByRun(ctx, run.ID)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to load disbursement batch"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if batch == nil {
+			return nil, utils.MakeError(errorUc.BadRequest, "disbursement file has not been generated for this payroll run")
+		}
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	now := time.Now().UTC()
+	ok, err := u.payrollRepo.UpdateRunStatus(txCtx, run.ID, from, req.Status, now)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update payroll run status"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update payroll run status")
+	}
+	if !ok {
+		return nil, utils.MakeError(errorUc.ConflictError, "payroll run status changed concurrently")
+	}
+	if err = u.recordRunEvent(txCtx, run.ID, model.PayrollRunActionTransition, from, req.Status, actorID, req.Comment); err != nil {
+		return nil, err
+	}
+
+	u.log.Info(log.LogData{Description: "payroll run status changed", Response: map[string]any{
+		"run_id": run.ID, "from": from, "to": req.Status, "by": actorID,
+	}})
+	run.Status = req.Status
+	run.StatusChangedAt = now
+	return u.toPayrollRunResponse(txCtx, run)
+}
+
+func (u *usecase) toPayrollRunResponse(ctx context.Context, run *model.PayrollRun) (*pDTO.PayrollRunResponse, error) {
+	events, err := u.payrollRepo.ListRunEvents(ctx, run.ID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load payroll run history"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error (payroll run history)")
+	}
+	resp := &pDTO.PayrollRunResponse{
+		RunID:           run.ID,
+		PeriodID:        run.PeriodID,
+		Status:          runStatusOf(run),
+		RunAt:           run.RunAt,
+		StatusChangedAt: run.StatusChangedAt,
+		CreatedBy:       run.CreatedBy,
+		CalculatedBy:    run.CalculatedBy,
+		Stale:           run.Stale,
+		History:         make([]pDTO.PayrollRunEventResponse, 0, len(events)),
+	}
+	for _, ev := range events {
+		resp.History = append(resp.History, pDTO.PayrollRunEventResponse{
+			Action:     ev.Action,
+			FromStatus: ev.FromStatus,
+			ToStatus:   ev.ToStatus,
+			ActorID:    ev.ActorID,
+			Comment:    ev.Comment,
+			CreatedAt:  ev.CreatedAt,
+		})
+	}
+
+	resp.RequiredApprovers = u.requiredApprovers()
+	resp.ApprovalRound = run.ApprovalRound
+	resp.Approvals = []pDTO.RunApprovalResponse{}
+	if run.ApprovalRound > 0 {
+		approvals, err := u.payrollRepo.ListRunApprovals(ctx, run.ID, run.ApprovalRound)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to load payroll run approvals"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error (payroll run approvals)")
+		}
+		for _, a := range approvals {
+			resp.Approvals = append(resp.Approvals, pDTO.RunApprovalResponse{
+				ApproverID: a.ApproverID,
+				Decision:   a.Decision,
+				Comment:    a.Comment,
+				CreatedAt:  a.CreatedAt,
+			})
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	pDTO "payslip-generation-system/internal/dto/payroll"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func augustPeriod(_ context.Context, id uint) (*model.AttendancePeriod, error) {
+	return &model.AttendancePeriod{
+		ID:        id,
+		StartDate: time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
+	}, nil
+}
+
+func TestRunPayroll_DraftCanBeRecalculated(t *testing.T) {
+	u := usecase.NewForTest()
+	var events []model.PayrollRunEvent
+	var replaced []*model.PayrollItem
+	payMock := &testm.PayRepoMock{
+		GetPeriodByIDFn:   augustPeriod,
+		HasRunForPeriodFn: func(_ context.Context, periodID uint) (bool, error) { return true, nil },
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			return &model.PayrollRun{ID: 4, PeriodID: periodID, Status: model.PayrollRunStatusDraft, CreatedBy: 1}, nil
+		},
+		GetAttendanceDaysByUserFn: func(_ context.Context, s, e time.Time) (map[uint]int, error) { return map[uint]int{7: 21}, nil },
+		GetOvertimeHoursByUserFn:  func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return nil, nil },
+		GetReimbTotalByUserFn:     func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return nil, nil },
+		GetUserSalariesFn:         func(_ context.Context) (map[uint]float64, error) { return map[uint]float64{7: 7000000}, nil },
+		ReplaceRunItemsFn: func(_ context.Context, run *model.PayrollRun, items []*model.PayrollItem) (bool, error) {
+			replaced = items
+			return true, nil
+		},
+		AddRunEventFn: func(_ context.Context, ev *model.PayrollRunEvent) error {
+			events = append(events, *ev)
+			return nil
+		},
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{
+		ListByUsersFn: func(_ context.Context, ids []uint) (map[uint]*model.EmployeeBankAccount, error) {
+			return map[uint]*model.EmployeeBankAccount{}, nil
+		},
+	}, testm.NewFieldCipher())
+
+	run, items, err := u.RunPayroll(makeGinCtx(), 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint(4), run.ID)
+	require.Equal(t, model.PayrollRunStatusDraft, run.Status)
+	require.Len(t, items, 1)
+	require.Equal(t, items, replaced)
+	require.Equal(t, 21, items[0].AttendanceDays)
+
+	require.Len(t, events, 1)
+	require.Equal(t, model.PayrollRunActionRecalculated, events[0].Action)
+	require.Equal(t, uint(2), events[0].ActorID)
+
+	// run yang sudah diajukan tidak bisa dihitung ulang lagi
+	payMock.ReplaceRunItemsFn = func(_ context.Context, run *model.PayrollRun, items []*model.PayrollItem) (bool, error) {
+		return false, nil
+	}
+	_, _, err = u.RunPayroll(makeGinCtx(), 2, 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no longer a draft")
+}
+
+func TestTransitionPayrollRun_FollowsStateMachine(t *testing.T) {
+	u := usecase.NewForTest()
+	run := &model.PayrollRun{ID: 4, PeriodID: 1, Status: model.PayrollRunStatusApproved}
+	var events []model.PayrollRunEvent
+	var batch *model.DisbursementBatch
+	payMock := &testm.PayRepoMock{
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			cp := *run
+			return &cp, nil
+		},
+		UpdateRunStatusFn: func(_ context.Context, runID uint, from, to string, at time.Time) (bool, error) {
+			if run.Status != from {
+				return false, nil
+			}
+			run.Status = to
+			return true, nil
+		},
+		AddRunEventFn: func(_ context.Context, ev *model.PayrollRunEvent) error {
+			events = append(events, *ev)
+			return nil
+		},
+		ListRunEventsFn: func(_ context.Context, runID uint) ([]model.PayrollRunEvent, error) { return events, nil },
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectDisbursementForTest(u, &testm.DisbursementRepoMock{
+		GetByRunFn: func(_ context.Context, runID uint) (*model.DisbursementBatch, error) { return batch, nil },
+	})
+
+	// approved -> closed melompati paid
+	_, err := u.TransitionPayrollRun(makeGinCtx(), 1, 1, pDTO.TransitionRunRequest{Status: model.PayrollRunStatusClosed})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "from approved to closed")
+
+	// pengajuan/approval tidak lewat endpoint status
+	_, err = u.TransitionPayrollRun(makeGinCtx(), 1, 1, pDTO.TransitionRunRequest{Status: model.PayrollRunStatusDraft})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "approval endpoints")
+
+	// paid butuh file disbursement
+	_, err = u.TransitionPayrollRun(makeGinCtx(), 2, 1, pDTO.TransitionRunRequest{Status: model.PayrollRunStatusPaid})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "disbursement file has not been generated")
+	require.Equal(t, model.PayrollRunStatusApproved, run.Status)
+
+	batch = &model.DisbursementBatch{ID: 1, PayrollRunID: 4}
+	resp, err := u.TransitionPayrollRun(makeGinCtx(), 2, 1, pDTO.TransitionRunRequest{Status: model.PayrollRunStatusPaid, Comment: "bank confirmed"})
+	require.NoError(t, err)
+	require.Equal(t, model.PayrollRunStatusPaid, resp.Status)
+	require.Len(t, resp.History, 1)
+	require.Equal(t, model.PayrollRunStatusApproved, resp.History[0].FromStatus)
+	require.Equal(t, uint(2), resp.History[0].ActorID)
+	require.Equal(t, "bank confirmed", resp.History[0].Comment)
+}
+
+func TestGeneratePayslip_DraftRunNotVisible(t *testing.T) {
+	u := usecase.NewForTest()
+	status := model.PayrollRunStatusDraft
+	payMock := &testm.PayRepoMock{
+		GetPeriodByIDFn: augustPeriod,
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			return &model.PayrollRun{ID: 4, PeriodID: periodID, Status: status}, nil
+		},
+		GetPayrollItemByUserFn: func(_ context.Context, runID uint, userID uint) (*model.PayrollItem, error) {
+			t.Fatal("draft snapshot must not be read")
+			return nil, nil
+		},
+		GetUserSalaryFn: func(_ context.Context, uid uint) (float64, error) {
+			t.Fatal("live estimate must not be shown while the run is not approved")
+			return 0, nil
+		},
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
+
+	for _, status = range []string{model.PayrollRunStatusDraft, model.PayrollRunStatusPendingApproval} {
+		resp, err := u.GeneratePayslip(makeGinCtx(), 7, 1)
+		require.Error(t, err, status)
+		require.Contains(t, err.Error(), "not available until payroll is approved")
+		require.Nil(t, resp)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"payslip-generation-system/config"
 	"payslip-generation-system/internal/model"
 	"payslip-generation-system/internal/usecase"
 	testm "payslip-generation-system/internal/usecase/test"
@@ -23,11 +24,15 @@ func TestRunPayroll_OnlyOnce(t *testing.T) {
 			}, nil
 		},
 		HasRunForPeriodFn: func(_ context.Context, periodID uint) (bool, error) { return true, nil },
+		GetRunByPeriodFn: func(_ context.Context, periodID uint) (*model.PayrollRun, error) {
+			return &model.PayrollRun{ID: 1, PeriodID: periodID, Status: model.PayrollRunStatusApproved}, nil
+		},
 	}
 	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
 
 	ctx := makeGinCtx()
-	run, items, err := u.RunPayroll(ctx, 1)
+	run, items, err := u.RunPayroll(ctx, 1, 1)
 	require.Error(t, err)
 	require.Nil(t, run)
 	require.Nil(t, items)
@@ -54,14 +59,73 @@ func TestRunPayroll_CalcNumbers(t *testing.T) {
 			run.ID = 99
 			return nil
 		},
+		AddRunEventFn: func(_ context.Context, ev *model.PayrollRunEvent) error { return nil },
 	}
 	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{
+		ListByUsersFn: func(_ context.Context, ids []uint) (map[uint]*model.EmployeeBankAccount, error) {
+			return map[uint]*model.EmployeeBankAccount{}, nil
+		},
+	}, testm.NewFieldCipher())
 
 	ctx := makeGinCtx()
-	run, items, err := u.RunPayroll(ctx, 1)
+	run, items, err := u.RunPayroll(ctx, 1, 1)
 	require.NoError(t, err)
 	require.Equal(t, uint(99), run.ID)
+	require.Equal(t, model.PayrollRunStatusDraft, run.Status)
 	require.Len(t, items, 1)
 	require.Equal(t, uint(7), items[0].UserID)
 	require.Greater(t, items[0].GrandTotal, 0.0)
+	require.Equal(t, model.BankAccountStatusMissing, items[0].BankAccountStatus)
+}
+
+func TestRunPayroll_HoursModeUsesWorkedHours(t *testing.T) {
+	u := usecase.NewForTest()
+	at := func(h, m int) *time.Time {
+		v := time.Date(2025, 8, 18, h, m, 0, 0, time.UTC)
+		return &v
+	}
+	var saved []*model.PayrollItem
+	payMock := &testm.PayRepoMock{
+		GetPeriodByIDFn:   augustPeriod,
+		HasRunForPeriodFn: func(_ context.Context, periodID uint) (bool, error) { return false, nil },
+		// 4 hari hadir: 1 submit lama tanpa jam + 3 hari dengan check-in
+		GetAttendanceDaysByUserFn: func(_ context.Context, s, e time.Time) (map[uint]int, error) { return map[uint]int{7: 4}, nil },
+		ListTimedAttendancesFn: func(_ context.Context, userID uint, s, e time.Time) ([]model.Attendance, error) {
+			require.Zero(t, userID)
+			return []model.Attendance{
+				{UserID: 7, CheckInAt: at(1, 0), CheckOutAt: at(10, 0), WorkedMinutes: 540}, // 9 jam -> dibayar 8
+				{UserID: 7, CheckInAt: at(2, 0), CheckOutAt: at(8, 0), WorkedMinutes: 360},  // 6 jam
+				{UserID: 7, CheckInAt: at(2, 0)},                                            // lupa check-out
+			}, nil
+		},
+		GetOvertimeHoursByUserFn: func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return nil, nil },
+		GetReimbTotalByUserFn:    func(_ context.Context, s, e time.Time) (map[uint]float64, error) { return nil, nil },
+		GetUserSalariesFn:        func(_ context.Context) (map[uint]float64, error) { return map[uint]float64{7: 6720000}, nil },
+		CreateRunFn: func(_ context.Context, run *model.PayrollRun, items []*model.PayrollItem) error {
+			saved = items
+			return nil
+		},
+		AddRunEventFn: func(_ context.Context, ev *model.PayrollRunEvent) error { return nil },
+	}
+	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
+	usecase.InjectBankAccountForTest(u, &testm.BankAccountRepoMock{
+		ListByUsersFn: func(_ context.Context, ids []uint) (map[uint]*model.EmployeeBankAccount, error) {
+			return map[uint]*model.EmployeeBankAccount{}, nil
+		},
+	}, testm.NewFieldCipher())
+	cfg := &config.Config{}
+	cfg.Payroll.BasePayMode = model.BasePayModeHours
+	usecase.InjectConfigForTest(u, cfg)
+
+	_, items, err := u.RunPayroll(makeGinCtx(), 1, 1)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	// Agustus 2025: 21 hari kerja -> 168 jam -> 40.000/jam; dibayar 8 + 8 + 6 + 0 jam
+	require.Equal(t, model.BasePayModeHours, items[0].BasePayMode)
+	require.Equal(t, 32, items[0].AttendanceHours)
+	require.Equal(t, 22.0, items[0].PaidHours)
+	require.Equal(t, 880000.0, items[0].BasePay)
 }
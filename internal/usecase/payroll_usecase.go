@@ -31,7 +31,9 @@ func round2(v float64) float64 {
 	return math.Round(v*100) / 100
 }
 
-func (u *usecase) RunPayroll(ctx *gin.Context, periodID uint) (*model.PayrollRun, []*model.PayrollItem, error) {
+// RunPayroll membuat run draft untuk period, atau menghitung ulang run yang masih draft.
+// Run yang sudah diajukan/approved tidak bisa di-run ulang.
+func (u *usecase) RunPayroll(ctx *gin.Context, actorID, periodID uint) (run *model.PayrollRun, items []*model.PayrollItem, err error) {
 	// Repos
 	var pr payRepo.Repo
 	if u.payrollRepo == nil {
@@ -50,15 +52,23 @@ func (u *usecase) RunPayroll(ctx *gin.Context, periodID uint) (*model.PayrollRun
 		u.log.Error(log.LogData{Err: err})
 		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
 	}
+	var existing *model.PayrollRun
 	if exists {
-		return nil, nil, utils.MakeError(errorUc.BadRequest, "payroll has already been run for this period")
+		existing, err = pr.GetRunByPeriod(ctx, periodID)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if runStatusOf(existing) != model.PayrollRunStatusDraft {
+			return nil, nil, utils.MakeError(errorUc.BadRequest, "payroll has already been run for this period")
+		}
 	}
 
 	start := time.Date(period.StartDate.Year(), period.StartDate.Month(), period.StartDate.Day(), 0, 0, 0, 0, time.UTC)
 	end := time.Date(period.EndDate.Year(), period.EndDate.Month(), period.EndDate.Day(), 0, 0, 0, 0, time.UTC)
 
 	workingDays := u.workingWeekdays(start, end)
-	workingHours := workingDays * 8
+	workingHours := workingDays * hoursPerDay
 	if workingDays <= 0 || workingHours <= 0 {
 		return nil, nil, utils.MakeError(errorUc.BadRequest, "period has no working days")
 	}
@@ -80,6 +90,19 @@ func (u *usecase) RunPayroll(ctx *gin.Context, periodID uint) (*model.PayrollRun
 	if err != nil {
 		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error (salaries)")
 	}
+	mode := u.basePayMode()
+	unpaid, err := u.unpaidHours(ctx, 0, start, end)
+	if err != nil {
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error (attendance hours)")
+	}
+	adjs, err := u.adjustmentRepo.ListByPeriod(ctx, periodID, 0, model.AdjustmentStatusApproved)
+	if err != nil {
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error (adjustments)")
+	}
+	adjByUser := map[uint][]model.PayrollAdjustment{}
+	for _, a := range adjs {
+		adjByUser[a.UserID] = append(adjByUser[a.UserID], a)
+	}
 
 	// build items untuk semua user yang punya attendance/overtime/reimburse ataupun punya salary
 	userSet := map[uint]struct{}{}
@@ -95,22 +118,27 @@ func (u *usecase) RunPayroll(ctx *gin.Context, periodID uint) (*model.PayrollRun
 	for uid := range rbTotals {
 		userSet[uid] = struct{}{}
 	}
+	for uid := range adjByUser {
+		userSet[uid] = struct{}{}
+	}
 
-	items := make([]*model.PayrollItem, 0, len(userSet))
+	items = make([]*model.PayrollItem, 0, len(userSet))
 	for uid := range userSet {
 		sal := salaries[uid] 
 		att := attDays[uid]
 		ot := otHours[uid]
 		rbt := rbTotals[uid]
 
-		attHours := att * 8
+		attHours := att * hoursPerDay
+		paidHours := round2(math.Max(float64(attHours)-unpaid[uid], 0))
 		hourly := 0.0
 		if workingHours > 0 {
 			hourly = sal / float64(workingHours)
 		}
-		basePay := round2(float64(attHours) * hourly)
+		basePay := round2(paidHours * hourly)
 		overtimePay := round2(ot * (hourly * 2))
-		total := round2(basePay + overtimePay + rbt)
+		_, adjHours, adjTotal := adjustmentLines(adjByUser[uid], hourly)
+		total := round2(basePay + overtimePay + rbt + adjTotal)
 
 		items = append(items, &model.PayrollItem{
 			UserID:             uid,
@@ -119,14 +147,34 @@ func (u *usecase) RunPayroll(ctx *gin.Context, periodID uint) (*model.PayrollRun
 			AttendanceDays:     att,
 			WorkingHours:       workingHours,
 			AttendanceHours:    attHours,
+			BasePayMode:        mode,
+			PaidHours:          paidHours,
 			OvertimeHours:      round2(ot),
 			BasePay:            basePay,
 			OvertimePay:        overtimePay,
 			ReimbursementTotal: round2(rbt),
 			GrandTotal:         total,
+			PaymentStatus:      model.PaymentStatusPending,
+
+			AdjustmentOvertimeHours: adjHours,
+			AdjustmentTotal:         adjTotal,
 		})
 	}
 
+	// validasi rekening: run tetap jalan, item tanpa rekening terverifikasi ditandai untuk ditindaklanjuti
+	userIDs := make([]uint, 0, len(items))
+	for _, it := range items {
+		userIDs = append(userIDs, it.UserID)
+	}
+	bankStatuses, err := u.bankAccountStatuses(ctx, userIDs)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to check bank accounts"})
+		return nil, nil, utils.MakeError(errorUc.InternalServerError, "db error (bank accounts)")
+	}
+	for _, it := range items {
+		it.BankAccountStatus = bankStatuses[it.UserID]
+	}
+
 	txCtx, err := u.txManager.Begin(ctx)
 	if err != nil {
 		return nil, nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
@@ -140,13 +188,38 @@ func (u *usecase) RunPayroll(ctx *gin.Context, periodID uint) (*model.PayrollRun
 		}
 	}()
 
-	run := &model.PayrollRun{
-		PeriodID: periodID,
-		RunAt:    time.Now().UTC(),
+	now := time.Now().UTC()
+	if existing == nil {
+		run = &model.PayrollRun{
+			PeriodID:        periodID,
+			RunAt:           now,
+			Status:          model.PayrollRunStatusDraft,
+			StatusChangedAt: now,
+			CreatedBy:       actorID,
+			CalculatedBy:    actorID,
+		}
+		if err := pr.CreateRun(txCtx, run, items); err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, nil, utils.MakeError(errorUc.InternalServerError, "failed to persist payroll")
+		}
+		err = u.recordRunEvent(txCtx, run.ID, model.PayrollRunActionCreated, "", model.PayrollRunStatusDraft, actorID, "")
+	} else {
+		run = existing
+		run.RunAt = now
+		run.CalculatedBy = actorID
+		var replaced bool
+		replaced, err = pr.ReplaceRunItems(txCtx, run, items)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, nil, utils.MakeError(errorUc.InternalServerError, "failed to persist payroll")
+		}
+		if !replaced {
+			return nil, nil, utils.MakeError(errorUc.ConflictError, "payroll run is no longer a draft")
+		}
+		err = u.recordRunEvent(txCtx, run.ID, model.PayrollRunActionRecalculated, model.PayrollRunStatusDraft, model.PayrollRunStatusDraft, actorID, "")
 	}
-	if err := pr.CreateRun(txCtx, run, items); err != nil {
-		u.log.Error(log.LogData{Err: err})
-		return nil, nil, utils.MakeError(errorUc.InternalServerError, "failed to persist payroll")
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return run, items, nil
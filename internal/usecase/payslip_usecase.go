@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"payslip-generation-system/utils"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 func round3(v float64) float64 { return math.Round(v*100) / 100 }
@@ -34,114 +36,77 @@ func (u *usecase) GeneratePayslip(ctx *gin.Context, userID, periodID uint) (*pay
 	resp.Period.EndDate = period.EndDate.Format("2006-01-02")
 	resp.OvertimeMultiplier = 2.0
 
-	// Sudah run?
-	run, errRun := pr.GetRunByPeriod(ctx, periodID)
-	if errRun == nil {
-		// gunakan snapshot payroll_items
-		item, err := pr.GetPayrollItemByUser(ctx, run.ID, userID)
-		if err != nil {
-			// user mungkin tidak punya item (tidak ada salary/aktivitas) → tetap 0
-			item = &model.PayrollItem{
-				UserID:         userID,
-				SnapshotSalary: 0,
-				WorkingDays:    0, AttendanceDays: 0,
-				WorkingHours: 0, AttendanceHours: 0,
-				OvertimeHours: 0, BasePay: 0, OvertimePay: 0,
-				ReimbursementTotal: 0, GrandTotal: 0,
-			}
-		}
-		resp.SnapshotUsed = true
-		resp.WorkingDays = item.WorkingDays
-		resp.AttendanceDays = item.AttendanceDays
-		resp.WorkingHours = item.WorkingHours
-		resp.AttendanceHours = item.AttendanceHours
-		// hourly dari snapshot salary / working hours (hindari div 0)
-		hourly := 0.0
-		if item.WorkingHours > 0 {
-			hourly = item.SnapshotSalary / float64(item.WorkingHours)
-		}
-		resp.HourlyRate = fmt.Sprintf("%.2f", round3(hourly))
-		resp.BasePay = fmt.Sprintf("%.2f", round3(item.BasePay))
-		resp.OvertimeHours = fmt.Sprintf("%.2f", round3(item.OvertimeHours))
-		resp.OvertimePay = fmt.Sprintf("%.2f", round3(item.OvertimePay))
-		resp.SalarySnapshot = fmt.Sprintf("%.2f", round3(item.SnapshotSalary))
-
-		// list reimburse (aman karena period terkunci)
-		reims, err := pr.ListReimbursementsForUser(ctx, userID, start, end)
-		if err != nil {
-			return nil, utils.MakeError(errorUc.InternalServerError, "db error (reimburse list)")
-		}
-		sum := 0.0
-		resp.Reimbursements = make([]payslip.ReimbursementLine, 0, len(reims))
-		for _, r := range reims {
-			sum += r.Amount
-			resp.Reimbursements = append(resp.Reimbursements, payslip.ReimbursementLine{
-				ID:          r.ID,
-				Date:        r.Date.Format("2006-01-02"),
-				Amount:      fmt.Sprintf("%.2f", round3(r.Amount)),
-				Description: r.Description,
-			})
-		}
-		resp.ReimbursementSum = fmt.Sprintf("%.2f", round3(sum))
-		resp.GrandTotal = fmt.Sprintf("%.2f", round3(item.BasePay+item.OvertimePay+sum))
-		return resp, nil
+	// payslip hanya terlihat setelah run approved; belum di-run / masih draft / pending_approval → not found
+	run, err := pr.GetRunByPeriod(ctx, periodID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error (payroll run)")
 	}
-
-	// Belum run → hitung on-the-fly
-	workingDays := u.workingWeekdays(start, end)
-	workingHours := workingDays * 8
-	if workingDays <= 0 || workingHours <= 0 {
-		return nil, utils.MakeError(errorUc.BadRequest, "period has no working days")
+	if err != nil || run == nil || !runIsFinal(run) {
+		return nil, utils.MakeError(errorUc.NotFoundError, "payslip not available until payroll is approved")
 	}
 
-	salary, err := pr.GetUserSalary(ctx, userID)
-	if err != nil {
-		return nil, utils.MakeError(errorUc.InternalServerError, "db error (salary)")
+	// semua angka dari snapshot payroll_items = yang dibayar lewat disbursement
+	item, err := pr.GetPayrollItemByUser(ctx, run.ID, userID)
+	if err == nil {
+		resp.Payment = toPaymentInfo(item)
+	} else {
+		// user mungkin tidak punya item (tidak ada salary/aktivitas) → tetap 0
+		item = &model.PayrollItem{UserID: userID}
 	}
-	attDays, err := pr.GetAttendanceDaysForUser(ctx, userID, start, end)
-	if err != nil {
-		return nil, utils.MakeError(errorUc.InternalServerError, "db error (attendance)")
+	resp.SnapshotUsed = true
+	resp.WorkingDays = item.WorkingDays
+	resp.AttendanceDays = item.AttendanceDays
+	resp.WorkingHours = item.WorkingHours
+	resp.AttendanceHours = item.AttendanceHours
+	// run lama (sebelum ada basePayMode) selalu dibayar per hari
+	resp.BasePayMode = item.BasePayMode
+	paidHours := item.PaidHours
+	if item.BasePayMode == "" {
+		resp.BasePayMode = model.BasePayModeDays
+		paidHours = float64(item.AttendanceHours)
 	}
-	otHours, err := pr.GetOvertimeHoursForUser(ctx, userID, start, end)
-	if err != nil {
-		return nil, utils.MakeError(errorUc.InternalServerError, "db error (overtime)")
+	resp.PaidHours = fmt.Sprintf("%.2f", round3(paidHours))
+	// hourly dari snapshot salary / working hours (hindari div 0)
+	hourly := 0.0
+	if item.WorkingHours > 0 {
+		hourly = item.SnapshotSalary / float64(item.WorkingHours)
 	}
+	resp.HourlyRate = fmt.Sprintf("%.2f", round3(hourly))
+	resp.BasePay = fmt.Sprintf("%.2f", round3(item.BasePay))
+	resp.OvertimeHours = fmt.Sprintf("%.2f", round3(item.OvertimeHours))
+	resp.OvertimePay = fmt.Sprintf("%.2f", round3(item.OvertimePay))
+	resp.SalarySnapshot = fmt.Sprintf("%.2f", round3(item.SnapshotSalary))
+
+	// rincian reimburse (period terkunci sejak pending_approval, jadi sama dengan saat run)
 	reims, err := pr.ListReimbursementsForUser(ctx, userID, start, end)
 	if err != nil {
 		return nil, utils.MakeError(errorUc.InternalServerError, "db error (reimburse list)")
 	}
-
-	attHours := attDays * 8
-	hourly := 0.0
-	if workingHours > 0 {
-		hourly = salary / float64(workingHours)
-	}
-	basePay := round3(float64(attHours) * hourly)
-	overtimePay := round3(otHours * (hourly * 2))
-	sum := 0.0
-	lines := make([]payslip.ReimbursementLine, 0, len(reims))
+	resp.Reimbursements = make([]payslip.ReimbursementLine, 0, len(reims))
 	for _, r := range reims {
-		sum += r.Amount
-		lines = append(lines, payslip.ReimbursementLine{
+		resp.Reimbursements = append(resp.Reimbursements, payslip.ReimbursementLine{
 			ID:          r.ID,
 			Date:        r.Date.Format("2006-01-02"),
 			Amount:      fmt.Sprintf("%.2f", round3(r.Amount)),
 			Description: r.Description,
 		})
 	}
+	resp.ReimbursementSum = fmt.Sprintf("%.2f", round3(item.ReimbursementTotal))
 
-	resp.SnapshotUsed = false
-	resp.WorkingDays = workingDays
-	resp.AttendanceDays = attDays
-	resp.WorkingHours = workingHours
-	resp.AttendanceHours = attHours
-	resp.HourlyRate = fmt.Sprintf("%.2f", round3(hourly))
-	resp.BasePay = fmt.Sprintf("%.2f", basePay)
-	resp.OvertimeHours = fmt.Sprintf("%.2f", round3(otHours))
-	resp.OvertimePay = fmt.Sprintf("%.2f", overtimePay)
-	resp.Reimbursements = lines
-	resp.ReimbursementSum = fmt.Sprintf("%.2f", round3(sum))
-	resp.SalarySnapshot = fmt.Sprintf("%.2f", round3(salary))
-	resp.GrandTotal = fmt.Sprintf("%.2f", round3(basePay+overtimePay+sum))
+	if err = u.payslipAdjustments(ctx, resp, periodID, userID, hourly); err != nil {
+		return nil, err
+	}
+	resp.AdjustmentSum = fmt.Sprintf("%.2f", round3(item.AdjustmentTotal))
+	resp.GrandTotal = fmt.Sprintf("%.2f", round3(item.GrandTotal))
 	return resp, nil
 }
+
+// payslipAdjustments mengisi baris prior period adjustment; totalnya diambil dari snapshot item.
+func (u *usecase) payslipAdjustments(ctx *gin.Context, resp *payslip.PayslipResponse, periodID, userID uint, hourly float64) error {
+	adjs, err := u.adjustmentRepo.ListByPeriod(ctx, periodID, userID, model.AdjustmentStatusApproved)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "db error (adjustments)")
+	}
+	resp.Adjustments, _, _ = adjustmentLines(adjs, hourly)
+	return nil
+}
@@ -36,19 +36,25 @@ func TestGeneratePayslip_UsesSnapshot(t *testing.T) {
 			}, nil
 		},
 		ListReimbursementsForUserFn: func(_ context.Context, uid uint, s, e time.Time) ([]model.Reimbursement, error) {
-			return []model.Reimbursement{{ID: 1, UserID: uid, Date: s.AddDate(0, 0, 2), Amount: 100000, Description: "meal"}}, nil
+			// id 2 tidak ada di snapshot: total tetap dari payroll_items
+			return []model.Reimbursement{
+				{ID: 1, UserID: uid, Date: s.AddDate(0, 0, 2), Amount: 100000, Description: "meal"},
+				{ID: 2, UserID: uid, Date: s.AddDate(0, 0, 3), Amount: 50000, Description: "taxi"},
+			}, nil
 		},
 	}
 	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
 
 	ctx := makeGinCtx()
 	resp, err := u.GeneratePayslip(ctx, 7, 1)
 	require.NoError(t, err)
 	require.True(t, resp.SnapshotUsed)
+	require.Equal(t, "100000.00", resp.ReimbursementSum)
 	require.Equal(t, "6480000.00", resp.GrandTotal)
 }
 
-func TestGeneratePayslip_LiveCalc(t *testing.T) {
+func TestGeneratePayslip_NotRunNotAvailable(t *testing.T) {
 	u := usecase.NewForTest()
 	payMock := &testm.PayRepoMock{
 		GetPeriodByIDFn: func(_ context.Context, id uint) (*model.AttendancePeriod, error) {
@@ -58,19 +64,13 @@ func TestGeneratePayslip_LiveCalc(t *testing.T) {
 				EndDate:   time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC),
 			}, nil
 		},
-		GetRunByPeriodFn:           func(_ context.Context, pid uint) (*model.PayrollRun, error) { return nil, gorm.ErrRecordNotFound },
-		GetUserSalaryFn:            func(_ context.Context, uid uint) (float64, error) { return 7000000, nil },
-		GetAttendanceDaysForUserFn: func(_ context.Context, uid uint, s, e time.Time) (int, error) { return 20, nil },
-		GetOvertimeHoursForUserFn:  func(_ context.Context, uid uint, s, e time.Time) (float64, error) { return 5, nil },
-		ListReimbursementsForUserFn: func(_ context.Context, uid uint, s, e time.Time) ([]model.Reimbursement, error) {
-			return []model.Reimbursement{{ID: 1, UserID: uid, Date: s.AddDate(0, 0, 2), Amount: 12345}}, nil
-		},
+		GetRunByPeriodFn: func(_ context.Context, pid uint) (*model.PayrollRun, error) { return nil, gorm.ErrRecordNotFound },
 	}
 	usecase.InjectForTest(u, nil, nil, nil, nil, payMock, testm.FakeTxManager{})
+	usecase.InjectAdjustmentForTest(u, testm.NoAdjustments())
 
 	ctx := makeGinCtx()
-	resp, err := u.GeneratePayslip(ctx, 7, 1)
-	require.NoError(t, err)
-	require.False(t, resp.SnapshotUsed)
-	require.NotEmpty(t, resp.GrandTotal)
+	_, err := u.GeneratePayslip(ctx, 7, 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "payslip not available until payroll is approved")
 }
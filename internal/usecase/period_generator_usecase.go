@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apDTO "payslip-generation-system/internal/dto/attendance_period"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/periodtemplate"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// periodTemplate: payroll.periodTemplate di config, ditimpa field request yang diisi.
+func (u *usecase) periodTemplate(req apDTO.GeneratePeriodsRequest) (periodtemplate.Template, error) {
+	tpl := periodtemplate.Template{Mode: periodtemplate.ModeCalendarMonth}
+	if u.cfg != nil {
+		c := u.cfg.Payroll.PeriodTemplate
+		if c.Mode != "" {
+			tpl.Mode = c.Mode
+		}
+		tpl.CutoffDay = c.CutoffDay
+		tpl.NameFormat = c.NameFormat
+	}
+	if req.Mode != "" {
+		tpl.Mode = req.Mode
+	}
+	if req.CutoffDay > 0 {
+		tpl.CutoffDay = req.CutoffDay
+	}
+	if req.NameFormat != "" {
+		tpl.NameFormat = req.NameFormat
+	}
+	if err := tpl.Validate(); err != nil {
+		return tpl, utils.MakeError(errorUc.BadRequest, "invalid period template (cutoff mode needs cutoff_day 1-28)")
+	}
+	return tpl, nil
+}
+
+// GenerateAttendancePeriods membuat Count period berurutan dari template; period yang overlap dilewati.
+func (u *usecase) GenerateAttendancePeriods(ctx *gin.Context, req apDTO.GeneratePeriodsRequest) (*apDTO.GeneratePeriodsResponse, error) {
+	tpl, err := u.periodTemplate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var from time.Time
+	if req.From != "" {
+		from, err = time.Parse("2006-01", req.From)
+		if err != nil {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "from")
+		}
+	} else {
+		// lanjut dari period terakhir; jika belum ada period mulai dari period hari ini
+		var latest *model.AttendancePeriod
+		latest, err = u.apRepo.Latest(ctx)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to load latest attendance period"})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if latest != nil {
+			from = tpl.LabelOf(latest.EndDate.AddDate(0, 0, 1))
+		} else {
+			from = tpl.LabelOf(time.Now().In(time.FixedZone("WIB", 7*3600)))
+		}
+	}
+
+	return u.generatePeriods(ctx, tpl.Next(from, req.Count))
+}
+
+// EnsureUpcomingPeriods dipanggil scheduler: memastikan period hari ini s/d leadDays ke depan sudah ada.
+func (u *usecase) EnsureUpcomingPeriods(ctx context.Context) (*apDTO.GeneratePeriodsResponse, error) {
+	tpl, err := u.periodTemplate(apDTO.GeneratePeriodsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	lead := 7
+	if u.cfg != nil && u.cfg.Payroll.PeriodScheduler.LeadDays > 0 {
+		lead = u.cfg.Payroll.PeriodScheduler.LeadDays
+	}
+	today := time.Now().In(time.FixedZone("WIB", 7*3600))
+	from := tpl.LabelOf(today)
+	to := tpl.LabelOf(today.AddDate(0, 0, lead))
+	count := (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
+
+	return u.generatePeriods(ctx, tpl.Next(from, count))
+}
+
+func (u *usecase) generatePeriods(ctx context.Context, periods []periodtemplate.Period) (resp *apDTO.GeneratePeriodsResponse, err error) {
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to begin transaction"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	resp = &apDTO.GeneratePeriodsResponse{
+		Created: []apDTO.PeriodResponse{},
+		Skipped: []apDTO.SkippedPeriod{},
+	}
+	for _, p := range periods {
+		var overlap bool
+		overlap, err = u.apRepo.IsOverlapping(txCtx, p.Start, p.End)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if overlap {
+			resp.Skipped = append(resp.Skipped, apDTO.SkippedPeriod{
+				Name:      p.Name,
+				StartDate: p.Start.Format("2006-01-02"),
+				EndDate:   p.End.Format("2006-01-02"),
+				Reason:    "overlaps existing payroll period",
+			})
+			continue
+		}
+
+		row := &model.AttendancePeriod{Name: p.Name, StartDate: p.Start, EndDate: p.End}
+		if err = u.apRepo.Create(txCtx, row); err != nil {
+			u.log.Error(log.LogData{Err: err})
+			return nil, utils.MakeError(errorUc.InternalServerError, "failed to create period")
+		}
+		resp.Created = append(resp.Created, apDTO.PeriodResponse{
+			ID:        row.ID,
+			Name:      row.Name,
+			StartDate: row.StartDate.Format("2006-01-02"),
+			EndDate:   row.EndDate.Format("2006-01-02"),
+			Status:    model.PeriodStatusOpen,
+		})
+	}
+	return resp, nil
+}
+
+// StartPeriodScheduler menjalankan EnsureUpcomingPeriods saat start dan tiap interval sampai ctx selesai.
+// Tidak melakukan apa-apa jika payroll.periodScheduler.enabled = false.
+func (u *usecase) StartPeriodScheduler(ctx context.Context) {
+	if u.cfg == nil || !u.cfg.Payroll.PeriodScheduler.Enabled {
+		return
+	}
+	interval := u.cfg.Payroll.PeriodScheduler.Interval
+	if interval <= 0 {
+		interval = 24 * time.Hour
+	}
+
+	run := func() {
+		resp, err := u.EnsureUpcomingPeriods(ctx)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "period scheduler failed"})
+			return
+		}
+		if len(resp.Created) > 0 {
+			u.log.Info(log.LogData{Description: "period scheduler created attendance periods", Response: resp.Created})
+		}
+	}
+
+	go func() {
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,79 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"payslip-generation-system/config"
+	apDTO "payslip-generation-system/internal/dto/attendance_period"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func TestGenerateAttendancePeriods_CutoffTemplateSkipsOverlap(t *testing.T) {
+	u := usecase.NewForTest()
+	existing := []model.AttendancePeriod{
+		{ID: 1, StartDate: time.Date(2025, 7, 26, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 8, 25, 0, 0, 0, 0, time.UTC)},
+		// dibuat manual sebelumnya, bentrok dengan period Oktober
+		{ID: 2, StartDate: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	apMock := &testm.APRepoMock{
+		LatestFn: func(_ context.Context) (*model.AttendancePeriod, error) { return &existing[0], nil },
+		OverlapFn: func(_ context.Context, start, end time.Time) (bool, error) {
+			for _, p := range existing {
+				if !p.StartDate.After(end) && !p.EndDate.Before(start) {
+					return true, nil
+				}
+			}
+			return false, nil
+		},
+		CreateFn: func(_ context.Context, p *model.AttendancePeriod) error {
+			p.ID = uint(len(existing) + 1)
+			existing = append(existing, *p)
+			return nil
+		},
+	}
+	usecase.InjectForTest(u, apMock, nil, nil, nil, nil, testm.FakeTxManager{})
+	cfg := &config.Config{}
+	cfg.Payroll.PeriodTemplate = config.PeriodTemplateConfig{Mode: "cutoff", CutoffDay: 25}
+	usecase.InjectConfigForTest(u, cfg)
+
+	resp, err := u.GenerateAttendancePeriods(makeGinCtx(), apDTO.GeneratePeriodsRequest{Count: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.Created, 2)
+	require.Equal(t, "Payroll Sep 2025", resp.Created[0].Name)
+	require.Equal(t, "2025-08-26", resp.Created[0].StartDate)
+	require.Equal(t, "2025-09-25", resp.Created[0].EndDate)
+	require.Equal(t, "2025-10-26", resp.Created[1].StartDate)
+	require.Equal(t, "2025-11-25", resp.Created[1].EndDate)
+
+	require.Len(t, resp.Skipped, 1)
+	require.Equal(t, "Payroll Oct 2025", resp.Skipped[0].Name)
+	require.Equal(t, "2025-09-26", resp.Skipped[0].StartDate)
+
+	// cutoff tanpa tanggal cut-off ditolak
+	cfg.Payroll.PeriodTemplate.CutoffDay = 0
+	_, err = u.GenerateAttendancePeriods(makeGinCtx(), apDTO.GeneratePeriodsRequest{Count: 1})
+	require.Error(t, err)
+}
+
+func TestGenerateAttendancePeriods_CalendarMonthFrom(t *testing.T) {
+	u := usecase.NewForTest()
+	apMock := &testm.APRepoMock{
+		OverlapFn: func(_ context.Context, start, end time.Time) (bool, error) { return false, nil },
+		CreateFn:  func(_ context.Context, p *model.AttendancePeriod) error { return nil },
+	}
+	usecase.InjectForTest(u, apMock, nil, nil, nil, nil, testm.FakeTxManager{})
+
+	resp, err := u.GenerateAttendancePeriods(makeGinCtx(), apDTO.GeneratePeriodsRequest{Count: 2, From: "2026-01", NameFormat: "January 2006"})
+	require.NoError(t, err)
+	require.Len(t, resp.Created, 2)
+	require.Equal(t, "January 2026", resp.Created[0].Name)
+	require.Equal(t, "2026-01-31", resp.Created[0].EndDate)
+	require.Equal(t, "2026-02-01", resp.Created[1].StartDate)
+	require.Equal(t, "2026-02-28", resp.Created[1].EndDate)
+}
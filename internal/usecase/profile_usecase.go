@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	profileDTO "payslip-generation-system/internal/dto/profile"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+)
+
+const (
+	maxNameLength       = 100
+	minAge              = 15
+	maxAge              = 100
+	maxBioLength        = 500
+	maxLocationLength   = 255
+	maxInterests        = 10
+	maxInterestLength   = 50
+	maxProfileImageSize = 2 << 20 // 2MB
+)
+
+// format gambar yang diterima, dideteksi dari isi file (bukan dari nama/header upload)
+var profileImageExt = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/webp": "webp",
+}
+
+// isProfileComplete: profil dianggap lengkap kalau email dan nama depan/belakang terisi.
+func isProfileComplete(user *model.User) bool {
+	return user.Email != "" && user.FirstName != "" && user.LastName != ""
+}
+
+func (u *usecase) GetMyProfile(ctx *gin.Context, userID uint) (*profileDTO.ProfileResponse, error) {
+	user, err := u.findProfileUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toProfileResponse(user), nil
+}
+
+// UpdateMyProfile menerapkan perubahan parsial lalu menghitung ulang IsProfileComplete.
+func (u *usecase) UpdateMyProfile(ctx *gin.Context, userID uint, req profileDTO.UpdateProfileRequest) (*profileDTO.ProfileResponse, error) {
+	user, err := u.findProfileUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FirstName != nil {
+		name := strings.TrimSpace(*req.FirstName)
+		if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "first_name")
+		}
+		user.FirstName = name
+	}
+	if req.LastName != nil {
+		name := strings.TrimSpace(*req.LastName)
+		if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "last_name")
+		}
+		user.LastName = name
+	}
+	if req.Age != nil {
+		if *req.Age < minAge || *req.Age > maxAge {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "age")
+		}
+		user.Age = *req.Age
+	}
+	if req.Bio != nil {
+		bio := strings.TrimSpace(*req.Bio)
+		if utf8.RuneCountInString(bio) > maxBioLength {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "bio")
+		}
+		user.Bio = bio
+	}
+	if req.Location != nil {
+		loc := strings.TrimSpace(*req.Location)
+		if utf8.RuneCountInString(loc) > maxLocationLength {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "location")
+		}
+		user.Location = loc
+	}
+	if req.Interests != nil {
+		interests, ok := normalizeInterests(*req.Interests)
+		if !ok {
+			return nil, utils.MakeError(errorUc.InvalidFormat, "interests")
+		}
+		user.Interests = interests
+	}
+
+	user.IsProfileComplete = isProfileComplete(user)
+	if err := u.authRepo.UpdateProfile(ctx, user); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update profile"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update profile")
+	}
+
+	u.log.Info(log.LogData{Description: "profile updated", Response: user.ID})
+	return toProfileResponse(user), nil
+}
+
+// UploadProfileImage menyimpan foto profil baru ke storage lalu menghapus foto lama (best effort).
+func (u *usecase) UploadProfileImage(ctx *gin.Context, userID uint, r io.Reader) (*profileDTO.ProfileResponse, error) {
+	user, err := u.findProfileUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxProfileImageSize+1))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to read profile image"})
+		return nil, utils.MakeError(errorUc.BadRequest, "failed to read image")
+	}
+	if len(data) == 0 {
+		return nil, utils.MakeError(errorUc.InvalidMandatory, "image")
+	}
+	if len(data) > maxProfileImageSize {
+		return nil, utils.MakeError(errorUc.BadRequest, "image exceeds 2MB")
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := profileImageExt[contentType]
+	if !ok {
+		return nil, utils.MakeError(errorUc.InvalidFormat, "image (jpeg/png/webp)")
+	}
+
+	name, err := utils.GenerateRandomString(16)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate image name"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to store image")
+	}
+	key := fmt.Sprintf("profile-images/%d/%s.%s", user.ID, name, ext)
+	url, err := u.storage.Put(ctx, key, bytes.NewReader(data), contentType)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store profile image"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to store image")
+	}
+
+	oldURL := user.ProfileImageURL
+	user.ProfileImageURL = url
+	user.IsProfileComplete = isProfileComplete(user)
+	if err := u.authRepo.UpdateProfile(ctx, user); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to update profile image"})
+		_ = u.storage.Delete(ctx, key)
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to update profile")
+	}
+
+	// URL eksternal (mis. dari registrasi) bukan milik storage ini, jadi dibiarkan
+	if oldKey, ok := u.storage.KeyFromURL(oldURL); ok {
+		if err := u.storage.Delete(ctx, oldKey); err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to delete old profile image"})
+		}
+	}
+
+	u.log.Info(log.LogData{Description: "profile image updated", Response: user.ID})
+	return toProfileResponse(user), nil
+}
+
+func (u *usecase) findProfileUser(ctx *gin.Context, userID uint) (*model.User, error) {
+	user, err := u.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load user"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.NotFoundError)
+	}
+	return user, nil
+}
+
+// normalizeInterests trim + buang duplikat (case-insensitive), urutan input dipertahankan.
+func normalizeInterests(in []string) (pq.StringArray, bool) {
+	out := make(pq.StringArray, 0, len(in))
+	seen := make(map[string]bool, len(in))
+	for _, s := range in {
+		s = strings.TrimSpace(s)
+		if s == "" || utf8.RuneCountInString(s) > maxInterestLength {
+			return nil, false
+		}
+		k := strings.ToLower(s)
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		out = append(out, s)
+	}
+	if len(out) > maxInterests {
+		return nil, false
+	}
+	return out, true
+}
+
+func toProfileResponse(user *model.User) *profileDTO.ProfileResponse {
+	interests := []string(user.Interests)
+	if interests == nil {
+		interests = []string{}
+	}
+	return &profileDTO.ProfileResponse{
+		ID:                user.ID,
+		Email:             user.Email,
+		FirstName:         user.FirstName,
+		LastName:          user.LastName,
+		ProfileImageURL:   user.ProfileImageURL,
+		Age:               user.Age,
+		Bio:               user.Bio,
+		Location:          user.Location,
+		Interests:         interests,
+		Role:              user.Role,
+		Salary:            user.Salary,
+		IsProfileComplete: user.IsProfileComplete,
+		TwoFactorEnabled:  user.TwoFactorEnabled,
+	}
+}
@@ -0,0 +1,112 @@
+package usecase_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	profileDTO "payslip-generation-system/internal/dto/profile"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestUpdateMyProfile_RecomputesCompleteness(t *testing.T) {
+	u := usecase.NewForTest()
+
+	var saved *model.User
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, Email: "a@example.com", FirstName: "Ani", Salary: 5000000, Role: "user"}, nil
+		},
+		UpdateProfileFn: func(_ context.Context, user *model.User) error {
+			saved = user
+			return nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+
+	interests := []string{" coding ", "Coding", "reading"}
+	resp, err := u.UpdateMyProfile(makeGinCtx(), 7, profileDTO.UpdateProfileRequest{
+		LastName:  strPtr("  Putri "),
+		Interests: &interests,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.IsProfileComplete)
+	require.Equal(t, "Putri", saved.LastName)
+	require.Equal(t, []string{"coding", "reading"}, []string(saved.Interests))
+	require.Equal(t, 5000000.0, saved.Salary) // tidak berubah
+}
+
+func TestUpdateMyProfile_ValidationErrors(t *testing.T) {
+	u := usecase.NewForTest()
+
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, Email: "a@example.com"}, nil
+		},
+		UpdateProfileFn: func(_ context.Context, user *model.User) error {
+			t.Fatal("profile must not be saved")
+			return nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+
+	age := 12
+	tooMany := make([]string, 11)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("x", i+1)
+	}
+	cases := map[string]profileDTO.UpdateProfileRequest{
+		"first_name": {FirstName: strPtr("   ")},
+		"age":        {Age: &age},
+		"bio":        {Bio: strPtr(strings.Repeat("b", 501))},
+		"interests":  {Interests: &tooMany},
+	}
+	for field, req := range cases {
+		_, err := u.UpdateMyProfile(makeGinCtx(), 7, req)
+		require.Error(t, err, field)
+		require.Contains(t, err.Error(), field)
+	}
+}
+
+func TestUploadProfileImage_ReplacesOldImage(t *testing.T) {
+	u := usecase.NewForTest()
+
+	store := testm.NewStorageMock()
+	store.Objects["profile-images/7/old.png"] = []byte("old")
+	var saved *model.User
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{ID: id, ProfileImageURL: "https://files.test/profile-images/7/old.png"}, nil
+		},
+		UpdateProfileFn: func(_ context.Context, user *model.User) error {
+			saved = user
+			return nil
+		},
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, &testm.SessionRepoMock{}, testm.FakeTxManager{})
+	usecase.InjectStorageForTest(u, store)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+
+	resp, err := u.UploadProfileImage(makeGinCtx(), 7, &buf)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(resp.ProfileImageURL, "https://files.test/profile-images/7/"))
+	require.True(t, strings.HasSuffix(resp.ProfileImageURL, ".png"))
+	require.Equal(t, resp.ProfileImageURL, saved.ProfileImageURL)
+	require.Equal(t, []string{"profile-images/7/old.png"}, store.Deleted)
+	require.Len(t, store.Objects, 1)
+
+	// bukan gambar
+	_, err = u.UploadProfileImage(makeGinCtx(), 7, strings.NewReader("%PDF-1.4 not an image"))
+	require.Error(t, err)
+}
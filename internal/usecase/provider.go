@@ -1,63 +1,233 @@
 package usecase
 
 import (
+	"context"
+	"io"
 	"payslip-generation-system/config"
 	"payslip-generation-system/internal/model"
+	adjRepo "payslip-generation-system/internal/repository/adjustment"
 	atRepo "payslip-generation-system/internal/repository/attendance"
+	acRepo "payslip-generation-system/internal/repository/attendancecorrection"
 	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
 	repositoryAuth "payslip-generation-system/internal/repository/auth"
+	bankAccountRepo "payslip-generation-system/internal/repository/bankaccount"
+	deviceRepo "payslip-generation-system/internal/repository/device"
+	disbursementRepo "payslip-generation-system/internal/repository/disbursement"
+	employeeRepo "payslip-generation-system/internal/repository/employee"
+	loginAttemptRepo "payslip-generation-system/internal/repository/loginattempt"
+	officeLocationRepo "payslip-generation-system/internal/repository/officelocation"
+	oidcStateRepo "payslip-generation-system/internal/repository/oidcstate"
 	otRepo "payslip-generation-system/internal/repository/overtime"
+	opRepo "payslip-generation-system/internal/repository/overtimeplan"
+	prRepo "payslip-generation-system/internal/repository/passwordreset"
 	payRepo "payslip-generation-system/internal/repository/payroll"
 	rbRepo "payslip-generation-system/internal/repository/reimbursement"
+	sessionRepo "payslip-generation-system/internal/repository/session"
+	tfRepo "payslip-generation-system/internal/repository/twofactor"
 	repoTx "payslip-generation-system/internal/repository/tx"
+	"payslip-generation-system/pkg/fieldcrypt"
+	"payslip-generation-system/pkg/jwtkey"
 	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/notify"
+	"payslip-generation-system/pkg/oidc"
+	"payslip-generation-system/pkg/storage"
+	"payslip-generation-system/utils"
+	"time"
 
+	adjDTO "payslip-generation-system/internal/dto/adjustment"
+	atDTO "payslip-generation-system/internal/dto/attendance"
+	apDTO "payslip-generation-system/internal/dto/attendance_period"
 	authDTO "payslip-generation-system/internal/dto/auth"
+	bankDTO "payslip-generation-system/internal/dto/bankaccount"
+	devDTO "payslip-generation-system/internal/dto/device"
+	employeeDTO "payslip-generation-system/internal/dto/employee"
+	olDTO "payslip-generation-system/internal/dto/officelocation"
+	otDTO "payslip-generation-system/internal/dto/overtime"
+	pDTO "payslip-generation-system/internal/dto/payroll"
 	"payslip-generation-system/internal/dto/payslip"
+	profileDTO "payslip-generation-system/internal/dto/profile"
+	rbDTO "payslip-generation-system/internal/dto/reimbursement"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
 	"gorm.io/gorm"
 )
 
 type IUsecase interface {
 	LoginUser(ctx *gin.Context, email, password string) (*model.User, error)
-	GenerateToken(userID uint, name, role string) (string, error)
+	GenerateToken(userID uint, name, role string, sessionID uint, tokenVersion int) (string, error)
+	ParseAccessToken(tokenString string) (jwt.MapClaims, error)
+	JWKS() jwtkey.JWKSet
 	RegisterUser(ctx *gin.Context, userDTO authDTO.RegisterUserRequest) (*model.User, error)
 
+	StartSession(ctx *gin.Context, user *model.User, deviceID string) (*authDTO.TokenResponse, error)
+	RefreshSession(ctx *gin.Context, refreshToken string) (*authDTO.TokenResponse, error)
+	Logout(ctx *gin.Context, userID, sessionID uint, allDevices bool) error
+	RevokeUserSessions(ctx *gin.Context, userID uint, reason string) error
+	ValidateSession(ctx *gin.Context, userID, sessionID uint, tokenVersion int) error
+
+	ForgotPassword(ctx *gin.Context, email string) error
+	ResetPassword(ctx *gin.Context, token, newPassword string) error
+	ChangePassword(ctx *gin.Context, userID uint, currentPassword, newPassword string) error
+
+	TwoFactorSetupRequired(user *model.User) bool
+	StartTwoFactorChallenge(ctx *gin.Context, user *model.User, deviceID string) (string, error)
+	VerifyTwoFactor(ctx *gin.Context, challengeToken, code string) (*authDTO.TokenResponse, error)
+	EnrollTwoFactor(ctx *gin.Context, userID uint) (*authDTO.TwoFactorEnrollResponse, error)
+	ActivateTwoFactor(ctx *gin.Context, userID uint, code string) (*authDTO.RecoveryCodesResponse, error)
+	RegenerateRecoveryCodes(ctx *gin.Context, userID uint, code string) (*authDTO.RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx *gin.Context, userID uint, password, code string) error
+
+	StartOIDCLogin(ctx *gin.Context, providerName, deviceID string) (*authDTO.OIDCStartResponse, error)
+	CompleteOIDCLogin(ctx *gin.Context, providerName, code, state string) (*authDTO.LoginUserResponse, error)
+
+	GetMyProfile(ctx *gin.Context, userID uint) (*profileDTO.ProfileResponse, error)
+	UpdateMyProfile(ctx *gin.Context, userID uint, req profileDTO.UpdateProfileRequest) (*profileDTO.ProfileResponse, error)
+	UploadProfileImage(ctx *gin.Context, userID uint, r io.Reader) (*profileDTO.ProfileResponse, error)
+
+	ListEmployees(ctx *gin.Context, q employeeDTO.ListEmployeesQuery) ([]employeeDTO.EmployeeResponse, *utils.Metadata, error)
+	GetEmployee(ctx *gin.Context, userID uint) (*employeeDTO.EmployeeResponse, error)
+	UpdateEmployee(ctx *gin.Context, actorID, userID uint, req employeeDTO.UpdateEmployeeRequest) (*employeeDTO.EmployeeResponse, error)
+
+	GetBankAccount(ctx *gin.Context, userID uint) (*bankDTO.BankAccountResponse, error)
+	SetBankAccount(ctx *gin.Context, actorID, userID uint, req bankDTO.SetBankAccountRequest) (*bankDTO.BankAccountResponse, error)
+	VerifyBankAccount(ctx *gin.Context, actorID, userID uint) (*bankDTO.BankAccountResponse, error)
+	ListBankAccountHistory(ctx *gin.Context, userID uint) ([]bankDTO.BankAccountHistoryResponse, error)
+
+	ListLockouts(ctx *gin.Context, all bool) ([]authDTO.LockoutResponse, error)
+	ClearLockout(ctx *gin.Context, scope, identifier string) error
+
 	CreateAttendancePeriod(ctx *gin.Context, name, start, end string) (*model.AttendancePeriod, error)
-	SubmitAttendance(ctx *gin.Context, userID uint, dateStr string) (*model.Attendance, bool, error)
+	ListAttendancePeriods(ctx *gin.Context, q apDTO.ListPeriodsQuery) ([]apDTO.PeriodResponse, *utils.Metadata, error)
+	GetAttendancePeriod(ctx *gin.Context, periodID uint) (*apDTO.PeriodResponse, error)
+	UpdateAttendancePeriod(ctx *gin.Context, periodID uint, req apDTO.UpdatePeriodRequest) (*apDTO.PeriodResponse, error)
+	CloseAttendancePeriod(ctx *gin.Context, actorID, periodID uint) (*apDTO.PeriodResponse, error)
+	DeleteAttendancePeriod(ctx *gin.Context, periodID uint) error
+	GenerateAttendancePeriods(ctx *gin.Context, req apDTO.GeneratePeriodsRequest) (*apDTO.GeneratePeriodsResponse, error)
+	EnsureUpcomingPeriods(ctx context.Context) (*apDTO.GeneratePeriodsResponse, error)
+	StartPeriodScheduler(ctx context.Context)
+	SubmitAttendance(ctx *gin.Context, userID uint, dateStr string, loc atDTO.Location) (*model.Attendance, bool, error)
+	CheckIn(ctx *gin.Context, userID uint, loc atDTO.Location) (*atDTO.AttendanceResponse, error)
+	CheckOut(ctx *gin.Context, userID uint) (*atDTO.AttendanceResponse, error)
+	ListFlaggedAttendances(ctx *gin.Context, periodID uint, q atDTO.FlaggedAttendanceQuery) ([]atDTO.FlaggedAttendanceResponse, *utils.Metadata, error)
+	RequestAttendanceCorrection(ctx *gin.Context, userID uint, req atDTO.CreateCorrectionRequest) (*atDTO.CorrectionResponse, error)
+	ListAttendanceCorrections(ctx *gin.Context, userID uint, q atDTO.ListCorrectionsQuery) ([]atDTO.CorrectionResponse, *utils.Metadata, error)
+	ApproveAttendanceCorrection(ctx *gin.Context, actorID, correctionID uint, req atDTO.ReviewCorrectionRequest) (*atDTO.CorrectionResponse, error)
+	RejectAttendanceCorrection(ctx *gin.Context, actorID, correctionID uint, req atDTO.ReviewCorrectionRequest) (*atDTO.CorrectionResponse, error)
+
+	ListOfficeLocations(ctx *gin.Context) ([]olDTO.OfficeLocationResponse, error)
+	CreateOfficeLocation(ctx *gin.Context, req olDTO.CreateOfficeLocationRequest) (*olDTO.OfficeLocationResponse, error)
+	UpdateOfficeLocation(ctx *gin.Context, id uint, req olDTO.UpdateOfficeLocationRequest) (*olDTO.OfficeLocationResponse, error)
+	DeleteOfficeLocation(ctx *gin.Context, id uint) error
+	ListDevices(ctx *gin.Context) ([]devDTO.DeviceResponse, error)
+	CreateDevice(ctx *gin.Context, actorID uint, req devDTO.CreateDeviceRequest) (*devDTO.DeviceKeyResponse, error)
+	UpdateDevice(ctx *gin.Context, id uint, req devDTO.UpdateDeviceRequest) (*devDTO.DeviceResponse, error)
+	RotateDeviceKey(ctx *gin.Context, id uint) (*devDTO.DeviceKeyResponse, error)
+	ListDeviceUsers(ctx *gin.Context, deviceID uint) ([]devDTO.DeviceUserResponse, error)
+	MapDeviceUser(ctx *gin.Context, deviceID uint, req devDTO.MapDeviceUserRequest) (*devDTO.DeviceUserResponse, error)
+	UnmapDeviceUser(ctx *gin.Context, deviceID uint, deviceUserID string) error
+	// kiriman punch dari mesin absensi, diautentikasi dengan API key mesin
+	IngestDevicePunches(ctx *gin.Context, apiKey string, body []byte) (*devDTO.IngestPunchesResponse, error)
 
 	SubmitOvertime(ctx *gin.Context, userID uint, dateStr string, hours float64) (*model.Overtime, bool, error)
+	// rencana overtime diajukan sebelum dijalankan & disetujui admin
+	RequestOvertimePlan(ctx *gin.Context, userID uint, req otDTO.CreateOvertimePlanRequest) (*otDTO.OvertimePlanResponse, error)
+	ListOvertimePlans(ctx *gin.Context, userID uint, q otDTO.ListOvertimePlansQuery) ([]otDTO.OvertimePlanResponse, *utils.Metadata, error)
+	ApproveOvertimePlan(ctx *gin.Context, actorID, planID uint, req otDTO.ReviewOvertimePlanRequest) (*otDTO.OvertimePlanResponse, error)
+	RejectOvertimePlan(ctx *gin.Context, actorID, planID uint, req otDTO.ReviewOvertimePlanRequest) (*otDTO.OvertimePlanResponse, error)
+	ListFlaggedOvertimes(ctx *gin.Context, periodID uint, q otDTO.FlaggedOvertimeQuery) ([]otDTO.FlaggedOvertimeResponse, *utils.Metadata, error)
 	CreateReimbursement(ctx *gin.Context, userID uint, dateStr string, amount float64, description string) (*model.Reimbursement, error)
+	// input admin atas nama karyawan; validasi sama dengan submit biasa
+	SubmitAttendanceOnBehalf(ctx *gin.Context, adminID, userID uint, dateStr, reason string) (*model.Attendance, bool, error)
+	SubmitOvertimeOnBehalf(ctx *gin.Context, adminID, userID uint, dateStr string, hours float64, reason string) (*model.Overtime, bool, error)
+	CreateReimbursementOnBehalf(ctx *gin.Context, adminID, userID uint, dateStr string, amount float64, description, reason string) (*model.Reimbursement, error)
+	// riwayat milik sendiri per period / rentang tanggal
+	ListMyAttendances(ctx *gin.Context, userID uint, q atDTO.HistoryQuery) ([]atDTO.AttendanceHistoryResponse, *utils.Metadata, error)
+	ListMyOvertimes(ctx *gin.Context, userID uint, q otDTO.HistoryQuery) ([]otDTO.OvertimeHistoryResponse, *utils.Metadata, error)
+	ListMyReimbursements(ctx *gin.Context, userID uint, q rbDTO.HistoryQuery) ([]rbDTO.ReimbursementHistoryResponse, *utils.Metadata, error)
+	GetMyAttendanceCalendar(ctx *gin.Context, userID uint, q atDTO.CalendarQuery) (*atDTO.AttendanceCalendarResponse, error)
+	// edit & hapus milik sendiri selama period belum di-run
+	UpdateMyOvertime(ctx *gin.Context, userID, id uint, req otDTO.UpdateOvertimeRequest) (*otDTO.OvertimeHistoryResponse, error)
+	DeleteMyOvertime(ctx *gin.Context, userID, id uint) error
+	UpdateMyReimbursement(ctx *gin.Context, userID, id uint, req rbDTO.UpdateReimbursementRequest) (*rbDTO.ReimbursementHistoryResponse, error)
+	DeleteMyReimbursement(ctx *gin.Context, userID, id uint) error
+	DeleteMyAttendance(ctx *gin.Context, userID, id uint) error
+	CreatePriorPeriodAdjustment(ctx *gin.Context, userID uint, req adjDTO.CreateAdjustmentRequest) (*adjDTO.AdjustmentResponse, error)
+	ListMyAdjustments(ctx *gin.Context, userID uint, q adjDTO.ListAdjustmentsQuery) ([]adjDTO.AdjustmentResponse, *utils.Metadata, error)
+	ListPeriodAdjustments(ctx *gin.Context, periodID uint) ([]adjDTO.AdjustmentResponse, error)
+	ApprovePriorPeriodAdjustment(ctx *gin.Context, actorID, id uint, req adjDTO.ReviewAdjustmentRequest) (*adjDTO.AdjustmentResponse, error)
+	RejectPriorPeriodAdjustment(ctx *gin.Context, actorID, id uint, req adjDTO.ReviewAdjustmentRequest) (*adjDTO.AdjustmentResponse, error)
 
-	RunPayroll(ctx *gin.Context, periodID uint) (*model.PayrollRun, []*model.PayrollItem, error)
+	RunPayroll(ctx *gin.Context, actorID, periodID uint) (*model.PayrollRun, []*model.PayrollItem, error)
+	GetPayrollRun(ctx *gin.Context, periodID uint) (*pDTO.PayrollRunResponse, error)
+	TransitionPayrollRun(ctx *gin.Context, actorID, periodID uint, req pDTO.TransitionRunRequest) (*pDTO.PayrollRunResponse, error)
+	RequestRunApproval(ctx *gin.Context, actorID, periodID uint, req pDTO.RunApprovalRequest) (*pDTO.PayrollRunResponse, error)
+	ApproveRun(ctx *gin.Context, actorID, periodID uint, req pDTO.RunApprovalRequest) (*pDTO.PayrollRunResponse, error)
+	RejectRun(ctx *gin.Context, actorID, periodID uint, req pDTO.RunApprovalRequest) (*pDTO.PayrollRunResponse, error)
+	GenerateDisbursement(ctx *gin.Context, actorID, periodID uint, req pDTO.GenerateDisbursementRequest) (*pDTO.DisbursementBatchResponse, error)
+	GetDisbursement(ctx *gin.Context, periodID uint) (*pDTO.DisbursementBatchResponse, error)
+	DisbursementFile(ctx *gin.Context, periodID uint) (*model.DisbursementBatch, error)
+	ListPayments(ctx *gin.Context, periodID uint) (*pDTO.PaymentListResponse, error)
+	ImportPaymentResults(ctx *gin.Context, actorID, periodID uint, file io.Reader) (*pDTO.ImportPaymentResultsResponse, error)
+	// import CSV attendance / overtime / reimbursement ke satu period (all-or-nothing)
+	ImportPeriodEntries(ctx *gin.Context, actorID, periodID uint, file io.Reader, dryRun bool) (*pDTO.ImportEntriesResponse, error)
 	GeneratePayslip(ctx *gin.Context, userID, periodID uint) (*payslip.PayslipResponse, error)
 }
 
 type usecase struct {
-	cfg         *config.Config
-	log         *log.LogCustom
-	authRepo    repositoryAuth.IAuthRepo
-	txManager   repoTx.TxManager
-	apRepo      apRepo.Repo
-	atRepo      atRepo.Repo
-	otRepo      otRepo.Repo
-	rbRepo      rbRepo.Repo
-	payrollRepo payRepo.Repo
+	cfg              *config.Config
+	log              *log.LogCustom
+	keys             *jwtkey.Manager
+	notifier         notify.Notifier
+	oidc             *oidc.Registry
+	storage          storage.Storage
+	fieldCipher      *fieldcrypt.Cipher
+	authRepo         repositoryAuth.IAuthRepo
+	txManager        repoTx.TxManager
+	apRepo           apRepo.Repo
+	atRepo           atRepo.Repo
+	otRepo           otRepo.Repo
+	rbRepo           rbRepo.Repo
+	payrollRepo      payRepo.Repo
+	sessionRepo      sessionRepo.Repo
+	resetRepo        prRepo.Repo
+	attemptRepo      loginAttemptRepo.Repo
+	twoFactorRepo    tfRepo.Repo
+	oidcStateRepo    oidcStateRepo.Repo
+	employeeRepo     employeeRepo.Repo
+	bankAccountRepo  bankAccountRepo.Repo
+	disbursementRepo disbursementRepo.Repo
+	officeLocRepo    officeLocationRepo.Repo
+	correctionRepo   acRepo.Repo
+	adjustmentRepo   adjRepo.Repo
+	deviceRepo       deviceRepo.Repo
+	planRepo         opRepo.Repo
+
+	now func() time.Time // nil = time.Now; diganti di test
 }
 
 func ProvideUsc(
 	cfg *config.Config,
 	l *log.LogCustom,
 	db *gorm.DB,
+	keys *jwtkey.Manager,
+	notifier notify.Notifier,
+	oidcRegistry *oidc.Registry,
+	store storage.Storage,
+	fieldCipher *fieldcrypt.Cipher,
 	authRepo repositoryAuth.IAuthRepo,
 	txManager repoTx.TxManager,
 ) IUsecase {
 	u := &usecase{
-		cfg:       cfg,
-		log:       l,
-		authRepo:  authRepo,
-		txManager: txManager,
+		cfg:         cfg,
+		log:         l,
+		keys:        keys,
+		notifier:    notifier,
+		oidc:        oidcRegistry,
+		storage:     store,
+		fieldCipher: fieldCipher,
+		authRepo:    authRepo,
+		txManager:   txManager,
 	}
 	// inject attendance repos
 	u.apRepo = apRepo.New(db)
@@ -65,5 +235,18 @@ func ProvideUsc(
 	u.otRepo = otRepo.New(db)
 	u.rbRepo = rbRepo.New(db)
 	u.payrollRepo = payRepo.New(db)
+	u.sessionRepo = sessionRepo.New(db)
+	u.resetRepo = prRepo.New(db)
+	u.attemptRepo = loginAttemptRepo.New(cfg.Auth.Lockout.Store, db)
+	u.twoFactorRepo = tfRepo.New(db)
+	u.oidcStateRepo = oidcStateRepo.New(db)
+	u.employeeRepo = employeeRepo.New(db)
+	u.bankAccountRepo = bankAccountRepo.New(db)
+	u.disbursementRepo = disbursementRepo.New(db)
+	u.officeLocRepo = officeLocationRepo.New(db)
+	u.correctionRepo = acRepo.New(db)
+	u.adjustmentRepo = adjRepo.New(db)
+	u.deviceRepo = deviceRepo.New(db)
+	u.planRepo = opRepo.New(db)
 	return u
 }
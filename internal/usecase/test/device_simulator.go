@@ -0,0 +1,53 @@
+package test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SimulatedDevice meniru mesin absensi ZKTeco: mengumpulkan punch lalu mengirimnya
+// sebagai ATTLOG (push protocol) ke endpoint ingest dengan header X-Device-Key.
+type SimulatedDevice struct {
+	URL    string
+	APIKey string
+	Client *http.Client
+
+	punches []string
+}
+
+// Punch mencatat scan PIN pada waktu (jam lokal mesin) dengan status 0 = masuk, 1 = pulang.
+func (d *SimulatedDevice) Punch(pin string, at time.Time, status int) {
+	d.punches = append(d.punches, fmt.Sprintf("%s\t%s\t%d\t1\t0\t0", pin, at.Format("2006-01-02 15:04:05"), status))
+}
+
+// Payload body ATTLOG dari punch yang sudah dicatat.
+func (d *SimulatedDevice) Payload() []byte {
+	return []byte(strings.Join(d.punches, "\n") + "\n")
+}
+
+// Push mengirim semua punch yang tercatat; punch tidak dihapus sehingga Push berikutnya
+// mengirim ulang (seperti mesin yang tidak menerima ack).
+func (d *SimulatedDevice) Push() (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodPost, d.URL, bytes.NewReader(d.Payload()))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("X-Device-Key", d.APIKey)
+
+	client := d.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	return resp.StatusCode, body, err
+}
@@ -0,0 +1,33 @@
+package test
+
+import (
+	"payslip-generation-system/pkg/fieldcrypt"
+	"payslip-generation-system/pkg/jwtkey"
+)
+
+const TestHSSecret = "0123456789abcdef0123456789abcdef"
+
+// NewKeyManager returns an HS256-only key manager for unit tests.
+func NewKeyManager() *jwtkey.Manager {
+	km, err := jwtkey.New(jwtkey.Config{
+		Issuer:    "payslip-test",
+		ActiveKid: "test-hs",
+		Keys:      []jwtkey.KeyConfig{{Kid: "test-hs", Alg: jwtkey.AlgHS256, Secret: TestHSSecret}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return km
+}
+
+// NewFieldCipher returns a field cipher with a fixed test key.
+func NewFieldCipher() *fieldcrypt.Cipher {
+	c, err := fieldcrypt.New(fieldcrypt.Config{
+		ActiveKid: "test",
+		Keys:      []fieldcrypt.KeyConfig{{Kid: "test", Key: "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="}},
+	})
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,53 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	adjRepo "payslip-generation-system/internal/repository/adjustment"
+)
+
+type AdjustmentRepoMock struct {
+	CreateFn       func(ctx context.Context, a *model.PayrollAdjustment) error
+	FindByIDFn     func(ctx context.Context, id uint) (*model.PayrollAdjustment, error)
+	ListByPeriodFn func(ctx context.Context, periodID, userID uint, status string) ([]model.PayrollAdjustment, error)
+	ListByUserFn   func(ctx context.Context, userID uint, offset, limit int) ([]model.PayrollAdjustment, int64, error)
+	HasOvertimeFn  func(ctx context.Context, userID uint, date time.Time) (bool, error)
+	DecideFn       func(ctx context.Context, id uint, d adjRepo.Decision) (bool, error)
+}
+
+// NoAdjustments: mock tanpa adjustment sama sekali (untuk test run payroll / payslip)
+func NoAdjustments() *AdjustmentRepoMock {
+	return &AdjustmentRepoMock{
+		ListByPeriodFn: func(ctx context.Context, periodID, userID uint, status string) ([]model.PayrollAdjustment, error) {
+			return nil, nil
+		},
+	}
+}
+
+func (m *AdjustmentRepoMock) Create(ctx context.Context, a *model.PayrollAdjustment) error {
+	return m.CreateFn(ctx, a)
+}
+
+func (m *AdjustmentRepoMock) FindByID(ctx context.Context, id uint) (*model.PayrollAdjustment, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *AdjustmentRepoMock) ListByPeriod(ctx context.Context, periodID, userID uint, status string) ([]model.PayrollAdjustment, error) {
+	return m.ListByPeriodFn(ctx, periodID, userID, status)
+}
+
+func (m *AdjustmentRepoMock) ListByUser(ctx context.Context, userID uint, offset, limit int) ([]model.PayrollAdjustment, int64, error) {
+	return m.ListByUserFn(ctx, userID, offset, limit)
+}
+
+func (m *AdjustmentRepoMock) HasOvertime(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	return m.HasOvertimeFn(ctx, userID, date)
+}
+
+func (m *AdjustmentRepoMock) Decide(ctx context.Context, id uint, d adjRepo.Decision) (bool, error) {
+	return m.DecideFn(ctx, id, d)
+}
+
+var _ adjRepo.Repo = (*AdjustmentRepoMock)(nil)
@@ -10,10 +10,75 @@ import (
 
 type ATRepoMock struct {
 	CreateIfNotExistsFn func(ctx context.Context, userID uint, date time.Time) (*model.Attendance, bool, error)
+	FindByUserDateFn    func(ctx context.Context, userID uint, date time.Time) (*model.Attendance, error)
+	CheckInFn           func(ctx context.Context, row *model.Attendance) (bool, error)
+	CheckOutFn          func(ctx context.Context, row *model.Attendance) (bool, error)
+	SetLocationFn       func(ctx context.Context, row *model.Attendance) error
+	ListFlaggedFn       func(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error)
+	SetSubmitterFn      func(ctx context.Context, id, adminID uint, reason string) error
+	ListByDateRangeFn   func(ctx context.Context, start, end time.Time) ([]model.Attendance, error)
+	CreateBatchFn       func(ctx context.Context, rows []*model.Attendance, batchSize int) error
+	ListByUserFn        func(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error)
+	DeleteFn            func(ctx context.Context, id uint) error
+	FindByIDFn          func(ctx context.Context, id uint) (*model.Attendance, error)
+	AddAuditFn          func(ctx context.Context, a *model.AttendanceAudit) error
+	HasAuditFn          func(ctx context.Context, attendanceID uint, action, source string) (bool, error)
 }
 
 func (m *ATRepoMock) CreateIfNotExists(ctx context.Context, userID uint, date time.Time) (*model.Attendance, bool, error) {
 	return m.CreateIfNotExistsFn(ctx, userID, date)
 }
 
+func (m *ATRepoMock) FindByUserDate(ctx context.Context, userID uint, date time.Time) (*model.Attendance, error) {
+	return m.FindByUserDateFn(ctx, userID, date)
+}
+
+func (m *ATRepoMock) CheckIn(ctx context.Context, row *model.Attendance) (bool, error) {
+	return m.CheckInFn(ctx, row)
+}
+
+func (m *ATRepoMock) CheckOut(ctx context.Context, row *model.Attendance) (bool, error) {
+	return m.CheckOutFn(ctx, row)
+}
+
+func (m *ATRepoMock) SetLocation(ctx context.Context, row *model.Attendance) error {
+	return m.SetLocationFn(ctx, row)
+}
+
+func (m *ATRepoMock) SetSubmitter(ctx context.Context, id, adminID uint, reason string) error {
+	return m.SetSubmitterFn(ctx, id, adminID, reason)
+}
+
+func (m *ATRepoMock) ListByDateRange(ctx context.Context, start, end time.Time) ([]model.Attendance, error) {
+	return m.ListByDateRangeFn(ctx, start, end)
+}
+
+func (m *ATRepoMock) CreateBatch(ctx context.Context, rows []*model.Attendance, batchSize int) error {
+	return m.CreateBatchFn(ctx, rows, batchSize)
+}
+
+func (m *ATRepoMock) ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error) {
+	return m.ListByUserFn(ctx, userID, start, end, offset, limit)
+}
+
+func (m *ATRepoMock) ListFlagged(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Attendance, int64, error) {
+	return m.ListFlaggedFn(ctx, start, end, offset, limit)
+}
+
+func (m *ATRepoMock) FindByID(ctx context.Context, id uint) (*model.Attendance, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *ATRepoMock) Delete(ctx context.Context, id uint) error {
+	return m.DeleteFn(ctx, id)
+}
+
+func (m *ATRepoMock) AddAudit(ctx context.Context, a *model.AttendanceAudit) error {
+	return m.AddAuditFn(ctx, a)
+}
+
+func (m *ATRepoMock) HasAudit(ctx context.Context, attendanceID uint, action, source string) (bool, error) {
+	return m.HasAuditFn(ctx, attendanceID, action, source)
+}
+
 var _ atRepo.Repo = (*ATRepoMock)(nil)
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	acRepo "payslip-generation-system/internal/repository/attendancecorrection"
+)
+
+type CorrectionRepoMock struct {
+	CreateFn     func(ctx context.Context, c *model.AttendanceCorrection) error
+	FindByIDFn   func(ctx context.Context, id uint) (*model.AttendanceCorrection, error)
+	ListFn       func(ctx context.Context, f acRepo.ListFilter) ([]model.AttendanceCorrection, int64, error)
+	HasPendingFn func(ctx context.Context, userID uint, date time.Time) (bool, error)
+	DecideFn     func(ctx context.Context, id uint, d acRepo.Decision) (bool, error)
+}
+
+func (m *CorrectionRepoMock) Create(ctx context.Context, c *model.AttendanceCorrection) error {
+	return m.CreateFn(ctx, c)
+}
+
+func (m *CorrectionRepoMock) FindByID(ctx context.Context, id uint) (*model.AttendanceCorrection, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *CorrectionRepoMock) List(ctx context.Context, f acRepo.ListFilter) ([]model.AttendanceCorrection, int64, error) {
+	return m.ListFn(ctx, f)
+}
+
+func (m *CorrectionRepoMock) HasPending(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	return m.HasPendingFn(ctx, userID, date)
+}
+
+func (m *CorrectionRepoMock) Decide(ctx context.Context, id uint, d acRepo.Decision) (bool, error) {
+	return m.DecideFn(ctx, id, d)
+}
+
+var _ acRepo.Repo = (*CorrectionRepoMock)(nil)
@@ -9,15 +9,60 @@ import (
 )
 
 type APRepoMock struct {
-	OverlapFn func(ctx context.Context, start, end time.Time) (bool, error)
-	CreateFn  func(ctx context.Context, p *model.AttendancePeriod) error
+	OverlapFn          func(ctx context.Context, start, end time.Time) (bool, error)
+	OverlapExcludingFn func(ctx context.Context, start, end time.Time, excludeID uint) (bool, error)
+	CreateFn           func(ctx context.Context, p *model.AttendancePeriod) error
+
+	LatestFn         func(ctx context.Context) (*model.AttendancePeriod, error)
+	ContainingFn     func(ctx context.Context, date time.Time) (*model.AttendancePeriod, error)
+	NextOpenFn       func(ctx context.Context, after time.Time) (*apRepo.PeriodRow, error)
+	ListFn           func(ctx context.Context, f apRepo.ListFilter) ([]apRepo.PeriodRow, int64, error)
+	ListOverlapFn    func(ctx context.Context, start, end time.Time) ([]apRepo.PeriodRow, error)
+	FindByIDFn       func(ctx context.Context, id uint) (*apRepo.PeriodRow, error)
+	UpdateFn         func(ctx context.Context, p *model.AttendancePeriod) error
+	CloseFn          func(ctx context.Context, id, actorID uint, at time.Time) (bool, error)
+	DeleteFn         func(ctx context.Context, id uint) error
+	HasSubmissionsFn func(ctx context.Context, start, end time.Time) (bool, error)
 }
 
 func (m *APRepoMock) IsOverlapping(ctx context.Context, start, end time.Time) (bool, error) {
 	return m.OverlapFn(ctx, start, end)
 }
+func (m *APRepoMock) IsOverlappingExcluding(ctx context.Context, start, end time.Time, excludeID uint) (bool, error) {
+	return m.OverlapExcludingFn(ctx, start, end, excludeID)
+}
 func (m *APRepoMock) Create(ctx context.Context, p *model.AttendancePeriod) error {
 	return m.CreateFn(ctx, p)
 }
+func (m *APRepoMock) Latest(ctx context.Context) (*model.AttendancePeriod, error) {
+	return m.LatestFn(ctx)
+}
+func (m *APRepoMock) Containing(ctx context.Context, date time.Time) (*model.AttendancePeriod, error) {
+	return m.ContainingFn(ctx, date)
+}
+func (m *APRepoMock) NextOpen(ctx context.Context, after time.Time) (*apRepo.PeriodRow, error) {
+	return m.NextOpenFn(ctx, after)
+}
+func (m *APRepoMock) List(ctx context.Context, f apRepo.ListFilter) ([]apRepo.PeriodRow, int64, error) {
+	return m.ListFn(ctx, f)
+}
+func (m *APRepoMock) ListOverlapping(ctx context.Context, start, end time.Time) ([]apRepo.PeriodRow, error) {
+	return m.ListOverlapFn(ctx, start, end)
+}
+func (m *APRepoMock) FindByID(ctx context.Context, id uint) (*apRepo.PeriodRow, error) {
+	return m.FindByIDFn(ctx, id)
+}
+func (m *APRepoMock) Update(ctx context.Context, p *model.AttendancePeriod) error {
+	return m.UpdateFn(ctx, p)
+}
+func (m *APRepoMock) Close(ctx context.Context, id, actorID uint, at time.Time) (bool, error) {
+	return m.CloseFn(ctx, id, actorID, at)
+}
+func (m *APRepoMock) Delete(ctx context.Context, id uint) error {
+	return m.DeleteFn(ctx, id)
+}
+func (m *APRepoMock) HasSubmissions(ctx context.Context, start, end time.Time) (bool, error) {
+	return m.HasSubmissionsFn(ctx, start, end)
+}
 
 var _ apRepo.Repo = (*APRepoMock)(nil)
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	repositoryAuth "payslip-generation-system/internal/repository/auth"
+)
+
+type AuthRepoMock struct {
+	FindByEmailAndPasswordFn func(ctx context.Context, email, password string) (*model.User, error)
+	FindByEmailFn            func(ctx context.Context, email string) (*model.User, error)
+	CreateUserFn             func(ctx context.Context, user *model.User) error
+	FindByIDFn               func(ctx context.Context, id uint) (*model.User, error)
+	BumpTokenVersionFn       func(ctx context.Context, userID uint) error
+	UpdatePasswordFn         func(ctx context.Context, userID uint, passwordHash string) error
+	UpdateProfileFn          func(ctx context.Context, user *model.User) error
+	SetTOTPSecretFn          func(ctx context.Context, userID uint, secretEnc string) error
+	EnableTwoFactorFn        func(ctx context.Context, userID uint, step int64, at time.Time) error
+	DisableTwoFactorFn       func(ctx context.Context, userID uint) error
+	MarkTOTPStepUsedFn       func(ctx context.Context, userID uint, step int64) (bool, error)
+	FindByGoogleIDFn         func(ctx context.Context, googleID string) (*model.User, error)
+	SetGoogleIDFn            func(ctx context.Context, userID uint, googleID string) (bool, error)
+}
+
+func (m *AuthRepoMock) FindByEmailAndPassword(ctx context.Context, email, password string) (*model.User, error) {
+	return m.FindByEmailAndPasswordFn(ctx, email, password)
+}
+func (m *AuthRepoMock) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	return m.FindByEmailFn(ctx, email)
+}
+func (m *AuthRepoMock) CreateUser(ctx context.Context, user *model.User) error {
+	return m.CreateUserFn(ctx, user)
+}
+func (m *AuthRepoMock) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	return m.FindByIDFn(ctx, id)
+}
+func (m *AuthRepoMock) BumpTokenVersion(ctx context.Context, userID uint) error {
+	return m.BumpTokenVersionFn(ctx, userID)
+}
+func (m *AuthRepoMock) UpdatePassword(ctx context.Context, userID uint, passwordHash string) error {
+	return m.UpdatePasswordFn(ctx, userID, passwordHash)
+}
+func (m *AuthRepoMock) UpdateProfile(ctx context.Context, user *model.User) error {
+	return m.UpdateProfileFn(ctx, user)
+}
+func (m *AuthRepoMock) SetTOTPSecret(ctx context.Context, userID uint, secretEnc string) error {
+	return m.SetTOTPSecretFn(ctx, userID, secretEnc)
+}
+func (m *AuthRepoMock) EnableTwoFactor(ctx context.Context, userID uint, step int64, at time.Time) error {
+	return m.EnableTwoFactorFn(ctx, userID, step, at)
+}
+func (m *AuthRepoMock) DisableTwoFactor(ctx context.Context, userID uint) error {
+	return m.DisableTwoFactorFn(ctx, userID)
+}
+func (m *AuthRepoMock) MarkTOTPStepUsed(ctx context.Context, userID uint, step int64) (bool, error) {
+	return m.MarkTOTPStepUsedFn(ctx, userID, step)
+}
+func (m *AuthRepoMock) FindByGoogleID(ctx context.Context, googleID string) (*model.User, error) {
+	return m.FindByGoogleIDFn(ctx, googleID)
+}
+func (m *AuthRepoMock) SetGoogleID(ctx context.Context, userID uint, googleID string) (bool, error) {
+	return m.SetGoogleIDFn(ctx, userID, googleID)
+}
+
+var _ repositoryAuth.IAuthRepo = (*AuthRepoMock)(nil)
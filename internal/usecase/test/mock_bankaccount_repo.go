@@ -0,0 +1,39 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	bankAccountRepo "payslip-generation-system/internal/repository/bankaccount"
+)
+
+type BankAccountRepoMock struct {
+	GetFn          func(ctx context.Context, userID uint) (*model.EmployeeBankAccount, error)
+	UpsertFn       func(ctx context.Context, acc *model.EmployeeBankAccount) error
+	MarkVerifiedFn func(ctx context.Context, userID, by uint, at time.Time) (bool, error)
+	AddHistoryFn   func(ctx context.Context, h *model.EmployeeBankAccountHistory) error
+	ListHistoryFn  func(ctx context.Context, userID uint) ([]model.EmployeeBankAccountHistory, error)
+	ListByUsersFn  func(ctx context.Context, userIDs []uint) (map[uint]*model.EmployeeBankAccount, error)
+}
+
+func (m *BankAccountRepoMock) Get(ctx context.Context, userID uint) (*model.EmployeeBankAccount, error) {
+	return m.GetFn(ctx, userID)
+}
+func (m *BankAccountRepoMock) Upsert(ctx context.Context, acc *model.EmployeeBankAccount) error {
+	return m.UpsertFn(ctx, acc)
+}
+func (m *BankAccountRepoMock) MarkVerified(ctx context.Context, userID, by uint, at time.Time) (bool, error) {
+	return m.MarkVerifiedFn(ctx, userID, by, at)
+}
+func (m *BankAccountRepoMock) AddHistory(ctx context.Context, h *model.EmployeeBankAccountHistory) error {
+	return m.AddHistoryFn(ctx, h)
+}
+func (m *BankAccountRepoMock) ListHistory(ctx context.Context, userID uint) ([]model.EmployeeBankAccountHistory, error) {
+	return m.ListHistoryFn(ctx, userID)
+}
+func (m *BankAccountRepoMock) ListByUsers(ctx context.Context, userIDs []uint) (map[uint]*model.EmployeeBankAccount, error) {
+	return m.ListByUsersFn(ctx, userIDs)
+}
+
+var _ bankAccountRepo.Repo = (*BankAccountRepoMock)(nil)
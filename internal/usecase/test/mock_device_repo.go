@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	deviceRepo "payslip-generation-system/internal/repository/device"
+)
+
+type DeviceRepoMock struct {
+	CreateFn        func(ctx context.Context, d *model.Device) error
+	ListFn          func(ctx context.Context) ([]model.Device, error)
+	FindByIDFn      func(ctx context.Context, id uint) (*model.Device, error)
+	FindByKeyHashFn func(ctx context.Context, hash string) (*model.Device, error)
+	UpdateFn        func(ctx context.Context, d *model.Device) error
+	SetKeyFn        func(ctx context.Context, id uint, hash, prefix string) error
+	TouchFn         func(ctx context.Context, id uint, at time.Time) error
+	UpsertUserFn    func(ctx context.Context, m *model.DeviceUser) error
+	ListUsersFn     func(ctx context.Context, deviceID uint) ([]model.DeviceUser, error)
+	DeleteUserFn    func(ctx context.Context, deviceID uint, deviceUserID string) (bool, error)
+	HasPunchFn      func(ctx context.Context, deviceID uint, deviceUserID string, at time.Time) (bool, error)
+	CreatePunchFn   func(ctx context.Context, p *model.DevicePunch) (bool, error)
+}
+
+func (m *DeviceRepoMock) Create(ctx context.Context, d *model.Device) error {
+	return m.CreateFn(ctx, d)
+}
+
+func (m *DeviceRepoMock) List(ctx context.Context) ([]model.Device, error) {
+	return m.ListFn(ctx)
+}
+
+func (m *DeviceRepoMock) FindByID(ctx context.Context, id uint) (*model.Device, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *DeviceRepoMock) FindByKeyHash(ctx context.Context, hash string) (*model.Device, error) {
+	return m.FindByKeyHashFn(ctx, hash)
+}
+
+func (m *DeviceRepoMock) Update(ctx context.Context, d *model.Device) error {
+	return m.UpdateFn(ctx, d)
+}
+
+func (m *DeviceRepoMock) SetKey(ctx context.Context, id uint, hash, prefix string) error {
+	return m.SetKeyFn(ctx, id, hash, prefix)
+}
+
+func (m *DeviceRepoMock) Touch(ctx context.Context, id uint, at time.Time) error {
+	return m.TouchFn(ctx, id, at)
+}
+
+func (m *DeviceRepoMock) UpsertUser(ctx context.Context, mu *model.DeviceUser) error {
+	return m.UpsertUserFn(ctx, mu)
+}
+
+func (m *DeviceRepoMock) ListUsers(ctx context.Context, deviceID uint) ([]model.DeviceUser, error) {
+	return m.ListUsersFn(ctx, deviceID)
+}
+
+func (m *DeviceRepoMock) DeleteUser(ctx context.Context, deviceID uint, deviceUserID string) (bool, error) {
+	return m.DeleteUserFn(ctx, deviceID, deviceUserID)
+}
+
+func (m *DeviceRepoMock) HasPunch(ctx context.Context, deviceID uint, deviceUserID string, at time.Time) (bool, error) {
+	return m.HasPunchFn(ctx, deviceID, deviceUserID, at)
+}
+
+func (m *DeviceRepoMock) CreatePunch(ctx context.Context, p *model.DevicePunch) (bool, error) {
+	return m.CreatePunchFn(ctx, p)
+}
+
+var _ deviceRepo.Repo = (*DeviceRepoMock)(nil)
@@ -0,0 +1,22 @@
+package test
+
+import (
+	"context"
+
+	"payslip-generation-system/internal/model"
+	disbursementRepo "payslip-generation-system/internal/repository/disbursement"
+)
+
+type DisbursementRepoMock struct {
+	CreateFn   func(ctx context.Context, b *model.DisbursementBatch) error
+	GetByRunFn func(ctx context.Context, runID uint) (*model.DisbursementBatch, error)
+}
+
+func (m *DisbursementRepoMock) Create(ctx context.Context, b *model.DisbursementBatch) error {
+	return m.CreateFn(ctx, b)
+}
+func (m *DisbursementRepoMock) GetByRun(ctx context.Context, runID uint) (*model.DisbursementBatch, error) {
+	return m.GetByRunFn(ctx, runID)
+}
+
+var _ disbursementRepo.Repo = (*DisbursementRepoMock)(nil)
@@ -0,0 +1,26 @@
+package test
+
+import (
+	"context"
+
+	"payslip-generation-system/internal/model"
+	employeeRepo "payslip-generation-system/internal/repository/employee"
+)
+
+type EmployeeRepoMock struct {
+	ListFn     func(ctx context.Context, f employeeRepo.ListFilter) ([]model.User, int64, error)
+	FindByIDFn func(ctx context.Context, id uint) (*model.User, error)
+	UpdateFn   func(ctx context.Context, id uint, f employeeRepo.UpdateFields) error
+}
+
+func (m *EmployeeRepoMock) List(ctx context.Context, f employeeRepo.ListFilter) ([]model.User, int64, error) {
+	return m.ListFn(ctx, f)
+}
+func (m *EmployeeRepoMock) FindByID(ctx context.Context, id uint) (*model.User, error) {
+	return m.FindByIDFn(ctx, id)
+}
+func (m *EmployeeRepoMock) Update(ctx context.Context, id uint, f employeeRepo.UpdateFields) error {
+	return m.UpdateFn(ctx, id, f)
+}
+
+var _ employeeRepo.Repo = (*EmployeeRepoMock)(nil)
@@ -0,0 +1,19 @@
+package test
+
+import (
+	"context"
+
+	"payslip-generation-system/pkg/notify"
+)
+
+// NotifierMock records every message sent.
+type NotifierMock struct {
+	Sent []notify.Message
+}
+
+func (m *NotifierMock) Send(_ context.Context, msg notify.Message) error {
+	m.Sent = append(m.Sent, msg)
+	return nil
+}
+
+var _ notify.Notifier = (*NotifierMock)(nil)
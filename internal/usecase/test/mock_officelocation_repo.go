@@ -0,0 +1,38 @@
+package test
+
+import (
+	"context"
+
+	"payslip-generation-system/internal/model"
+	olRepo "payslip-generation-system/internal/repository/officelocation"
+)
+
+type OfficeLocationRepoMock struct {
+	CreateFn   func(ctx context.Context, o *model.OfficeLocation) error
+	ListFn     func(ctx context.Context, activeOnly bool) ([]model.OfficeLocation, error)
+	FindByIDFn func(ctx context.Context, id uint) (*model.OfficeLocation, error)
+	UpdateFn   func(ctx context.Context, o *model.OfficeLocation) error
+	DeleteFn   func(ctx context.Context, id uint) error
+}
+
+func (m *OfficeLocationRepoMock) Create(ctx context.Context, o *model.OfficeLocation) error {
+	return m.CreateFn(ctx, o)
+}
+
+func (m *OfficeLocationRepoMock) List(ctx context.Context, activeOnly bool) ([]model.OfficeLocation, error) {
+	return m.ListFn(ctx, activeOnly)
+}
+
+func (m *OfficeLocationRepoMock) FindByID(ctx context.Context, id uint) (*model.OfficeLocation, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *OfficeLocationRepoMock) Update(ctx context.Context, o *model.OfficeLocation) error {
+	return m.UpdateFn(ctx, o)
+}
+
+func (m *OfficeLocationRepoMock) Delete(ctx context.Context, id uint) error {
+	return m.DeleteFn(ctx, id)
+}
+
+var _ olRepo.Repo = (*OfficeLocationRepoMock)(nil)
@@ -0,0 +1,23 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	oidcStateRepo "payslip-generation-system/internal/repository/oidcstate"
+)
+
+type OIDCStateRepoMock struct {
+	CreateFn  func(ctx context.Context, s *model.OIDCLoginState) error
+	ConsumeFn func(ctx context.Context, stateHash string, at time.Time) (*model.OIDCLoginState, error)
+}
+
+func (m *OIDCStateRepoMock) Create(ctx context.Context, s *model.OIDCLoginState) error {
+	return m.CreateFn(ctx, s)
+}
+func (m *OIDCStateRepoMock) Consume(ctx context.Context, stateHash string, at time.Time) (*model.OIDCLoginState, error) {
+	return m.ConsumeFn(ctx, stateHash, at)
+}
+
+var _ oidcStateRepo.Repo = (*OIDCStateRepoMock)(nil)
@@ -10,10 +10,60 @@ import (
 
 type OTRepoMock struct {
 	CreateIfNotExistsFn func(ctx context.Context, userID uint, date time.Time, hours float64) (*model.Overtime, bool, error)
+	ExistsFn            func(ctx context.Context, userID uint, date time.Time) (bool, error)
+	SetSubmitterFn      func(ctx context.Context, id, adminID uint, reason string) error
+	ListByDateRangeFn   func(ctx context.Context, start, end time.Time) ([]model.Overtime, error)
+	CreateBatchFn       func(ctx context.Context, rows []*model.Overtime, batchSize int) error
+	ListByUserFn        func(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error)
+	FindByIDFn          func(ctx context.Context, id uint) (*model.Overtime, error)
+	UpdateHoursFn       func(ctx context.Context, id uint, hours float64) error
+	DeleteFn            func(ctx context.Context, id uint) error
+	SetPlanMatchFn      func(ctx context.Context, id uint, planID *uint, flagReason string) error
+	ListFlaggedFn       func(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error)
 }
 
 func (m *OTRepoMock) CreateIfNotExists(ctx context.Context, userID uint, date time.Time, hours float64) (*model.Overtime, bool, error) {
 	return m.CreateIfNotExistsFn(ctx, userID, date, hours)
 }
 
+func (m *OTRepoMock) Exists(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	return m.ExistsFn(ctx, userID, date)
+}
+
+func (m *OTRepoMock) SetSubmitter(ctx context.Context, id, adminID uint, reason string) error {
+	return m.SetSubmitterFn(ctx, id, adminID, reason)
+}
+
+func (m *OTRepoMock) ListByDateRange(ctx context.Context, start, end time.Time) ([]model.Overtime, error) {
+	return m.ListByDateRangeFn(ctx, start, end)
+}
+
+func (m *OTRepoMock) CreateBatch(ctx context.Context, rows []*model.Overtime, batchSize int) error {
+	return m.CreateBatchFn(ctx, rows, batchSize)
+}
+
+func (m *OTRepoMock) ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error) {
+	return m.ListByUserFn(ctx, userID, start, end, offset, limit)
+}
+
+func (m *OTRepoMock) FindByID(ctx context.Context, id uint) (*model.Overtime, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *OTRepoMock) UpdateHours(ctx context.Context, id uint, hours float64) error {
+	return m.UpdateHoursFn(ctx, id, hours)
+}
+
+func (m *OTRepoMock) Delete(ctx context.Context, id uint) error {
+	return m.DeleteFn(ctx, id)
+}
+
+func (m *OTRepoMock) SetPlanMatch(ctx context.Context, id uint, planID *uint, flagReason string) error {
+	return m.SetPlanMatchFn(ctx, id, planID, flagReason)
+}
+
+func (m *OTRepoMock) ListFlagged(ctx context.Context, start, end time.Time, offset, limit int) ([]model.Overtime, int64, error) {
+	return m.ListFlaggedFn(ctx, start, end, offset, limit)
+}
+
 var _ otRepo.Repo = (*OTRepoMock)(nil)
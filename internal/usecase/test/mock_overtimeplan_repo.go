@@ -0,0 +1,44 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	opRepo "payslip-generation-system/internal/repository/overtimeplan"
+)
+
+type OvertimePlanRepoMock struct {
+	CreateFn       func(ctx context.Context, p *model.OvertimePlan) error
+	FindByIDFn     func(ctx context.Context, id uint) (*model.OvertimePlan, error)
+	ListFn         func(ctx context.Context, f opRepo.ListFilter) ([]model.OvertimePlan, int64, error)
+	HasActiveFn    func(ctx context.Context, userID uint, date time.Time) (bool, error)
+	FindApprovedFn func(ctx context.Context, userID uint, date time.Time) (*model.OvertimePlan, error)
+	DecideFn       func(ctx context.Context, id uint, d opRepo.Decision) (bool, error)
+}
+
+func (m *OvertimePlanRepoMock) Create(ctx context.Context, p *model.OvertimePlan) error {
+	return m.CreateFn(ctx, p)
+}
+
+func (m *OvertimePlanRepoMock) FindByID(ctx context.Context, id uint) (*model.OvertimePlan, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *OvertimePlanRepoMock) List(ctx context.Context, f opRepo.ListFilter) ([]model.OvertimePlan, int64, error) {
+	return m.ListFn(ctx, f)
+}
+
+func (m *OvertimePlanRepoMock) HasActive(ctx context.Context, userID uint, date time.Time) (bool, error) {
+	return m.HasActiveFn(ctx, userID, date)
+}
+
+func (m *OvertimePlanRepoMock) FindApproved(ctx context.Context, userID uint, date time.Time) (*model.OvertimePlan, error) {
+	return m.FindApprovedFn(ctx, userID, date)
+}
+
+func (m *OvertimePlanRepoMock) Decide(ctx context.Context, id uint, d opRepo.Decision) (bool, error) {
+	return m.DecideFn(ctx, id, d)
+}
+
+var _ opRepo.Repo = (*OvertimePlanRepoMock)(nil)
@@ -0,0 +1,31 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	prRepo "payslip-generation-system/internal/repository/passwordreset"
+)
+
+type PasswordResetRepoMock struct {
+	CreateFn            func(ctx context.Context, t *model.PasswordResetToken) error
+	GetByHashFn         func(ctx context.Context, hash string) (*model.PasswordResetToken, error)
+	MarkUsedFn          func(ctx context.Context, id uint, at time.Time) (bool, error)
+	InvalidateForUserFn func(ctx context.Context, userID uint, at time.Time) error
+}
+
+func (m *PasswordResetRepoMock) Create(ctx context.Context, t *model.PasswordResetToken) error {
+	return m.CreateFn(ctx, t)
+}
+func (m *PasswordResetRepoMock) GetByHash(ctx context.Context, hash string) (*model.PasswordResetToken, error) {
+	return m.GetByHashFn(ctx, hash)
+}
+func (m *PasswordResetRepoMock) MarkUsed(ctx context.Context, id uint, at time.Time) (bool, error) {
+	return m.MarkUsedFn(ctx, id, at)
+}
+func (m *PasswordResetRepoMock) InvalidateForUser(ctx context.Context, userID uint, at time.Time) error {
+	return m.InvalidateForUserFn(ctx, userID, at)
+}
+
+var _ prRepo.Repo = (*PasswordResetRepoMock)(nil)
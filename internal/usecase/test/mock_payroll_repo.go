@@ -15,15 +15,31 @@ type PayRepoMock struct {
 	GetPeriodByIDFn   func(ctx context.Context, id uint) (*model.AttendancePeriod, error)
 	GetRunByPeriodFn  func(ctx context.Context, periodID uint) (*model.PayrollRun, error)
 
+	// lifecycle
+	ReplaceRunItemsFn func(ctx context.Context, run *model.PayrollRun, items []*model.PayrollItem) (bool, error)
+	MarkRunStaleFn    func(ctx context.Context, periodID uint) (bool, error)
+	UpdateRunStatusFn func(ctx context.Context, runID uint, from, to string, at time.Time) (bool, error)
+	AddRunEventFn     func(ctx context.Context, ev *model.PayrollRunEvent) error
+	ListRunEventsFn   func(ctx context.Context, runID uint) ([]model.PayrollRunEvent, error)
+
+	// approval
+	LockRunFn            func(ctx context.Context, runID uint) (*model.PayrollRun, error)
+	StartApprovalRoundFn func(ctx context.Context, runID uint, round int, at time.Time) (bool, error)
+	AddRunApprovalFn     func(ctx context.Context, a *model.PayrollRunApproval) error
+	ListRunApprovalsFn   func(ctx context.Context, runID uint, round int) ([]model.PayrollRunApproval, error)
+
 	// aggs & salary
 	GetAttendanceDaysByUserFn func(ctx context.Context, start, end time.Time) (map[uint]int, error)
 	GetOvertimeHoursByUserFn  func(ctx context.Context, start, end time.Time) (map[uint]float64, error)
 	GetReimbTotalByUserFn     func(ctx context.Context, start, end time.Time) (map[uint]float64, error)
+	ListTimedAttendancesFn    func(ctx context.Context, userID uint, start, end time.Time) ([]model.Attendance, error)
 	GetUserSalariesFn         func(ctx context.Context) (map[uint]float64, error)
 	GetUserSalaryFn           func(ctx context.Context, userID uint) (float64, error)
 
 	// per-user
 	GetPayrollItemByUserFn      func(ctx context.Context, runID uint, userID uint) (*model.PayrollItem, error)
+	ListItemsByRunFn            func(ctx context.Context, runID uint) ([]model.PayrollItem, error)
+	UpdateItemPaymentFn         func(ctx context.Context, item *model.PayrollItem, fromStatus string) (bool, error)
 	GetAttendanceDaysForUserFn  func(ctx context.Context, userID uint, start, end time.Time) (int, error)
 	GetOvertimeHoursForUserFn   func(ctx context.Context, userID uint, start, end time.Time) (float64, error)
 	ListReimbursementsForUserFn func(ctx context.Context, userID uint, start, end time.Time) ([]model.Reimbursement, error)
@@ -38,6 +54,33 @@ func (m *PayRepoMock) HasRunForPeriod(ctx context.Context, periodID uint) (bool,
 func (m *PayRepoMock) CreateRun(ctx context.Context, run *model.PayrollRun, items []*model.PayrollItem) error {
 	return m.CreateRunFn(ctx, run, items)
 }
+func (m *PayRepoMock) ReplaceRunItems(ctx context.Context, run *model.PayrollRun, items []*model.PayrollItem) (bool, error) {
+	return m.ReplaceRunItemsFn(ctx, run, items)
+}
+func (m *PayRepoMock) MarkRunStale(ctx context.Context, periodID uint) (bool, error) {
+	return m.MarkRunStaleFn(ctx, periodID)
+}
+func (m *PayRepoMock) UpdateRunStatus(ctx context.Context, runID uint, from, to string, at time.Time) (bool, error) {
+	return m.UpdateRunStatusFn(ctx, runID, from, to, at)
+}
+func (m *PayRepoMock) AddRunEvent(ctx context.Context, ev *model.PayrollRunEvent) error {
+	return m.AddRunEventFn(ctx, ev)
+}
+func (m *PayRepoMock) ListRunEvents(ctx context.Context, runID uint) ([]model.PayrollRunEvent, error) {
+	return m.ListRunEventsFn(ctx, runID)
+}
+func (m *PayRepoMock) LockRun(ctx context.Context, runID uint) (*model.PayrollRun, error) {
+	return m.LockRunFn(ctx, runID)
+}
+func (m *PayRepoMock) StartApprovalRound(ctx context.Context, runID uint, round int, at time.Time) (bool, error) {
+	return m.StartApprovalRoundFn(ctx, runID, round, at)
+}
+func (m *PayRepoMock) AddRunApproval(ctx context.Context, a *model.PayrollRunApproval) error {
+	return m.AddRunApprovalFn(ctx, a)
+}
+func (m *PayRepoMock) ListRunApprovals(ctx context.Context, runID uint, round int) ([]model.PayrollRunApproval, error) {
+	return m.ListRunApprovalsFn(ctx, runID, round)
+}
 func (m *PayRepoMock) GetWorkingWeekdays(ctx context.Context, start, end time.Time) (int, error) {
 	// tidak digunakan; usecase hitung sendiri
 	return 0, nil
@@ -45,6 +88,9 @@ func (m *PayRepoMock) GetWorkingWeekdays(ctx context.Context, start, end time.Ti
 func (m *PayRepoMock) GetAttendanceDaysByUser(ctx context.Context, start, end time.Time) (map[uint]int, error) {
 	return m.GetAttendanceDaysByUserFn(ctx, start, end)
 }
+func (m *PayRepoMock) ListTimedAttendances(ctx context.Context, userID uint, start, end time.Time) ([]model.Attendance, error) {
+	return m.ListTimedAttendancesFn(ctx, userID, start, end)
+}
 func (m *PayRepoMock) GetOvertimeHoursByUser(ctx context.Context, start, end time.Time) (map[uint]float64, error) {
 	return m.GetOvertimeHoursByUserFn(ctx, start, end)
 }
@@ -66,6 +112,12 @@ func (m *PayRepoMock) GetRunByPeriod(ctx context.Context, periodID uint) (*model
 func (m *PayRepoMock) GetPayrollItemByUser(ctx context.Context, runID uint, userID uint) (*model.PayrollItem, error) {
 	return m.GetPayrollItemByUserFn(ctx, runID, userID)
 }
+func (m *PayRepoMock) ListItemsByRun(ctx context.Context, runID uint) ([]model.PayrollItem, error) {
+	return m.ListItemsByRunFn(ctx, runID)
+}
+func (m *PayRepoMock) UpdateItemPayment(ctx context.Context, item *model.PayrollItem, fromStatus string) (bool, error) {
+	return m.UpdateItemPaymentFn(ctx, item, fromStatus)
+}
 func (m *PayRepoMock) GetUserSalary(ctx context.Context, userID uint) (float64, error) {
 	return m.GetUserSalaryFn(ctx, userID)
 }
@@ -2,17 +2,43 @@ package test
 
 import (
 	"context"
+	"time"
 
 	"payslip-generation-system/internal/model"
 	rbRepo "payslip-generation-system/internal/repository/reimbursement"
 )
 
 type RBRepoMock struct {
-	CreateFn func(ctx context.Context, r *model.Reimbursement) error
+	CreateFn      func(ctx context.Context, r *model.Reimbursement) error
+	CreateBatchFn func(ctx context.Context, rows []*model.Reimbursement, batchSize int) error
+	ListByUserFn  func(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Reimbursement, int64, error)
+	FindByIDFn    func(ctx context.Context, id uint) (*model.Reimbursement, error)
+	UpdateFn      func(ctx context.Context, r *model.Reimbursement) error
+	DeleteFn      func(ctx context.Context, id uint) error
 }
 
 func (m *RBRepoMock) Create(ctx context.Context, r *model.Reimbursement) error {
 	return m.CreateFn(ctx, r)
 }
 
+func (m *RBRepoMock) CreateBatch(ctx context.Context, rows []*model.Reimbursement, batchSize int) error {
+	return m.CreateBatchFn(ctx, rows, batchSize)
+}
+
+func (m *RBRepoMock) ListByUser(ctx context.Context, userID uint, start, end time.Time, offset, limit int) ([]model.Reimbursement, int64, error) {
+	return m.ListByUserFn(ctx, userID, start, end, offset, limit)
+}
+
+func (m *RBRepoMock) FindByID(ctx context.Context, id uint) (*model.Reimbursement, error) {
+	return m.FindByIDFn(ctx, id)
+}
+
+func (m *RBRepoMock) Update(ctx context.Context, r *model.Reimbursement) error {
+	return m.UpdateFn(ctx, r)
+}
+
+func (m *RBRepoMock) Delete(ctx context.Context, id uint) error {
+	return m.DeleteFn(ctx, id)
+}
+
 var _ rbRepo.Repo = (*RBRepoMock)(nil)
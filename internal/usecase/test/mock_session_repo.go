@@ -0,0 +1,47 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	"payslip-generation-system/internal/model"
+	sessionRepo "payslip-generation-system/internal/repository/session"
+)
+
+type SessionRepoMock struct {
+	CreateSessionFn           func(ctx context.Context, s *model.AuthSession) error
+	GetSessionFn              func(ctx context.Context, id uint) (*model.AuthSession, error)
+	TouchSessionFn            func(ctx context.Context, id uint, at time.Time) error
+	RevokeSessionFn           func(ctx context.Context, id uint, reason string, at time.Time) error
+	RevokeAllForUserFn        func(ctx context.Context, userID uint, reason string, at time.Time) error
+	CreateRefreshTokenFn      func(ctx context.Context, t *model.RefreshToken) error
+	GetRefreshTokenByHashFn   func(ctx context.Context, hash string) (*model.RefreshToken, error)
+	MarkRefreshTokenRotatedFn func(ctx context.Context, id uint, at time.Time) (bool, error)
+}
+
+func (m *SessionRepoMock) CreateSession(ctx context.Context, s *model.AuthSession) error {
+	return m.CreateSessionFn(ctx, s)
+}
+func (m *SessionRepoMock) GetSession(ctx context.Context, id uint) (*model.AuthSession, error) {
+	return m.GetSessionFn(ctx, id)
+}
+func (m *SessionRepoMock) TouchSession(ctx context.Context, id uint, at time.Time) error {
+	return m.TouchSessionFn(ctx, id, at)
+}
+func (m *SessionRepoMock) RevokeSession(ctx context.Context, id uint, reason string, at time.Time) error {
+	return m.RevokeSessionFn(ctx, id, reason, at)
+}
+func (m *SessionRepoMock) RevokeAllForUser(ctx context.Context, userID uint, reason string, at time.Time) error {
+	return m.RevokeAllForUserFn(ctx, userID, reason, at)
+}
+func (m *SessionRepoMock) CreateRefreshToken(ctx context.Context, t *model.RefreshToken) error {
+	return m.CreateRefreshTokenFn(ctx, t)
+}
+func (m *SessionRepoMock) GetRefreshTokenByHash(ctx context.Context, hash string) (*model.RefreshToken, error) {
+	return m.GetRefreshTokenByHashFn(ctx, hash)
+}
+func (m *SessionRepoMock) MarkRefreshTokenRotated(ctx context.Context, id uint, at time.Time) (bool, error) {
+	return m.MarkRefreshTokenRotatedFn(ctx, id, at)
+}
+
+var _ sessionRepo.Repo = (*SessionRepoMock)(nil)
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"payslip-generation-system/pkg/storage"
+)
+
+const storageMockURL = "https://files.test/"
+
+// StorageMock keeps uploaded objects in memory.
+type StorageMock struct {
+	Objects map[string][]byte
+	Deleted []string
+}
+
+func NewStorageMock() *StorageMock {
+	return &StorageMock{Objects: map[string][]byte{}}
+}
+
+func (m *StorageMock) Put(_ context.Context, key string, r io.Reader, _ string) (string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	m.Objects[key] = b
+	return storageMockURL + key, nil
+}
+
+func (m *StorageMock) Delete(_ context.Context, key string) error {
+	delete(m.Objects, key)
+	m.Deleted = append(m.Deleted, key)
+	return nil
+}
+
+func (m *StorageMock) KeyFromURL(url string) (string, bool) {
+	if !strings.HasPrefix(url, storageMockURL) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, storageMockURL), true
+}
+
+var _ storage.Storage = (*StorageMock)(nil)
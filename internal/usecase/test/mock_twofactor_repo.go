@@ -0,0 +1,26 @@
+package test
+
+import (
+	"context"
+	"time"
+
+	tfRepo "payslip-generation-system/internal/repository/twofactor"
+)
+
+type TwoFactorRepoMock struct {
+	ReplaceRecoveryCodesFn func(ctx context.Context, userID uint, hashes []string) error
+	UseRecoveryCodeFn      func(ctx context.Context, userID uint, hash string, at time.Time) (bool, error)
+	DeleteRecoveryCodesFn  func(ctx context.Context, userID uint) error
+}
+
+func (m *TwoFactorRepoMock) ReplaceRecoveryCodes(ctx context.Context, userID uint, hashes []string) error {
+	return m.ReplaceRecoveryCodesFn(ctx, userID, hashes)
+}
+func (m *TwoFactorRepoMock) UseRecoveryCode(ctx context.Context, userID uint, hash string, at time.Time) (bool, error) {
+	return m.UseRecoveryCodeFn(ctx, userID, hash, at)
+}
+func (m *TwoFactorRepoMock) DeleteRecoveryCodes(ctx context.Context, userID uint) error {
+	return m.DeleteRecoveryCodesFn(ctx, userID)
+}
+
+var _ tfRepo.Repo = (*TwoFactorRepoMock)(nil)
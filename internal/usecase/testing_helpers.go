@@ -1,12 +1,34 @@
 package usecase
 
 import (
+	"time"
+
+	"payslip-generation-system/config"
+	adjRepo "payslip-generation-system/internal/repository/adjustment"
 	atRepo "payslip-generation-system/internal/repository/attendance"
+	acRepo "payslip-generation-system/internal/repository/attendancecorrection"
 	apRepo "payslip-generation-system/internal/repository/attendanceperiod"
+	repositoryAuth "payslip-generation-system/internal/repository/auth"
+	bankAccountRepo "payslip-generation-system/internal/repository/bankaccount"
+	deviceRepo "payslip-generation-system/internal/repository/device"
+	disbursementRepo "payslip-generation-system/internal/repository/disbursement"
+	employeeRepo "payslip-generation-system/internal/repository/employee"
+	loginAttemptRepo "payslip-generation-system/internal/repository/loginattempt"
+	officeLocationRepo "payslip-generation-system/internal/repository/officelocation"
+	oidcStateRepo "payslip-generation-system/internal/repository/oidcstate"
 	otRepo "payslip-generation-system/internal/repository/overtime"
+	opRepo "payslip-generation-system/internal/repository/overtimeplan"
+	prRepo "payslip-generation-system/internal/repository/passwordreset"
 	payRepo "payslip-generation-system/internal/repository/payroll"
 	rbRepo "payslip-generation-system/internal/repository/reimbursement"
+	sessionRepo "payslip-generation-system/internal/repository/session"
+	tfRepo "payslip-generation-system/internal/repository/twofactor"
 	repoTx "payslip-generation-system/internal/repository/tx"
+	"payslip-generation-system/pkg/fieldcrypt"
+	"payslip-generation-system/pkg/jwtkey"
+	"payslip-generation-system/pkg/notify"
+	"payslip-generation-system/pkg/oidc"
+	"payslip-generation-system/pkg/storage"
 )
 
 // NewForTest creates a blank usecase instance for unit tests.
@@ -30,3 +52,127 @@ func InjectForTest(target IUsecase,
 		u.txManager = tx
 	}
 }
+
+// InjectAuthForTest wires auth-related mock dependencies into a test instance created by NewForTest.
+func InjectAuthForTest(target IUsecase,
+	keys *jwtkey.Manager,
+	auth repositoryAuth.IAuthRepo,
+	sess sessionRepo.Repo,
+	tx repoTx.TxManager,
+) {
+	if u, ok := target.(*usecase); ok {
+		u.keys = keys
+		u.authRepo = auth
+		u.sessionRepo = sess
+		u.txManager = tx
+	}
+}
+
+// InjectClockForTest replaces the clock used for check-in/check-out.
+func InjectClockForTest(target IUsecase, now func() time.Time) {
+	if u, ok := target.(*usecase); ok {
+		u.now = now
+	}
+}
+
+// InjectConfigForTest sets the config used by a test instance (defaults apply when not set).
+func InjectConfigForTest(target IUsecase, cfg *config.Config) {
+	if u, ok := target.(*usecase); ok {
+		u.cfg = cfg
+	}
+}
+
+// InjectPasswordForTest wires password-reset mocks into a test instance created by NewForTest.
+func InjectPasswordForTest(target IUsecase, reset prRepo.Repo, notifier notify.Notifier) {
+	if u, ok := target.(*usecase); ok {
+		u.resetRepo = reset
+		u.notifier = notifier
+	}
+}
+
+// InjectLockoutForTest sets the login attempt store (e.g. loginattempt.NewMemory()).
+func InjectLockoutForTest(target IUsecase, attempts loginAttemptRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.attemptRepo = attempts
+	}
+}
+
+// InjectTwoFactorForTest sets the recovery code repo and the field cipher used for the TOTP secret.
+func InjectTwoFactorForTest(target IUsecase, twoFactor tfRepo.Repo, cipher *fieldcrypt.Cipher) {
+	if u, ok := target.(*usecase); ok {
+		u.twoFactorRepo = twoFactor
+		u.fieldCipher = cipher
+	}
+}
+
+// InjectOIDCForTest sets the SSO provider registry and login state repo.
+func InjectOIDCForTest(target IUsecase, registry *oidc.Registry, states oidcStateRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.oidc = registry
+		u.oidcStateRepo = states
+	}
+}
+
+// InjectStorageForTest sets the file storage used for uploads.
+func InjectStorageForTest(target IUsecase, store storage.Storage) {
+	if u, ok := target.(*usecase); ok {
+		u.storage = store
+	}
+}
+
+// InjectEmployeeForTest sets the employee directory repo.
+func InjectEmployeeForTest(target IUsecase, employees employeeRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.employeeRepo = employees
+	}
+}
+
+// InjectBankAccountForTest sets the bank account repo and the field cipher used to encrypt it.
+func InjectBankAccountForTest(target IUsecase, accounts bankAccountRepo.Repo, cipher *fieldcrypt.Cipher) {
+	if u, ok := target.(*usecase); ok {
+		u.bankAccountRepo = accounts
+		u.fieldCipher = cipher
+	}
+}
+
+// InjectDisbursementForTest sets the disbursement batch repo.
+func InjectDisbursementForTest(target IUsecase, batches disbursementRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.disbursementRepo = batches
+	}
+}
+
+// InjectOfficeLocationForTest sets the office location repo used by the attendance geofence.
+func InjectOfficeLocationForTest(target IUsecase, offices officeLocationRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.officeLocRepo = offices
+	}
+}
+
+// InjectCorrectionForTest sets the attendance correction request repo.
+func InjectCorrectionForTest(target IUsecase, corrections acRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.correctionRepo = corrections
+	}
+}
+
+// InjectAdjustmentForTest sets the prior period adjustment repo (RunPayroll & GeneratePayslip read it).
+func InjectAdjustmentForTest(target IUsecase, adjustments adjRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.adjustmentRepo = adjustments
+	}
+}
+
+// InjectDeviceForTest sets the biometric device repo (registry, user mapping & punch log).
+func InjectDeviceForTest(target IUsecase, devices deviceRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.deviceRepo = devices
+	}
+}
+
+// InjectOvertimePlanForTest sets the overtime plan repo used to match overtime submissions.
+func InjectOvertimePlanForTest(target IUsecase, plans opRepo.Repo) {
+	if u, ok := target.(*usecase); ok {
+		u.planRepo = plans
+	}
+}
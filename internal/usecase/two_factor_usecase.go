@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/crypto/bcrypt"
+
+	"payslip-generation-system/config"
+	authDTO "payslip-generation-system/internal/dto/auth"
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/pkg/jwtkey"
+	"payslip-generation-system/pkg/log"
+	"payslip-generation-system/pkg/totp"
+	"payslip-generation-system/utils"
+)
+
+const (
+	tokenTypeChallenge = "2fa_challenge"
+
+	defaultTwoFactorIssuer = "Payslip"
+	defaultChallengeTTL    = 5 * time.Minute
+
+	totpSkew           = 1 // toleransi +/- 30 detik
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	recoveryAlphabet   = "abcdefghjkmnpqrstuvwxyz23456789" // tanpa i l o 0 1 supaya tidak tertukar
+)
+
+// aad mengikat ciphertext secret ke user-nya; secret yang disalin ke user lain gagal didekripsi.
+func twoFactorAAD(userID uint) string {
+	return fmt.Sprintf("two_factor:%d:totp_secret", userID)
+}
+
+func (u *usecase) twoFactorConfig() config.TwoFactorConfig {
+	var c config.TwoFactorConfig
+	if u.cfg != nil {
+		c = u.cfg.Auth.TwoFactor
+	}
+	if c.Issuer == "" {
+		c.Issuer = defaultTwoFactorIssuer
+	}
+	if c.ChallengeTTL <= 0 {
+		c.ChallengeTTL = defaultChallengeTTL
+	}
+	return c
+}
+
+// TwoFactorSetupRequired: admin wajib 2FA (config) tapi belum mengaktifkannya.
+func (u *usecase) TwoFactorSetupRequired(user *model.User) bool {
+	return u.twoFactorConfig().RequiredForAdmin && user.Role == "admin" && !user.TwoFactorEnabled
+}
+
+// StartTwoFactorChallenge dipanggil setelah password benar untuk user dengan 2FA aktif.
+// Challenge token berumur pendek dan hanya bisa ditukar lewat VerifyTwoFactor.
+func (u *usecase) StartTwoFactorChallenge(ctx *gin.Context, user *model.User, deviceID string) (string, error) {
+	return u.startTwoFactorChallenge(user, deviceID, amrPassword)
+}
+
+// startTwoFactorChallenge: firstFactor (pwd / oidc) ikut ke amr session setelah verifikasi.
+func (u *usecase) startTwoFactorChallenge(user *model.User, deviceID, firstFactor string) (string, error) {
+	now := time.Now()
+	token, err := u.keys.Sign(jwt.MapClaims{
+		"typ":       tokenTypeChallenge,
+		"user_id":   user.ID,
+		"ver":       user.TokenVersion,
+		"device_id": deviceID,
+		"amr":       firstFactor,
+		"exp":       now.Add(u.twoFactorConfig().ChallengeTTL).Unix(),
+		"iat":       now.Unix(),
+	})
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to sign 2fa challenge"})
+		return "", utils.MakeError(errorUc.InternalServerError, "failed to start two-factor challenge")
+	}
+	return token, nil
+}
+
+// VerifyTwoFactor menukar challenge token + kode (TOTP atau recovery code) dengan session baru.
+func (u *usecase) VerifyTwoFactor(ctx *gin.Context, challengeToken, code string) (*authDTO.TokenResponse, error) {
+	claims, err := u.keys.Parse(challengeToken)
+	if err != nil || claims["typ"] != tokenTypeChallenge {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	userID := jwtkey.UintClaim(claims, "user_id")
+	deviceID, _ := claims["device_id"].(string)
+
+	user, err := u.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load user for 2fa"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil || !user.TwoFactorEnabled || user.TokenVersion != int(jwtkey.UintClaim(claims, "ver")) {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+
+	if err := u.checkSecondFactor(ctx, user, code); err != nil {
+		return nil, err
+	}
+	firstFactor, _ := claims["amr"].(string)
+	if firstFactor == "" {
+		firstFactor = amrPassword
+	}
+	return u.startSession(ctx, user, deviceID, []string{firstFactor, amrOTP})
+}
+
+// EnrollTwoFactor membuat secret baru (belum aktif) dan otpauth URI untuk QR code.
+func (u *usecase) EnrollTwoFactor(ctx *gin.Context, userID uint) (*authDTO.TwoFactorEnrollResponse, error) {
+	user, err := u.loadUserForTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, utils.MakeError(errorUc.ConflictError, "two-factor authentication already enabled")
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate totp secret"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to generate secret")
+	}
+	secretEnc, err := u.fieldCipher.Encrypt(secret, twoFactorAAD(userID))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to encrypt totp secret"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to generate secret")
+	}
+	if err := u.authRepo.SetTOTPSecret(ctx, userID, secretEnc); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store totp secret"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	return &authDTO.TwoFactorEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(u.twoFactorConfig().Issuer, user.Email, secret),
+	}, nil
+}
+
+// ActivateTwoFactor mengaktifkan 2FA setelah user membuktikan authenticator sudah tersetting.
+func (u *usecase) ActivateTwoFactor(ctx *gin.Context, userID uint, code string) (resp *authDTO.RecoveryCodesResponse, err error) {
+	user, err := u.loadUserForTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TwoFactorEnabled {
+		return nil, utils.MakeError(errorUc.ConflictError, "two-factor authentication already enabled")
+	}
+	if user.TOTPSecretEnc == "" {
+		return nil, utils.MakeError(errorUc.BadRequest, "enroll two-factor authentication first")
+	}
+	secret, err := u.totpSecret(user)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+	if !ok {
+		return nil, utils.MakeError(errorUc.InvalidOTP)
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate recovery codes"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to generate recovery codes")
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			resp, err = nil, utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	if err = u.authRepo.EnableTwoFactor(txCtx, userID, step, time.Now().UTC()); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to enable 2fa"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if err = u.twoFactorRepo.ReplaceRecoveryCodes(txCtx, userID, hashes); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store recovery codes"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	u.log.Info(log.LogData{Description: "two-factor authentication enabled", Response: userID})
+	return &authDTO.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// RegenerateRecoveryCodes mengganti semua recovery code (yang lama tidak berlaku lagi).
+func (u *usecase) RegenerateRecoveryCodes(ctx *gin.Context, userID uint, code string) (*authDTO.RecoveryCodesResponse, error) {
+	user, err := u.loadUserForTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.TwoFactorEnabled {
+		return nil, utils.MakeError(errorUc.BadRequest, "two-factor authentication is not enabled")
+	}
+	if err := u.checkSecondFactor(ctx, user, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to generate recovery codes"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "failed to generate recovery codes")
+	}
+	if err := u.twoFactorRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to store recovery codes"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	return &authDTO.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// DisableTwoFactor butuh password dan kode; admin tidak bisa mematikan 2FA kalau diwajibkan config.
+func (u *usecase) DisableTwoFactor(ctx *gin.Context, userID uint, password, code string) (err error) {
+	user, err := u.loadUserForTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !user.TwoFactorEnabled {
+		return utils.MakeError(errorUc.BadRequest, "two-factor authentication is not enabled")
+	}
+	if u.twoFactorConfig().RequiredForAdmin && user.Role == "admin" {
+		return utils.MakeError(errorUc.ErrForbidden, "two-factor authentication is mandatory for admin")
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return utils.MakeError(errorUc.BadRequest, "current password is incorrect")
+	}
+	if err := u.checkSecondFactor(ctx, user, code); err != nil {
+		return err
+	}
+
+	txCtx, err := u.txManager.Begin(ctx)
+	if err != nil {
+		return utils.MakeError(errorUc.InternalServerError, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			_ = u.txManager.Rollback(txCtx)
+		} else if cmErr := u.txManager.Commit(txCtx); cmErr != nil {
+			_ = u.txManager.Rollback(txCtx)
+			err = utils.MakeError(errorUc.InternalServerError, "failed to commit transaction")
+		}
+	}()
+
+	if err = u.authRepo.DisableTwoFactor(txCtx, userID); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to disable 2fa"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if err = u.twoFactorRepo.DeleteRecoveryCodes(txCtx, userID); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to delete recovery codes"})
+		return utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+
+	u.log.Info(log.LogData{Description: "two-factor authentication disabled", Response: userID})
+	return nil
+}
+
+func (u *usecase) loadUserForTwoFactor(ctx *gin.Context, userID uint) (*model.User, error) {
+	user, err := u.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to load user"})
+		return nil, utils.MakeError(errorUc.InternalServerError, "db error")
+	}
+	if user == nil {
+		return nil, utils.MakeError(errorUc.ErrUnauthorized)
+	}
+	return user, nil
+}
+
+// totpSecret mendekripsi secret TOTP milik user.
+func (u *usecase) totpSecret(user *model.User) (string, error) {
+	secret, err := u.fieldCipher.Decrypt(user.TOTPSecretEnc, twoFactorAAD(user.ID))
+	if err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to decrypt totp secret"})
+		return "", utils.MakeError(errorUc.InternalServerError, "failed to verify code")
+	}
+	return secret, nil
+}
+
+// checkSecondFactor menerima kode TOTP (6 digit) atau recovery code. Kegagalan dihitung
+// per user memakai store lockout yang sama dengan login.
+func (u *usecase) checkSecondFactor(ctx *gin.Context, user *model.User, code string) error {
+	now := time.Now().UTC()
+	key := strconv.FormatUint(uint64(user.ID), 10)
+	if err := u.checkLocked(ctx, model.LoginAttemptScopeOTP, key, now); err != nil {
+		return err
+	}
+
+	var ok bool
+	code = strings.TrimSpace(code)
+	if isTOTPCode(code) {
+		secret, err := u.totpSecret(user)
+		if err != nil {
+			return err
+		}
+		if step, valid := totp.Validate(secret, code, now, totpSkew); valid {
+			fresh, err := u.authRepo.MarkTOTPStepUsed(ctx, user.ID, step)
+			if err != nil {
+				u.log.Error(log.LogData{Err: err, Description: "failed to mark totp step"})
+				return utils.MakeError(errorUc.InternalServerError, "db error")
+			}
+			ok = fresh
+		}
+	} else {
+		used, err := u.twoFactorRepo.UseRecoveryCode(ctx, user.ID, hashToken(normalizeRecoveryCode(code)), now)
+		if err != nil {
+			u.log.Error(log.LogData{Err: err, Description: "failed to use recovery code"})
+			return utils.MakeError(errorUc.InternalServerError, "db error")
+		}
+		if used {
+			u.log.Info(log.LogData{Description: "recovery code used", Response: user.ID})
+		}
+		ok = used
+	}
+
+	if !ok {
+		p := u.lockoutPolicy()
+		u.recordFailure(ctx, model.LoginAttemptScopeOTP, key, p.MaxAccountFailures, p, now)
+		return utils.MakeError(errorUc.InvalidOTP)
+	}
+	if err := u.attemptRepo.Clear(ctx, model.LoginAttemptScopeOTP, key); err != nil {
+		u.log.Error(log.LogData{Err: err, Description: "failed to reset 2fa attempts"})
+	}
+	return nil
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// newRecoveryCodes menghasilkan kode untuk ditampilkan (format xxxxx-xxxxx) dan hash-nya untuk disimpan.
+func newRecoveryCodes() (codes, hashes []string, err error) {
+	buf := make([]byte, recoveryCodeLength)
+	for i := 0; i < recoveryCodeCount; i++ {
+		if _, err = rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := make([]byte, recoveryCodeLength)
+		for j, b := range buf {
+			raw[j] = recoveryAlphabet[int(b)%len(recoveryAlphabet)]
+		}
+		code := string(raw[:recoveryCodeLength/2]) + "-" + string(raw[recoveryCodeLength/2:])
+		codes = append(codes, code)
+		hashes = append(hashes, hashToken(normalizeRecoveryCode(code)))
+	}
+	return codes, hashes, nil
+}
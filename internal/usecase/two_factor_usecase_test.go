@@ -0,0 +1,178 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	errorUc "payslip-generation-system/internal/error"
+	"payslip-generation-system/internal/model"
+	"payslip-generation-system/internal/repository/loginattempt"
+	"payslip-generation-system/internal/usecase"
+	testm "payslip-generation-system/internal/usecase/test"
+	"payslip-generation-system/pkg/totp"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+// secret di tabel users selalu terenkripsi
+func encryptedTOTPSecret(t *testing.T, userID uint) string {
+	t.Helper()
+	enc, err := testm.NewFieldCipher().Encrypt(testTOTPSecret, fmt.Sprintf("two_factor:%d:totp_secret", userID))
+	require.NoError(t, err)
+	return enc
+}
+
+func newTwoFactorTestUsecase(t *testing.T, user *model.User, sess *testm.SessionRepoMock, tf *testm.TwoFactorRepoMock) (usecase.IUsecase, *testm.AuthRepoMock) {
+	t.Helper()
+	u := usecase.NewForTest()
+
+	var lastStep int64
+	authMock := &testm.AuthRepoMock{
+		FindByIDFn: func(_ context.Context, id uint) (*model.User, error) { return user, nil },
+		MarkTOTPStepUsedFn: func(_ context.Context, userID uint, step int64) (bool, error) {
+			if step <= lastStep {
+				return false, nil
+			}
+			lastStep = step
+			return true, nil
+		},
+	}
+	if sess == nil {
+		sess = &testm.SessionRepoMock{}
+	}
+	if tf == nil {
+		tf = &testm.TwoFactorRepoMock{}
+	}
+	usecase.InjectAuthForTest(u, testm.NewKeyManager(), authMock, sess, testm.FakeTxManager{})
+	usecase.InjectLockoutForTest(u, loginattempt.NewMemory())
+	usecase.InjectTwoFactorForTest(u, tf, testm.NewFieldCipher())
+	return u, authMock
+}
+
+func TestVerifyTwoFactor_IssuesOTPSessionAndRejectsReplay(t *testing.T) {
+	user := &model.User{ID: 7, FirstName: "Budi", Role: "admin", TOTPSecretEnc: encryptedTOTPSecret(t, 7), TwoFactorEnabled: true}
+	var createdAMR string
+	sessMock := &testm.SessionRepoMock{
+		CreateSessionFn: func(_ context.Context, s *model.AuthSession) error {
+			s.ID = 10
+			createdAMR = s.AMR
+			return nil
+		},
+		CreateRefreshTokenFn: func(_ context.Context, tok *model.RefreshToken) error { return nil },
+	}
+	u, _ := newTwoFactorTestUsecase(t, user, sessMock, nil)
+	ctx := makeGinCtx()
+
+	challenge, err := u.StartTwoFactorChallenge(ctx, user, "laptop")
+	require.NoError(t, err)
+
+	code, err := totp.CodeAt(testTOTPSecret, totp.Step(time.Now()))
+	require.NoError(t, err)
+
+	tokens, err := u.VerifyTwoFactor(ctx, challenge, code)
+	require.NoError(t, err)
+	require.Equal(t, "pwd otp", createdAMR)
+
+	claims, err := u.ParseAccessToken(tokens.AccessToken)
+	require.NoError(t, err)
+	require.Contains(t, claims["amr"], "otp")
+
+	// kode yang sama tidak boleh dipakai dua kali
+	_, err = u.VerifyTwoFactor(ctx, challenge, code)
+	require.Error(t, err)
+	require.Equal(t, errorUc.InvalidOTP, err.Error())
+}
+
+func TestVerifyTwoFactor_RecoveryCode(t *testing.T) {
+	user := &model.User{ID: 7, FirstName: "Budi", Role: "user", TOTPSecretEnc: encryptedTOTPSecret(t, 7), TwoFactorEnabled: true}
+	var usedHash string
+	tfMock := &testm.TwoFactorRepoMock{
+		UseRecoveryCodeFn: func(_ context.Context, userID uint, hash string, at time.Time) (bool, error) {
+			usedHash = hash
+			return true, nil
+		},
+	}
+	sessMock := &testm.SessionRepoMock{
+		CreateSessionFn:      func(_ context.Context, s *model.AuthSession) error { return nil },
+		CreateRefreshTokenFn: func(_ context.Context, tok *model.RefreshToken) error { return nil },
+	}
+	u, _ := newTwoFactorTestUsecase(t, user, sessMock, tfMock)
+	ctx := makeGinCtx()
+
+	challenge, err := u.StartTwoFactorChallenge(ctx, user, "")
+	require.NoError(t, err)
+
+	_, err = u.VerifyTwoFactor(ctx, challenge, "ABCDE-FGHJK")
+	require.NoError(t, err)
+	require.NotEmpty(t, usedHash)
+}
+
+func TestChallengeTokenIsNotAnAccessToken(t *testing.T) {
+	user := &model.User{ID: 7, TwoFactorEnabled: true}
+	u, _ := newTwoFactorTestUsecase(t, user, nil, nil)
+
+	challenge, err := u.StartTwoFactorChallenge(makeGinCtx(), user, "")
+	require.NoError(t, err)
+
+	_, err = u.ParseAccessToken(challenge)
+	require.Error(t, err)
+}
+
+func TestActivateTwoFactor_ReturnsRecoveryCodes(t *testing.T) {
+	user := &model.User{ID: 7, Email: "budi@example.com", TOTPSecretEnc: encryptedTOTPSecret(t, 7)}
+	var stored []string
+	tfMock := &testm.TwoFactorRepoMock{
+		ReplaceRecoveryCodesFn: func(_ context.Context, userID uint, hashes []string) error {
+			stored = hashes
+			return nil
+		},
+	}
+	u, authMock := newTwoFactorTestUsecase(t, user, nil, tfMock)
+	var enabled bool
+	authMock.EnableTwoFactorFn = func(_ context.Context, userID uint, step int64, at time.Time) error {
+		enabled = true
+		return nil
+	}
+
+	code, _ := totp.CodeAt(testTOTPSecret, totp.Step(time.Now()))
+	resp, err := u.ActivateTwoFactor(makeGinCtx(), 7, code)
+	require.NoError(t, err)
+	require.True(t, enabled)
+	require.Len(t, resp.RecoveryCodes, 10)
+	require.Len(t, stored, 10)
+
+	_, err = u.ActivateTwoFactor(makeGinCtx(), 7, "abcdef")
+	require.Error(t, err)
+}
+
+func TestEnrollTwoFactor_StoresEncryptedSecret(t *testing.T) {
+	user := &model.User{ID: 7, Email: "budi@example.com"}
+	u, authMock := newTwoFactorTestUsecase(t, user, nil, &testm.TwoFactorRepoMock{
+		ReplaceRecoveryCodesFn: func(_ context.Context, userID uint, hashes []string) error { return nil },
+	})
+	authMock.SetTOTPSecretFn = func(_ context.Context, userID uint, secretEnc string) error {
+		user.TOTPSecretEnc = secretEnc
+		return nil
+	}
+	authMock.EnableTwoFactorFn = func(_ context.Context, userID uint, step int64, at time.Time) error { return nil }
+
+	resp, err := u.EnrollTwoFactor(makeGinCtx(), 7)
+	require.NoError(t, err)
+	require.NotEmpty(t, user.TOTPSecretEnc)
+	require.NotContains(t, user.TOTPSecretEnc, resp.Secret)
+
+	// secret hasil dekripsi harus sama dengan yang ditampilkan ke user
+	code, err := totp.CodeAt(resp.Secret, totp.Step(time.Now()))
+	require.NoError(t, err)
+	_, err = u.ActivateTwoFactor(makeGinCtx(), 7, code)
+	require.NoError(t, err)
+
+	// ciphertext milik user lain tidak bisa dipakai
+	user.ID = 8
+	_, err = u.ActivateTwoFactor(makeGinCtx(), 8, code)
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+// Package fieldcrypt mengenkripsi kolom sensitif (mis. nomor rekening) sebelum disimpan ke database.
+// Format ciphertext: "<kid>:<base64(nonce|ciphertext)>" sehingga key bisa dirotasi tanpa migrasi data.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrNoActiveKey = errors.New("fieldcrypt: active key not configured")
+	ErrUnknownKid  = errors.New("fieldcrypt: unknown kid")
+	ErrMalformed   = errors.New("fieldcrypt: malformed ciphertext")
+)
+
+type Config struct {
+	ActiveKid string      `mapstructure:"activeKid"` // key untuk enkripsi data baru
+	Keys      []KeyConfig `mapstructure:"keys"`      // semua key yang masih dipakai untuk dekripsi
+}
+
+type KeyConfig struct {
+	Kid string `mapstructure:"kid"`
+	Key string `mapstructure:"key"` // base64, 32 byte (AES-256)
+}
+
+// Cipher: AES-256-GCM per kolom.
+type Cipher struct {
+	active string
+	aeads  map[string]cipher.AEAD
+}
+
+func New(cfg Config) (*Cipher, error) {
+	c := &Cipher{active: cfg.ActiveKid, aeads: make(map[string]cipher.AEAD, len(cfg.Keys))}
+	for _, kc := range cfg.Keys {
+		if kc.Kid == "" || strings.Contains(kc.Kid, ":") {
+			return nil, errors.New("fieldcrypt: kid is required and must not contain ':'")
+		}
+		if _, dup := c.aeads[kc.Kid]; dup {
+			return nil, fmt.Errorf("fieldcrypt: duplicate kid %q", kc.Kid)
+		}
+		raw, err := base64.StdEncoding.DecodeString(kc.Key)
+		if err != nil {
+			return nil, fmt.Errorf("fieldcrypt: kid %q: invalid base64 key: %w", kc.Kid, err)
+		}
+		if len(raw) != 32 {
+			return nil, fmt.Errorf("fieldcrypt: kid %q: key must be 32 bytes, got %d", kc.Kid, len(raw))
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		c.aeads[kc.Kid] = aead
+	}
+	if _, ok := c.aeads[c.active]; !ok {
+		return nil, ErrNoActiveKey
+	}
+	return c, nil
+}
+
+func (c *Cipher) ActiveKid() string { return c.active }
+
+// Encrypt; aad mengikat ciphertext ke konteksnya (mis. "bank_account:7") supaya tidak bisa dipindah ke baris lain.
+func (c *Cipher) Encrypt(plaintext, aad string) (string, error) {
+	aead := c.aeads[c.active]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), []byte(aad))
+	return c.active + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *Cipher) Decrypt(ciphertext, aad string) (string, error) {
+	kid, enc, ok := strings.Cut(ciphertext, ":")
+	if !ok {
+		return "", ErrMalformed
+	}
+	aead, ok := c.aeads[kid]
+	if !ok {
+		return "", ErrUnknownKid
+	}
+	raw, err := base64.RawStdEncoding.DecodeString(enc)
+	if err != nil || len(raw) < aead.NonceSize() {
+		return "", ErrMalformed
+	}
+	plain, err := aead.Open(nil, raw[:aead.NonceSize()], raw[aead.NonceSize():], []byte(aad))
+	if err != nil {
+		return "", fmt.Errorf("fieldcrypt: decrypt: %w", err)
+	}
+	return string(plain), nil
+}
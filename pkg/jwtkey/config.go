@@ -0,0 +1,21 @@
+package jwtkey
+
+type Config struct {
+	Issuer    string      `mapstructure:"issuer"`    // diisi ke claim "iss" dan dicek saat verifikasi
+	ActiveKid string      `mapstructure:"activeKid"` // key yang dipakai untuk sign token baru
+	Keys      []KeyConfig `mapstructure:"keys"`      // semua key yang masih diterima saat verifikasi
+}
+
+type KeyConfig struct {
+	Kid string `mapstructure:"kid"`
+	Alg string `mapstructure:"alg"` // HS256, RS256, EdDSA
+
+	// HS256
+	Secret string `mapstructure:"secret"`
+
+	// RS256 / EdDSA (PEM). Private key boleh kosong untuk key yang sudah dirotasi (verify-only).
+	PrivateKeyPath string `mapstructure:"privateKeyPath"`
+	PublicKeyPath  string `mapstructure:"publicKeyPath"`
+	PrivateKeyPEM  string `mapstructure:"privateKeyPem"`
+	PublicKeyPEM   string `mapstructure:"publicKeyPem"`
+}
@@ -0,0 +1,56 @@
+package jwtkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWK mengikuti RFC 7517 (hanya field yang kita butuhkan).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS mengembalikan public key untuk semua key asimetris (aktif + verify-only)
+// agar service internal lain bisa memverifikasi token kita.
+func (m *Manager) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(m.order))}
+	for _, kid := range m.order {
+		k := m.keys[kid]
+		switch pk := k.publicKey().(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: k.kid,
+				Use: "sig",
+				Alg: k.alg,
+				N:   base64.RawURLEncoding.EncodeToString(pk.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pk.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: k.kid,
+				Use: "sig",
+				Alg: k.alg,
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pk),
+			})
+		}
+	}
+	return set
+}
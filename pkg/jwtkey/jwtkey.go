@@ -0,0 +1,212 @@
+package jwtkey
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+var (
+	ErrNoActiveKey   = errors.New("jwtkey: active signing key not configured")
+	ErrUnknownKid    = errors.New("jwtkey: unknown kid")
+	ErrAlgMismatch   = errors.New("jwtkey: token alg does not match key alg")
+	ErrInvalidIssuer = errors.New("jwtkey: invalid issuer")
+)
+
+type key struct {
+	kid       string
+	alg       string
+	method    jwt.SigningMethod
+	signKey   interface{} // nil => verify-only
+	verifyKey interface{}
+}
+
+// Manager memegang semua key JWT: satu key aktif untuk sign, beberapa key untuk verifikasi (rotasi).
+type Manager struct {
+	issuer string
+	active *key
+	keys   map[string]*key
+	order  []string
+}
+
+func New(cfg Config) (*Manager, error) {
+	m := &Manager{
+		issuer: cfg.Issuer,
+		keys:   make(map[string]*key, len(cfg.Keys)),
+	}
+	for _, kc := range cfg.Keys {
+		if kc.Kid == "" {
+			return nil, errors.New("jwtkey: kid is required")
+		}
+		if _, dup := m.keys[kc.Kid]; dup {
+			return nil, fmt.Errorf("jwtkey: duplicate kid %q", kc.Kid)
+		}
+		k, err := loadKey(kc)
+		if err != nil {
+			return nil, fmt.Errorf("jwtkey: kid %q: %w", kc.Kid, err)
+		}
+		m.keys[kc.Kid] = k
+		m.order = append(m.order, kc.Kid)
+	}
+
+	active, ok := m.keys[cfg.ActiveKid]
+	if !ok || active.signKey == nil {
+		return nil, ErrNoActiveKey
+	}
+	m.active = active
+	return m, nil
+}
+
+func loadKey(kc KeyConfig) (*key, error) {
+	k := &key{kid: kc.Kid, alg: kc.Alg}
+	switch kc.Alg {
+	case AlgHS256:
+		if len(kc.Secret) < 32 {
+			return nil, errors.New("HS256 secret must be at least 32 bytes")
+		}
+		k.method = jwt.SigningMethodHS256
+		k.signKey = []byte(kc.Secret)
+		k.verifyKey = []byte(kc.Secret)
+
+	case AlgRS256:
+		k.method = jwt.SigningMethodRS256
+		if priv, err := readPEM(kc.PrivateKeyPEM, kc.PrivateKeyPath); err != nil {
+			return nil, err
+		} else if priv != nil {
+			pk, err := jwt.ParseRSAPrivateKeyFromPEM(priv)
+			if err != nil {
+				return nil, err
+			}
+			k.signKey = pk
+			k.verifyKey = &pk.PublicKey
+		}
+		if pub, err := readPEM(kc.PublicKeyPEM, kc.PublicKeyPath); err != nil {
+			return nil, err
+		} else if pub != nil {
+			pk, err := jwt.ParseRSAPublicKeyFromPEM(pub)
+			if err != nil {
+				return nil, err
+			}
+			k.verifyKey = pk
+		}
+
+	case AlgEdDSA:
+		k.method = jwt.SigningMethodEdDSA
+		if priv, err := readPEM(kc.PrivateKeyPEM, kc.PrivateKeyPath); err != nil {
+			return nil, err
+		} else if priv != nil {
+			pk, err := jwt.ParseEdPrivateKeyFromPEM(priv)
+			if err != nil {
+				return nil, err
+			}
+			edPriv, ok := pk.(ed25519.PrivateKey)
+			if !ok {
+				return nil, jwt.ErrNotEdPrivateKey
+			}
+			k.signKey = edPriv
+			k.verifyKey = edPriv.Public()
+		}
+		if pub, err := readPEM(kc.PublicKeyPEM, kc.PublicKeyPath); err != nil {
+			return nil, err
+		} else if pub != nil {
+			pk, err := jwt.ParseEdPublicKeyFromPEM(pub)
+			if err != nil {
+				return nil, err
+			}
+			k.verifyKey = pk
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported alg %q", kc.Alg)
+	}
+
+	if k.verifyKey == nil {
+		return nil, errors.New("no key material configured")
+	}
+	return k, nil
+}
+
+func readPEM(inline, path string) ([]byte, error) {
+	if inline != "" {
+		return []byte(inline), nil
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(path)
+}
+
+// ActiveKid mengembalikan kid yang sedang dipakai untuk sign.
+func (m *Manager) ActiveKid() string { return m.active.kid }
+
+// Sign menandatangani claims dengan key aktif; header "kid" selalu diisi.
+func (m *Manager) Sign(claims jwt.MapClaims) (string, error) {
+	if m.issuer != "" {
+		if _, ok := claims["iss"]; !ok {
+			claims["iss"] = m.issuer
+		}
+	}
+	token := jwt.NewWithClaims(m.active.method, claims)
+	token.Header["kid"] = m.active.kid
+	return token.SignedString(m.active.signKey)
+}
+
+// Parse memverifikasi token dengan key sesuai header "kid" (termasuk key lama yang masih aktif untuk verifikasi).
+func (m *Manager) Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, m.keyfunc)
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("jwtkey: invalid token")
+	}
+	if m.issuer != "" && !claims.VerifyIssuer(m.issuer, true) {
+		return nil, ErrInvalidIssuer
+	}
+	return claims, nil
+}
+
+func (m *Manager) keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	k, ok := m.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKid
+	}
+	// cegah alg confusion (mis. token HS256 yang ditandatangani pakai public key RSA)
+	if token.Method.Alg() != k.alg {
+		return nil, ErrAlgMismatch
+	}
+	return k.verifyKey, nil
+}
+
+// publicKey hanya untuk key asimetris; secret HS256 tidak pernah dipublikasikan.
+func (k *key) publicKey() crypto.PublicKey {
+	switch pk := k.verifyKey.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		return pk
+	}
+	return nil
+}
+
+// UintClaim membaca claim numerik (angka JSON ter-decode sebagai float64).
+func UintClaim(claims jwt.MapClaims, key string) uint {
+	switch t := claims[key].(type) {
+	case float64:
+		return uint(t)
+	case int:
+		return uint(t)
+	}
+	return 0
+}
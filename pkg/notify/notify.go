@@ -0,0 +1,87 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"payslip-generation-system/pkg/log"
+)
+
+const (
+	DriverLog  = "log"
+	DriverSMTP = "smtp"
+)
+
+type Config struct {
+	Driver string     `mapstructure:"driver"` // log (default), smtp
+	From   string     `mapstructure:"from"`
+	SMTP   SMTPConfig `mapstructure:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     string `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+}
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Notifier mengirim pesan ke user (email, chat, dsb). Implementasi bisa diganti lewat config.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+func New(cfg Config, l *log.LogCustom) (Notifier, error) {
+	switch cfg.Driver {
+	case "", DriverLog:
+		return &logNotifier{log: l}, nil
+	case DriverSMTP:
+		if cfg.SMTP.Host == "" || cfg.From == "" {
+			return nil, fmt.Errorf("notify: smtp host and from are required")
+		}
+		return &smtpNotifier{cfg: cfg}, nil
+	default:
+		return nil, fmt.Errorf("notify: unknown driver %q", cfg.Driver)
+	}
+}
+
+// logNotifier hanya menulis pesan ke log; cocok untuk dev/test.
+type logNotifier struct {
+	log *log.LogCustom
+}
+
+func (n *logNotifier) Send(_ context.Context, msg Message) error {
+	n.log.Info(log.LogData{
+		Description: fmt.Sprintf("notification to %s: %s", msg.To, msg.Subject),
+		Response:    msg.Body,
+	})
+	return nil
+}
+
+type smtpNotifier struct {
+	cfg Config
+}
+
+func (n *smtpNotifier) Send(_ context.Context, msg Message) error {
+	addr := n.cfg.SMTP.Host + ":" + n.cfg.SMTP.Port
+	var auth smtp.Auth
+	if n.cfg.SMTP.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.SMTP.Username, n.cfg.SMTP.Password, n.cfg.SMTP.Host)
+	}
+
+	var b strings.Builder
+	b.WriteString("From: " + n.cfg.From + "\r\n")
+	b.WriteString("To: " + msg.To + "\r\n")
+	b.WriteString("Subject: " + msg.Subject + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	return smtp.SendMail(addr, auth, n.cfg.From, []string{msg.To}, []byte(b.String()))
+}
@@ -0,0 +1,21 @@
+package oidc
+
+import "time"
+
+type Config struct {
+	StateTTL  time.Duration    `mapstructure:"stateTTL"` // default 10m, batas waktu user menyelesaikan login di provider
+	Providers []ProviderConfig `mapstructure:"providers"`
+}
+
+type ProviderConfig struct {
+	Name         string   `mapstructure:"name"`   // dipakai di URL: /v1/auth/oidc/{name}/start
+	Issuer       string   `mapstructure:"issuer"` // discovery di {issuer}/.well-known/openid-configuration
+	ClientID     string   `mapstructure:"clientId"`
+	ClientSecret string   `mapstructure:"clientSecret"`
+	RedirectURL  string   `mapstructure:"redirectUrl"`
+	Scopes       []string `mapstructure:"scopes"` // default: openid email profile
+	// AllowedIssuers: nilai iss lain yang juga diterima (Google memakai "accounts.google.com" dan "https://accounts.google.com")
+	AllowedIssuers []string `mapstructure:"allowedIssuers"`
+	// AllowedDomains: opsional, hanya email/hosted domain ini yang boleh login
+	AllowedDomains []string `mapstructure:"allowedDomains"`
+}
@@ -0,0 +1,231 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// JWKS provider di-refresh saat kid tidak dikenal (rotasi key), maksimal sekali per menit.
+const jwksMinRefresh = time.Minute
+
+type IDClaims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	GivenName     string
+	FamilyName    string
+	Picture       string
+	HostedDomain  string // Google Workspace "hd"
+}
+
+// VerifyIDToken memeriksa signature (RS256/ES256 via JWKS), iss, aud/azp, exp dan nonce.
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (*IDClaims, error) {
+	if _, err := p.discover(ctx); err != nil {
+		return nil, err
+	}
+
+	tok, err := jwt.Parse(raw, func(t *jwt.Token) (interface{}, error) {
+		switch t.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("unexpected alg %v", t.Header["alg"])
+		}
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	})
+	if err != nil || !tok.Valid {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	mc, ok := tok.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, ErrInvalidIDToken
+	}
+
+	c := &IDClaims{}
+	c.Issuer, _ = mc["iss"].(string)
+	c.Subject, _ = mc["sub"].(string)
+	c.Email, _ = mc["email"].(string)
+	c.Name, _ = mc["name"].(string)
+	c.GivenName, _ = mc["given_name"].(string)
+	c.FamilyName, _ = mc["family_name"].(string)
+	c.Picture, _ = mc["picture"].(string)
+	c.HostedDomain, _ = mc["hd"].(string)
+	switch v := mc["email_verified"].(type) {
+	case bool:
+		c.EmailVerified = v
+	case string: // beberapa provider mengirim "true"
+		c.EmailVerified = v == "true"
+	}
+
+	if !p.issuerAllowed(c.Issuer) {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidIDToken, c.Issuer)
+	}
+	if !mc.VerifyAudience(p.cfg.ClientID, true) {
+		return nil, fmt.Errorf("%w: audience mismatch", ErrInvalidIDToken)
+	}
+	if azp, ok := mc["azp"].(string); ok && azp != "" && azp != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: azp mismatch", ErrInvalidIDToken)
+	}
+	if _, ok := mc["exp"]; !ok {
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidIDToken)
+	}
+	if got, _ := mc["nonce"].(string); nonce != "" && got != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+	if c.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	if !p.domainAllowed(c) {
+		return nil, fmt.Errorf("%w: domain not allowed", ErrInvalidIDToken)
+	}
+	return c, nil
+}
+
+func (p *Provider) issuerAllowed(iss string) bool {
+	if iss == p.cfg.Issuer {
+		return true
+	}
+	for _, a := range p.cfg.AllowedIssuers {
+		if iss == a {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Provider) domainAllowed(c *IDClaims) bool {
+	if len(p.cfg.AllowedDomains) == 0 {
+		return true
+	}
+	domain := c.HostedDomain
+	if domain == "" {
+		if i := strings.LastIndex(c.Email, "@"); i >= 0 {
+			domain = c.Email[i+1:]
+		}
+	}
+	for _, d := range p.cfg.AllowedDomains {
+		if strings.EqualFold(domain, d) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Provider) key(ctx context.Context, kid string) (any, error) {
+	p.mu.Lock()
+	k, ok := p.keys[kid]
+	stale := time.Since(p.keysFetchedAt) > jwksMinRefresh
+	p.mu.Unlock()
+	if ok {
+		return k, nil
+	}
+	if !stale && p.keys != nil {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if err := p.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if k, ok := p.keys[kid]; ok {
+		return k, nil
+	}
+	// provider dengan satu key kadang tidak mengirim kid
+	if kid == "" && len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown kid %q", kid)
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (p *Provider) refreshKeys(ctx context.Context) error {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.getJSON(ctx, d.JWKSURI, &set); err != nil {
+		return fmt.Errorf("oidc: jwks: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pub, err := k.publicKey()
+		if err != nil {
+			continue // key yang tidak didukung dilewati
+		}
+		keys[k.Kid] = pub
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+	p.mu.Unlock()
+	return nil
+}
+
+func (k jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := b64Int(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := b64Int(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := b64Int(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := b64Int(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	}
+	return nil, fmt.Errorf("unsupported kty %q", k.Kty)
+}
+
+func b64Int(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
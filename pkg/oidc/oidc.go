@@ -0,0 +1,240 @@
+// Package oidc adalah client OpenID Connect minimal untuk authorization code flow dengan PKCE:
+// discovery, pertukaran code, dan verifikasi ID token terhadap JWKS provider.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultStateTTL = 10 * time.Minute
+	httpTimeout     = 10 * time.Second
+)
+
+var (
+	ErrUnknownProvider = errors.New("oidc: unknown provider")
+	ErrInvalidIDToken  = errors.New("oidc: invalid id token")
+	ErrExchange        = errors.New("oidc: code exchange failed")
+)
+
+// Registry berisi semua provider dari config; discovery dilakukan lazily saat pertama dipakai.
+type Registry struct {
+	providers map[string]*Provider
+	stateTTL  time.Duration
+}
+
+// New memvalidasi config. client boleh nil (default timeout 10 detik).
+func New(cfg Config, client *http.Client) (*Registry, error) {
+	if client == nil {
+		client = &http.Client{Timeout: httpTimeout}
+	}
+	r := &Registry{providers: make(map[string]*Provider), stateTTL: cfg.StateTTL}
+	if r.stateTTL <= 0 {
+		r.stateTTL = defaultStateTTL
+	}
+	for _, pc := range cfg.Providers {
+		if pc.Name == "" || pc.Issuer == "" || pc.ClientID == "" || pc.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc: provider %q needs name, issuer, clientId and redirectUrl", pc.Name)
+		}
+		if _, dup := r.providers[pc.Name]; dup {
+			return nil, fmt.Errorf("oidc: duplicate provider %q", pc.Name)
+		}
+		if len(pc.Scopes) == 0 {
+			pc.Scopes = []string{"openid", "email", "profile"}
+		}
+		r.providers[pc.Name] = &Provider{cfg: pc, client: client}
+	}
+	return r, nil
+}
+
+func (r *Registry) Provider(name string) (*Provider, error) {
+	if r == nil {
+		return nil, ErrUnknownProvider
+	}
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+func (r *Registry) StateTTL() time.Duration {
+	if r == nil || r.stateTTL <= 0 {
+		return defaultStateTTL
+	}
+	return r.stateTTL
+}
+
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type Provider struct {
+	cfg    ProviderConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	meta          *discovery
+	keys          map[string]any // kid -> *rsa.PublicKey / *ecdsa.PublicKey
+	keysFetchedAt time.Time
+}
+
+func (p *Provider) Name() string { return p.cfg.Name }
+
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	var d discovery
+	wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, wellKnown, &d); err != nil {
+		return nil, fmt.Errorf("oidc: discovery: %w", err)
+	}
+	if d.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc: discovery issuer mismatch: %q", d.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, errors.New("oidc: discovery document is incomplete")
+	}
+	p.meta = &d
+	return p.meta, nil
+}
+
+// AuthCodeURL membuat URL login di provider (response_type=code, PKCE S256).
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(d.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return d.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+type Tokens struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Exchange menukar authorization code (plus code_verifier PKCE) dengan token di token endpoint.
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier string) (*Tokens, error) {
+	d, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("client_id", p.cfg.ClientID)
+	form.Set("code_verifier", codeVerifier)
+	if p.cfg.ClientSecret != "" {
+		form.Set("client_secret", p.cfg.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode != http.StatusOK {
+		var e struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal(body, &e)
+		return nil, fmt.Errorf("%w: status %d %s %s", ErrExchange, resp.StatusCode, e.Error, e.Description)
+	}
+
+	var t Tokens
+	if err := json.Unmarshal(body, &t); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchange, err)
+	}
+	if t.IDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in response", ErrExchange)
+	}
+	return &t, nil
+}
+
+func (p *Provider) getJSON(ctx context.Context, u string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", u, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(out)
+}
+
+// NewCodeVerifier menghasilkan code_verifier PKCE (43 karakter, RFC 7636).
+func NewCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// CodeChallengeS256 = BASE64URL(SHA256(verifier)).
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// NewNonce untuk parameter nonce (dicek kembali di ID token).
+func NewNonce() (string, error) {
+	return randomString(24)
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
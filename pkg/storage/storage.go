@@ -0,0 +1,126 @@
+// Package storage menyimpan file upload (mis. foto profil). Driver dipilih lewat config;
+// saat ini hanya "local", driver lain (S3/GCS) cukup mengimplementasikan Storage.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+
+	defaultLocalDir       = "./uploads"
+	defaultLocalPublicURL = "/uploads"
+)
+
+var ErrInvalidKey = errors.New("storage: invalid key")
+
+type Config struct {
+	Driver string      `mapstructure:"driver"` // local (default)
+	Local  LocalConfig `mapstructure:"local"`
+}
+
+type LocalConfig struct {
+	Dir       string `mapstructure:"dir"`       // folder di disk, default ./uploads
+	PublicURL string `mapstructure:"publicUrl"` // prefix URL yang disajikan router.Static, default /uploads
+}
+
+type Storage interface {
+	// Put menyimpan object dengan key (path relatif, mis. profile-images/7/abc.png) dan mengembalikan URL publiknya.
+	Put(ctx context.Context, key string, r io.Reader, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+	// KeyFromURL mengembalikan key untuk URL yang dibuat oleh storage ini (false untuk URL eksternal).
+	KeyFromURL(url string) (string, bool)
+}
+
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocal(cfg.Local), nil
+	default:
+		return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+	}
+}
+
+// Local menyimpan file di disk; router menyajikannya lewat LocalConfig.PublicURL.
+type Local struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocal(cfg LocalConfig) *Local {
+	l := &Local{dir: cfg.Dir, publicURL: strings.TrimSuffix(cfg.PublicURL, "/")}
+	if l.dir == "" {
+		l.dir = defaultLocalDir
+	}
+	if l.publicURL == "" {
+		l.publicURL = defaultLocalPublicURL
+	}
+	return l
+}
+
+func (l *Local) Dir() string       { return l.dir }
+func (l *Local) PublicURL() string { return l.publicURL }
+
+func (l *Local) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)[1:]
+	if clean == "" || clean != key || strings.HasPrefix(clean, "..") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(clean)), nil
+}
+
+func (l *Local) Put(_ context.Context, key string, r io.Reader, _ string) (string, error) {
+	p, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return "", err
+	}
+
+	// tulis ke file sementara lalu rename supaya tidak ada file setengah jadi yang tersaji
+	tmp, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), p); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+	return l.publicURL + "/" + key, nil
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) KeyFromURL(url string) (string, bool) {
+	prefix := l.publicURL + "/"
+	if !strings.HasPrefix(url, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(url, prefix), true
+}
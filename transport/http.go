@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -74,6 +75,7 @@ func ProvideHttp(Config *config.Config,
 
 func (h *HTTP) Serve() {
 	h.Route.SetupRoute(h.Server)
+	h.Route.StartBackgroundJobs(context.Background())
 	h.setupGracefulShutdown()
 	h.State = ServerStateReady
 
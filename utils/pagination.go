@@ -0,0 +1,41 @@
+package utils
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Pagination query ?page=&pageSize=; nilai kosong/invalid diganti default lewat Normalize.
+type Pagination struct {
+	Page     int `form:"page"`
+	PageSize int `form:"pageSize"`
+}
+
+func (p Pagination) Normalize() Pagination {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.PageSize < 1 {
+		p.PageSize = DefaultPageSize
+	}
+	if p.PageSize > MaxPageSize {
+		p.PageSize = MaxPageSize
+	}
+	return p
+}
+
+func (p Pagination) Offset() int { return (p.Page - 1) * p.PageSize }
+
+// NewMetadata mengisi Metadata untuk response list berdasarkan total data.
+func NewMetadata(p Pagination, total int64) *Metadata {
+	totalPage := 0
+	if p.PageSize > 0 {
+		totalPage = int((total + int64(p.PageSize) - 1) / int64(p.PageSize))
+	}
+	return &Metadata{
+		PageSize:  p.PageSize,
+		Page:      p.Page,
+		TotalPage: totalPage,
+		TotalData: int(total),
+	}
+}
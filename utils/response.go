@@ -21,9 +21,10 @@ func respond(c *gin.Context, baseResponse *Base) {
 }
 
 type Response[T any] struct {
-	ResponseCode    string `json:"responseCode"`
-	ResponseMessage string `json:"responseMessage"`
-	Data            T      `json:"data,omitempty"`
+	ResponseCode    string    `json:"responseCode"`
+	ResponseMessage string    `json:"responseMessage"`
+	Data            T         `json:"data,omitempty"`
+	Metadata        *Metadata `json:"metadata,omitempty"` // hanya untuk response list
 }
 
 func (r *Response[T]) SetToSuccess() {
@@ -28,6 +28,11 @@ var LoggerSet = wire.NewSet(
 // Infra (DB, dsb)
 var InfraSet = wire.NewSet(
 	infra.ProvideInfra,
+	infra.ProvideKeyManager,
+	infra.ProvideNotifier,
+	infra.ProvideOIDC,
+	infra.ProvideStorage,
+	infra.ProvideFieldCipher,
 	wire.FieldsOf(new(*infra.Infra), "DB"),
 )
 
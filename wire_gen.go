@@ -26,9 +26,14 @@ func ServerApp() *transport.HTTP {
 	logCustom := log.ProvideLogger()
 	infraInfra := infra.ProvideInfra(configConfig, logCustom)
 	db := infraInfra.DB
+	manager := infra.ProvideKeyManager(configConfig, logCustom)
+	notifier := infra.ProvideNotifier(configConfig, logCustom)
+	registry := infra.ProvideOIDC(configConfig, logCustom)
+	storage := infra.ProvideStorage(configConfig, logCustom)
+	cipher := infra.ProvideFieldCipher(configConfig, logCustom)
 	iAuthRepo := auth.ProvideAuthRepo(infraInfra)
 	txManager := tx.ProvideTxManager(infraInfra)
-	iUsecase := usecase.ProvideUsc(configConfig, logCustom, db, iAuthRepo, txManager)
+	iUsecase := usecase.ProvideUsc(configConfig, logCustom, db, manager, notifier, registry, storage, cipher, iAuthRepo, txManager)
 	handlerHandler := handler.ProvideHandler(configConfig, logCustom, iUsecase)
 	route := router.ProvideRoute(configConfig, logCustom, handlerHandler, iUsecase)
 	http := transport.ProvideHttp(configConfig, route, logCustom)
@@ -43,7 +48,7 @@ var Configs = wire.NewSet(config.ProvideConfig)
 var LoggerSet = wire.NewSet(log.ProvideLogger)
 
 // Infra (DB, dsb)
-var InfraSet = wire.NewSet(infra.ProvideInfra, wire.FieldsOf(new(*infra.Infra), "DB"))
+var InfraSet = wire.NewSet(infra.ProvideInfra, infra.ProvideKeyManager, infra.ProvideNotifier, infra.ProvideOIDC, infra.ProvideStorage, infra.ProvideFieldCipher, wire.FieldsOf(new(*infra.Infra), "DB"))
 
 // Repositories dasar yang di-inject ke usecase
 var RepoSet = wire.NewSet(auth.ProvideAuthRepo, tx.ProvideTxManager)